@@ -1,12 +1,27 @@
 package main
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/PatrickChagastavares/game-of-thrones/config"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/auth"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/graphql"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/idempotency"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/inflate"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/ratelimit"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/handlers"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/apikeys"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/jobs"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/purge"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/grpcServer"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/jwt"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
 	migration "github.com/PatrickChagastavares/game-of-thrones/pkg/migrations"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
@@ -19,6 +34,15 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
+// @securityDefinitions.apikey	ApiKeyAuth
+// @in							header
+// @name						X-API-Key
+// @description				API key created on /admin/keys, or the bootstrap key of GOT_BOOTSTRAP_KEY
+
+// @securityDefinitions.apikey	BearerAuth
+// @in							header
+// @name						Authorization
+// @description				"Bearer " followed by a JWT of the platform, signed by a key of the auth.jwt JWKS
 func main() {
 
 	var log = logger.NewLogrusLogger()
@@ -28,13 +52,34 @@ func main() {
 		return
 	}
 
+	if len(configs.Auth.BootstrapKey) > 0 && configs.Env != config.EnvDevelopment {
+		log.Error("the bootstrap key is an admin key, unset ", config.EnvBootstrapKey, " once the first admin key is created")
+	}
+
 	migration.RunMigrations(configs.Database.Writer)
 
 	trace := tracer.New(tracerjaeger.NewExporter(configs.Tracer))
 	defer trace.Close()
 
+	var verifier jwt.Verifier
+	if jwtConfig := configs.Auth.JWT; len(jwtConfig.JWKSFile) > 0 || len(jwtConfig.JWKSURL) > 0 {
+		verifier, err = jwt.New(jwt.Options{
+			JWKSFile: jwtConfig.JWKSFile,
+			JWKSURL:  jwtConfig.JWKSURL,
+			Issuer:   jwtConfig.Issuer,
+			Audience: jwtConfig.Audience,
+			Leeway:   jwtConfig.Leeway,
+			Refresh:  jwtConfig.Refresh,
+		})
+		if err != nil {
+			log.Fatal("failed to load jwks: ", err)
+			return
+		}
+	}
+
 	var (
 		router       = httpRouter.NewGinRouter()
+		server       = grpcServer.NewGrpcServer()
 		repositories = repositories.New(repositories.Options{
 			WriterSqlx: otelsqlx.MustConnect(
 				"postgres",
@@ -53,22 +98,113 @@ func main() {
 			Log: log,
 		})
 		services = services.New(services.Options{
-			Repo: repositories,
-			Log:  log,
+			Repo:             repositories,
+			Log:              log,
+			IdempotencyTTL:   configs.Idempotency.TTL,
+			IdempotencyLease: configs.Idempotency.Lease,
+			StatsCacheTTL:    configs.Stats.CacheTTL,
+			Purge: purge.Options{
+				HousesRetention:     configs.Purge.Retention.Houses,
+				CharactersRetention: configs.Purge.Retention.Characters,
+				BatchSize:           configs.Purge.BatchSize,
+			},
+			APIKeys: apikeys.Options{
+				BootstrapKey: configs.Auth.BootstrapKey,
+			},
+			Jobs: jobs.Options{
+				Workers:         configs.Jobs.Workers,
+				PollInterval:    configs.Jobs.PollInterval,
+				Heartbeat:       configs.Jobs.Heartbeat,
+				MaxAttempts:     configs.Jobs.MaxAttempts,
+				ExportDir:       configs.Jobs.ExportDir,
+				Retention:       configs.Jobs.Retention,
+				CleanupInterval: configs.Jobs.CleanupInterval,
+			},
 		})
 		controllers = controllers.New(controllers.Options{
 			Srv: services,
 			Log: log,
+			GraphQL: graphql.Options{
+				MaxDepth:         configs.GraphQL.MaxDepth,
+				PersistedOnly:    configs.GraphQL.PersistedOnly,
+				PersistedQueries: configs.GraphQL.PersistedQueries,
+			},
+			Idempotency: idempotency.Options{
+				MaxBodySize: configs.Idempotency.MaxBodySize,
+			},
+			Auth: auth.Options{
+				Verifier:   verifier,
+				RolesClaim: configs.Auth.JWT.RolesClaim,
+				Roles:      configs.Auth.JWT.Roles,
+			},
+			RateLimit: ratelimit.Options{
+				Store:  configs.RateLimit.Store,
+				Groups: configs.RateLimit.Groups,
+			},
+			Inflate: inflate.Options{
+				MaxSize: configs.Compression.MaxInflatedSize,
+			},
 		})
 	)
 
-	handlers.NewRouter(handlers.Options{
-		Router: router,
-		Ctrl:   controllers,
+	if err := router.TrustProxies(configs.RateLimit.TrustedProxies); err != nil {
+		log.Fatal("failed to trust proxies: ", err)
+		return
+	}
+
+	err = router.Compress(httpRouter.Compression{
+		Encodings: configs.Compression.Encodings,
+		MinSize:   configs.Compression.MinSize,
 	})
+	if err != nil {
+		log.Fatal("failed to compress responses: ", err)
+		return
+	}
 
-	log.Info("start serve in port:", configs.Port)
-	if err := router.Server(configs.Port); err != nil {
-		log.Fatal(err)
+	// a stop signal requeues the running jobs before the process exits
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go services.Purge.Schedule(ctx, configs.Purge.Interval)
+	jobsDone := make(chan struct{})
+	go func() {
+		defer close(jobsDone)
+		services.Jobs.Start(ctx)
+	}()
+	if configs.RateLimit.Store == ratelimit.StorePostgres {
+		go services.RateLimit.Schedule(ctx, configs.RateLimit.CleanupInterval)
 	}
+
+	handlers.NewRouter(handlers.Options{
+		Router:      router,
+		Ctrl:        controllers,
+		Root:        configs.Routes.Root,
+		V1:          configs.Routes.V1,
+		GraphiQL:    configs.Env == config.EnvDevelopment,
+		CORS:        configs.CORS,
+		Security:    configs.Security.Headers,
+		SwaggerCSP:  configs.Security.SwaggerCSP,
+		GraphiQLCSP: configs.Security.GraphiQLCSP,
+	})
+
+	handlers.NewGRPC(server, controllers)
+
+	go func() {
+		log.Info("start grpc serve in port:", configs.GRPCPort)
+		if err := server.Server(configs.GRPCPort); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	defer server.Stop()
+
+	go func() {
+		log.Info("start serve in port:", configs.Port)
+		if err := router.Server(configs.Port); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Info("shutting down, waiting for the running jobs")
+	<-jobsDone
 }
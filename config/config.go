@@ -1,21 +1,141 @@
 package config
 
 import (
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
 	tracerjaeger "github.com/PatrickChagastavares/game-of-thrones/pkg/tracer/tracer_jaeger"
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
 
+const (
+	// EnvDevelopment is the env of the local and docker configs.
+	EnvDevelopment = "development"
+	// EnvBootstrapKey is the environment variable of auth.bootstrap_key,
+	// which is kept out of the config files.
+	EnvBootstrapKey = "GOT_BOOTSTRAP_KEY"
+)
+
 type (
 	Config struct {
-		Env      string               `mapstructure:"env"`
-		Port     string               `mapstructure:"port"`
-		Tracer   tracerjaeger.Options `mapstructure:"tracer"`
-		Database Database             `mapstructure:"database"`
+		Env         string               `mapstructure:"env"`
+		Port        string               `mapstructure:"port"`
+		GRPCPort    string               `mapstructure:"grpc_port"`
+		Tracer      tracerjaeger.Options `mapstructure:"tracer"`
+		Database    Database             `mapstructure:"database"`
+		Idempotency Idempotency          `mapstructure:"idempotency"`
+		Purge       Purge                `mapstructure:"purge"`
+		Stats       Stats                `mapstructure:"stats"`
+		Jobs        Jobs                 `mapstructure:"jobs"`
+		Auth        Auth                 `mapstructure:"auth"`
+		RateLimit   RateLimit            `mapstructure:"rate_limit"`
+		Routes      Routes               `mapstructure:"routes"`
+		CORS        httpRouter.CORS      `mapstructure:"cors"`
+		Security    Security             `mapstructure:"security"`
+		Compression Compression          `mapstructure:"compression"`
+		GraphQL     GraphQL              `mapstructure:"graphql"`
 	}
 	Database struct {
 		Writer string `mapstructure:"writer"`
 		Reader string `mapstructure:"reader"`
 	}
+	// Idempotency keeps the responses of the keys for ttl. A request holds
+	// its key for lease, a retry takes over a key whose request didn't
+	// complete by then, so it must outlast the slowest request.
+	// max_body_size caps the bodies hashed with the keys, in bytes.
+	Idempotency struct {
+		TTL         time.Duration `mapstructure:"ttl"`
+		Lease       time.Duration `mapstructure:"lease"`
+		MaxBodySize int64         `mapstructure:"max_body_size"`
+	}
+	Purge struct {
+		Interval  time.Duration `mapstructure:"interval"`
+		BatchSize int           `mapstructure:"batch_size"`
+		Retention Retention     `mapstructure:"retention"`
+	}
+	Retention struct {
+		Houses     time.Duration `mapstructure:"houses"`
+		Characters time.Duration `mapstructure:"characters"`
+	}
+	Stats struct {
+		CacheTTL time.Duration `mapstructure:"cache_ttl"`
+	}
+	// Auth holds the bootstrap key, an admin API key meant to create the
+	// first keys, empty disables it. It is read from GOT_BOOTSTRAP_KEY.
+	Auth struct {
+		BootstrapKey string `mapstructure:"bootstrap_key"`
+		JWT          JWT    `mapstructure:"jwt"`
+	}
+	// JWT validates the bearer tokens of the platform, without a jwks_file or
+	// jwks_url only the API keys authenticate. roles maps the values of the
+	// roles_claim to the roles reader, editor and admin.
+	JWT struct {
+		JWKSFile   string            `mapstructure:"jwks_file"`
+		JWKSURL    string            `mapstructure:"jwks_url"`
+		Issuer     string            `mapstructure:"issuer"`
+		Audience   string            `mapstructure:"audience"`
+		Leeway     time.Duration     `mapstructure:"leeway"`
+		Refresh    time.Duration     `mapstructure:"refresh"`
+		RolesClaim string            `mapstructure:"roles_claim"`
+		Roles      map[string]string `mapstructure:"roles"`
+	}
+	// Jobs runs the jobs in a pool of workers, a job taken max_attempts times
+	// fails. The exports are written to export_dir, shared by the instances,
+	// and the jobs finished longer than retention ago are deleted every
+	// cleanup_interval.
+	Jobs struct {
+		Workers         int           `mapstructure:"workers"`
+		PollInterval    time.Duration `mapstructure:"poll_interval"`
+		Heartbeat       time.Duration `mapstructure:"heartbeat"`
+		MaxAttempts     int           `mapstructure:"max_attempts"`
+		ExportDir       string        `mapstructure:"export_dir"`
+		Retention       time.Duration `mapstructure:"retention"`
+		CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+	}
+	// RateLimit limits the clients of each group of routes, the default group
+	// applies to the groups without their own. The ip group limits each IP
+	// before the authentication. The store is memory, each
+	// instance limits on its own, or postgres, the instances share the
+	// buckets. trusted_proxies lists the proxies whose X-Forwarded-For gives
+	// the IP of the clients.
+	RateLimit struct {
+		Store           string                      `mapstructure:"store"`
+		CleanupInterval time.Duration               `mapstructure:"cleanup_interval"`
+		TrustedProxies  []string                    `mapstructure:"trusted_proxies"`
+		Groups          map[string]httpRouter.Limit `mapstructure:"groups"`
+	}
+	// Security holds the security headers of the responses. swagger_csp and
+	// graphiql_csp replace the content security policy of the API on those
+	// pages, that run scripts.
+	Security struct {
+		Headers     httpRouter.SecurityHeaders `mapstructure:"headers"`
+		SwaggerCSP  string                     `mapstructure:"swagger_csp"`
+		GraphiQLCSP string                     `mapstructure:"graphiql_csp"`
+	}
+	// Compression compresses the responses of at least min_size bytes in the
+	// encodings, gzip and zstd, the Accept-Encoding of the client prefers.
+	// max_inflated_size caps the gzip bodies of the bulk and import routes
+	// once inflated, in bytes.
+	Compression struct {
+		Encodings       []string `mapstructure:"encodings"`
+		MinSize         int      `mapstructure:"min_size"`
+		MaxInflatedSize int64    `mapstructure:"max_inflated_size"`
+	}
+	// GraphQL rejects the queries nesting more than max_depth selections,
+	// zero means no limit. With persisted_only it runs only the
+	// persisted_queries, the clients send their hashes.
+	GraphQL struct {
+		MaxDepth         int      `mapstructure:"max_depth"`
+		PersistedOnly    bool     `mapstructure:"persisted_only"`
+		PersistedQueries []string `mapstructure:"persisted_queries"`
+	}
+	// Routes announces the deprecation of each version of the API, the dates
+	// are written in RFC 3339.
+	Routes struct {
+		Root httpRouter.Deprecation `mapstructure:"root"`
+		V1   httpRouter.Deprecation `mapstructure:"v1"`
+	}
 )
 
 func LoadConfig(path string) (config Config, err error) {
@@ -28,6 +148,15 @@ func LoadConfig(path string) (config Config, err error) {
 		return
 	}
 
-	viper.Unmarshal(&config)
+	err = viper.BindEnv("auth.bootstrap_key", EnvBootstrapKey)
+	if err != nil {
+		return
+	}
+
+	viper.Unmarshal(&config, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		mapstructure.StringToTimeHookFunc(time.RFC3339),
+	)))
 	return
 }
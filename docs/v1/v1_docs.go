@@ -0,0 +1,3648 @@
+// Code generated by swaggo/swag. DO NOT EDIT.
+
+package v1
+
+import "github.com/swaggo/swag"
+
+const docTemplatev1 = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "contact": {},
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/admin/keys": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the API keys, revoked and expired ones included, without their secrets",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.APIKey"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create an API key. The key is only shown in this response, the scopes are cumulative: write also reads and admin also writes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "parameters": [
+                    {
+                        "description": "name, scopes and expiry of the key",
+                        "name": "key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.APIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.CreatedAPIKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke an API key, it stops authenticating at once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id api key",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/purge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Hard delete the houses and characters deleted longer than their retention",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "only report what would be purged",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.PurgeReport"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Load a snapshot of GET /admin/snapshot in one transaction, keeping the ids and timestamps. The database must be empty unless replace=true, which drops its houses and characters first",
+                "consumes": [
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "drop the houses and characters of the database first",
+                        "name": "replace",
+                        "in": "query"
+                    },
+                    {
+                        "description": "NDJSON snapshot",
+                        "name": "snapshot",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.RestoreReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/snapshot": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stream every house and character as NDJSON, a header record with the schema version opens it and a footer with the counts closes it. A snapshot without its footer was cut",
+                "produces": [
+                    "application/x-ndjson",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "also export the soft deleted rows",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.SnapshotRecord"
+                        },
+                        "headers": {
+                            "X-Snapshot-Version": {
+                                "type": "integer",
+                                "description": "the schema version of the snapshot"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/characters": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find characters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json",
+                    "application/ld+json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "character"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated json fields to return, e.g. id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated ids to fetch at once, answered as an entities.CharacterBatch in their order",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "admin only, also returns deleted characters",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "size of the page, every character when not informed",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "characters skipped before the page",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.Character"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create one character",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "character"
+                ],
+                "parameters": [
+                    {
+                        "description": "create new character",
+                        "name": "character",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "replays the first response when the request is retried",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "who makes the change, recorded in the history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/characters/:id": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find character by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json",
+                    "application/ld+json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "character"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated json fields to return, e.g. id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Character ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "admin only, also finds a deleted character",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update character",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json",
+                    "application/ld+json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "character"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Character ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "create new character",
+                        "name": "character",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "who makes the change, recorded in the history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete character",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "character"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Character ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "create new character",
+                        "name": "character",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "who makes the change, recorded in the history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.Character"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/characters/:id/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the changes made to a character, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "character"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated json fields to return, e.g. id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Character ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/characters/:id/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore a deleted character",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "character"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Character ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "make the character lord again of the houses it ruled before being deleted",
+                        "name": "reinstate_lordships",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "who makes the change, recorded in the history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterRestored"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/characters/batch-get": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find many characters by id at once, for lists of ids too long for GET /characters?ids=",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "character"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated json fields to return, e.g. id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "admin only, also finds deleted characters",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "description": "ids of the characters",
+                        "name": "ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.BatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterBatch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/characters/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create or update many characters, items with id are updated and the others created",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "character"
+                ],
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "roll back every item when one of them fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "description": "characters to create or update",
+                        "name": "characters",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterBulkRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "replays the first response when the request is retried",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "gzip to send the body compressed",
+                        "name": "Content-Encoding",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "who makes the change, recorded in the history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.BulkResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/houses": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find houses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json",
+                    "application/ld+json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "house"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated json fields to return, e.g. id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated ids to fetch at once, answered as an entities.HouseBatch in their order",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name house",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "admin only, also returns deleted houses when no name is informed",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "size of the page, every house when not informed",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "houses skipped before the page",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.House"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create one house",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "house"
+                ],
+                "parameters": [
+                    {
+                        "description": "create new house",
+                        "name": "house",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HouseRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "replays the first response when the request is retried",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "who makes the change, recorded in the history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/houses/:id": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find house by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json",
+                    "application/ld+json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "house"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated json fields to return, e.g. id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "House ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "admin only, also finds a deleted house",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.House"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update house",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json",
+                    "application/ld+json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "house"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "House ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "create new house",
+                        "name": "house",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HouseRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "who makes the change, recorded in the history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.House"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete house",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "house"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "House ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "create new house",
+                        "name": "house",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HouseRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "who makes the change, recorded in the history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.House"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/houses/:id/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the changes made to a house, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "house"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated json fields to return, e.g. id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "House ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/houses/:id/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore a deleted house, its name must not be used by another house",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json",
+                    "application/ld+json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "house"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "House ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "who makes the change, recorded in the history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.House"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/houses/batch-get": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find many houses by id at once, for lists of ids too long for GET /houses?ids=",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "house"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated json fields to return, e.g. id,name",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "admin only, also finds deleted houses",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "description": "ids of the houses",
+                        "name": "ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.BatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HouseBatch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/houses/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create or update many houses, items with id are updated and the others created",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "house"
+                ],
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "roll back every item when one of them fails",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "description": "houses to create or update",
+                        "name": "houses",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HouseBulkRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "replays the first response when the request is retried",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "gzip to send the body compressed",
+                        "name": "Content-Encoding",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "who makes the change, recorded in the history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.BulkResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/import/characters": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import characters from a CSV or JSON file, the body itself or the file field of a form. The tv_series of a CSV are separated by ;",
+                "consumes": [
+                    "text/csv",
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "parameters": [
+                    {
+                        "enum": [
+                            "insert"
+                        ],
+                        "type": "string",
+                        "description": "only insert, characters have no unique name",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only report what would be imported",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "source:field pairs renaming the columns, e.g. Nome:name,Temporadas:tv_series",
+                        "name": "mapping",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV or JSON file",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "gzip to send the body compressed",
+                        "name": "Content-Encoding",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "who makes the change, recorded in the history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.ImportReport"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.ImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/import/houses": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import houses from a CSV or JSON file, the body itself or the file field of a form",
+                "consumes": [
+                    "text/csv",
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "parameters": [
+                    {
+                        "enum": [
+                            "insert",
+                            "upsert-by-name"
+                        ],
+                        "type": "string",
+                        "description": "insert, the default, or upsert-by-name to update the houses with the name of a row",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only report what would be imported",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "source:field pairs renaming the columns, e.g. Nome:name,Regiao:region",
+                        "name": "mapping",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV or JSON file",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "gzip to send the body compressed",
+                        "name": "Content-Encoding",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "who makes the change, recorded in the history",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.ImportReport"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.ImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Submit a job run in the background, answered with its id at once. The params depend on the kind: purge takes dry_run, export include_deleted and the imports mode, dry_run and the items to import. The imports need the editor role, the purge and the export the admin role. An export is downloaded on GET /jobs/{id}/export",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "parameters": [
+                    {
+                        "description": "kind and params of the job",
+                        "name": "job",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.JobRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.Job"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "path of the job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find a job, with its status, progress and the result once it succeeded. Only the principal that submitted it and the admins find it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.Job"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cancel a job. A queued job is canceled at once, a running one is asked to stop and answered with 202 until its work returns",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.Job"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.Job"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Download the snapshot written by a succeeded export job, as NDJSON. Only the principal that submitted it and the admins download it, the file is deleted with the job once past the retention",
+                "produces": [
+                    "application/x-ndjson",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.SnapshotRecord"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Search the houses, by name and region, and the characters, by name, in one ranked list. Accents are ignored, each word also matches the words it starts and the names close to it match despite typos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "text to search",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated types to search, house and character by default",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "most results to return, 20 by default and up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.SearchResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Statistics of the houses and the characters: totals, soft deleted totals, houses per region and without a lord, characters per tv series and the creations and updates per time bucket",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "time bucket of the creations and updates: day, week, month (default) or year",
+                        "name": "bucket",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "counts the creations and updates from this date in RFC 3339, every one when not informed",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.Stats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/characters": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Statistics of the characters: totals, soft deleted total, characters per tv series and the creations and updates per time bucket",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "time bucket of the creations and updates: day, week, month (default) or year",
+                        "name": "bucket",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "counts the creations and updates from this date in RFC 3339, every one when not informed",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/houses": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Statistics of the houses: totals, soft deleted total, houses per region and without a lord and the creations and updates per time bucket",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "time bucket of the creations and updates: day, week, month (default) or year",
+                        "name": "bucket",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "counts the creations and updates from this date in RFC 3339, every one when not informed",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HouseStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.APIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.AuditChange": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {}
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.AuditChanges": {
+            "type": "object",
+            "additionalProperties": {
+                "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.AuditChange"
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "changes": {
+                    "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.AuditChanges"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "trace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.BatchRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.BulkResponse": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.BulkResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.BulkResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.Character": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tv_series": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterBatch": {
+            "type": "object",
+            "properties": {
+                "characters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.Character"
+                    }
+                },
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterBulkItem": {
+            "type": "object",
+            "required": [
+                "name",
+                "tv_series"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 3
+                },
+                "tv_series": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterBulkRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterBulkItem"
+                    }
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "tv_series"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 3
+                },
+                "tv_series": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterRestored": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reinstated_houses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tv_series": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterStats": {
+            "type": "object",
+            "properties": {
+                "by_tv_series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.StatsCount"
+                    }
+                },
+                "deleted": {
+                    "type": "integer"
+                },
+                "timeline": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.StatsTime"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.CreatedAPIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.House": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current_lord": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "foundation_year": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.HouseBatch": {
+            "type": "object",
+            "properties": {
+                "houses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.House"
+                    }
+                },
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.HouseBulkItem": {
+            "type": "object",
+            "required": [
+                "foundation_year",
+                "name",
+                "region"
+            ],
+            "properties": {
+                "current_lord": {
+                    "type": "string"
+                },
+                "foundation_year": {
+                    "type": "string",
+                    "maxLength": 5,
+                    "minLength": 1
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 3
+                },
+                "region": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.HouseBulkRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HouseBulkItem"
+                    }
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.HouseRequest": {
+            "type": "object",
+            "required": [
+                "foundation_year",
+                "name",
+                "region"
+            ],
+            "properties": {
+                "current_lord": {
+                    "type": "string"
+                },
+                "foundation_year": {
+                    "type": "string",
+                    "maxLength": 5,
+                    "minLength": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 3
+                },
+                "region": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.HouseStats": {
+            "type": "object",
+            "properties": {
+                "by_region": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.StatsCount"
+                    }
+                },
+                "deleted": {
+                    "type": "integer"
+                },
+                "timeline": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.StatsTime"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "without_lord": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.HttpErr": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "trace_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.ImportReport": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.BulkResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.Job": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "cancel_requested": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "params": {
+                    "type": "object"
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "object"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "submitted_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.JobRequest": {
+            "type": "object",
+            "required": [
+                "kind"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "params": {
+                    "type": "object"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.PurgeEntityReport": {
+            "type": "object",
+            "properties": {
+                "batches": {
+                    "type": "integer"
+                },
+                "candidates": {
+                    "type": "integer"
+                },
+                "deleted_before": {
+                    "type": "string"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "purged": {
+                    "type": "integer"
+                },
+                "retention": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.PurgeReport": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "entities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.PurgeEntityReport"
+                    }
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.RemovedLordship": {
+            "type": "object",
+            "properties": {
+                "house_id": {
+                    "type": "string"
+                },
+                "lord_id": {
+                    "type": "string"
+                },
+                "removed_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.RestoreReport": {
+            "type": "object",
+            "properties": {
+                "replaced": {
+                    "type": "boolean"
+                },
+                "restored": {
+                    "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.SnapshotCounts"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.SearchResult": {
+            "type": "object",
+            "properties": {
+                "highlight": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.SnapshotCounts": {
+            "type": "object",
+            "properties": {
+                "characters": {
+                    "type": "integer"
+                },
+                "houses": {
+                    "type": "integer"
+                },
+                "removed_lordships": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.SnapshotRecord": {
+            "type": "object",
+            "properties": {
+                "character": {
+                    "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.Character"
+                },
+                "counts": {
+                    "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.SnapshotCounts"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "house": {
+                    "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.House"
+                },
+                "include_deleted": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "removed_lordship": {
+                    "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.RemovedLordship"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.Stats": {
+            "type": "object",
+            "properties": {
+                "characters": {
+                    "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.CharacterStats"
+                },
+                "houses": {
+                    "$ref": "#/definitions/github_com_PatrickChagastavares_game-of-thrones_internal_entities.HouseStats"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.StatsCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_PatrickChagastavares_game-of-thrones_internal_entities.StatsTime": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "API key created on /admin/keys, or the bootstrap key of GOT_BOOTSTRAP_KEY",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "\"Bearer \" followed by a JWT of the platform, signed by a key of the auth.jwt JWKS",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    }
+}`
+
+// SwaggerInfov1 holds exported Swagger Info so clients can modify it
+var SwaggerInfov1 = &swag.Spec{
+	Version:          "",
+	Host:             "",
+	BasePath:         "",
+	Schemes:          []string{},
+	Title:            "",
+	Description:      "",
+	InfoInstanceName: "v1",
+	SwaggerTemplate:  docTemplatev1,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfov1.InstanceName(), SwaggerInfov1)
+}
@@ -0,0 +1,121 @@
+package apikeys
+
+import (
+	"net/http"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+type (
+	IController interface {
+		Create(c httpRouter.Context)
+		Find(c httpRouter.Context)
+		Revoke(c httpRouter.Context)
+	}
+	controllers struct {
+		srv *services.Container
+		log logger.Logger
+	}
+)
+
+func New(srv *services.Container, log logger.Logger) IController {
+	return &controllers{srv: srv, log: log}
+}
+
+// admin swagger document
+// @Description Create an API key. The key is only shown in this response, the scopes are cumulative: write also reads and admin also writes
+// @Tags admin
+// @Accept json
+// @Produce json,application/yaml,application/msgpack,application/problem+json
+// @Param key body entities.APIKeyRequest true "name, scopes and expiry of the key"
+// @Success 201 {object} entities.CreatedAPIKey
+// @Failure 400 {object} entities.HttpErr
+// @Failure 401 {object} entities.HttpErr
+// @Failure 403 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /admin/keys [post]
+func (ctrl *controllers) Create(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.apikeys.create")
+	defer span.End()
+
+	var request entities.APIKeyRequest
+	if err := c.Decode(&request); err != nil {
+		responseErr(ctx, c, entities.ErrDecode)
+		return
+	}
+
+	if err := c.Validate(request); err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	created, err := ctrl.srv.APIKey.Create(ctx, request)
+	if err != nil {
+		ctrl.log.Error("Ctrl.Create: ", "Error on create api key: ", request.Name, err)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	c.Respond(http.StatusCreated, created)
+}
+
+// admin swagger document
+// @Description List the API keys, revoked and expired ones included, without their secrets
+// @Tags admin
+// @Accept json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Success 200 {object} []entities.APIKey
+// @Failure 401 {object} entities.HttpErr
+// @Failure 403 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /admin/keys [get]
+func (ctrl *controllers) Find(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.apikeys.find")
+	defer span.End()
+
+	keys, err := ctrl.srv.APIKey.Find(ctx)
+	if err != nil {
+		ctrl.log.Error("Ctrl.Find: ", "Error on find api keys: ", err)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	c.Respond(http.StatusOK, keys)
+}
+
+// admin swagger document
+// @Description Revoke an API key, it stops authenticating at once
+// @Tags admin
+// @Accept json
+// @Produce json,application/problem+json
+// @Param	id	path	string	true	"id api key"
+// @Success 204
+// @Failure 401 {object} entities.HttpErr
+// @Failure 403 {object} entities.HttpErr
+// @Failure 404 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /admin/keys/{id} [delete]
+func (ctrl *controllers) Revoke(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.apikeys.revoke")
+	defer span.End()
+
+	if _, err := ctrl.srv.APIKey.Revoke(ctx, c.GetParam("id")); err != nil {
+		ctrl.log.Error("Ctrl.Revoke: ", "Error on revoke api key: ", c.GetParam("id"), err)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	c.Respond(http.StatusNoContent, nil)
+}
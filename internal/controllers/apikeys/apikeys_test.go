@@ -0,0 +1,232 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/apikeys"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var created = time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+
+func Test_Create(t *testing.T) {
+	endpoint := "/admin/keys"
+
+	cases := map[string]struct {
+		input        string
+		expectedCode int
+		expectedData string
+		prepareMock  func(mock *apikeys.MockIService)
+	}{
+		"Should return created": {
+			input:        `{"name":"importer","scopes":["write"]}`,
+			expectedCode: http.StatusCreated,
+			expectedData: `{"id":"id_1","name":"importer","prefix":"got_abcd","scopes":["write"],"created_at":"2023-01-02T15:04:05Z","expires_at":null,"revoked_at":null,"key":"got_abcdef"}`,
+			prepareMock: func(mock *apikeys.MockIService) {
+				mock.EXPECT().
+					Create(gomock.Any(), entities.APIKeyRequest{Name: "importer", Scopes: pq.StringArray{entities.ScopeWrite}}).
+					Times(1).
+					Return(entities.CreatedAPIKey{
+						APIKey: entities.APIKey{ID: "id_1", Name: "importer", Prefix: "got_abcd", Hash: "hash",
+							Scopes: pq.StringArray{entities.ScopeWrite}, CreatedAt: created},
+						Key: "got_abcdef",
+					}, nil)
+			},
+		},
+		"Should return error validation of the scopes": {
+			input:        `{"name":"importer","scopes":["root"]}`,
+			expectedCode: http.StatusBadRequest,
+			prepareMock:  func(mock *apikeys.MockIService) {},
+		},
+		"Should return error expiry": {
+			input:        `{"name":"importer","scopes":["read"],"expires_at":"2020-01-02T15:04:05Z"}`,
+			expectedCode: http.StatusBadRequest,
+			prepareMock: func(mock *apikeys.MockIService) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(entities.CreatedAPIKey{}, entities.ErrAPIKeyExpiry)
+			},
+		},
+		"Should return error service": {
+			input:        `{"name":"importer","scopes":["read"]}`,
+			expectedCode: http.StatusInternalServerError,
+			prepareMock: func(mock *apikeys.MockIService) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(entities.CreatedAPIKey{}, errors.New("problem to create api key"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := apikeys.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{APIKey: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.Create)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(cs.input)).WithContext(ctx)
+			request.Header.Set("Content-Type", "application/json")
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			if cs.expectedData != "" {
+				assert.Equal(t, cs.expectedData, string(responseData))
+			}
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
+
+func Test_Find(t *testing.T) {
+	endpoint := "/admin/keys"
+
+	cases := map[string]struct {
+		expectedCode int
+		expectedData string
+		prepareMock  func(mock *apikeys.MockIService)
+	}{
+		"Should return success": {
+			expectedCode: http.StatusOK,
+			expectedData: `[{"id":"id_1","name":"reader","prefix":"got_abcd","scopes":["read"],"created_at":"2023-01-02T15:04:05Z","expires_at":null,"revoked_at":"2023-01-02T15:04:05Z"}]`,
+			prepareMock: func(mock *apikeys.MockIService) {
+				mock.EXPECT().
+					Find(gomock.Any()).
+					Times(1).
+					Return([]entities.APIKey{{ID: "id_1", Name: "reader", Prefix: "got_abcd", Hash: "hash",
+						Scopes: pq.StringArray{entities.ScopeRead}, CreatedAt: created, RevokedAt: &created}}, nil)
+			},
+		},
+		"Should return error service": {
+			expectedCode: http.StatusInternalServerError,
+			prepareMock: func(mock *apikeys.MockIService) {
+				mock.EXPECT().
+					Find(gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("problem to find api keys"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := apikeys.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{APIKey: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Get(endpoint, ctr.Find)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodGet, endpoint, nil).WithContext(ctx)
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			if cs.expectedData != "" {
+				assert.Equal(t, cs.expectedData, string(responseData))
+			}
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
+
+func Test_Revoke(t *testing.T) {
+	endpoint := "/admin/keys/:id"
+
+	cases := map[string]struct {
+		expectedCode int
+		prepareMock  func(mock *apikeys.MockIService)
+	}{
+		"Should return no content": {
+			expectedCode: http.StatusNoContent,
+			prepareMock: func(mock *apikeys.MockIService) {
+				mock.EXPECT().Revoke(gomock.Any(), "id_1").Times(1).
+					Return(entities.APIKey{ID: "id_1", RevokedAt: &created}, nil)
+			},
+		},
+		"Should return not found": {
+			expectedCode: http.StatusNotFound,
+			prepareMock: func(mock *apikeys.MockIService) {
+				mock.EXPECT().Revoke(gomock.Any(), "id_1").Times(1).
+					Return(entities.APIKey{}, apikeys.ErrAPIKeyNotFound)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := apikeys.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{APIKey: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Delete(endpoint, ctr.Revoke)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodDelete, "/admin/keys/id_1", nil).WithContext(ctx)
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package apikeys
+
+import (
+	"context"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+func responseErr(ctx context.Context, c httpRouter.Context, err error) {
+	ctx, span := tracer.Span(ctx, "controllers.apikeys.responseErr")
+	defer span.End()
+
+	problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+	c.Problem(problem.Status, problem)
+}
@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	HeaderActor     = "X-Actor"
+	HeaderRequestID = "X-Request-ID"
+
+	maxHeaderLength = 100
+	// maxActorLength is the size of the actor columns of the audit entries
+	// and the jobs
+	maxActorLength = 200
+)
+
+type (
+	IController interface {
+		// Handle must run before the handlers that change houses or characters.
+		Handle(c httpRouter.Context)
+		// Principal must run after the authentication, it records the
+		// principal of the request as its actor.
+		Principal(c httpRouter.Context)
+		// Unary is the interceptor that does both for the gRPC calls, it
+		// must run after their authentication.
+		Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error)
+	}
+	controllers struct {
+		log logger.Logger
+	}
+)
+
+func New(log logger.Logger) IController {
+	return &controllers{log: log}
+}
+
+// Handle puts who is making the request in its context, so the audit entries
+// written by the services can name them. A request id is generated when the
+// client doesn't send one and is always echoed back.
+func (ctrl *controllers) Handle(c httpRouter.Context) {
+	metadata := entities.AuditMetadata{
+		Actor:     truncate(c.GetHeader(HeaderActor), maxHeaderLength),
+		RequestID: truncate(c.GetHeader(HeaderRequestID), maxHeaderLength),
+	}
+	if len(metadata.RequestID) == 0 {
+		metadata.RequestID = uuid.NewString()
+	}
+
+	c.SetHeader(HeaderRequestID, metadata.RequestID)
+	c.SetContext(entities.ContextWithAuditMetadata(c.Context(), metadata))
+	c.Next()
+}
+
+// Principal names the actor after the principal of the request, keeping the
+// actor of the X-Actor header the principal acts for.
+func (ctrl *controllers) Principal(c httpRouter.Context) {
+	principal, ok := httpRouter.PrincipalFromContext(c.Context())
+	if !ok {
+		c.Next()
+		return
+	}
+
+	metadata := entities.AuditMetadataFromContext(c.Context())
+	metadata.Actor = actor(metadata.Actor, principal)
+
+	c.SetContext(entities.ContextWithAuditMetadata(c.Context(), metadata))
+	c.Next()
+}
+
+// Unary reads the actor and the request id of the metadata of the call, the
+// request id is echoed back in the header of the response.
+func (ctrl *controllers) Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	audit := entities.AuditMetadata{
+		Actor:     truncate(first(md, HeaderActor), maxHeaderLength),
+		RequestID: truncate(first(md, HeaderRequestID), maxHeaderLength),
+	}
+	if len(audit.RequestID) == 0 {
+		audit.RequestID = uuid.NewString()
+	}
+	if principal, ok := httpRouter.PrincipalFromContext(ctx); ok {
+		audit.Actor = actor(audit.Actor, principal)
+	}
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(HeaderRequestID, audit.RequestID)); err != nil {
+		ctrl.log.Error("Ctrl.Unary: ", "Error on set request id header: ", err)
+	}
+	return handler(entities.ContextWithAuditMetadata(ctx, audit), req)
+}
+
+// actor names the principal, keeping the actor it acts for.
+func actor(actor string, principal httpRouter.Principal) string {
+	if len(actor) > 0 {
+		return truncate(fmt.Sprintf("%s (%s)", actor, truncate(principal.Name, maxHeaderLength)), maxActorLength)
+	}
+	return truncate(principal.Name, maxHeaderLength)
+}
+
+func first(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// truncate keeps the first length characters of value, cut between two runes
+// so the value stays valid UTF-8 for the database.
+func truncate(value string, length int) string {
+	end := 0
+	for runes := 0; end < len(value) && runes < length; runes++ {
+		_, size := utf8.DecodeRuneInString(value[end:])
+		end += size
+	}
+	return value[:end]
+}
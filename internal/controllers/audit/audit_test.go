@@ -0,0 +1,193 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func Test_Handle(t *testing.T) {
+	endpoint := "/houses"
+
+	cases := map[string]struct {
+		headers           map[string]string
+		expectedActor     string
+		expectedRequestID string
+	}{
+		"Should keep the headers sent": {
+			headers:           map[string]string{HeaderActor: "patrick", HeaderRequestID: "request_1"},
+			expectedActor:     "patrick",
+			expectedRequestID: "request_1",
+		},
+		"Should generate a request id": {
+			headers:       map[string]string{HeaderActor: "patrick"},
+			expectedActor: "patrick",
+		},
+		"Should truncate long headers": {
+			headers:           map[string]string{HeaderActor: strings.Repeat("a", 150), HeaderRequestID: "request_1"},
+			expectedActor:     strings.Repeat("a", maxHeaderLength),
+			expectedRequestID: "request_1",
+		},
+		"Should truncate long headers between two runes": {
+			headers:           map[string]string{HeaderActor: strings.Repeat("ã", 150), HeaderRequestID: strings.Repeat("ç", 150)},
+			expectedActor:     strings.Repeat("ã", maxHeaderLength),
+			expectedRequestID: strings.Repeat("ç", maxHeaderLength),
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ START CONTROLLER ============
+			ctr := New(logger.NewLogrusLogger())
+
+			// ============ START ROUTER ============
+			var metadata entities.AuditMetadata
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.Handle, func(c httpRouter.Context) {
+				metadata = entities.AuditMetadataFromContext(c.Context())
+				c.Respond(http.StatusCreated, nil)
+			})
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodPost, endpoint, nil)
+			writer := httptest.NewRecorder()
+			request.Header.Set("Content-Type", "application/json")
+			for key, value := range cs.headers {
+				request.Header.Set(key, value)
+			}
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			assert.Equal(t, cs.expectedActor, metadata.Actor)
+			assert.NotEmpty(t, metadata.RequestID)
+			if len(cs.expectedRequestID) > 0 {
+				assert.Equal(t, cs.expectedRequestID, metadata.RequestID)
+			}
+			assert.Equal(t, metadata.RequestID, writer.Header().Get(HeaderRequestID))
+			assert.Equal(t, http.StatusCreated, writer.Code)
+		})
+	}
+}
+
+func Test_Principal(t *testing.T) {
+	endpoint := "/houses"
+
+	cases := map[string]struct {
+		actor         string
+		principal     *httpRouter.Principal
+		expectedActor string
+	}{
+		"Should name the actor after the principal": {
+			principal:     &httpRouter.Principal{Name: "ci"},
+			expectedActor: "ci",
+		},
+		"Should keep the actor the principal acts for": {
+			actor:         "patrick",
+			principal:     &httpRouter.Principal{Name: "ci"},
+			expectedActor: "patrick (ci)",
+		},
+		"Should cap the actor to the size of its column": {
+			actor:         strings.Repeat("a", maxHeaderLength),
+			principal:     &httpRouter.Principal{Name: strings.Repeat("k", maxHeaderLength)},
+			expectedActor: strings.Repeat("a", maxHeaderLength) + " (" + strings.Repeat("k", maxActorLength-maxHeaderLength-2),
+		},
+		"Should keep the actor without principal": {
+			actor:         "patrick",
+			expectedActor: "patrick",
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ START CONTROLLER ============
+			ctr := New(logger.NewLogrusLogger())
+
+			// ============ START ROUTER ============
+			var metadata entities.AuditMetadata
+			authenticate := func(c httpRouter.Context) {
+				if cs.principal != nil {
+					c.SetContext(httpRouter.ContextWithPrincipal(c.Context(), *cs.principal))
+				}
+			}
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.Handle, authenticate, ctr.Principal, func(c httpRouter.Context) {
+				metadata = entities.AuditMetadataFromContext(c.Context())
+				c.Respond(http.StatusCreated, nil)
+			})
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodPost, endpoint, nil)
+			writer := httptest.NewRecorder()
+			request.Header.Set(HeaderActor, cs.actor)
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			assert.Equal(t, cs.expectedActor, metadata.Actor)
+			assert.Equal(t, http.StatusCreated, writer.Code)
+		})
+	}
+}
+
+func Test_Unary(t *testing.T) {
+	cases := map[string]struct {
+		metadata          metadata.MD
+		principal         *httpRouter.Principal
+		expectedActor     string
+		expectedRequestID string
+	}{
+		"Should keep the metadata sent": {
+			metadata:          metadata.Pairs(HeaderActor, "patrick", HeaderRequestID, "request_1"),
+			expectedActor:     "patrick",
+			expectedRequestID: "request_1",
+		},
+		"Should name the actor after the principal": {
+			metadata:      metadata.Pairs(HeaderActor, "patrick"),
+			principal:     &httpRouter.Principal{Name: "ci"},
+			expectedActor: "patrick (ci)",
+		},
+		"Should generate a request id": {
+			principal:     &httpRouter.Principal{Name: "ci"},
+			expectedActor: "ci",
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ START CONTROLLER ============
+			ctr := New(logger.NewLogrusLogger())
+
+			// ============ START MOCK CALL ============
+			ctx := metadata.NewIncomingContext(context.Background(), cs.metadata)
+			if cs.principal != nil {
+				ctx = httpRouter.ContextWithPrincipal(ctx, *cs.principal)
+			}
+
+			var audit entities.AuditMetadata
+			_, err := ctr.Unary(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+				audit = entities.AuditMetadataFromContext(ctx)
+				return nil, nil
+			})
+
+			// ============ START VALIDATION ============
+			assert.NoError(t, err)
+			assert.Equal(t, cs.expectedActor, audit.Actor)
+			assert.NotEmpty(t, audit.RequestID)
+			if len(cs.expectedRequestID) > 0 {
+				assert.Equal(t, cs.expectedRequestID, audit.RequestID)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/apikeys"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/grpcServer"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/jwt"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"google.golang.org/grpc"
+)
+
+// The ids of the principals are prefixed by their credential, so an API key
+// and the subject of a token never share the jobs, idempotency keys and rate
+// limits of one another. The bootstrap key goes by its own id.
+const (
+	keyPrefix = "key:"
+	jwtPrefix = "jwt:"
+)
+
+// scopeRoles gives the API keys the role of their scope.
+var scopeRoles = map[string]string{
+	entities.ScopeRead:  httpRouter.RoleReader,
+	entities.ScopeWrite: httpRouter.RoleEditor,
+	entities.ScopeAdmin: httpRouter.RoleAdmin,
+}
+
+type (
+	IController interface {
+		// Authenticate is the middleware that authenticates the request by
+		// its bearer token or API key.
+		Authenticate(c httpRouter.Context)
+		// Require is the middleware of a route that needs role, declared
+		// next to the registration of the route.
+		Require(role string) httpRouter.HandlerFunc
+		// Unary is the interceptor that authenticates the gRPC calls by the
+		// same credentials, roles maps the full name of the methods to the
+		// role they need.
+		Unary(roles map[string]string) grpc.UnaryServerInterceptor
+	}
+
+	// Options of the bearer tokens, without a Verifier only the API keys
+	// authenticate.
+	Options struct {
+		Verifier jwt.Verifier
+		// RolesClaim is the path of the claim with the roles of the token,
+		// realm_access.roles for a nested one.
+		RolesClaim string
+		// Roles maps the values of the claim, compared in lower case, to the
+		// roles. The values it doesn't map are taken as roles.
+		Roles map[string]string
+	}
+
+	controllers struct {
+		srv  *services.Container
+		log  logger.Logger
+		opts Options
+		auth httpRouter.Auth
+	}
+)
+
+// New returns the controller of the authentication, deny answers the
+// requests it rejects.
+func New(srv *services.Container, log logger.Logger, opts Options, deny func(c httpRouter.Context, err error)) IController {
+	if len(opts.RolesClaim) == 0 {
+		opts.RolesClaim = "roles"
+	}
+
+	ctrl := &controllers{srv: srv, log: log, opts: opts}
+	ctrl.auth = httpRouter.Auth{APIKey: ctrl.apiKey, Deny: deny}
+	if opts.Verifier != nil {
+		ctrl.auth.Bearer = ctrl.bearer
+	}
+	return ctrl
+}
+
+func (ctrl *controllers) Authenticate(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.auth.authenticate")
+	defer span.End()
+
+	c.SetContext(ctx)
+	ctrl.auth.Handle(c)
+}
+
+func (ctrl *controllers) Require(role string) httpRouter.HandlerFunc {
+	return ctrl.auth.Require(role)
+}
+
+func (ctrl *controllers) Unary(roles map[string]string) grpc.UnaryServerInterceptor {
+	auth := grpcServer.Auth{APIKey: ctrl.apiKey, Bearer: ctrl.auth.Bearer, Roles: roles}
+	return auth.Unary()
+}
+
+func (ctrl *controllers) apiKey(ctx context.Context, key string) (principal httpRouter.Principal, err error) {
+	apiKey, err := ctrl.srv.APIKey.Authenticate(ctx, key)
+	if errors.Is(err, apikeys.ErrInvalidAPIKey) {
+		return principal, httpRouter.ErrUnauthenticated
+	}
+	if err != nil {
+		ctrl.log.Error("Ctrl.Authenticate: ", "Error on authenticate api key: ", err)
+		return principal, err
+	}
+
+	principal = httpRouter.Principal{ID: keyPrefix + apiKey.ID, Name: apiKey.Name}
+	if apiKey.ID == apikeys.BootstrapID {
+		principal.ID = apikeys.BootstrapID
+	}
+	for _, scope := range apiKey.Scopes {
+		principal.Roles = append(principal.Roles, scopeRoles[scope])
+	}
+	return principal, nil
+}
+
+func (ctrl *controllers) bearer(ctx context.Context, token string) (principal httpRouter.Principal, err error) {
+	claims, err := ctrl.opts.Verifier.Verify(ctx, token)
+	if errors.Is(err, jwt.ErrInvalidToken) {
+		ctrl.log.Info("Ctrl.Authenticate: ", "Rejected bearer token: ", err)
+		return principal, httpRouter.ErrUnauthenticated
+	}
+	if err != nil {
+		ctrl.log.Error("Ctrl.Authenticate: ", "Error on verify bearer token: ", err)
+		return principal, err
+	}
+
+	if len(claims.Subject) == 0 {
+		ctrl.log.Info("Ctrl.Authenticate: ", "Rejected bearer token: ", "no sub")
+		return principal, httpRouter.ErrUnauthenticated
+	}
+
+	principal = httpRouter.Principal{ID: jwtPrefix + claims.Subject, Name: claims.Subject}
+	for _, value := range claims.Strings(ctrl.opts.RolesClaim) {
+		if role, ok := ctrl.opts.Roles[strings.ToLower(value)]; ok {
+			value = role
+		}
+		principal.Roles = append(principal.Roles, value)
+	}
+	return principal, nil
+}
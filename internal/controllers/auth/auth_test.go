@@ -0,0 +1,290 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/problem"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/apikeys"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/jwt"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// verifier accepts the tokens of its map.
+type verifier map[string]jwt.Claims
+
+func (v verifier) Verify(ctx context.Context, token string) (jwt.Claims, error) {
+	if token == "broken" {
+		return jwt.Claims{}, errors.New("jwt: fetch jwks: status 500")
+	}
+	claims, ok := v[token]
+	if !ok {
+		return claims, fmt.Errorf("%w: bad signature", jwt.ErrInvalidToken)
+	}
+	return claims, nil
+}
+
+func Test_Authenticate(t *testing.T) {
+	tokens := verifier{
+		"editor": {Subject: "user_1", Raw: map[string]any{"realm_access": map[string]any{"roles": []any{"got-editors"}}}},
+		"admin":  {Subject: "user_2", Raw: map[string]any{"realm_access": map[string]any{"roles": []any{"admin"}}}},
+		"nosub":  {Raw: map[string]any{"realm_access": map[string]any{"roles": []any{"admin"}}}},
+	}
+
+	cases := map[string]struct {
+		method            string
+		key               string
+		token             string
+		noVerifier        bool
+		expectedCode      int
+		expectedPrincipal httpRouter.Principal
+		prepareMock       func(mock *apikeys.MockIService)
+	}{
+		"Should read with an API key of the read scope": {
+			method:            http.MethodGet,
+			key:               "got_reader",
+			expectedCode:      http.StatusOK,
+			expectedPrincipal: httpRouter.Principal{ID: "key:id_1", Name: "reader", Roles: []string{httpRouter.RoleReader}},
+			prepareMock: func(mock *apikeys.MockIService) {
+				mock.EXPECT().Authenticate(gomock.Any(), "got_reader").Times(1).
+					Return(entities.APIKey{ID: "id_1", Name: "reader", Scopes: pq.StringArray{entities.ScopeRead}}, nil)
+			},
+		},
+		"Should delete with the bootstrap key under its own id": {
+			method:            http.MethodDelete,
+			key:               "got_bootstrap",
+			expectedCode:      http.StatusOK,
+			expectedPrincipal: httpRouter.Principal{ID: "bootstrap", Name: "bootstrap", Roles: []string{httpRouter.RoleAdmin}},
+			prepareMock: func(mock *apikeys.MockIService) {
+				mock.EXPECT().Authenticate(gomock.Any(), "got_bootstrap").Times(1).
+					Return(entities.APIKey{ID: apikeys.BootstrapID, Name: "bootstrap", Scopes: pq.StringArray{entities.ScopeAdmin}}, nil)
+			},
+		},
+		"Should return forbidden when an API key of the write scope deletes": {
+			method:       http.MethodDelete,
+			key:          "got_writer",
+			expectedCode: http.StatusForbidden,
+			prepareMock: func(mock *apikeys.MockIService) {
+				mock.EXPECT().Authenticate(gomock.Any(), "got_writer").Times(1).
+					Return(entities.APIKey{ID: "id_2", Name: "writer", Scopes: pq.StringArray{entities.ScopeWrite}}, nil)
+			},
+		},
+		"Should return unauthorized with an invalid API key": {
+			method:       http.MethodGet,
+			key:          "got_revoked",
+			expectedCode: http.StatusUnauthorized,
+			prepareMock: func(mock *apikeys.MockIService) {
+				mock.EXPECT().Authenticate(gomock.Any(), "got_revoked").Times(1).
+					Return(entities.APIKey{}, apikeys.ErrInvalidAPIKey)
+			},
+		},
+		"Should return error service of the API keys": {
+			method:       http.MethodGet,
+			key:          "got_reader",
+			expectedCode: http.StatusInternalServerError,
+			prepareMock: func(mock *apikeys.MockIService) {
+				mock.EXPECT().Authenticate(gomock.Any(), "got_reader").Times(1).
+					Return(entities.APIKey{}, errors.New("problem to find api key"))
+			},
+		},
+		"Should write with a token mapped to the editor role": {
+			method:            http.MethodPost,
+			token:             "editor",
+			expectedCode:      http.StatusOK,
+			expectedPrincipal: httpRouter.Principal{ID: "jwt:user_1", Name: "user_1", Roles: []string{httpRouter.RoleEditor}},
+			prepareMock:       func(mock *apikeys.MockIService) {},
+		},
+		"Should return forbidden when a token of an editor deletes": {
+			method:       http.MethodDelete,
+			token:        "editor",
+			expectedCode: http.StatusForbidden,
+			prepareMock:  func(mock *apikeys.MockIService) {},
+		},
+		"Should delete with a token of an admin": {
+			method:            http.MethodDelete,
+			token:             "admin",
+			expectedCode:      http.StatusOK,
+			expectedPrincipal: httpRouter.Principal{ID: "jwt:user_2", Name: "user_2", Roles: []string{httpRouter.RoleAdmin}},
+			prepareMock:       func(mock *apikeys.MockIService) {},
+		},
+		"Should return unauthorized with a token without sub": {
+			method:       http.MethodGet,
+			token:        "nosub",
+			expectedCode: http.StatusUnauthorized,
+			prepareMock:  func(mock *apikeys.MockIService) {},
+		},
+		"Should return unauthorized with an invalid token": {
+			method:       http.MethodGet,
+			token:        "forged",
+			expectedCode: http.StatusUnauthorized,
+			prepareMock:  func(mock *apikeys.MockIService) {},
+		},
+		"Should return error when the JWKS can't be fetched": {
+			method:       http.MethodGet,
+			token:        "broken",
+			expectedCode: http.StatusInternalServerError,
+			prepareMock:  func(mock *apikeys.MockIService) {},
+		},
+		"Should return unauthorized with a token without verifier": {
+			method:       http.MethodGet,
+			token:        "admin",
+			noVerifier:   true,
+			expectedCode: http.StatusUnauthorized,
+			prepareMock:  func(mock *apikeys.MockIService) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := apikeys.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			opts := Options{
+				Verifier:   tokens,
+				RolesClaim: "realm_access.roles",
+				Roles:      map[string]string{"got-editors": httpRouter.RoleEditor},
+			}
+			if cs.noVerifier {
+				opts.Verifier = nil
+			}
+			ctr := New(
+				&services.Container{APIKey: mock},
+				logger.NewLogrusLogger(),
+				opts,
+				problem.New().Denied,
+			)
+
+			var principal httpRouter.Principal
+			handler := func(c httpRouter.Context) {
+				principal, _ = httpRouter.PrincipalFromContext(c.Context())
+				c.Respond(http.StatusOK, nil)
+			}
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			group := router.Group("", ctr.Authenticate)
+			group.Get("/houses", ctr.Require(httpRouter.RoleReader), handler)
+			group.Post("/houses", ctr.Require(httpRouter.RoleEditor), handler)
+			group.Delete("/houses", ctr.Require(httpRouter.RoleAdmin), handler)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(cs.method, "/houses", nil).WithContext(ctx)
+			if len(cs.key) > 0 {
+				request.Header.Set(httpRouter.HeaderAPIKey, cs.key)
+			}
+			if len(cs.token) > 0 {
+				request.Header.Set(httpRouter.HeaderAuthorization, "Bearer "+cs.token)
+			}
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			assert.Equal(t, cs.expectedCode, writer.Code)
+			assert.Equal(t, cs.expectedPrincipal, principal)
+		})
+	}
+}
+
+func Test_Unary(t *testing.T) {
+	tokens := verifier{
+		"editor": {Subject: "user_1", Raw: map[string]any{"roles": []any{httpRouter.RoleEditor}}},
+	}
+	roles := map[string]string{
+		"/gameofthrones.v1.HouseService/GetHouse":    httpRouter.RoleReader,
+		"/gameofthrones.v1.HouseService/UpdateHouse": httpRouter.RoleEditor,
+		"/gameofthrones.v1.HouseService/DeleteHouse": httpRouter.RoleAdmin,
+	}
+
+	cases := map[string]struct {
+		method            string
+		metadata          metadata.MD
+		expectedCode      codes.Code
+		expectedPrincipal httpRouter.Principal
+		prepareMock       func(mock *apikeys.MockIService)
+	}{
+		"Should get with an API key of the read scope": {
+			method:            "/gameofthrones.v1.HouseService/GetHouse",
+			metadata:          metadata.Pairs(httpRouter.HeaderAPIKey, "got_reader"),
+			expectedPrincipal: httpRouter.Principal{ID: "key:id_1", Name: "reader", Roles: []string{httpRouter.RoleReader}},
+			prepareMock: func(mock *apikeys.MockIService) {
+				mock.EXPECT().Authenticate(gomock.Any(), "got_reader").Times(1).
+					Return(entities.APIKey{ID: "id_1", Name: "reader", Scopes: pq.StringArray{entities.ScopeRead}}, nil)
+			},
+		},
+		"Should update with a bearer token of an editor": {
+			method:            "/gameofthrones.v1.HouseService/UpdateHouse",
+			metadata:          metadata.Pairs(httpRouter.HeaderAuthorization, "Bearer editor"),
+			expectedPrincipal: httpRouter.Principal{ID: "jwt:user_1", Name: "user_1", Roles: []string{httpRouter.RoleEditor}},
+			prepareMock:       func(mock *apikeys.MockIService) {},
+		},
+		"Should return error role of delete": {
+			method:       "/gameofthrones.v1.HouseService/DeleteHouse",
+			metadata:     metadata.Pairs(httpRouter.HeaderAuthorization, "Bearer editor"),
+			expectedCode: codes.PermissionDenied,
+			prepareMock:  func(mock *apikeys.MockIService) {},
+		},
+		"Should return error invalid API key": {
+			method:       "/gameofthrones.v1.HouseService/GetHouse",
+			metadata:     metadata.Pairs(httpRouter.HeaderAPIKey, "got_revoked"),
+			expectedCode: codes.Unauthenticated,
+			prepareMock: func(mock *apikeys.MockIService) {
+				mock.EXPECT().Authenticate(gomock.Any(), "got_revoked").Times(1).
+					Return(entities.APIKey{}, apikeys.ErrInvalidAPIKey)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := apikeys.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{APIKey: mock},
+				logger.NewLogrusLogger(),
+				Options{Verifier: tokens},
+				problem.New().Denied,
+			)
+
+			// ============ START MOCK CALL ============
+			var principal httpRouter.Principal
+			handler := func(ctx context.Context, req any) (any, error) {
+				principal, _ = httpRouter.PrincipalFromContext(ctx)
+				return nil, nil
+			}
+
+			ctx = metadata.NewIncomingContext(ctx, cs.metadata)
+			_, err := ctr.Unary(roles)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: cs.method}, handler)
+
+			// ============ START VALIDATION ============
+			assert.Equal(t, cs.expectedCode, status.Code(err))
+			assert.Equal(t, cs.expectedPrincipal, principal)
+		})
+	}
+}
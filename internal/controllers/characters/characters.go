@@ -1,6 +1,7 @@
 package characters
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
@@ -8,6 +9,7 @@ import (
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/validator"
 )
 
 type (
@@ -17,27 +19,37 @@ type (
 		FindByID(c httpRouter.Context)
 		Update(c httpRouter.Context)
 		Delete(c httpRouter.Context)
+		Restore(c httpRouter.Context)
+		History(c httpRouter.Context)
+		Bulk(c httpRouter.Context)
+		BatchGet(c httpRouter.Context)
 	}
 	controllers struct {
-		srv *services.Container
-		log logger.Logger
+		srv       *services.Container
+		log       logger.Logger
+		validator validator.Validator
 	}
 )
 
 func New(srv *services.Container, log logger.Logger) IController {
-	return &controllers{srv: srv, log: log}
+	return &controllers{srv: srv, log: log, validator: validator.New()}
 }
 
 // character swagger document
 // @Description Create one character
 // @Tags character
 // @Accept json
-// @Produce json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
 // @Param character body entities.CharacterRequest true "create new character"
+// @Param Idempotency-Key header string false "replays the first response when the request is retried"
+// @Param X-Actor header string false "who makes the change, recorded in the history"
 // @Success 201
 // @Failure 400 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
 // @Failure 409 {object} entities.HttpErr
-// @Failure 500
+// @Failure 422 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
 // @Security ApiKeyAuth
 // @Router /characters [post]
 func (ctrl *controllers) Create(c httpRouter.Context) {
@@ -46,23 +58,23 @@ func (ctrl *controllers) Create(c httpRouter.Context) {
 
 	var newCharacter entities.CharacterRequest
 	if err := c.Decode(&newCharacter); err != nil {
-		c.JSON(http.StatusBadRequest, entities.ErrDecode)
+		responseErr(ctx, c, entities.ErrDecode)
 		return
 	}
 
 	if err := c.Validate(newCharacter); err != nil {
-		c.JSON(http.StatusBadRequest, err)
+		responseErr(ctx, c, err)
 		return
 	}
 
 	id, err := ctrl.srv.Character.Create(ctx, newCharacter)
 	if err != nil {
 		ctrl.log.Error("Ctrl.Create: ", "Error on create character: ", newCharacter)
-		responseErr(ctx, err, c.JSON)
+		responseErr(ctx, c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, map[string]any{
+	c.Respond(http.StatusCreated, map[string]any{
 		"id": id,
 	})
 }
@@ -71,33 +83,66 @@ func (ctrl *controllers) Create(c httpRouter.Context) {
 // @Description Find characters
 // @Tags character
 // @Accept json
-// @Produce json
+// @Produce json,application/hal+json,application/ld+json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	fields	query	string	false	"comma separated json fields to return, e.g. id,name"
+// @Param	ids	query	string	false	"comma separated ids to fetch at once, answered as an entities.CharacterBatch in their order"
+// @Param	include_deleted	query	bool	false	"admin only, also returns deleted characters"
+// @Param	limit	query	int	false	"size of the page, every character when not informed"
+// @Param	offset	query	int	false	"characters skipped before the page"
 // @Success 200 {object} []entities.Character
-// @Failure 500
+// @Failure 400 {object} entities.HttpErr
+// @Failure 403 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
 // @Security ApiKeyAuth
 // @Router /characters [get]
 func (ctrl *controllers) Find(c httpRouter.Context) {
 	ctx, span := tracer.Span(c.Context(), "controllers.characters.find")
 	defer span.End()
 
-	characters, err := ctrl.srv.Character.Find(ctx)
+	if raw := c.GetQuery("ids"); len(raw) > 0 {
+		ids, err := entities.ParseIDs(raw)
+		if err != nil {
+			responseErr(ctx, c, err)
+			return
+		}
+
+		ctrl.findByIDs(ctx, c, ids)
+		return
+	}
+
+	f, err := pageFilter(c)
+	if err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	characters, err := ctrl.srv.Character.Find(ctx, f)
 	if err != nil {
 		ctrl.log.Error("Ctrl.Find: ", "Error on find characters: ", err)
-		responseErr(ctx, err, c.JSON)
+		responseErr(ctx, c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, characters)
+	responseCharacters(c, characters, f)
 }
 
 // character swagger document
 // @Description find character by id
 // @Tags character
 // @Accept json
-// @Produce json
+// @Produce json,application/hal+json,application/ld+json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	fields	query	string	false	"comma separated json fields to return, e.g. id,name"
 // @Param id path string true "Character ID"
+// @Param	include_deleted	query	bool	false	"admin only, also finds a deleted character"
 // @Success 200 {object} entities.Character
-// @Failure 500
+// @Failure 400 {object} entities.HttpErr
+// @Failure 403 {object} entities.HttpErr
+// @Failure 404 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
 // @Security ApiKeyAuth
 // @Router /characters/:id [get]
 func (ctrl *controllers) FindByID(c httpRouter.Context) {
@@ -106,26 +151,36 @@ func (ctrl *controllers) FindByID(c httpRouter.Context) {
 
 	id := c.GetParam("id")
 
-	characters, err := ctrl.srv.Character.FindByID(ctx, id)
+	f, err := filter(c)
+	if err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	characters, err := ctrl.srv.Character.FindByID(ctx, id, f)
 	if err != nil {
 		ctrl.log.Error("Ctrl.FindByID: ", "Error on find character: ", id)
-		responseErr(ctx, err, c.JSON)
+		responseErr(ctx, c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, characters)
+	responseCharacter(c, http.StatusOK, characters, f.Fields)
 }
 
 // character swagger document
 // @Description Update character
 // @Tags character
 // @Accept json
-// @Produce json
+// @Produce json,application/hal+json,application/ld+json,text/csv,application/yaml,application/msgpack,application/problem+json
 // @Param id path string true "Character ID"
 // @Param character body entities.CharacterRequest true "create new character"
+// @Param X-Actor header string false "who makes the change, recorded in the history"
 // @Success 200 {object} entities.Character
 // @Failure 400 {object} entities.HttpErr
-// @Failure 500
+// @Failure 404 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
 // @Security ApiKeyAuth
 // @Router /characters/:id [put]
 func (ctrl *controllers) Update(c httpRouter.Context) {
@@ -134,12 +189,12 @@ func (ctrl *controllers) Update(c httpRouter.Context) {
 
 	var updateCharacter entities.CharacterRequest
 	if err := c.Decode(&updateCharacter); err != nil {
-		c.JSON(http.StatusBadRequest, entities.ErrDecode)
+		responseErr(ctx, c, entities.ErrDecode)
 		return
 	}
 
 	if err := c.Validate(updateCharacter); err != nil {
-		c.JSON(http.StatusBadRequest, err)
+		responseErr(ctx, c, err)
 		return
 	}
 
@@ -148,23 +203,26 @@ func (ctrl *controllers) Update(c httpRouter.Context) {
 	characters, err := ctrl.srv.Character.Update(ctx, updateCharacter)
 	if err != nil {
 		ctrl.log.Error("Ctrl.Update: ", "Error on update character: ", updateCharacter)
-		responseErr(ctx, err, c.JSON)
+		responseErr(ctx, c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, characters)
+	responseCharacter(c, http.StatusOK, characters, nil)
 }
 
 // character swagger document
 // @Description Delete character
 // @Tags character
 // @Accept json
-// @Produce json
+// @Produce json,application/problem+json
 // @Param id path string true "Character ID"
 // @Param character body entities.CharacterRequest true "create new character"
+// @Param X-Actor header string false "who makes the change, recorded in the history"
 // @Success 200 {object} entities.Character
 // @Failure 400 {object} entities.HttpErr
-// @Failure 500
+// @Failure 404 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
 // @Security ApiKeyAuth
 // @Router /characters/:id [delete]
 func (ctrl *controllers) Delete(c httpRouter.Context) {
@@ -176,9 +234,223 @@ func (ctrl *controllers) Delete(c httpRouter.Context) {
 	err := ctrl.srv.Character.Delete(ctx, id)
 	if err != nil {
 		ctrl.log.Error("Ctrl.Delete: ", "Error on delete character: ", id)
-		responseErr(ctx, err, c.JSON)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	c.Respond(http.StatusNoContent, nil)
+}
+
+// character swagger document
+// @Description Restore a deleted character
+// @Tags character
+// @Accept json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param id path string true "Character ID"
+// @Param	reinstate_lordships	query	bool	false	"make the character lord again of the houses it ruled before being deleted"
+// @Param X-Actor header string false "who makes the change, recorded in the history"
+// @Success 200 {object} entities.CharacterRestored
+// @Failure 400 {object} entities.HttpErr
+// @Failure 404 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 409 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /characters/:id/restore [post]
+func (ctrl *controllers) Restore(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.characters.restore")
+	defer span.End()
+
+	id := c.GetParam("id")
+	reinstateLordships := c.GetQuery("reinstate_lordships") == "true"
+
+	character, err := ctrl.srv.Character.Restore(ctx, id, reinstateLordships)
+	if err != nil {
+		ctrl.log.Error("Ctrl.Restore: ", "Error on restore character: ", id)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	c.Respond(http.StatusOK, character)
+}
+
+// character swagger document
+// @Description List the changes made to a character, newest first
+// @Tags character
+// @Accept json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	fields	query	string	false	"comma separated json fields to return, e.g. id,name"
+// @Param id path string true "Character ID"
+// @Success 200 {array} entities.AuditEntry
+// @Failure 400 {object} entities.HttpErr
+// @Failure 404 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /characters/:id/history [get]
+func (ctrl *controllers) History(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.characters.history")
+	defer span.End()
+
+	id := c.GetParam("id")
+
+	fields, err := entities.ParseFields(c.GetQuery("fields"), entities.AuditEntry{})
+	if err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	entries, err := ctrl.srv.Character.History(ctx, id, fields)
+	if err != nil {
+		ctrl.log.Error("Ctrl.History: ", "Error on find history of character: ", id)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	c.Respond(http.StatusOK, entities.Project(entries, fields))
+}
+
+// character swagger document
+// @Description Create or update many characters, items with id are updated and the others created
+// @Tags character
+// @Accept json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	atomic	query	bool	false	"roll back every item when one of them fails"
+// @Param characters body entities.CharacterBulkRequest true "characters to create or update"
+// @Param Idempotency-Key header string false "replays the first response when the request is retried"
+// @Param Content-Encoding header string false "gzip to send the body compressed"
+// @Param X-Actor header string false "who makes the change, recorded in the history"
+// @Success 200 {object} entities.BulkResponse
+// @Success 207 {object} entities.BulkResponse
+// @Failure 400 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 409 {object} entities.HttpErr
+// @Failure 413 {object} entities.HttpErr
+// @Failure 415 {object} entities.HttpErr
+// @Failure 422 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /characters/bulk [post]
+func (ctrl *controllers) Bulk(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.characters.bulk")
+	defer span.End()
+
+	var bulk entities.CharacterBulkRequest
+	if err := c.Decode(&bulk); err != nil {
+		responseErr(ctx, c, entities.ErrDecode)
+		return
+	}
+
+	if err := c.Validate(bulk); err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	atomic := c.GetQuery("atomic") == "true"
+
+	results := make([]entities.BulkResult, len(bulk.Items))
+	items := make([]entities.CharacterRequest, 0, len(bulk.Items))
+	positions := make([]int, 0, len(bulk.Items))
+	for i, item := range bulk.Items {
+		results[i].Index = i
+		if err := ctrl.validator.Validate(item); err != nil {
+			results[i].Err = entities.ErrBulkInvalidItem
+			results[i].Detail = err.Violations
+			continue
+		}
+
+		item.CharacterRequest.ID = item.ID
+		items = append(items, item.CharacterRequest)
+		positions = append(positions, i)
+	}
+
+	// an atomic batch with an invalid item is rejected without touching the database
+	if atomic && len(items) < len(bulk.Items) {
+		entities.Rollback(results)
+		bulkResponse(ctx, c, atomic, results)
+		return
+	}
+
+	processed, err := ctrl.srv.Character.Bulk(ctx, items, atomic)
+	if err != nil {
+		ctrl.log.Error("Ctrl.Bulk: ", "Error on bulk characters: ", err)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	for i, result := range processed {
+		result.Index = positions[i]
+		if result.Err == nil {
+			result.Status = http.StatusOK
+			if len(bulk.Items[positions[i]].ID) == 0 {
+				result.Status = http.StatusCreated
+			}
+		}
+		results[positions[i]] = result
+	}
+
+	bulkResponse(ctx, c, atomic, results)
+}
+
+// character swagger document
+// @Description Find many characters by id at once, for lists of ids too long for GET /characters?ids=
+// @Tags character
+// @Accept json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	fields	query	string	false	"comma separated json fields to return, e.g. id,name"
+// @Param	include_deleted	query	bool	false	"admin only, also finds deleted characters"
+// @Param ids body entities.BatchRequest true "ids of the characters"
+// @Success 200 {object} entities.CharacterBatch
+// @Failure 400 {object} entities.HttpErr
+// @Failure 403 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 422 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /characters/batch-get [post]
+func (ctrl *controllers) BatchGet(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.characters.batchget")
+	defer span.End()
+
+	var batch entities.BatchRequest
+	if err := c.Decode(&batch); err != nil {
+		responseErr(ctx, c, entities.ErrDecode)
+		return
+	}
+
+	if err := c.Validate(batch); err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	ids, err := entities.BatchIDs(batch.IDs)
+	if err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	ctrl.findByIDs(ctx, c, ids)
+}
+
+// findByIDs answers the characters among ids in their order, with the ids not
+// found.
+func (ctrl *controllers) findByIDs(ctx context.Context, c httpRouter.Context, ids []string) {
+	f, err := filter(c)
+	if err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	batch, err := ctrl.srv.Character.FindByIDs(ctx, ids, f)
+	if err != nil {
+		ctrl.log.Error("Ctrl.FindByIDs: ", "Error on find characters by ids: ", err)
+		responseErr(ctx, c, err)
 		return
 	}
 
-	c.JSON(http.StatusNoContent, nil)
+	responseCharacterBatch(c, batch, f.Fields)
 }
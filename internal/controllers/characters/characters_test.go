@@ -64,8 +64,7 @@ func Test_Create(t *testing.T) {
 			},
 			expectedCode: http.StatusBadRequest,
 			expectedData: func() string {
-				bt, _ := json.Marshal(entities.ErrDecode)
-				return string(bt)
+				return `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"problem to decode your input","instance":"/characters"}`
 			},
 			prepareMock: func(mock *characters.MockIService) {},
 		},
@@ -79,7 +78,7 @@ func Test_Create(t *testing.T) {
 			},
 			expectedCode: http.StatusBadRequest,
 			expectedData: func() string {
-				bt := []byte(`{"http_code":400,"message":"invalid_payload","detail":[{"field":"name","error":"min","value":"Pa"},{"field":"tv_series","error":"required","value":null}]}`)
+				bt := []byte(`{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"invalid_payload","instance":"/characters","violations":[{"field":"name","error":"min","value":"Pa"},{"field":"tv_series","error":"required","value":null}]}`)
 				return string(bt)
 			},
 			prepareMock: func(mock *characters.MockIService) {},
@@ -95,7 +94,7 @@ func Test_Create(t *testing.T) {
 			},
 			expectedCode: http.StatusInternalServerError,
 			expectedData: func() string {
-				return "\"failed to create character\""
+				return `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"failed to create character","instance":"/characters"}`
 			},
 			prepareMock: func(mock *characters.MockIService) {
 				mock.EXPECT().
@@ -151,6 +150,7 @@ func Test_Find(t *testing.T) {
 		{ID: "id_2", Name: "character Patrick", TVSeries: pq.StringArray{"session 1", "session 2"}},
 	}
 	cases := map[string]struct {
+		inputAccept  string
 		expectedCode int
 		expectedData func() string
 		prepareMock  func(mock *characters.MockIService)
@@ -163,21 +163,48 @@ func Test_Find(t *testing.T) {
 			},
 			prepareMock: func(mock *characters.MockIService) {
 				mock.EXPECT().
-					Find(gomock.Any()).
+					Find(gomock.Any(), entities.Filter{}).
+					Times(1).
+					Return(data, nil)
+			},
+		},
+		"Should return success in CSV": {
+			inputAccept:  "text/csv",
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return "id,name,tv_series,created_at,updated_at\n" +
+					"id_1,character Patrick,session 1;session 2,0001-01-01T00:00:00Z,\n" +
+					"id_2,character Patrick,session 1;session 2,0001-01-01T00:00:00Z,\n"
+			},
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					Find(gomock.Any(), entities.Filter{}).
+					Times(1).
+					Return(data, nil)
+			},
+		},
+		"Should return success in YAML": {
+			inputAccept:  "application/yaml",
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return "- id: id_1\n  name: character Patrick\n  tv_series:\n    - session 1\n    - session 2\n  created_at: \"0001-01-01T00:00:00Z\"\n  updated_at: null\n" +
+					"- id: id_2\n  name: character Patrick\n  tv_series:\n    - session 1\n    - session 2\n  created_at: \"0001-01-01T00:00:00Z\"\n  updated_at: null\n"
+			},
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					Find(gomock.Any(), entities.Filter{}).
 					Times(1).
 					Return(data, nil)
 			},
 		},
 		"Should return error service": {
-			expectedCode: http.StatusBadRequest,
+			expectedCode: http.StatusInternalServerError,
 			expectedData: func() string {
-				resp := entities.NewHttpErr(http.StatusBadRequest, characters.ErrFind.Error(), nil)
-				bt, _ := json.Marshal(resp)
-				return string(bt)
+				return `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"failed to find character","instance":"/characters"}`
 			},
 			prepareMock: func(mock *characters.MockIService) {
 				mock.EXPECT().
-					Find(gomock.Any()).
+					Find(gomock.Any(), entities.Filter{}).
 					Times(1).
 					Return(nil, characters.ErrFind)
 			},
@@ -207,6 +234,7 @@ func Test_Find(t *testing.T) {
 			request := httptest.NewRequest(http.MethodGet, endpoint, nil).WithContext(ctx)
 			writer := httptest.NewRecorder()
 			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Accept", cs.inputAccept)
 
 			// ============ START SERVER HTTP ============
 			router.ServeHTTP(writer, request)
@@ -227,6 +255,9 @@ func Test_FindByID(t *testing.T) {
 		TVSeries: pq.StringArray{"session 1", "session 2"},
 	}
 	cases := map[string]struct {
+		inputQuery   string
+		inputAccept  string
+		inputRoles   []string
 		expectedCode int
 		expectedData func() string
 		prepareMock  func(mock *characters.MockIService)
@@ -239,21 +270,68 @@ func Test_FindByID(t *testing.T) {
 			},
 			prepareMock: func(mock *characters.MockIService) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), data.ID).
+					FindByID(gomock.Any(), data.ID, entities.Filter{}).
 					Times(1).
 					Return(data, nil)
 			},
 		},
-		"Should return error service": {
-			expectedCode: http.StatusBadRequest,
+		"Should return success in HAL": {
+			inputAccept:  entities.HALContentType,
+			expectedCode: http.StatusOK,
 			expectedData: func() string {
-				resp := entities.NewHttpErr(http.StatusBadRequest, characters.ErrCharacterNotFound.Error(), nil)
-				bt, _ := json.Marshal(resp)
+				return `{"id":"id_123","name":"character Patrick","tv_series":["session 1","session 2"],"created_at":"0001-01-01T00:00:00Z","updated_at":null,"_links":{"self":{"href":"/characters/id_123"}}}`
+			},
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), data.ID, entities.Filter{}).
+					Times(1).
+					Return(data, nil)
+			},
+		},
+		"Should return success in JSON-LD": {
+			inputAccept:  entities.JSONLDContentType,
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"@context":{"@vocab":"https://schema.org/","created_at":"dateCreated","id":"identifier","tv_series":"subjectOf","updated_at":"dateModified"},"@id":"/characters/id_123","@type":"Person","id":"id_123","name":"character Patrick","tv_series":["session 1","session 2"],"created_at":"0001-01-01T00:00:00Z","updated_at":null}`
+			},
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), data.ID, entities.Filter{}).
+					Times(1).
+					Return(data, nil)
+			},
+		},
+		"Should return a deleted character to an admin": {
+			inputQuery:   "?include_deleted=true",
+			inputRoles:   []string{httpRouter.RoleAdmin},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				bt, _ := json.Marshal(data)
 				return string(bt)
 			},
 			prepareMock: func(mock *characters.MockIService) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), data.ID).
+					FindByID(gomock.Any(), data.ID, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(data, nil)
+			},
+		},
+		"Should return error include deleted not admin": {
+			inputQuery:   "?include_deleted=true",
+			expectedCode: http.StatusForbidden,
+			expectedData: func() string {
+				return `{"type":"/problems/forbidden","title":"Forbidden","status":403,"detail":"only the admins can include the deleted records","instance":"/characters/id_123"}`
+			},
+			prepareMock: func(mock *characters.MockIService) {},
+		},
+		"Should return error service": {
+			expectedCode: http.StatusNotFound,
+			expectedData: func() string {
+				return `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"this character is not found or deleted","instance":"/characters/id_123"}`
+			},
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), data.ID, entities.Filter{}).
 					Times(1).
 					Return(entities.Character{}, characters.ErrCharacterNotFound)
 			},
@@ -280,9 +358,13 @@ func Test_FindByID(t *testing.T) {
 			router.Get(endpoint+":id", ctr.FindByID)
 
 			// ============ START MOCK REQUEST ============
-			request := httptest.NewRequest(http.MethodGet, endpoint+data.ID, nil).WithContext(ctx)
+			if len(cs.inputRoles) > 0 {
+				ctx = httpRouter.ContextWithPrincipal(ctx, httpRouter.Principal{ID: "key_1", Roles: cs.inputRoles})
+			}
+			request := httptest.NewRequest(http.MethodGet, endpoint+data.ID+cs.inputQuery, nil).WithContext(ctx)
 			writer := httptest.NewRecorder()
 			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Accept", cs.inputAccept)
 
 			// ============ START SERVER HTTP ============
 			router.ServeHTTP(writer, request)
@@ -291,6 +373,9 @@ func Test_FindByID(t *testing.T) {
 			responseData, _ := ioutil.ReadAll(writer.Body)
 			assert.Equal(t, cs.expectedData(), string(responseData))
 			assert.Equal(t, cs.expectedCode, writer.Code)
+			if cs.expectedCode == http.StatusOK && len(cs.inputAccept) > 0 {
+				assert.Equal(t, cs.inputAccept, writer.Header().Get("Content-Type"))
+			}
 		})
 	}
 }
@@ -341,8 +426,7 @@ func Test_Update(t *testing.T) {
 			},
 			expectedCode: http.StatusBadRequest,
 			expectedData: func() string {
-				bt, _ := json.Marshal(entities.ErrDecode)
-				return string(bt)
+				return `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"problem to decode your input","instance":"/characters/id_123"}`
 			},
 			prepareMock: func(mock *characters.MockIService) {},
 		},
@@ -356,7 +440,7 @@ func Test_Update(t *testing.T) {
 			},
 			expectedCode: http.StatusBadRequest,
 			expectedData: func() string {
-				bt := []byte(`{"http_code":400,"message":"invalid_payload","detail":[{"field":"name","error":"min","value":"Pa"},{"field":"tv_series","error":"required","value":null}]}`)
+				bt := []byte(`{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"invalid_payload","instance":"/characters/id_123","violations":[{"field":"name","error":"min","value":"Pa"},{"field":"tv_series","error":"required","value":null}]}`)
 				return string(bt)
 			},
 			prepareMock: func(mock *characters.MockIService) {},
@@ -372,7 +456,7 @@ func Test_Update(t *testing.T) {
 			},
 			expectedCode: http.StatusInternalServerError,
 			expectedData: func() string {
-				return "\"failed to create character\""
+				return `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"failed to create character","instance":"/characters/id_123"}`
 			},
 			prepareMock: func(mock *characters.MockIService) {
 				mock.EXPECT().
@@ -447,7 +531,7 @@ func Test_Delete(t *testing.T) {
 			paramInput:   "33c55a43-f163-4a67-9f6c-75161410f376",
 			expectedCode: http.StatusInternalServerError,
 			expectedData: func() string {
-				return "\"failed to delete character\""
+				return `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"failed to delete character","instance":"/characters/33c55a43-f163-4a67-9f6c-75161410f376"}`
 			},
 			prepareMock: func(mock *characters.MockIService) {
 				mock.EXPECT().
@@ -492,3 +576,271 @@ func Test_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_Bulk(t *testing.T) {
+	endpoint := "/characters/bulk"
+	valid := entities.CharacterRequest{Name: "Jon Snow", TVSeries: pq.StringArray{"Season 1"}}
+	cases := map[string]struct {
+		inputPath    string
+		inputBody    func() io.Reader
+		expectedCode int
+		expectedData func() string
+		prepareMock  func(mock *characters.MockIService)
+	}{
+		"Should return success": {
+			inputBody: func() io.Reader {
+				bt, _ := json.Marshal(entities.CharacterBulkRequest{Items: []entities.CharacterBulkItem{
+					{CharacterRequest: valid},
+				}})
+				return bytes.NewReader(bt)
+			},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"atomic":false,"succeeded":1,"failed":0,"results":[{"index":0,"status":201,"id":"id_1"}]}`
+			},
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					Bulk(gomock.Any(), []entities.CharacterRequest{valid}, false).
+					Times(1).
+					Return([]entities.BulkResult{{Index: 0, ID: "id_1"}}, nil)
+			},
+		},
+		"Should return multi status when atomic batch is rolled back": {
+			inputPath: "?atomic=true",
+			inputBody: func() io.Reader {
+				bt, _ := json.Marshal(entities.CharacterBulkRequest{Items: []entities.CharacterBulkItem{
+					{CharacterRequest: valid},
+					{ID: "id_2", CharacterRequest: valid},
+				}})
+				return bytes.NewReader(bt)
+			},
+			expectedCode: http.StatusMultiStatus,
+			expectedData: func() string {
+				return `{"atomic":true,"succeeded":0,"failed":2,"results":[{"index":0,"status":424,"error":"item was not applied because the batch was rolled back"},{"index":1,"status":404,"error":"this character is not found or deleted"}]}`
+			},
+			prepareMock: func(mock *characters.MockIService) {
+				updated := valid
+				updated.ID = "id_2"
+				mock.EXPECT().
+					Bulk(gomock.Any(), []entities.CharacterRequest{valid, updated}, true).
+					Times(1).
+					Return([]entities.BulkResult{
+						{Index: 0, Err: entities.ErrBulkRolledBack},
+						{Index: 1, Err: characters.ErrCharacterNotFound},
+					}, nil)
+			},
+		},
+		"Should return error decode": {
+			inputBody: func() io.Reader {
+				return bytes.NewReader([]byte(`{"items":"jon"}`))
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedData: func() string {
+				return `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"problem to decode your input","instance":"/characters/bulk"}`
+			},
+			prepareMock: func(mock *characters.MockIService) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := characters.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Character: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.Bulk)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodPost, endpoint+cs.inputPath, cs.inputBody()).WithContext(ctx)
+			writer := httptest.NewRecorder()
+			request.Header.Set("Content-Type", "application/json")
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData(), string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
+
+func Test_Restore(t *testing.T) {
+	endpoint := "/characters/"
+	cases := map[string]struct {
+		paramInput   string
+		queryInput   string
+		expectedCode int
+		expectedData func() string
+		prepareMock  func(mock *characters.MockIService)
+	}{
+		"Should return success": {
+			paramInput:   "33c55a43-f163-4a67-9f6c-75161410f376",
+			queryInput:   "?reinstate_lordships=true",
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				data, _ := json.Marshal(entities.CharacterRestored{Character: entities.Character{ID: "33c55a43-f163-4a67-9f6c-75161410f376", Name: "Jon Snow"}, ReinstatedHouses: []string{"house_1"}})
+				return string(data)
+			},
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					Restore(gomock.Any(), "33c55a43-f163-4a67-9f6c-75161410f376", true).
+					Times(1).
+					Return(entities.CharacterRestored{Character: entities.Character{ID: "33c55a43-f163-4a67-9f6c-75161410f376", Name: "Jon Snow"}, ReinstatedHouses: []string{"house_1"}}, nil)
+			},
+		},
+		"Should return error not deleted": {
+			paramInput:   "33c55a43-f163-4a67-9f6c-75161410f376",
+			expectedCode: http.StatusConflict,
+			expectedData: func() string {
+				return `{"type":"/problems/conflict","title":"Conflict","status":409,"detail":"this character is not deleted","instance":"/characters/33c55a43-f163-4a67-9f6c-75161410f376/restore"}`
+			},
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					Restore(gomock.Any(), "33c55a43-f163-4a67-9f6c-75161410f376", false).
+					Times(1).
+					Return(entities.CharacterRestored{}, characters.ErrCharacterNotDeleted)
+			},
+		},
+		"Should return error service": {
+			paramInput:   "33c55a43-f163-4a67-9f6c-75161410f376",
+			expectedCode: http.StatusInternalServerError,
+			expectedData: func() string {
+				return `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"failed to restore character","instance":"/characters/33c55a43-f163-4a67-9f6c-75161410f376/restore"}`
+			},
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					Restore(gomock.Any(), "33c55a43-f163-4a67-9f6c-75161410f376", false).
+					Times(1).
+					Return(entities.CharacterRestored{}, errors.New("failed to restore character"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := characters.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Character: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint+":id/restore", ctr.Restore)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodPost, endpoint+cs.paramInput+"/restore"+cs.queryInput, nil).WithContext(ctx)
+			writer := httptest.NewRecorder()
+			request.Header.Set("Content-Type", "application/json")
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData(), string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
+
+func Test_History(t *testing.T) {
+	endpoint := "/characters/"
+	entries := []entities.AuditEntry{{
+		ID:       "entry_1",
+		Entity:   entities.AuditCharacter,
+		EntityID: "33c55a43-f163-4a67-9f6c-75161410f376",
+		Action:   entities.AuditUpdate,
+		Actor:    "patrick",
+		Changes:  entities.AuditChanges{"name": {Before: "old name", After: "new name"}},
+	}}
+
+	cases := map[string]struct {
+		paramInput   string
+		expectedCode int
+		expectedData func() string
+		prepareMock  func(mock *characters.MockIService)
+	}{
+		"Should return success": {
+			paramInput:   "33c55a43-f163-4a67-9f6c-75161410f376",
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				data, _ := json.Marshal(entries)
+				return string(data)
+			},
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					History(gomock.Any(), "33c55a43-f163-4a67-9f6c-75161410f376", nil).
+					Times(1).
+					Return(entries, nil)
+			},
+		},
+		"Should return error service": {
+			paramInput:   "33c55a43-f163-4a67-9f6c-75161410f376",
+			expectedCode: http.StatusInternalServerError,
+			expectedData: func() string {
+				return `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"failed to find audit entries","instance":"/characters/33c55a43-f163-4a67-9f6c-75161410f376/history"}`
+			},
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					History(gomock.Any(), "33c55a43-f163-4a67-9f6c-75161410f376", nil).
+					Times(1).
+					Return(nil, errors.New("failed to find audit entries"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := characters.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Character: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Get(endpoint+":id/history", ctr.History)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodGet, endpoint+cs.paramInput+"/history", nil).WithContext(ctx)
+			writer := httptest.NewRecorder()
+			request.Header.Set("Content-Type", "application/json")
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData(), string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
@@ -1,27 +1,79 @@
 package controllers
 
 import (
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/apikeys"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/audit"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/auth"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/characters"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/graphql"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/grpc"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/houses"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/idempotency"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/imports"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/inflate"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/jobs"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/problem"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/purge"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/ratelimit"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/search"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/snapshot"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/stats"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
 )
 
 type (
 	Container struct {
-		House     houses.IController
-		Character characters.IController
+		House       houses.IController
+		Character   characters.IController
+		Idempotency idempotency.IController
+		Purge       purge.IController
+		Import      imports.IController
+		Snapshot    snapshot.IController
+		Search      search.IController
+		Stats       stats.IController
+		Jobs        jobs.IController
+		APIKey      apikeys.IController
+		Auth        auth.IController
+		RateLimit   ratelimit.IController
+		Inflate     inflate.IController
+		Audit       audit.IController
+		Problem     problem.IController
+		GraphQL     graphql.IController
+		GRPC        grpc.IController
 	}
 
 	Options struct {
-		Srv *services.Container
-		Log logger.Logger
+		Srv         *services.Container
+		Log         logger.Logger
+		GraphQL     graphql.Options
+		Auth        auth.Options
+		Idempotency idempotency.Options
+		RateLimit   ratelimit.Options
+		Inflate     inflate.Options
 	}
 )
 
 func New(opts Options) *Container {
+	problems := problem.New()
+
 	return &Container{
-		House:     houses.New(opts.Srv, opts.Log),
-		Character: characters.New(opts.Srv, opts.Log),
+		House:       houses.New(opts.Srv, opts.Log),
+		Character:   characters.New(opts.Srv, opts.Log),
+		Idempotency: idempotency.New(opts.Srv, opts.Log, opts.Idempotency),
+		Purge:       purge.New(opts.Srv, opts.Log),
+		Import:      imports.New(opts.Srv, opts.Log),
+		Snapshot:    snapshot.New(opts.Srv, opts.Log),
+		Search:      search.New(opts.Srv, opts.Log),
+		Stats:       stats.New(opts.Srv, opts.Log),
+		Jobs:        jobs.New(opts.Srv, opts.Log),
+		APIKey:      apikeys.New(opts.Srv, opts.Log),
+		Auth:        auth.New(opts.Srv, opts.Log, opts.Auth, problems.Denied),
+		RateLimit:   ratelimit.New(opts.Srv, opts.Log, opts.RateLimit, problems.Denied),
+		Inflate:     inflate.New(opts.Inflate, problems.Denied),
+		Audit:       audit.New(opts.Log),
+		Problem:     problems,
+		GraphQL:     graphql.New(opts.Srv, opts.Log, opts.GraphQL),
+		GRPC:        grpc.New(opts.Srv, opts.Log),
 	}
 }
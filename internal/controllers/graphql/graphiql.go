@@ -0,0 +1,24 @@
+package graphql
+
+// graphiql loads the IDE from a CDN and points it at the endpoint serving it.
+var graphiql = []byte(`<!DOCTYPE html>
+<html>
+<head>
+	<title>game-of-thrones GraphiQL</title>
+	<link href="https://unpkg.com/graphiql@3/graphiql.min.css" rel="stylesheet" />
+	<style>body { height: 100vh; margin: 0; } #graphiql { height: 100vh; }</style>
+</head>
+<body>
+	<div id="graphiql">Loading...</div>
+	<script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+	<script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+	<script crossorigin src="https://unpkg.com/graphiql@3/graphiql.min.js"></script>
+	<script>
+		const fetcher = GraphiQL.createFetcher({ url: window.location.pathname });
+		ReactDOM.createRoot(document.getElementById('graphiql')).render(
+			React.createElement(GraphiQL, { fetcher: fetcher })
+		);
+	</script>
+</body>
+</html>
+`)
@@ -0,0 +1,130 @@
+package graphql
+
+import (
+	_ "embed"
+	"errors"
+	"net/http"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/validator"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+// maxParallelism is how many fields of a query are resolved at the same time,
+// the items of a list past it wait for a free slot.
+const maxParallelism = 50
+
+//go:embed schema.graphql
+var schema string
+
+type (
+	IController interface {
+		// Query runs a query or mutation of the schema.
+		Query(c httpRouter.Context)
+		// GraphiQL serves an in-browser IDE for the schema.
+		GraphiQL(c httpRouter.Context)
+	}
+
+	Options struct {
+		// MaxDepth rejects the queries nesting more selections than it, zero
+		// means no limit
+		MaxDepth int
+		// PersistedOnly runs only the PersistedQueries, the clients send their
+		// hashes instead of the whole query
+		PersistedOnly    bool
+		PersistedQueries []string
+	}
+
+	controllers struct {
+		srv       *services.Container
+		log       logger.Logger
+		schema    *graphqlgo.Schema
+		persisted *persistedQueries
+	}
+)
+
+func New(srv *services.Container, log logger.Logger, opts Options) IController {
+	root := &resolver{srv: srv, log: log, validator: validator.New()}
+
+	return &controllers{
+		srv: srv,
+		log: log,
+		schema: graphqlgo.MustParseSchema(schema, root,
+			graphqlgo.UseFieldResolvers(),
+			graphqlgo.MaxDepth(opts.MaxDepth),
+			graphqlgo.MaxParallelism(maxParallelism),
+		),
+		persisted: newPersistedQueries(opts.PersistedOnly, opts.PersistedQueries),
+	}
+}
+
+// graphql swagger document
+// @Description Run a GraphQL query or mutation over houses and characters. Persisted queries are sent with the sha256Hash of the query in extensions.persistedQuery.
+// @Tags graphql
+// @Accept json
+// @Produce json,application/problem+json
+// @Param query body entities.GraphQLRequest true "query, operation and variables"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} entities.HttpErr
+// @Router /graphql [post]
+func (ctrl *controllers) Query(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.graphql.query")
+	defer span.End()
+
+	var req entities.GraphQLRequest
+	if err := c.Decode(&req); err != nil {
+		responseErr(c, entities.ErrDecode)
+		return
+	}
+
+	query, err := ctrl.persisted.Query(req)
+	if err != nil {
+		if errors.Is(err, ErrPersistedQueryNotFound) || errors.Is(err, ErrPersistedQueryNotSupported) {
+			// the protocol answers these as GraphQL errors so the client retries
+			c.Render(http.StatusOK, httpRouter.JSONContentType, &graphqlgo.Response{Errors: []*gqlerrors.QueryError{persistedErr(err)}})
+			return
+		}
+		ctrl.log.Error("Ctrl.GraphQL.Query: ", "Error on persisted query: ", err)
+		responseErr(c, entities.NewHttpErr(http.StatusBadRequest, err.Error(), nil))
+		return
+	}
+
+	ctx = withLordLoader(ctx, newLordLoader(ctrl.srv.Character))
+
+	c.Render(http.StatusOK, httpRouter.JSONContentType, ctrl.schema.Exec(ctx, query, req.OperationName, req.Variables))
+}
+
+func (ctrl *controllers) GraphiQL(c httpRouter.Context) {
+	_, span := tracer.Span(c.Context(), "controllers.graphql.graphiql")
+	defer span.End()
+
+	w := c.GetResponseWriter()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(graphiql)
+}
+
+func responseErr(c httpRouter.Context, err error) {
+	ctx, span := tracer.Span(c.Context(), "controllers.graphql.responseErr")
+	defer span.End()
+
+	problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+	c.Problem(problem.Status, problem)
+}
+
+func persistedErr(err error) *gqlerrors.QueryError {
+	code := "PERSISTED_QUERY_NOT_FOUND"
+	if errors.Is(err, ErrPersistedQueryNotSupported) {
+		code = "PERSISTED_QUERY_NOT_SUPPORTED"
+	}
+
+	return &gqlerrors.QueryError{
+		Message:    err.Error(),
+		Extensions: map[string]any{"code": code},
+	}
+}
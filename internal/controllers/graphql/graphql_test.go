@@ -0,0 +1,356 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/characters"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/houses"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Query(t *testing.T) {
+	endpoint := "/graphql"
+	createdAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		opts         Options
+		inputRoles   []string
+		inputBody    func() io.Reader
+		expectedCode int
+		expectedData func() string
+		prepareMock  func(house *houses.MockIService, character *characters.MockIService)
+	}{
+		"Should return houses with their lords loaded in one batch": {
+			inputBody: func() io.Reader {
+				return request(entities.GraphQLRequest{Query: `{ houses { name currentLord { name } } }`})
+			},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"data":{"houses":[{"name":"Stark","currentLord":{"name":"Sansa Stark"}},{"name":"Tully","currentLord":{"name":"Sansa Stark"}},{"name":"Arryn","currentLord":null},{"name":"Bolton","currentLord":null}]}}`
+			},
+			prepareMock: func(house *houses.MockIService, character *characters.MockIService) {
+				house.EXPECT().
+					Find(gomock.Any(), "", entities.Filter{Limit: 20}).
+					Times(1).
+					Return([]entities.House{
+						{ID: "id_1", Name: "Stark", CurrentLord: "lord_1"},
+						{ID: "id_2", Name: "Tully", CurrentLord: "lord_1"},
+						{ID: "id_3", Name: "Arryn", CurrentLord: "lord_2"},
+						{ID: "id_4", Name: "Bolton"},
+					}, nil)
+				character.EXPECT().
+					FindByIDs(gomock.Any(), gomock.Any(), entities.Filter{}).
+					Times(1).
+					DoAndReturn(func(_ context.Context, ids []string, _ entities.Filter) (entities.CharacterBatch, error) {
+						assert.ElementsMatch(t, []string{"lord_1", "lord_2"}, ids)
+						return entities.NewCharacterBatch(ids, []entities.Character{{ID: "lord_1", Name: "Sansa Stark"}}), nil
+					})
+			},
+		},
+		"Should return a page of characters": {
+			inputBody: func() io.Reader {
+				return request(entities.GraphQLRequest{Query: `{ characters(limit: 100, offset: 200) { name } }`})
+			},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"data":{"characters":[{"name":"Sansa Stark"}]}}`
+			},
+			prepareMock: func(house *houses.MockIService, character *characters.MockIService) {
+				character.EXPECT().
+					Find(gomock.Any(), entities.Filter{Limit: 100, Offset: 200}).
+					Times(1).
+					Return([]entities.Character{{ID: "lord_1", Name: "Sansa Stark"}}, nil)
+			},
+		},
+		"Should return error limit over the max": {
+			inputBody: func() io.Reader {
+				return request(entities.GraphQLRequest{Query: `{ houses(limit: 101) { name } }`})
+			},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"errors":[{"message":"limit must be an integer from 1 to 100 and offset must not be negative","path":["houses"],"extensions":{"status":400,"type":"/problems/invalid-request"}}],"data":null}`
+			},
+			prepareMock: func(house *houses.MockIService, character *characters.MockIService) {},
+		},
+		"Should return a character": {
+			inputBody: func() io.Reader {
+				return request(entities.GraphQLRequest{
+					Query:     `query Character($id: ID!) { character(id: $id) { id tvSeries createdAt updatedAt } }`,
+					Variables: map[string]any{"id": "lord_1"},
+				})
+			},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"data":{"character":{"id":"lord_1","tvSeries":["S01","S02"],"createdAt":"2023-05-01T10:00:00Z","updatedAt":null}}}`
+			},
+			prepareMock: func(house *houses.MockIService, character *characters.MockIService) {
+				character.EXPECT().
+					FindByID(gomock.Any(), "lord_1", entities.Filter{}).
+					Times(1).
+					Return(entities.Character{ID: "lord_1", TVSeries: []string{"S01", "S02"}, CreatedAt: createdAt}, nil)
+			},
+		},
+		"Should return error not found with its status": {
+			inputBody: func() io.Reader {
+				return request(entities.GraphQLRequest{Query: `{ house(id: "id_1") { name } }`})
+			},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"errors":[{"message":"this house is not found or deleted","path":["house"],"extensions":{"status":404,"type":"/problems/not-found"}}],"data":{"house":null}}`
+			},
+			prepareMock: func(house *houses.MockIService, character *characters.MockIService) {
+				house.EXPECT().
+					FindByID(gomock.Any(), "id_1", entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, houses.ErrHouseNotFound.Wrap(sql.ErrNoRows))
+			},
+		},
+		"Should create a house": {
+			inputBody: func() io.Reader {
+				return request(entities.GraphQLRequest{
+					Query: `mutation { createHouse(input: {name: "Stark", region: "North", foundationYear: "299", currentLord: "lord_1"}) { id name } }`,
+				})
+			},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"data":{"createHouse":{"id":"id_1","name":"Stark"}}}`
+			},
+			prepareMock: func(house *houses.MockIService, character *characters.MockIService) {
+				house.EXPECT().
+					Create(gomock.Any(), entities.HouseRequest{Name: "Stark", Region: "North", FoundationYear: "299", CurrentLord: "lord_1"}).
+					Times(1).
+					Return("id_1", nil)
+			},
+		},
+		"Should return error validate": {
+			inputBody: func() io.Reader {
+				return request(entities.GraphQLRequest{
+					Query: `mutation { createCharacter(input: {name: "Jo", tvSeries: []}) { id } }`,
+				})
+			},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"errors":[{"message":"invalid_payload","path":["createCharacter"],"extensions":{"status":400,"type":"/problems/invalid-request","violations":[{"field":"name","error":"min","value":"Jo"},{"field":"tv_series","error":"min","value":[]}]}}],"data":null}`
+			},
+			prepareMock: func(house *houses.MockIService, character *characters.MockIService) {},
+		},
+		"Should return error mutation of a reader": {
+			inputRoles: []string{httpRouter.RoleReader},
+			inputBody: func() io.Reader {
+				return request(entities.GraphQLRequest{Query: `mutation { createCharacter(input: {name: "Jo", tvSeries: []}) { id } }`})
+			},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"errors":[{"message":"the credentials don't have the role of the mutation","path":["createCharacter"],"extensions":{"status":403,"type":"/problems/forbidden"}}],"data":null}`
+			},
+			prepareMock: func(house *houses.MockIService, character *characters.MockIService) {},
+		},
+		"Should return error delete mutation without the admin role": {
+			inputBody: func() io.Reader {
+				return request(entities.GraphQLRequest{Query: `mutation { deleteHouse(id: "id_1") }`})
+			},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"errors":[{"message":"the credentials don't have the role of the mutation","path":["deleteHouse"],"extensions":{"status":403,"type":"/problems/forbidden"}}],"data":null}`
+			},
+			prepareMock: func(house *houses.MockIService, character *characters.MockIService) {},
+		},
+		"Should return error max depth": {
+			opts: Options{MaxDepth: 2},
+			inputBody: func() io.Reader {
+				return request(entities.GraphQLRequest{Query: `{ houses { currentLord { name } } }`})
+			},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"errors":[{"message":"Field \"name\" has depth 3 that exceeds max depth 2","locations":[{"line":1,"column":26}]}]}`
+			},
+			prepareMock: func(house *houses.MockIService, character *characters.MockIService) {},
+		},
+		"Should return error decode": {
+			inputBody: func() io.Reader {
+				return bytes.NewReader([]byte(`{"query":123}`))
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedData: func() string {
+				return `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"problem to decode your input","instance":"/graphql"}`
+			},
+			prepareMock: func(house *houses.MockIService, character *characters.MockIService) {},
+		},
+		"Should return error query missing": {
+			inputBody: func() io.Reader {
+				return request(entities.GraphQLRequest{})
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedData: func() string {
+				return `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"no query was informed","instance":"/graphql"}`
+			},
+			prepareMock: func(house *houses.MockIService, character *characters.MockIService) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			if cs.inputRoles == nil {
+				cs.inputRoles = []string{httpRouter.RoleEditor}
+			}
+			ctx = httpRouter.ContextWithPrincipal(ctx, httpRouter.Principal{Name: "ci", Roles: cs.inputRoles})
+
+			// ============ MOCKS ============
+			house := houses.NewMockIService(ctrl)
+			character := characters.NewMockIService(ctrl)
+			cs.prepareMock(house, character)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{House: house, Character: character},
+				logger.NewLogrusLogger(),
+				cs.opts,
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.Query)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodPost, endpoint, cs.inputBody()).WithContext(ctx)
+			writer := httptest.NewRecorder()
+			request.Header.Set("Content-Type", "application/json")
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData(), string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
+
+func Test_PersistedQuery(t *testing.T) {
+	endpoint := "/graphql"
+	query := `{ characters { name } }`
+	cases := map[string]struct {
+		opts         Options
+		inputBodies  []entities.GraphQLRequest
+		expectedCode int
+		expectedData string
+		prepareMock  func(character *characters.MockIService)
+	}{
+		"Should ask for the query when the hash is unknown": {
+			inputBodies: []entities.GraphQLRequest{
+				{Extensions: persistedQuery(hash(query))},
+			},
+			expectedCode: http.StatusOK,
+			expectedData: `{"errors":[{"message":"PersistedQueryNotFound","extensions":{"code":"PERSISTED_QUERY_NOT_FOUND"}}]}`,
+			prepareMock:  func(character *characters.MockIService) {},
+		},
+		"Should run the query registered by a previous request": {
+			inputBodies: []entities.GraphQLRequest{
+				{Query: query, Extensions: persistedQuery(hash(query))},
+				{Extensions: persistedQuery(hash(query))},
+			},
+			expectedCode: http.StatusOK,
+			expectedData: `{"data":{"characters":[{"name":"Sansa Stark"}]}}`,
+			prepareMock: func(character *characters.MockIService) {
+				character.EXPECT().
+					Find(gomock.Any(), entities.Filter{Limit: 20}).
+					Times(2).
+					Return([]entities.Character{{ID: "lord_1", Name: "Sansa Stark"}}, nil)
+			},
+		},
+		"Should run a preloaded query by its hash": {
+			opts: Options{PersistedOnly: true, PersistedQueries: []string{query}},
+			inputBodies: []entities.GraphQLRequest{
+				{Extensions: persistedQuery(hash(query))},
+			},
+			expectedCode: http.StatusOK,
+			expectedData: `{"data":{"characters":[{"name":"Sansa Stark"}]}}`,
+			prepareMock: func(character *characters.MockIService) {
+				character.EXPECT().
+					Find(gomock.Any(), entities.Filter{Limit: 20}).
+					Times(1).
+					Return([]entities.Character{{ID: "lord_1", Name: "Sansa Stark"}}, nil)
+			},
+		},
+		"Should return error query not persisted": {
+			opts: Options{PersistedOnly: true},
+			inputBodies: []entities.GraphQLRequest{
+				{Query: query},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedData: `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"only persisted queries are accepted","instance":"/graphql"}`,
+			prepareMock:  func(character *characters.MockIService) {},
+		},
+		"Should return error hash mismatch": {
+			inputBodies: []entities.GraphQLRequest{
+				{Query: query, Extensions: persistedQuery(hash("{ houses { name } }"))},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedData: `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"provided sha does not match query","instance":"/graphql"}`,
+			prepareMock:  func(character *characters.MockIService) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			character := characters.NewMockIService(ctrl)
+			cs.prepareMock(character)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Character: character},
+				logger.NewLogrusLogger(),
+				cs.opts,
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.Query)
+
+			// ============ START SERVER HTTP ============
+			var writer *httptest.ResponseRecorder
+			for _, body := range cs.inputBodies {
+				request := httptest.NewRequest(http.MethodPost, endpoint, request(body)).WithContext(ctx)
+				request.Header.Set("Content-Type", "application/json")
+				writer = httptest.NewRecorder()
+				router.ServeHTTP(writer, request)
+			}
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData, string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
+
+func request(data entities.GraphQLRequest) io.Reader {
+	bt, _ := json.Marshal(data)
+	return bytes.NewReader(bt)
+}
+
+func persistedQuery(sha256Hash string) entities.GraphQLExtensions {
+	return entities.GraphQLExtensions{
+		PersistedQuery: &entities.PersistedQuery{Version: 1, Sha256Hash: sha256Hash},
+	}
+}
@@ -0,0 +1,129 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/characters"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+const (
+	// loaderWait is how long the loader waits for other keys before fetching a batch
+	loaderWait = 2 * time.Millisecond
+	// loaderMaxBatch bounds the ids of a single query, matching the resolvers run in parallel
+	loaderMaxBatch = maxParallelism
+)
+
+type (
+	loaderKey struct{}
+
+	// lordLoader batches the lookups of the lords of the houses listed by a
+	// query, so a list of houses costs a single query for their lords instead of
+	// one per house. It lives for a single request and caches what it loaded.
+	lordLoader struct {
+		srv characters.IService
+
+		mu    sync.Mutex
+		cache map[string]*loaderResult
+		batch *loaderBatch
+	}
+
+	loaderBatch struct {
+		ids  []string
+		done chan struct{}
+	}
+
+	loaderResult struct {
+		batch     *loaderBatch
+		character *entities.Character
+		err       error
+	}
+)
+
+func newLordLoader(srv characters.IService) *lordLoader {
+	return &lordLoader{srv: srv, cache: make(map[string]*loaderResult)}
+}
+
+func withLordLoader(ctx context.Context, loader *lordLoader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, loader)
+}
+
+func lordLoaderFrom(ctx context.Context) *lordLoader {
+	loader, _ := ctx.Value(loaderKey{}).(*lordLoader)
+	return loader
+}
+
+// Load returns the live character with id, nil when it doesn't exist or was
+// deleted. The ids asked together are fetched in the same query.
+func (l *lordLoader) Load(ctx context.Context, id string) (*entities.Character, error) {
+	l.mu.Lock()
+	result, ok := l.cache[id]
+	if !ok {
+		if l.batch == nil {
+			l.batch = &loaderBatch{done: make(chan struct{})}
+			go l.wait(ctx, l.batch)
+		}
+
+		result = &loaderResult{batch: l.batch}
+		l.cache[id] = result
+		l.batch.ids = append(l.batch.ids, id)
+
+		if len(l.batch.ids) >= loaderMaxBatch {
+			batch := l.batch
+			l.batch = nil
+			go l.fetch(ctx, batch)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-result.batch.done:
+		return result.character, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// wait fetches batch once the window is over, unless it was already sent for
+// being full.
+func (l *lordLoader) wait(ctx context.Context, batch *loaderBatch) {
+	time.Sleep(loaderWait)
+
+	l.mu.Lock()
+	if l.batch != batch {
+		l.mu.Unlock()
+		return
+	}
+	l.batch = nil
+	l.mu.Unlock()
+
+	l.fetch(ctx, batch)
+}
+
+func (l *lordLoader) fetch(ctx context.Context, batch *loaderBatch) {
+	ctx, span := tracer.Span(ctx, "controllers.graphql.loader.fetch")
+	defer span.End()
+
+	lords, err := l.srv.FindByIDs(ctx, batch.ids, entities.Filter{})
+
+	found := make(map[string]*entities.Character, len(lords.Characters))
+	for i := range lords.Characters {
+		found[lords.Characters[i].ID] = &lords.Characters[i]
+	}
+
+	l.mu.Lock()
+	for _, id := range batch.ids {
+		result := l.cache[id]
+		result.character, result.err = found[id], err
+		if err != nil {
+			// a failure isn't cached, the next query of the request asks again
+			delete(l.cache, id)
+		}
+	}
+	l.mu.Unlock()
+
+	close(batch.done)
+}
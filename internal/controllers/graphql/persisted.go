@@ -0,0 +1,101 @@
+package graphql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+)
+
+// maxPersistedQueries bounds the queries registered by the clients, the ones
+// sent after it is reached run but aren't kept.
+const maxPersistedQueries = 1000
+
+var (
+	// the messages of the automatic persisted queries protocol, the clients
+	// send the whole query again when they read ErrPersistedQueryNotFound
+	ErrPersistedQueryNotFound     = errors.New("PersistedQueryNotFound")
+	ErrPersistedQueryNotSupported = errors.New("PersistedQueryNotSupported")
+	ErrPersistedQueryHash         = errors.New("provided sha does not match query")
+	ErrPersistedQueryOnly         = errors.New("only persisted queries are accepted")
+	ErrQueryMissing               = errors.New("no query was informed")
+)
+
+// persistedQueries keeps the queries by their sha256 hash. The preloaded ones
+// form an allow-list: when only is true no other query is run or registered.
+type persistedQueries struct {
+	mu      sync.RWMutex
+	queries map[string]string
+	only    bool
+}
+
+func newPersistedQueries(only bool, preloaded []string) *persistedQueries {
+	p := &persistedQueries{queries: make(map[string]string, len(preloaded)), only: only}
+	for _, query := range preloaded {
+		p.queries[hash(query)] = query
+	}
+	return p
+}
+
+// Query returns the query to run for req, registering it by its hash when
+// the client sent both.
+func (p *persistedQueries) Query(req entities.GraphQLRequest) (string, error) {
+	persisted := req.Extensions.PersistedQuery
+	if persisted == nil {
+		if len(req.Query) == 0 {
+			return "", ErrQueryMissing
+		}
+		if p.only && !p.known(hash(req.Query)) {
+			return "", ErrPersistedQueryOnly
+		}
+		return req.Query, nil
+	}
+
+	if persisted.Version != 1 {
+		return "", ErrPersistedQueryNotSupported
+	}
+
+	if len(req.Query) == 0 {
+		p.mu.RLock()
+		query, ok := p.queries[persisted.Sha256Hash]
+		p.mu.RUnlock()
+		if !ok {
+			return "", ErrPersistedQueryNotFound
+		}
+		return query, nil
+	}
+
+	if hash(req.Query) != persisted.Sha256Hash {
+		return "", ErrPersistedQueryHash
+	}
+
+	if p.only {
+		if !p.known(persisted.Sha256Hash) {
+			return "", ErrPersistedQueryOnly
+		}
+		return req.Query, nil
+	}
+
+	p.mu.Lock()
+	if len(p.queries) < maxPersistedQueries {
+		p.queries[persisted.Sha256Hash] = req.Query
+	}
+	p.mu.Unlock()
+
+	return req.Query, nil
+}
+
+func (p *persistedQueries) known(hash string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	_, ok := p.queries[hash]
+	return ok
+}
+
+func hash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
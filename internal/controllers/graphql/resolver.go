@@ -0,0 +1,403 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/validator"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+// MaxLimit is the largest page of the lists, the schema gives their default.
+const MaxLimit = 100
+
+var (
+	// ErrMutationForbidden the route only needs the reader role, which covers the
+	// queries
+	ErrMutationForbidden = entities.NewHttpErr(http.StatusForbidden, "the credentials don't have the role of the mutation", nil)
+	ErrLimit             = entities.NewHttpErr(http.StatusBadRequest, "limit must be an integer from 1 to 100 and offset must not be negative", nil)
+)
+
+type (
+	// resolver is the root of the schema, its methods resolve the fields of
+	// Query and Mutation through the same services used by the REST handlers.
+	resolver struct {
+		srv       *services.Container
+		log       logger.Logger
+		validator validator.Validator
+	}
+
+	houseResolver struct {
+		house entities.House
+	}
+
+	characterResolver struct {
+		character entities.Character
+	}
+
+	houseInput struct {
+		Name           string
+		Region         string
+		FoundationYear string
+		CurrentLord    *graphqlgo.ID
+	}
+
+	characterInput struct {
+		Name     string
+		TvSeries []string
+	}
+
+	// pageArgs pages the lists, the schema defaults both.
+	pageArgs struct {
+		Limit  int32
+		Offset int32
+	}
+)
+
+func (r *resolver) Houses(ctx context.Context, args struct {
+	Name *string
+	pageArgs
+}) ([]*houseResolver, error) {
+	ctx, span := tracer.Span(ctx, "controllers.graphql.houses")
+	defer span.End()
+
+	var name string
+	if args.Name != nil {
+		name = *args.Name
+	}
+
+	filter, err := args.filter()
+	if err != nil {
+		return nil, err
+	}
+
+	houses, err := r.srv.House.Find(ctx, name, filter)
+	if err != nil {
+		r.log.Error("Ctrl.GraphQL.Houses: ", "Error on find houses: ", name)
+		return nil, newResolverErr(err)
+	}
+
+	resolvers := make([]*houseResolver, len(houses))
+	for i := range houses {
+		resolvers[i] = &houseResolver{house: houses[i]}
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) House(ctx context.Context, args struct{ ID graphqlgo.ID }) (*houseResolver, error) {
+	ctx, span := tracer.Span(ctx, "controllers.graphql.house")
+	defer span.End()
+
+	house, err := r.srv.House.FindByID(ctx, string(args.ID), entities.Filter{})
+	if err != nil {
+		r.log.Error("Ctrl.GraphQL.House: ", "Error on find house: ", args.ID)
+		return nil, newResolverErr(err)
+	}
+
+	return &houseResolver{house: house}, nil
+}
+
+func (r *resolver) Characters(ctx context.Context, args pageArgs) ([]*characterResolver, error) {
+	ctx, span := tracer.Span(ctx, "controllers.graphql.characters")
+	defer span.End()
+
+	filter, err := args.filter()
+	if err != nil {
+		return nil, err
+	}
+
+	characters, err := r.srv.Character.Find(ctx, filter)
+	if err != nil {
+		r.log.Error("Ctrl.GraphQL.Characters: ", "Error on find characters: ", err)
+		return nil, newResolverErr(err)
+	}
+
+	resolvers := make([]*characterResolver, len(characters))
+	for i := range characters {
+		resolvers[i] = &characterResolver{character: characters[i]}
+	}
+	return resolvers, nil
+}
+
+func (r *resolver) Character(ctx context.Context, args struct{ ID graphqlgo.ID }) (*characterResolver, error) {
+	ctx, span := tracer.Span(ctx, "controllers.graphql.character")
+	defer span.End()
+
+	character, err := r.srv.Character.FindByID(ctx, string(args.ID), entities.Filter{})
+	if err != nil {
+		r.log.Error("Ctrl.GraphQL.Character: ", "Error on find character: ", args.ID)
+		return nil, newResolverErr(err)
+	}
+
+	return &characterResolver{character: character}, nil
+}
+
+func (r *resolver) CreateHouse(ctx context.Context, args struct{ Input houseInput }) (*houseResolver, error) {
+	ctx, span := tracer.Span(ctx, "controllers.graphql.createhouse")
+	defer span.End()
+
+	if err := authorize(ctx, httpRouter.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	newHouse := args.Input.request("")
+	if err := r.validate(newHouse); err != nil {
+		return nil, err
+	}
+
+	id, err := r.srv.House.Create(ctx, newHouse)
+	if err != nil {
+		r.log.Error("Ctrl.GraphQL.CreateHouse: ", "Error on create house: ", newHouse)
+		return nil, newResolverErr(err)
+	}
+
+	return &houseResolver{house: entities.House{
+		ID:             id,
+		Name:           newHouse.Name,
+		Region:         newHouse.Region,
+		FoundationYear: newHouse.FoundationYear,
+		CurrentLord:    newHouse.CurrentLord,
+		CreatedAt:      newHouse.CreatedAt,
+	}}, nil
+}
+
+func (r *resolver) UpdateHouse(ctx context.Context, args struct {
+	ID    graphqlgo.ID
+	Input houseInput
+}) (*houseResolver, error) {
+	ctx, span := tracer.Span(ctx, "controllers.graphql.updatehouse")
+	defer span.End()
+
+	if err := authorize(ctx, httpRouter.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	updateHouse := args.Input.request(string(args.ID))
+	if err := r.validate(updateHouse); err != nil {
+		return nil, err
+	}
+
+	house, err := r.srv.House.Update(ctx, updateHouse)
+	if err != nil {
+		r.log.Error("Ctrl.GraphQL.UpdateHouse: ", "Error on update house: ", updateHouse)
+		return nil, newResolverErr(err)
+	}
+
+	return &houseResolver{house: house}, nil
+}
+
+func (r *resolver) DeleteHouse(ctx context.Context, args struct{ ID graphqlgo.ID }) (bool, error) {
+	ctx, span := tracer.Span(ctx, "controllers.graphql.deletehouse")
+	defer span.End()
+
+	if err := authorize(ctx, httpRouter.RoleAdmin); err != nil {
+		return false, err
+	}
+
+	if err := r.srv.House.Delete(ctx, string(args.ID)); err != nil {
+		r.log.Error("Ctrl.GraphQL.DeleteHouse: ", "Error on delete house: ", args.ID)
+		return false, newResolverErr(err)
+	}
+
+	return true, nil
+}
+
+func (r *resolver) CreateCharacter(ctx context.Context, args struct{ Input characterInput }) (*characterResolver, error) {
+	ctx, span := tracer.Span(ctx, "controllers.graphql.createcharacter")
+	defer span.End()
+
+	if err := authorize(ctx, httpRouter.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	newCharacter := args.Input.request("")
+	if err := r.validate(newCharacter); err != nil {
+		return nil, err
+	}
+
+	id, err := r.srv.Character.Create(ctx, newCharacter)
+	if err != nil {
+		r.log.Error("Ctrl.GraphQL.CreateCharacter: ", "Error on create character: ", newCharacter)
+		return nil, newResolverErr(err)
+	}
+
+	return &characterResolver{character: entities.Character{
+		ID:        id,
+		Name:      newCharacter.Name,
+		TVSeries:  newCharacter.TVSeries,
+		CreatedAt: newCharacter.CreatedAt,
+	}}, nil
+}
+
+func (r *resolver) UpdateCharacter(ctx context.Context, args struct {
+	ID    graphqlgo.ID
+	Input characterInput
+}) (*characterResolver, error) {
+	ctx, span := tracer.Span(ctx, "controllers.graphql.updatecharacter")
+	defer span.End()
+
+	if err := authorize(ctx, httpRouter.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	updateCharacter := args.Input.request(string(args.ID))
+	if err := r.validate(updateCharacter); err != nil {
+		return nil, err
+	}
+
+	character, err := r.srv.Character.Update(ctx, updateCharacter)
+	if err != nil {
+		r.log.Error("Ctrl.GraphQL.UpdateCharacter: ", "Error on update character: ", updateCharacter)
+		return nil, newResolverErr(err)
+	}
+
+	return &characterResolver{character: character}, nil
+}
+
+func (r *resolver) DeleteCharacter(ctx context.Context, args struct{ ID graphqlgo.ID }) (bool, error) {
+	ctx, span := tracer.Span(ctx, "controllers.graphql.deletecharacter")
+	defer span.End()
+
+	if err := authorize(ctx, httpRouter.RoleAdmin); err != nil {
+		return false, err
+	}
+
+	if err := r.srv.Character.Delete(ctx, string(args.ID)); err != nil {
+		r.log.Error("Ctrl.GraphQL.DeleteCharacter: ", "Error on delete character: ", args.ID)
+		return false, newResolverErr(err)
+	}
+
+	return true, nil
+}
+
+// authorize fails the mutations of a principal without role, the same the
+// REST routes need: editor to create and update, admin to delete.
+func authorize(ctx context.Context, role string) error {
+	principal, _ := httpRouter.PrincipalFromContext(ctx)
+	if !principal.HasRole(role) {
+		return newResolverErr(ErrMutationForbidden)
+	}
+	return nil
+}
+
+// validate applies to the inputs the rules of the REST payloads.
+func (r *resolver) validate(input any) error {
+	if err := r.validator.Validate(input); err != nil {
+		return newResolverErr(err.ToHttpErr())
+	}
+	return nil
+}
+
+// filter is the page of the args, a list is never answered whole.
+func (a pageArgs) filter() (entities.Filter, error) {
+	if a.Limit < 1 || a.Limit > MaxLimit || a.Offset < 0 {
+		return entities.Filter{}, newResolverErr(ErrLimit)
+	}
+	return entities.Filter{Limit: int(a.Limit), Offset: int(a.Offset)}, nil
+}
+
+func (i houseInput) request(id string) entities.HouseRequest {
+	house := entities.HouseRequest{
+		ID:             id,
+		Name:           i.Name,
+		Region:         i.Region,
+		FoundationYear: i.FoundationYear,
+	}
+	if i.CurrentLord != nil {
+		house.CurrentLord = string(*i.CurrentLord)
+	}
+	return house
+}
+
+func (i characterInput) request(id string) entities.CharacterRequest {
+	return entities.CharacterRequest{ID: id, Name: i.Name, TVSeries: i.TvSeries}
+}
+
+func (h *houseResolver) ID() graphqlgo.ID          { return graphqlgo.ID(h.house.ID) }
+func (h *houseResolver) Name() string              { return h.house.Name }
+func (h *houseResolver) Region() string            { return h.house.Region }
+func (h *houseResolver) FoundationYear() string    { return h.house.FoundationYear }
+func (h *houseResolver) CreatedAt() graphqlgo.Time { return graphqlgo.Time{Time: h.house.CreatedAt} }
+func (h *houseResolver) UpdatedAt() *graphqlgo.Time {
+	return optionalTime(h.house.UpdatedAt)
+}
+
+// CurrentLord is resolved by the loader of the request, so the lords of a
+// list of houses are fetched together.
+func (h *houseResolver) CurrentLord(ctx context.Context) (*characterResolver, error) {
+	if len(h.house.CurrentLord) == 0 {
+		return nil, nil
+	}
+
+	ctx, span := tracer.Span(ctx, "controllers.graphql.house.currentlord")
+	defer span.End()
+
+	lord, err := lordLoaderFrom(ctx).Load(ctx, h.house.CurrentLord)
+	if err != nil {
+		return nil, newResolverErr(err)
+	}
+	if lord == nil {
+		return nil, nil
+	}
+
+	return &characterResolver{character: *lord}, nil
+}
+
+func (c *characterResolver) ID() graphqlgo.ID   { return graphqlgo.ID(c.character.ID) }
+func (c *characterResolver) Name() string       { return c.character.Name }
+func (c *characterResolver) TvSeries() []string { return c.character.TVSeries }
+func (c *characterResolver) CreatedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: c.character.CreatedAt}
+}
+func (c *characterResolver) UpdatedAt() *graphqlgo.Time {
+	return optionalTime(c.character.UpdatedAt)
+}
+
+func optionalTime(t *time.Time) *graphqlgo.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphqlgo.Time{Time: *t}
+}
+
+// resolverErr carries into the extensions of the GraphQL error the status and
+// problem type the REST endpoints would answer for err.
+type resolverErr struct {
+	err error
+}
+
+func newResolverErr(err error) error {
+	return &resolverErr{err: err}
+}
+
+func (e *resolverErr) Error() string {
+	var httpErr *entities.HttpErr
+	if errors.As(e.err, &httpErr) {
+		return httpErr.Detail
+	}
+	return e.err.Error()
+}
+
+func (e *resolverErr) Unwrap() error {
+	return e.err
+}
+
+func (e *resolverErr) Extensions() map[string]any {
+	problem := entities.NewProblem(context.Background(), "", e.err)
+
+	extensions := map[string]any{
+		"type":   problem.Type,
+		"status": problem.Status,
+	}
+	if problem.Violations != nil {
+		extensions["violations"] = problem.Violations
+	}
+	return extensions
+}
@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+
+	gameofthronesv1 "github.com/PatrickChagastavares/game-of-thrones/api/gameofthrones/v1"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (ctrl *controllers) CreateCharacter(ctx context.Context, req *gameofthronesv1.CreateCharacterRequest) (*gameofthronesv1.CreateCharacterResponse, error) {
+	ctx, span := tracer.Span(ctx, "controllers.grpc.characters.create")
+	defer span.End()
+
+	newCharacter := entities.CharacterRequest{
+		Name:     req.GetName(),
+		TVSeries: req.GetTvSeries(),
+	}
+	if err := ctrl.validator.Validate(newCharacter); err != nil {
+		return nil, responseErr(ctx, err)
+	}
+
+	id, err := ctrl.srv.Character.Create(ctx, newCharacter)
+	if err != nil {
+		ctrl.log.Error("Ctrl.GRPC.CreateCharacter: ", "Error on create character: ", newCharacter)
+		return nil, responseErr(ctx, err)
+	}
+
+	return &gameofthronesv1.CreateCharacterResponse{Id: id}, nil
+}
+
+func (ctrl *controllers) ListCharacters(ctx context.Context, req *gameofthronesv1.ListCharactersRequest) (*gameofthronesv1.ListCharactersResponse, error) {
+	ctx, span := tracer.Span(ctx, "controllers.grpc.characters.list")
+	defer span.End()
+
+	characters, err := ctrl.srv.Character.Find(ctx, entities.Filter{})
+	if err != nil {
+		ctrl.log.Error("Ctrl.GRPC.ListCharacters: ", "Error on find characters: ", err)
+		return nil, responseErr(ctx, err)
+	}
+
+	resp := &gameofthronesv1.ListCharactersResponse{Characters: make([]*gameofthronesv1.Character, len(characters))}
+	for i, character := range characters {
+		resp.Characters[i] = toCharacter(character)
+	}
+	return resp, nil
+}
+
+func (ctrl *controllers) GetCharacter(ctx context.Context, req *gameofthronesv1.GetCharacterRequest) (*gameofthronesv1.Character, error) {
+	ctx, span := tracer.Span(ctx, "controllers.grpc.characters.get")
+	defer span.End()
+
+	character, err := ctrl.srv.Character.FindByID(ctx, req.GetId(), entities.Filter{})
+	if err != nil {
+		ctrl.log.Error("Ctrl.GRPC.GetCharacter: ", "Error on find character: ", req.GetId())
+		return nil, responseErr(ctx, err)
+	}
+
+	return toCharacter(character), nil
+}
+
+func (ctrl *controllers) UpdateCharacter(ctx context.Context, req *gameofthronesv1.UpdateCharacterRequest) (*gameofthronesv1.Character, error) {
+	ctx, span := tracer.Span(ctx, "controllers.grpc.characters.update")
+	defer span.End()
+
+	updateCharacter := entities.CharacterRequest{
+		ID:       req.GetId(),
+		Name:     req.GetName(),
+		TVSeries: req.GetTvSeries(),
+	}
+	if err := ctrl.validator.Validate(updateCharacter); err != nil {
+		return nil, responseErr(ctx, err)
+	}
+
+	character, err := ctrl.srv.Character.Update(ctx, updateCharacter)
+	if err != nil {
+		ctrl.log.Error("Ctrl.GRPC.UpdateCharacter: ", "Error on update character: ", updateCharacter)
+		return nil, responseErr(ctx, err)
+	}
+
+	return toCharacter(character), nil
+}
+
+func (ctrl *controllers) DeleteCharacter(ctx context.Context, req *gameofthronesv1.DeleteCharacterRequest) (*emptypb.Empty, error) {
+	ctx, span := tracer.Span(ctx, "controllers.grpc.characters.delete")
+	defer span.End()
+
+	if err := ctrl.srv.Character.Delete(ctx, req.GetId()); err != nil {
+		ctrl.log.Error("Ctrl.GRPC.DeleteCharacter: ", "Error on delete character: ", req.GetId())
+		return nil, responseErr(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,130 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gameofthronesv1 "github.com/PatrickChagastavares/game-of-thrones/api/gameofthrones/v1"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/characters"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_ListCharacters(t *testing.T) {
+	createdAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	cases := map[string]struct {
+		expected     *gameofthronesv1.ListCharactersResponse
+		expectedCode codes.Code
+		prepareMock  func(mock *characters.MockIService)
+	}{
+		"Should return success": {
+			expected: &gameofthronesv1.ListCharactersResponse{Characters: []*gameofthronesv1.Character{
+				{Id: "id_1", Name: "Sansa Stark", TvSeries: []string{"S01"}, CreatedAt: timestamppb.New(createdAt), UpdatedAt: timestamppb.New(updatedAt)},
+			}},
+			expectedCode: codes.OK,
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					Find(gomock.Any(), entities.Filter{}).
+					Times(1).
+					Return([]entities.Character{{ID: "id_1", Name: "Sansa Stark", TVSeries: []string{"S01"}, CreatedAt: createdAt, UpdatedAt: &updatedAt}}, nil)
+			},
+		},
+		"Should return error canceled": {
+			expectedCode: codes.Canceled,
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					Find(gomock.Any(), entities.Filter{}).
+					Times(1).
+					Return(nil, context.Canceled)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := characters.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(&services.Container{Character: mock}, logger.NewLogrusLogger())
+
+			// ============ START VALIDATION ============
+			resp, err := ctr.ListCharacters(ctx, &gameofthronesv1.ListCharactersRequest{})
+			assert.Equal(t, cs.expectedCode, status.Code(err))
+			if err == nil {
+				assert.True(t, proto.Equal(cs.expected, resp))
+			}
+		})
+	}
+}
+
+func Test_UpdateCharacter(t *testing.T) {
+	createdAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		input        *gameofthronesv1.UpdateCharacterRequest
+		expected     *gameofthronesv1.Character
+		expectedCode codes.Code
+		prepareMock  func(mock *characters.MockIService)
+	}{
+		"Should return success": {
+			input:        &gameofthronesv1.UpdateCharacterRequest{Id: "id_1", Name: "Sansa Stark", TvSeries: []string{"S01", "S02"}},
+			expected:     &gameofthronesv1.Character{Id: "id_1", Name: "Sansa Stark", TvSeries: []string{"S01", "S02"}, CreatedAt: timestamppb.New(createdAt)},
+			expectedCode: codes.OK,
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					Update(gomock.Any(), entities.CharacterRequest{ID: "id_1", Name: "Sansa Stark", TVSeries: []string{"S01", "S02"}}).
+					Times(1).
+					Return(entities.Character{ID: "id_1", Name: "Sansa Stark", TVSeries: []string{"S01", "S02"}, CreatedAt: createdAt}, nil)
+			},
+		},
+		"Should return error validate": {
+			input:        &gameofthronesv1.UpdateCharacterRequest{Id: "id_1", Name: "Sansa Stark"},
+			expectedCode: codes.InvalidArgument,
+			prepareMock:  func(mock *characters.MockIService) {},
+		},
+		"Should return error not found": {
+			input:        &gameofthronesv1.UpdateCharacterRequest{Id: "id_1", Name: "Sansa Stark", TvSeries: []string{"S01"}},
+			expectedCode: codes.NotFound,
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(entities.Character{}, characters.ErrCharacterNotFound)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := characters.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(&services.Container{Character: mock}, logger.NewLogrusLogger())
+
+			// ============ START VALIDATION ============
+			resp, err := ctr.UpdateCharacter(ctx, cs.input)
+			assert.Equal(t, cs.expectedCode, status.Code(err))
+			if err == nil {
+				assert.True(t, proto.Equal(cs.expected, resp))
+			}
+		})
+	}
+}
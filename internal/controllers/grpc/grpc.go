@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	gameofthronesv1 "github.com/PatrickChagastavares/game-of-thrones/api/gameofthrones/v1"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/validator"
+)
+
+type (
+	// IController serves the gRPC services of the api package, through the
+	// same services and validations as the http controllers.
+	IController interface {
+		gameofthronesv1.HouseServiceServer
+		gameofthronesv1.CharacterServiceServer
+	}
+	controllers struct {
+		gameofthronesv1.UnimplementedHouseServiceServer
+		gameofthronesv1.UnimplementedCharacterServiceServer
+
+		srv       *services.Container
+		log       logger.Logger
+		validator validator.Validator
+	}
+)
+
+func New(srv *services.Container, log logger.Logger) IController {
+	return &controllers{srv: srv, log: log, validator: validator.New()}
+}
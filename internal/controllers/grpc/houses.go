@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+
+	gameofthronesv1 "github.com/PatrickChagastavares/game-of-thrones/api/gameofthrones/v1"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (ctrl *controllers) CreateHouse(ctx context.Context, req *gameofthronesv1.CreateHouseRequest) (*gameofthronesv1.CreateHouseResponse, error) {
+	ctx, span := tracer.Span(ctx, "controllers.grpc.houses.create")
+	defer span.End()
+
+	newHouse := entities.HouseRequest{
+		Name:           req.GetName(),
+		Region:         req.GetRegion(),
+		FoundationYear: req.GetFoundationYear(),
+		CurrentLord:    req.GetCurrentLord(),
+	}
+	if err := ctrl.validator.Validate(newHouse); err != nil {
+		return nil, responseErr(ctx, err)
+	}
+
+	id, err := ctrl.srv.House.Create(ctx, newHouse)
+	if err != nil {
+		ctrl.log.Error("Ctrl.GRPC.CreateHouse: ", "Error on create house: ", newHouse)
+		return nil, responseErr(ctx, err)
+	}
+
+	return &gameofthronesv1.CreateHouseResponse{Id: id}, nil
+}
+
+func (ctrl *controllers) ListHouses(ctx context.Context, req *gameofthronesv1.ListHousesRequest) (*gameofthronesv1.ListHousesResponse, error) {
+	ctx, span := tracer.Span(ctx, "controllers.grpc.houses.list")
+	defer span.End()
+
+	houses, err := ctrl.srv.House.Find(ctx, req.GetName(), entities.Filter{})
+	if err != nil {
+		ctrl.log.Error("Ctrl.GRPC.ListHouses: ", "Error on find houses: ", req.GetName())
+		return nil, responseErr(ctx, err)
+	}
+
+	resp := &gameofthronesv1.ListHousesResponse{Houses: make([]*gameofthronesv1.House, len(houses))}
+	for i, house := range houses {
+		resp.Houses[i] = toHouse(house)
+	}
+	return resp, nil
+}
+
+func (ctrl *controllers) GetHouse(ctx context.Context, req *gameofthronesv1.GetHouseRequest) (*gameofthronesv1.House, error) {
+	ctx, span := tracer.Span(ctx, "controllers.grpc.houses.get")
+	defer span.End()
+
+	house, err := ctrl.srv.House.FindByID(ctx, req.GetId(), entities.Filter{})
+	if err != nil {
+		ctrl.log.Error("Ctrl.GRPC.GetHouse: ", "Error on find house: ", req.GetId())
+		return nil, responseErr(ctx, err)
+	}
+
+	return toHouse(house), nil
+}
+
+func (ctrl *controllers) UpdateHouse(ctx context.Context, req *gameofthronesv1.UpdateHouseRequest) (*gameofthronesv1.House, error) {
+	ctx, span := tracer.Span(ctx, "controllers.grpc.houses.update")
+	defer span.End()
+
+	updateHouse := entities.HouseRequest{
+		ID:             req.GetId(),
+		Name:           req.GetName(),
+		Region:         req.GetRegion(),
+		FoundationYear: req.GetFoundationYear(),
+		CurrentLord:    req.GetCurrentLord(),
+	}
+	if err := ctrl.validator.Validate(updateHouse); err != nil {
+		return nil, responseErr(ctx, err)
+	}
+
+	house, err := ctrl.srv.House.Update(ctx, updateHouse)
+	if err != nil {
+		ctrl.log.Error("Ctrl.GRPC.UpdateHouse: ", "Error on update house: ", updateHouse)
+		return nil, responseErr(ctx, err)
+	}
+
+	return toHouse(house), nil
+}
+
+func (ctrl *controllers) DeleteHouse(ctx context.Context, req *gameofthronesv1.DeleteHouseRequest) (*emptypb.Empty, error) {
+	ctx, span := tracer.Span(ctx, "controllers.grpc.houses.delete")
+	defer span.End()
+
+	if err := ctrl.srv.House.Delete(ctx, req.GetId()); err != nil {
+		ctrl.log.Error("Ctrl.GRPC.DeleteHouse: ", "Error on delete house: ", req.GetId())
+		return nil, responseErr(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
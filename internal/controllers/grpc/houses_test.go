@@ -0,0 +1,233 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	gameofthronesv1 "github.com/PatrickChagastavares/game-of-thrones/api/gameofthrones/v1"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/houses"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_CreateHouse(t *testing.T) {
+	cases := map[string]struct {
+		input        *gameofthronesv1.CreateHouseRequest
+		expected     *gameofthronesv1.CreateHouseResponse
+		expectedCode codes.Code
+		expectedMsg  string
+		prepareMock  func(mock *houses.MockIService)
+	}{
+		"Should return success": {
+			input:        &gameofthronesv1.CreateHouseRequest{Name: "house Patrick", Region: "sao paulo", FoundationYear: "2023"},
+			expected:     &gameofthronesv1.CreateHouseResponse{Id: "id_123"},
+			expectedCode: codes.OK,
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Create(gomock.Any(), entities.HouseRequest{Name: "house Patrick", Region: "sao paulo", FoundationYear: "2023"}).
+					Times(1).
+					Return("id_123", nil)
+			},
+		},
+		"Should return error validate": {
+			input:        &gameofthronesv1.CreateHouseRequest{Name: "Pa", Region: "sao paulo", FoundationYear: "2023"},
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "invalid_payload",
+			prepareMock:  func(mock *houses.MockIService) {},
+		},
+		"Should return error name used": {
+			input:        &gameofthronesv1.CreateHouseRequest{Name: "house Patrick", Region: "sao paulo", FoundationYear: "2023"},
+			expectedCode: codes.AlreadyExists,
+			expectedMsg:  "name informed already used in another house",
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("", houses.ErrNameUsed)
+			},
+		},
+		"Should return error validation of the service": {
+			input:        &gameofthronesv1.CreateHouseRequest{Name: "house Patrick", Region: "sao paulo", FoundationYear: "2023", CurrentLord: "id_1"},
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "the current lord is not a character",
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("", entities.NewDomainErr(entities.ErrValidation, "the current lord is not a character", nil))
+			},
+		},
+		"Should return error service": {
+			input:        &gameofthronesv1.CreateHouseRequest{Name: "house Patrick", Region: "sao paulo", FoundationYear: "2023"},
+			expectedCode: codes.Internal,
+			expectedMsg:  "failed to create house",
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("", errors.New("failed to create house"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := houses.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(&services.Container{House: mock}, logger.NewLogrusLogger())
+
+			// ============ START VALIDATION ============
+			resp, err := ctr.CreateHouse(ctx, cs.input)
+			assert.Equal(t, cs.expectedCode, status.Code(err))
+			if err != nil {
+				assert.Equal(t, cs.expectedMsg, status.Convert(err).Message())
+				return
+			}
+			assert.True(t, proto.Equal(cs.expected, resp))
+		})
+	}
+}
+
+func Test_CreateHouse_violations(t *testing.T) {
+	ctr := New(&services.Container{}, logger.NewLogrusLogger())
+
+	_, err := ctr.CreateHouse(context.Background(), &gameofthronesv1.CreateHouseRequest{Name: "Pa"})
+
+	details := status.Convert(err).Details()
+	assert.Len(t, details, 1)
+	badRequest, _ := details[0].(*errdetails.BadRequest)
+	assert.True(t, proto.Equal(&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+		{Field: "name", Description: "min"},
+		{Field: "region", Description: "required"},
+		{Field: "foundation_year", Description: "required"},
+	}}, badRequest))
+}
+
+func Test_GetHouse(t *testing.T) {
+	createdAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		input        *gameofthronesv1.GetHouseRequest
+		expected     *gameofthronesv1.House
+		expectedCode codes.Code
+		expectedMsg  string
+		prepareMock  func(mock *houses.MockIService)
+	}{
+		"Should return success": {
+			input: &gameofthronesv1.GetHouseRequest{Id: "id_1"},
+			expected: &gameofthronesv1.House{
+				Id: "id_1", Name: "Stark", Region: "North", FoundationYear: "299", CurrentLord: "lord_1",
+				CreatedAt: timestamppb.New(createdAt),
+			},
+			expectedCode: codes.OK,
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), "id_1", entities.Filter{}).
+					Times(1).
+					Return(entities.House{ID: "id_1", Name: "Stark", Region: "North", FoundationYear: "299", CurrentLord: "lord_1", CreatedAt: createdAt}, nil)
+			},
+		},
+		"Should return error not found": {
+			input:        &gameofthronesv1.GetHouseRequest{Id: "id_1"},
+			expectedCode: codes.NotFound,
+			expectedMsg:  "this house is not found or deleted",
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), "id_1", entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, houses.ErrHouseNotFound.Wrap(sql.ErrNoRows))
+			},
+		},
+		"Should return error unavailable": {
+			input:        &gameofthronesv1.GetHouseRequest{Id: "id_1"},
+			expectedCode: codes.Unavailable,
+			expectedMsg:  "database unavailable",
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), "id_1", entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, entities.NewDomainErr(entities.ErrUnavailable, "database unavailable", nil))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := houses.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(&services.Container{House: mock}, logger.NewLogrusLogger())
+
+			// ============ START VALIDATION ============
+			resp, err := ctr.GetHouse(ctx, cs.input)
+			assert.Equal(t, cs.expectedCode, status.Code(err))
+			if err != nil {
+				assert.Equal(t, cs.expectedMsg, status.Convert(err).Message())
+				return
+			}
+			assert.True(t, proto.Equal(cs.expected, resp))
+		})
+	}
+}
+
+func Test_DeleteHouse(t *testing.T) {
+	cases := map[string]struct {
+		input        *gameofthronesv1.DeleteHouseRequest
+		expectedCode codes.Code
+		prepareMock  func(mock *houses.MockIService)
+	}{
+		"Should return success": {
+			input:        &gameofthronesv1.DeleteHouseRequest{Id: "id_1"},
+			expectedCode: codes.OK,
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().Delete(gomock.Any(), "id_1").Times(1).Return(nil)
+			},
+		},
+		"Should return error not found": {
+			input:        &gameofthronesv1.DeleteHouseRequest{Id: "id_1"},
+			expectedCode: codes.NotFound,
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().Delete(gomock.Any(), "id_1").Times(1).Return(houses.ErrHouseNotFound)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := houses.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(&services.Container{House: mock}, logger.NewLogrusLogger())
+
+			// ============ START VALIDATION ============
+			_, err := ctr.DeleteHouse(ctx, cs.input)
+			assert.Equal(t, cs.expectedCode, status.Code(err))
+		})
+	}
+}
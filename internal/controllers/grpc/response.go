@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gameofthronesv1 "github.com/PatrickChagastavares/game-of-thrones/api/gameofthrones/v1"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// responseErr converts err into the status answered to the call, domain
+// errors take the code of their kind.
+func responseErr(ctx context.Context, err error) error {
+	_, span := tracer.Span(ctx, "controllers.grpc.responseErr")
+	defer span.End()
+
+	var validationErr *validator.ValidationError
+	if errors.As(err, &validationErr) {
+		return invalidArgument(validationErr)
+	}
+
+	return status.Error(code(err), err.Error())
+}
+
+func code(err error) codes.Code {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, entities.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, entities.ErrConflict):
+		return codes.AlreadyExists
+	case errors.Is(err, entities.ErrValidation):
+		return codes.InvalidArgument
+	case errors.Is(err, entities.ErrUnavailable):
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// invalidArgument lists the violations of the request as the details of the
+// status, named after the fields of the proto messages.
+func invalidArgument(err *validator.ValidationError) error {
+	st := status.New(codes.InvalidArgument, err.Message)
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range err.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.FieldJSON,
+			Description: violation.Tag,
+		})
+	}
+
+	detailed, detailErr := st.WithDetails(badRequest)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func toHouse(house entities.House) *gameofthronesv1.House {
+	return &gameofthronesv1.House{
+		Id:             house.ID,
+		Name:           house.Name,
+		Region:         house.Region,
+		FoundationYear: house.FoundationYear,
+		CurrentLord:    house.CurrentLord,
+		CreatedAt:      timestamppb.New(house.CreatedAt),
+		UpdatedAt:      toTimestamp(house.UpdatedAt),
+	}
+}
+
+func toCharacter(character entities.Character) *gameofthronesv1.Character {
+	return &gameofthronesv1.Character{
+		Id:        character.ID,
+		Name:      character.Name,
+		TvSeries:  character.TVSeries,
+		CreatedAt: timestamppb.New(character.CreatedAt),
+		UpdatedAt: toTimestamp(character.UpdatedAt),
+	}
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
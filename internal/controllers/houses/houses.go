@@ -1,6 +1,7 @@
 package houses
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
@@ -8,6 +9,7 @@ import (
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/validator"
 )
 
 type (
@@ -17,27 +19,37 @@ type (
 		FindByID(c httpRouter.Context)
 		Update(c httpRouter.Context)
 		Delete(c httpRouter.Context)
+		Restore(c httpRouter.Context)
+		History(c httpRouter.Context)
+		Bulk(c httpRouter.Context)
+		BatchGet(c httpRouter.Context)
 	}
 	controllers struct {
-		srv *services.Container
-		log logger.Logger
+		srv       *services.Container
+		log       logger.Logger
+		validator validator.Validator
 	}
 )
 
 func New(srv *services.Container, log logger.Logger) IController {
-	return &controllers{srv: srv, log: log}
+	return &controllers{srv: srv, log: log, validator: validator.New()}
 }
 
 // house swagger document
 // @Description Create one house
 // @Tags house
 // @Accept json
-// @Produce json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
 // @Param house body entities.HouseRequest true "create new house"
+// @Param Idempotency-Key header string false "replays the first response when the request is retried"
+// @Param X-Actor header string false "who makes the change, recorded in the history"
 // @Success 201
 // @Failure 400 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
 // @Failure 409 {object} entities.HttpErr
-// @Failure 500
+// @Failure 422 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
 // @Security ApiKeyAuth
 // @Router /houses [post]
 func (ctrl *controllers) Create(c httpRouter.Context) {
@@ -46,23 +58,23 @@ func (ctrl *controllers) Create(c httpRouter.Context) {
 
 	var newHouse entities.HouseRequest
 	if err := c.Decode(&newHouse); err != nil {
-		c.JSON(http.StatusBadRequest, entities.ErrDecode)
+		responseErr(ctx, c, entities.ErrDecode)
 		return
 	}
 
 	if err := c.Validate(newHouse); err != nil {
-		c.JSON(http.StatusBadRequest, err)
+		responseErr(ctx, c, err)
 		return
 	}
 
 	id, err := ctrl.srv.House.Create(ctx, newHouse)
 	if err != nil {
 		ctrl.log.Error("Ctrl.Create: ", "Error on create house: ", newHouse)
-		responseErr(ctx, err, c.JSON)
+		responseErr(ctx, c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, map[string]any{
+	c.Respond(http.StatusCreated, map[string]any{
 		"id": id,
 	})
 }
@@ -71,36 +83,70 @@ func (ctrl *controllers) Create(c httpRouter.Context) {
 // @Description Find houses
 // @Tags house
 // @Accept json
-// @Produce json
+// @Produce json,application/hal+json,application/ld+json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	fields	query	string	false	"comma separated json fields to return, e.g. id,name"
+// @Param	ids	query	string	false	"comma separated ids to fetch at once, answered as an entities.HouseBatch in their order"
 // @Param	name	query	string	false	"name house"
+// @Param	include_deleted	query	bool	false	"admin only, also returns deleted houses when no name is informed"
+// @Param	limit	query	int	false	"size of the page, every house when not informed"
+// @Param	offset	query	int	false	"houses skipped before the page"
 // @Success 200 {object} []entities.House
-// @Failure 500
+// @Failure 400 {object} entities.HttpErr
+// @Failure 403 {object} entities.HttpErr
+// @Failure 404 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
 // @Security ApiKeyAuth
 // @Router /houses [get]
 func (ctrl *controllers) Find(c httpRouter.Context) {
 	ctx, span := tracer.Span(c.Context(), "controllers.houses.find")
 	defer span.End()
 
+	if raw := c.GetQuery("ids"); len(raw) > 0 {
+		ids, err := entities.ParseIDs(raw)
+		if err != nil {
+			responseErr(ctx, c, err)
+			return
+		}
+
+		ctrl.findByIDs(ctx, c, ids)
+		return
+	}
+
 	name := c.GetQuery("name")
 
-	houses, err := ctrl.srv.House.Find(ctx, name)
+	f, err := pageFilter(c)
+	if err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	houses, err := ctrl.srv.House.Find(ctx, name, f)
 	if err != nil {
 		ctrl.log.Error("Ctrl.Find: ", "Error on find houses: ", name)
-		responseErr(ctx, err, c.JSON)
+		responseErr(ctx, c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, houses)
+	responseHouses(c, houses, f)
 }
 
 // house swagger document
 // @Description find house by id
 // @Tags house
 // @Accept json
-// @Produce json
+// @Produce json,application/hal+json,application/ld+json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	fields	query	string	false	"comma separated json fields to return, e.g. id,name"
 // @Param id path string true "House ID"
+// @Param	include_deleted	query	bool	false	"admin only, also finds a deleted house"
 // @Success 200 {object} entities.House
-// @Failure 500
+// @Failure 400 {object} entities.HttpErr
+// @Failure 403 {object} entities.HttpErr
+// @Failure 404 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
 // @Security ApiKeyAuth
 // @Router /houses/:id [get]
 func (ctrl *controllers) FindByID(c httpRouter.Context) {
@@ -109,26 +155,37 @@ func (ctrl *controllers) FindByID(c httpRouter.Context) {
 
 	id := c.GetParam("id")
 
-	houses, err := ctrl.srv.House.FindByID(ctx, id)
+	f, err := filter(c)
+	if err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	houses, err := ctrl.srv.House.FindByID(ctx, id, f)
 	if err != nil {
 		ctrl.log.Error("Ctrl.FindByID: ", "Error on find house: ", id)
-		responseErr(ctx, err, c.JSON)
+		responseErr(ctx, c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, houses)
+	responseHouse(c, http.StatusOK, houses, f.Fields)
 }
 
 // house swagger document
 // @Description Update house
 // @Tags house
 // @Accept json
-// @Produce json
+// @Produce json,application/hal+json,application/ld+json,text/csv,application/yaml,application/msgpack,application/problem+json
 // @Param id path string true "House ID"
 // @Param house body entities.HouseRequest true "create new house"
+// @Param X-Actor header string false "who makes the change, recorded in the history"
 // @Success 200 {object} entities.House
 // @Failure 400 {object} entities.HttpErr
-// @Failure 500
+// @Failure 404 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 409 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
 // @Security ApiKeyAuth
 // @Router /houses/:id [put]
 func (ctrl *controllers) Update(c httpRouter.Context) {
@@ -137,12 +194,12 @@ func (ctrl *controllers) Update(c httpRouter.Context) {
 
 	var updateHouse entities.HouseRequest
 	if err := c.Decode(&updateHouse); err != nil {
-		c.JSON(http.StatusBadRequest, entities.ErrDecode)
+		responseErr(ctx, c, entities.ErrDecode)
 		return
 	}
 
 	if err := c.Validate(updateHouse); err != nil {
-		c.JSON(http.StatusBadRequest, err)
+		responseErr(ctx, c, err)
 		return
 	}
 
@@ -151,23 +208,26 @@ func (ctrl *controllers) Update(c httpRouter.Context) {
 	houses, err := ctrl.srv.House.Update(ctx, updateHouse)
 	if err != nil {
 		ctrl.log.Error("Ctrl.Update: ", "Error on update house: ", updateHouse)
-		responseErr(ctx, err, c.JSON)
+		responseErr(ctx, c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, houses)
+	responseHouse(c, http.StatusOK, houses, nil)
 }
 
 // house swagger document
 // @Description Delete house
 // @Tags house
 // @Accept json
-// @Produce json
+// @Produce json,application/problem+json
 // @Param id path string true "House ID"
 // @Param house body entities.HouseRequest true "create new house"
+// @Param X-Actor header string false "who makes the change, recorded in the history"
 // @Success 200 {object} entities.House
 // @Failure 400 {object} entities.HttpErr
-// @Failure 500
+// @Failure 404 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
 // @Security ApiKeyAuth
 // @Router /houses/:id [delete]
 func (ctrl *controllers) Delete(c httpRouter.Context) {
@@ -179,9 +239,221 @@ func (ctrl *controllers) Delete(c httpRouter.Context) {
 	err := ctrl.srv.House.Delete(ctx, id)
 	if err != nil {
 		ctrl.log.Error("Ctrl.Delete: ", "Error on delete house: ", id)
-		responseErr(ctx, err, c.JSON)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	c.Respond(http.StatusNoContent, nil)
+}
+
+// house swagger document
+// @Description Restore a deleted house, its name must not be used by another house
+// @Tags house
+// @Accept json
+// @Produce json,application/hal+json,application/ld+json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param id path string true "House ID"
+// @Param X-Actor header string false "who makes the change, recorded in the history"
+// @Success 200 {object} entities.House
+// @Failure 400 {object} entities.HttpErr
+// @Failure 404 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 409 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /houses/:id/restore [post]
+func (ctrl *controllers) Restore(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.houses.restore")
+	defer span.End()
+
+	id := c.GetParam("id")
+
+	house, err := ctrl.srv.House.Restore(ctx, id)
+	if err != nil {
+		ctrl.log.Error("Ctrl.Restore: ", "Error on restore house: ", id)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	responseHouse(c, http.StatusOK, house, nil)
+}
+
+// house swagger document
+// @Description List the changes made to a house, newest first
+// @Tags house
+// @Accept json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	fields	query	string	false	"comma separated json fields to return, e.g. id,name"
+// @Param id path string true "House ID"
+// @Success 200 {array} entities.AuditEntry
+// @Failure 400 {object} entities.HttpErr
+// @Failure 404 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /houses/:id/history [get]
+func (ctrl *controllers) History(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.houses.history")
+	defer span.End()
+
+	id := c.GetParam("id")
+
+	fields, err := entities.ParseFields(c.GetQuery("fields"), entities.AuditEntry{})
+	if err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	entries, err := ctrl.srv.House.History(ctx, id, fields)
+	if err != nil {
+		ctrl.log.Error("Ctrl.History: ", "Error on find history of house: ", id)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	c.Respond(http.StatusOK, entities.Project(entries, fields))
+}
+
+// house swagger document
+// @Description Create or update many houses, items with id are updated and the others created
+// @Tags house
+// @Accept json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	atomic	query	bool	false	"roll back every item when one of them fails"
+// @Param houses body entities.HouseBulkRequest true "houses to create or update"
+// @Param Idempotency-Key header string false "replays the first response when the request is retried"
+// @Param Content-Encoding header string false "gzip to send the body compressed"
+// @Param X-Actor header string false "who makes the change, recorded in the history"
+// @Success 200 {object} entities.BulkResponse
+// @Success 207 {object} entities.BulkResponse
+// @Failure 400 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 409 {object} entities.HttpErr
+// @Failure 413 {object} entities.HttpErr
+// @Failure 415 {object} entities.HttpErr
+// @Failure 422 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /houses/bulk [post]
+func (ctrl *controllers) Bulk(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.houses.bulk")
+	defer span.End()
+
+	var bulk entities.HouseBulkRequest
+	if err := c.Decode(&bulk); err != nil {
+		responseErr(ctx, c, entities.ErrDecode)
+		return
+	}
+
+	if err := c.Validate(bulk); err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	atomic := c.GetQuery("atomic") == "true"
+
+	results := make([]entities.BulkResult, len(bulk.Items))
+	items := make([]entities.HouseRequest, 0, len(bulk.Items))
+	positions := make([]int, 0, len(bulk.Items))
+	for i, item := range bulk.Items {
+		results[i].Index = i
+		if err := ctrl.validator.Validate(item); err != nil {
+			results[i].Err = entities.ErrBulkInvalidItem
+			results[i].Detail = err.Violations
+			continue
+		}
+
+		item.HouseRequest.ID = item.ID
+		items = append(items, item.HouseRequest)
+		positions = append(positions, i)
+	}
+
+	// an atomic batch with an invalid item is rejected without touching the database
+	if atomic && len(items) < len(bulk.Items) {
+		entities.Rollback(results)
+		bulkResponse(ctx, c, atomic, results)
+		return
+	}
+
+	processed, err := ctrl.srv.House.Bulk(ctx, items, atomic)
+	if err != nil {
+		ctrl.log.Error("Ctrl.Bulk: ", "Error on bulk houses: ", err)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	for i, result := range processed {
+		result.Index = positions[i]
+		if result.Err == nil {
+			result.Status = http.StatusOK
+			if len(bulk.Items[positions[i]].ID) == 0 {
+				result.Status = http.StatusCreated
+			}
+		}
+		results[positions[i]] = result
+	}
+
+	bulkResponse(ctx, c, atomic, results)
+}
+
+// house swagger document
+// @Description Find many houses by id at once, for lists of ids too long for GET /houses?ids=
+// @Tags house
+// @Accept json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	fields	query	string	false	"comma separated json fields to return, e.g. id,name"
+// @Param	include_deleted	query	bool	false	"admin only, also finds deleted houses"
+// @Param ids body entities.BatchRequest true "ids of the houses"
+// @Success 200 {object} entities.HouseBatch
+// @Failure 400 {object} entities.HttpErr
+// @Failure 403 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 422 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /houses/batch-get [post]
+func (ctrl *controllers) BatchGet(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.houses.batchget")
+	defer span.End()
+
+	var batch entities.BatchRequest
+	if err := c.Decode(&batch); err != nil {
+		responseErr(ctx, c, entities.ErrDecode)
+		return
+	}
+
+	if err := c.Validate(batch); err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	ids, err := entities.BatchIDs(batch.IDs)
+	if err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	ctrl.findByIDs(ctx, c, ids)
+}
+
+// findByIDs answers the houses among ids in their order, with the ids not
+// found.
+func (ctrl *controllers) findByIDs(ctx context.Context, c httpRouter.Context, ids []string) {
+	f, err := filter(c)
+	if err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	batch, err := ctrl.srv.House.FindByIDs(ctx, ids, f)
+	if err != nil {
+		ctrl.log.Error("Ctrl.FindByIDs: ", "Error on find houses by ids: ", err)
+		responseErr(ctx, c, err)
 		return
 	}
 
-	c.JSON(http.StatusNoContent, nil)
+	responseHouseBatch(c, batch, f.Fields)
 }
@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
@@ -67,8 +68,7 @@ func Test_Create(t *testing.T) {
 			},
 			expectedCode: http.StatusBadRequest,
 			expectedData: func() string {
-				bt, _ := json.Marshal(entities.ErrDecode)
-				return string(bt)
+				return `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"problem to decode your input","instance":"/houses"}`
 			},
 			prepareMock: func(mock *houses.MockIService) {},
 		},
@@ -82,7 +82,7 @@ func Test_Create(t *testing.T) {
 			},
 			expectedCode: http.StatusBadRequest,
 			expectedData: func() string {
-				bt := []byte(`{"http_code":400,"message":"invalid_payload","detail":[{"field":"name","error":"min","value":"Pa"},{"field":"region","error":"required","value":""},{"field":"foundation_year","error":"required","value":""}]}`)
+				bt := []byte(`{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"invalid_payload","instance":"/houses","violations":[{"field":"name","error":"min","value":"Pa"},{"field":"region","error":"required","value":""},{"field":"foundation_year","error":"required","value":""}]}`)
 				return string(bt)
 			},
 			prepareMock: func(mock *houses.MockIService) {},
@@ -100,7 +100,7 @@ func Test_Create(t *testing.T) {
 			},
 			expectedCode: http.StatusInternalServerError,
 			expectedData: func() string {
-				return "\"failed to create house\""
+				return `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"failed to create house","instance":"/houses"}`
 			},
 			prepareMock: func(mock *houses.MockIService) {
 				mock.EXPECT().
@@ -159,6 +159,7 @@ func Test_Find(t *testing.T) {
 	}
 	cases := map[string]struct {
 		inputPath    string
+		inputAccept  string
 		expectedCode int
 		expectedData func() string
 		prepareMock  func(mock *houses.MockIService)
@@ -172,7 +173,7 @@ func Test_Find(t *testing.T) {
 			},
 			prepareMock: func(mock *houses.MockIService) {
 				mock.EXPECT().
-					Find(gomock.Any(), "House Algood").
+					Find(gomock.Any(), "House Algood", entities.Filter{}).
 					Times(1).
 					Return([]entities.House{data[0]}, nil)
 			},
@@ -185,21 +186,125 @@ func Test_Find(t *testing.T) {
 			},
 			prepareMock: func(mock *houses.MockIService) {
 				mock.EXPECT().
-					Find(gomock.Any(), "").
+					Find(gomock.Any(), "", entities.Filter{}).
 					Times(1).
 					Return(data, nil)
 			},
 		},
-		"Should return error service ": {
+		"Should return a page in HAL": {
+			inputPath:    "?limit=2&offset=2",
+			inputAccept:  entities.HALContentType,
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"_links":{"self":{"href":"/houses?limit=2&offset=2"},"members":[{"href":"/houses/id_1"},{"href":"/houses/id_1"}],"next":{"href":"/houses?limit=2&offset=4"},"prev":{"href":"/houses?limit=2&offset=0"}},"count":2,"_embedded":{"houses":[{"id":"id_1","name":"House Algood","region":"sao paulo","foundation_year":"2023","current_lord":"","created_at":"0001-01-01T00:00:00Z","updated_at":null,"_links":{"self":{"href":"/houses/id_1"}}},{"id":"id_1","name":"house Patrick Chagas","region":"sao paulo","foundation_year":"2023","current_lord":"","created_at":"0001-01-01T00:00:00Z","updated_at":null,"_links":{"self":{"href":"/houses/id_1"}}}]}}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Find(gomock.Any(), "", entities.Filter{Limit: 2, Offset: 2}).
+					Times(1).
+					Return(data, nil)
+			},
+		},
+		"Should return only the fields asked for": {
+			inputPath:    "?fields=name,current_lord",
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `[{"name":"House Algood","current_lord":""},{"name":"house Patrick Chagas","current_lord":""}]`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Find(gomock.Any(), "", entities.Filter{Fields: []string{"name", "current_lord"}}).
+					Times(1).
+					Return(data, nil)
+			},
+		},
+		"Should keep the links of the fields asked for in HAL": {
+			inputPath:    "?fields=name",
+			inputAccept:  entities.HALContentType,
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"_links":{"self":{"href":"/houses?fields=name"},"members":[{"href":"/houses/id_1"},{"href":"/houses/id_1"}]},"count":2,"_embedded":{"houses":[{"name":"House Algood","_links":{"self":{"href":"/houses/id_1"}}},{"name":"house Patrick Chagas","_links":{"self":{"href":"/houses/id_1"}}}]}}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Find(gomock.Any(), "", entities.Filter{Fields: []string{"name"}}).
+					Times(1).
+					Return(data, nil)
+			},
+		},
+		"Should return error fields": {
+			inputPath:    "?fields=name,lord",
 			expectedCode: http.StatusBadRequest,
 			expectedData: func() string {
-				resp := entities.NewHttpErr(http.StatusBadRequest, houses.ErrFind.Error(), nil)
-				bt, _ := json.Marshal(resp)
-				return string(bt)
+				return `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"fields has unknown fields: lord","instance":"/houses"}`
+			},
+			prepareMock: func(mock *houses.MockIService) {},
+		},
+		"Should return success in CSV": {
+			inputAccept:  "text/csv",
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return "id,name,region,foundation_year,current_lord,created_at,updated_at\n" +
+					"id_1,House Algood,sao paulo,2023,,0001-01-01T00:00:00Z,\n" +
+					"id_1,house Patrick Chagas,sao paulo,2023,,0001-01-01T00:00:00Z,\n"
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Find(gomock.Any(), "", entities.Filter{}).
+					Times(1).
+					Return(data, nil)
+			},
+		},
+		"Should return error not acceptable": {
+			inputAccept:  "application/xml",
+			expectedCode: http.StatusNotAcceptable,
+			expectedData: func() string {
+				return ""
 			},
 			prepareMock: func(mock *houses.MockIService) {
 				mock.EXPECT().
-					Find(gomock.Any(), "").
+					Find(gomock.Any(), "", entities.Filter{}).
+					Times(1).
+					Return(data, nil)
+			},
+		},
+		"Should return the houses of the ids in their order": {
+			inputPath:    "?ids=id_2,id_1,id_3,id_2&fields=name",
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"houses":[{"name":"house Patrick Chagas"},{"name":"House Algood"}],"missing":["id_3"]}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					FindByIDs(gomock.Any(), []string{"id_2", "id_1", "id_3"}, entities.Filter{Fields: []string{"name"}}).
+					Times(1).
+					Return(entities.HouseBatch{Houses: []entities.House{{ID: "id_2", Name: "house Patrick Chagas"}, {ID: "id_1", Name: "House Algood"}}, Missing: []string{"id_3"}}, nil)
+			},
+		},
+		"Should return error ids": {
+			inputPath:    "?ids=,",
+			expectedCode: http.StatusBadRequest,
+			expectedData: func() string {
+				return `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"ids must have from 1 to 1000 ids","instance":"/houses"}`
+			},
+			prepareMock: func(mock *houses.MockIService) {},
+		},
+		"Should return error invalid page": {
+			inputPath:    "?limit=-1",
+			expectedCode: http.StatusBadRequest,
+			expectedData: func() string {
+				return `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"limit and offset must be positive integers","instance":"/houses"}`
+			},
+			prepareMock: func(mock *houses.MockIService) {},
+		},
+		"Should return error service ": {
+			expectedCode: http.StatusNotFound,
+			expectedData: func() string {
+				return `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"house not found","instance":"/houses"}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Find(gomock.Any(), "", entities.Filter{}).
 					Times(1).
 					Return(nil, houses.ErrFind)
 			},
@@ -229,6 +334,7 @@ func Test_Find(t *testing.T) {
 			request := httptest.NewRequest(http.MethodGet, endpoint+cs.inputPath, nil).WithContext(ctx)
 			writer := httptest.NewRecorder()
 			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Accept", cs.inputAccept)
 
 			// ============ START SERVER HTTP ============
 			router.ServeHTTP(writer, request)
@@ -252,6 +358,8 @@ func Test_FindByID(t *testing.T) {
 	}
 	cases := map[string]struct {
 		inputPath    string
+		inputAccept  string
+		inputRoles   []string
 		expectedCode int
 		expectedData func() string
 		prepareMock  func(mock *houses.MockIService)
@@ -265,26 +373,93 @@ func Test_FindByID(t *testing.T) {
 			},
 			prepareMock: func(mock *houses.MockIService) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), data.ID).
+					FindByID(gomock.Any(), data.ID, entities.Filter{}).
 					Times(1).
 					Return(data, nil)
 			},
 		},
+		"Should return success in HAL": {
+			inputPath:    data.ID,
+			inputAccept:  "application/json;q=0.5, application/hal+json",
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"id":"id_1","name":"Patrick","region":"sao paulo","foundation_year":"2023","current_lord":"lord_1","created_at":"0001-01-01T00:00:00Z","updated_at":null,"_links":{"self":{"href":"/houses/id_1"},"current_lord":{"href":"/characters/lord_1"}}}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				house := data
+				house.CurrentLord = "lord_1"
+				mock.EXPECT().
+					FindByID(gomock.Any(), data.ID, entities.Filter{}).
+					Times(1).
+					Return(house, nil)
+			},
+		},
+		"Should return success in JSON-LD": {
+			inputPath:    data.ID,
+			inputAccept:  entities.JSONLDContentType,
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"@context":{"@vocab":"https://schema.org/","created_at":"dateCreated","current_lord":{"@id":"member","@type":"@id"},"foundation_year":"foundingDate","id":"identifier","region":"areaServed","updated_at":"dateModified"},"@id":"/houses/id_1","@type":"Organization","id":"id_1","name":"Patrick","region":"sao paulo","foundation_year":"2023","current_lord":"/characters/lord_1","created_at":"0001-01-01T00:00:00Z","updated_at":null}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				house := data
+				house.CurrentLord = "lord_1"
+				mock.EXPECT().
+					FindByID(gomock.Any(), data.ID, entities.Filter{}).
+					Times(1).
+					Return(house, nil)
+			},
+		},
 		"Should return error service": {
 			inputPath:    data.ID,
-			expectedCode: http.StatusBadRequest,
+			expectedCode: http.StatusNotFound,
 			expectedData: func() string {
-				resp := entities.NewHttpErr(http.StatusBadRequest, houses.ErrHouseNotFound.Error(), nil)
-				bt, _ := json.Marshal(resp)
-				return string(bt)
+				return `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"this house is not found or deleted","instance":"/houses/id_1"}`
 			},
 			prepareMock: func(mock *houses.MockIService) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), data.ID).
+					FindByID(gomock.Any(), data.ID, entities.Filter{}).
 					Times(1).
 					Return(entities.House{}, houses.ErrHouseNotFound)
 			},
 		},
+		"Should return a deleted house to an admin": {
+			inputPath:    data.ID + "?include_deleted=true",
+			inputRoles:   []string{httpRouter.RoleAdmin},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				bt, _ := json.Marshal(data)
+				return string(bt)
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), data.ID, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(data, nil)
+			},
+		},
+		"Should return error include deleted not admin": {
+			inputPath:    data.ID + "?include_deleted=true",
+			inputRoles:   []string{httpRouter.RoleEditor},
+			expectedCode: http.StatusForbidden,
+			expectedData: func() string {
+				return `{"type":"/problems/forbidden","title":"Forbidden","status":403,"detail":"only the admins can include the deleted records","instance":"/houses/id_1"}`
+			},
+			prepareMock: func(mock *houses.MockIService) {},
+		},
+		"Should return error unavailable": {
+			inputPath:    data.ID,
+			expectedCode: http.StatusServiceUnavailable,
+			expectedData: func() string {
+				return `{"type":"/problems/unavailable","title":"Service Unavailable","status":503,"detail":"house is not found or deleted","instance":"/houses/id_1"}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), data.ID, entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, entities.NewDomainErr(entities.ErrUnavailable, "house is not found or deleted", errors.New("connection refused")))
+			},
+		},
 	}
 
 	for name, cs := range cases {
@@ -307,9 +482,13 @@ func Test_FindByID(t *testing.T) {
 			router.Get(endpoint+":id", ctr.FindByID)
 
 			// ============ START MOCK REQUEST ============
+			if len(cs.inputRoles) > 0 {
+				ctx = httpRouter.ContextWithPrincipal(ctx, httpRouter.Principal{ID: "key_1", Roles: cs.inputRoles})
+			}
 			request := httptest.NewRequest(http.MethodGet, endpoint+cs.inputPath, nil).WithContext(ctx)
 			writer := httptest.NewRecorder()
 			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Accept", cs.inputAccept)
 
 			// ============ START SERVER HTTP ============
 			router.ServeHTTP(writer, request)
@@ -318,6 +497,12 @@ func Test_FindByID(t *testing.T) {
 			responseData, _ := ioutil.ReadAll(writer.Body)
 			assert.Equal(t, cs.expectedData(), string(responseData))
 			assert.Equal(t, cs.expectedCode, writer.Code)
+			if len(cs.inputAccept) > 0 {
+				assert.Equal(t, "Accept", writer.Header().Get("Vary"))
+			}
+			if cs.expectedCode >= http.StatusBadRequest {
+				assert.Equal(t, httpRouter.ProblemContentType, writer.Header().Get("Content-Type"))
+			}
 		})
 	}
 }
@@ -377,8 +562,7 @@ func Test_Update(t *testing.T) {
 			},
 			expectedCode: http.StatusBadRequest,
 			expectedData: func() string {
-				bt, _ := json.Marshal(entities.ErrDecode)
-				return string(bt)
+				return `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"problem to decode your input","instance":"/houses/id_1"}`
 			},
 			prepareMock: func(mock *houses.MockIService) {},
 		},
@@ -393,7 +577,7 @@ func Test_Update(t *testing.T) {
 			},
 			expectedCode: http.StatusBadRequest,
 			expectedData: func() string {
-				bt := []byte(`{"http_code":400,"message":"invalid_payload","detail":[{"field":"name","error":"min","value":"Pa"},{"field":"region","error":"required","value":""},{"field":"foundation_year","error":"required","value":""}]}`)
+				bt := []byte(`{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"invalid_payload","instance":"/houses/id_1","violations":[{"field":"name","error":"min","value":"Pa"},{"field":"region","error":"required","value":""},{"field":"foundation_year","error":"required","value":""}]}`)
 				return string(bt)
 			},
 			prepareMock: func(mock *houses.MockIService) {},
@@ -412,7 +596,7 @@ func Test_Update(t *testing.T) {
 			},
 			expectedCode: http.StatusInternalServerError,
 			expectedData: func() string {
-				return "\"failed to update house\""
+				return `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"failed to update house","instance":"/houses/id_1"}`
 			},
 			prepareMock: func(mock *houses.MockIService) {
 				mock.EXPECT().
@@ -489,7 +673,7 @@ func Test_Delete(t *testing.T) {
 			paramInput:   "33c55a43-f163-4a67-9f6c-75161410f376",
 			expectedCode: http.StatusInternalServerError,
 			expectedData: func() string {
-				return "\"failed to delete house\""
+				return `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"failed to delete house","instance":"/houses/33c55a43-f163-4a67-9f6c-75161410f376"}`
 			},
 			prepareMock: func(mock *houses.MockIService) {
 				mock.EXPECT().
@@ -534,3 +718,385 @@ func Test_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_Bulk(t *testing.T) {
+	endpoint := "/houses/bulk"
+	valid := entities.HouseRequest{Name: "house Patrick", Region: "sao paulo", FoundationYear: "2023"}
+	cases := map[string]struct {
+		inputPath    string
+		inputBody    func() io.Reader
+		expectedCode int
+		expectedData func() string
+		prepareMock  func(mock *houses.MockIService)
+	}{
+		"Should return success": {
+			inputBody: func() io.Reader {
+				bt, _ := json.Marshal(entities.HouseBulkRequest{Items: []entities.HouseBulkItem{
+					{HouseRequest: valid},
+					{ID: "id_1", HouseRequest: valid},
+				}})
+				return bytes.NewReader(bt)
+			},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"atomic":false,"succeeded":2,"failed":0,"results":[{"index":0,"status":201,"id":"id_2"},{"index":1,"status":200,"id":"id_1"}]}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				updated := valid
+				updated.ID = "id_1"
+				mock.EXPECT().
+					Bulk(gomock.Any(), []entities.HouseRequest{valid, updated}, false).
+					Times(1).
+					Return([]entities.BulkResult{{Index: 0, ID: "id_2"}, {Index: 1, ID: "id_1"}}, nil)
+			},
+		},
+		"Should return multi status with invalid and failed items": {
+			inputBody: func() io.Reader {
+				bt, _ := json.Marshal(entities.HouseBulkRequest{Items: []entities.HouseBulkItem{
+					{HouseRequest: entities.HouseRequest{Name: "Pa", Region: "sao paulo", FoundationYear: "2023"}},
+					{HouseRequest: valid},
+				}})
+				return bytes.NewReader(bt)
+			},
+			expectedCode: http.StatusMultiStatus,
+			expectedData: func() string {
+				return `{"atomic":false,"succeeded":0,"failed":2,"results":[{"index":0,"status":422,"error":"item is invalid","detail":[{"field":"name","error":"min","value":"Pa"}]},{"index":1,"status":409,"error":"name informed already used in another house"}]}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Bulk(gomock.Any(), []entities.HouseRequest{valid}, false).
+					Times(1).
+					Return([]entities.BulkResult{{Index: 0, Err: houses.ErrNameUsed}}, nil)
+			},
+		},
+		"Should reject the atomic batch without calling the service": {
+			inputPath: "?atomic=true",
+			inputBody: func() io.Reader {
+				bt, _ := json.Marshal(entities.HouseBulkRequest{Items: []entities.HouseBulkItem{
+					{HouseRequest: valid},
+					{HouseRequest: entities.HouseRequest{Name: "Pa", Region: "sao paulo", FoundationYear: "2023"}},
+				}})
+				return bytes.NewReader(bt)
+			},
+			expectedCode: http.StatusMultiStatus,
+			expectedData: func() string {
+				return `{"atomic":true,"succeeded":0,"failed":2,"results":[{"index":0,"status":424,"error":"item was not applied because the batch was rolled back"},{"index":1,"status":422,"error":"item is invalid","detail":[{"field":"name","error":"min","value":"Pa"}]}]}`
+			},
+			prepareMock: func(mock *houses.MockIService) {},
+		},
+		"Should return error validate": {
+			inputBody: func() io.Reader {
+				return bytes.NewReader([]byte(`{"items":[]}`))
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedData: func() string {
+				return `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"invalid_payload","instance":"/houses/bulk","violations":[{"field":"items","error":"min","value":[]}]}`
+			},
+			prepareMock: func(mock *houses.MockIService) {},
+		},
+		"Should return error service": {
+			inputPath: "?atomic=true",
+			inputBody: func() io.Reader {
+				bt, _ := json.Marshal(entities.HouseBulkRequest{Items: []entities.HouseBulkItem{{HouseRequest: valid}}})
+				return bytes.NewReader(bt)
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedData: func() string {
+				return `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"problem to begin transaction","instance":"/houses/bulk"}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Bulk(gomock.Any(), []entities.HouseRequest{valid}, true).
+					Times(1).
+					Return(nil, errors.New("problem to begin transaction"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := houses.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{House: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.Bulk)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodPost, endpoint+cs.inputPath, cs.inputBody()).WithContext(ctx)
+			writer := httptest.NewRecorder()
+			request.Header.Set("Content-Type", "application/json")
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData(), string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
+
+func Test_Restore(t *testing.T) {
+	endpoint := "/houses/"
+	cases := map[string]struct {
+		paramInput   string
+		queryInput   string
+		expectedCode int
+		expectedData func() string
+		prepareMock  func(mock *houses.MockIService)
+	}{
+		"Should return success": {
+			paramInput:   "33c55a43-f163-4a67-9f6c-75161410f376",
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				data, _ := json.Marshal(entities.House{ID: "33c55a43-f163-4a67-9f6c-75161410f376", Name: "house Patrick"})
+				return string(data)
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Restore(gomock.Any(), "33c55a43-f163-4a67-9f6c-75161410f376").
+					Times(1).
+					Return(entities.House{ID: "33c55a43-f163-4a67-9f6c-75161410f376", Name: "house Patrick"}, nil)
+			},
+		},
+		"Should return error not deleted": {
+			paramInput:   "33c55a43-f163-4a67-9f6c-75161410f376",
+			expectedCode: http.StatusConflict,
+			expectedData: func() string {
+				return `{"type":"/problems/conflict","title":"Conflict","status":409,"detail":"this house is not deleted","instance":"/houses/33c55a43-f163-4a67-9f6c-75161410f376/restore"}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Restore(gomock.Any(), "33c55a43-f163-4a67-9f6c-75161410f376").
+					Times(1).
+					Return(entities.House{}, houses.ErrHouseNotDeleted)
+			},
+		},
+		"Should return error service": {
+			paramInput:   "33c55a43-f163-4a67-9f6c-75161410f376",
+			expectedCode: http.StatusInternalServerError,
+			expectedData: func() string {
+				return `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"failed to restore house","instance":"/houses/33c55a43-f163-4a67-9f6c-75161410f376/restore"}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Restore(gomock.Any(), "33c55a43-f163-4a67-9f6c-75161410f376").
+					Times(1).
+					Return(entities.House{}, errors.New("failed to restore house"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := houses.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{House: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint+":id/restore", ctr.Restore)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodPost, endpoint+cs.paramInput+"/restore"+cs.queryInput, nil).WithContext(ctx)
+			writer := httptest.NewRecorder()
+			request.Header.Set("Content-Type", "application/json")
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData(), string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
+
+func Test_History(t *testing.T) {
+	endpoint := "/houses/"
+	entries := []entities.AuditEntry{{
+		ID:       "entry_1",
+		Entity:   entities.AuditHouse,
+		EntityID: "33c55a43-f163-4a67-9f6c-75161410f376",
+		Action:   entities.AuditUpdate,
+		Actor:    "patrick",
+		Changes:  entities.AuditChanges{"name": {Before: "old name", After: "new name"}},
+	}}
+
+	cases := map[string]struct {
+		paramInput   string
+		expectedCode int
+		expectedData func() string
+		prepareMock  func(mock *houses.MockIService)
+	}{
+		"Should return success": {
+			paramInput:   "33c55a43-f163-4a67-9f6c-75161410f376",
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				data, _ := json.Marshal(entries)
+				return string(data)
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					History(gomock.Any(), "33c55a43-f163-4a67-9f6c-75161410f376", nil).
+					Times(1).
+					Return(entries, nil)
+			},
+		},
+		"Should return error service": {
+			paramInput:   "33c55a43-f163-4a67-9f6c-75161410f376",
+			expectedCode: http.StatusInternalServerError,
+			expectedData: func() string {
+				return `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"failed to find audit entries","instance":"/houses/33c55a43-f163-4a67-9f6c-75161410f376/history"}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					History(gomock.Any(), "33c55a43-f163-4a67-9f6c-75161410f376", nil).
+					Times(1).
+					Return(nil, errors.New("failed to find audit entries"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := houses.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{House: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Get(endpoint+":id/history", ctr.History)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodGet, endpoint+cs.paramInput+"/history", nil).WithContext(ctx)
+			writer := httptest.NewRecorder()
+			request.Header.Set("Content-Type", "application/json")
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData(), string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
+
+func Test_BatchGet(t *testing.T) {
+	endpoint := "/houses/batch-get"
+
+	cases := map[string]struct {
+		inputBody    func() io.Reader
+		expectedCode int
+		expectedData func() string
+		prepareMock  func(mock *houses.MockIService)
+	}{
+		"Should return the houses of the ids in their order": {
+			inputBody: func() io.Reader {
+				return strings.NewReader(`{"ids":["id_2"," id_1"]}`)
+			},
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				return `{"houses":[{"id":"id_1","name":"House Algood","region":"","foundation_year":"","current_lord":"","created_at":"0001-01-01T00:00:00Z","updated_at":null}],"missing":["id_2"]}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					FindByIDs(gomock.Any(), []string{"id_2", "id_1"}, entities.Filter{}).
+					Times(1).
+					Return(entities.HouseBatch{Houses: []entities.House{{ID: "id_1", Name: "House Algood"}}, Missing: []string{"id_2"}}, nil)
+			},
+		},
+		"Should return error decode": {
+			inputBody: func() io.Reader {
+				return strings.NewReader(`{"ids":"id_1"}`)
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedData: func() string {
+				return `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"problem to decode your input","instance":"/houses/batch-get"}`
+			},
+			prepareMock: func(mock *houses.MockIService) {},
+		},
+		"Should return error service": {
+			inputBody: func() io.Reader {
+				return strings.NewReader(`{"ids":["id_1"]}`)
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			expectedData: func() string {
+				return `{"type":"/problems/unavailable","title":"Service Unavailable","status":503,"detail":"problem to find houses","instance":"/houses/batch-get"}`
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					FindByIDs(gomock.Any(), []string{"id_1"}, entities.Filter{}).
+					Times(1).
+					Return(entities.HouseBatch{}, entities.NewDomainErr(entities.ErrUnavailable, "problem to find houses", nil))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := houses.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{House: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.BatchGet)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodPost, endpoint, cs.inputBody()).WithContext(ctx)
+			writer := httptest.NewRecorder()
+			request.Header.Set("Content-Type", "application/json")
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData(), string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
@@ -2,22 +2,124 @@ package houses
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
-	"github.com/PatrickChagastavares/game-of-thrones/internal/services/houses"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
 )
 
-func responseErr(ctx context.Context, err error, f func(int, any)) {
-	_, span := tracer.Span(ctx, "controllers.houses.responseErr")
+// responseErr answers err as a problem, domain errors take the status of
+// their kind.
+func responseErr(ctx context.Context, c httpRouter.Context, err error) {
+	ctx, span := tracer.Span(ctx, "controllers.houses.responseErr")
 	defer span.End()
 
-	switch err {
-	case houses.ErrFind, houses.ErrNameUsed, houses.ErrHouseNotFound:
-		f(http.StatusBadRequest, entities.NewHttpErr(http.StatusBadRequest, err.Error(), nil))
+	problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+	c.Problem(problem.Status, problem)
+}
+
+// filter reads the query parameters shared by the find endpoints, fields
+// must name json fields of a house. Only the admins include the deleted ones.
+func filter(c httpRouter.Context) (entities.Filter, error) {
+	includeDeleted := c.GetQuery("include_deleted") == "true"
+	if includeDeleted {
+		principal, _ := httpRouter.PrincipalFromContext(c.Context())
+		if !principal.HasRole(httpRouter.RoleAdmin) {
+			return entities.Filter{}, entities.ErrIncludeDeleted
+		}
+	}
+
+	fields, err := entities.ParseFields(c.GetQuery("fields"), entities.House{})
+	return entities.Filter{
+		IncludeDeleted: includeDeleted,
+		Fields:         fields,
+	}, err
+}
+
+// pageFilter is the filter of the lists, paged by the limit and offset
+// query parameters.
+func pageFilter(c httpRouter.Context) (entities.Filter, error) {
+	f, err := filter(c)
+	if err != nil {
+		return f, err
+	}
+
+	for param, value := range map[string]*int{"limit": &f.Limit, "offset": &f.Offset} {
+		raw := c.GetQuery(param)
+		if len(raw) == 0 {
+			continue
+		}
+
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			return f, entities.ErrInvalidPage
+		}
+		*value = n
+	}
+
+	return f, nil
+}
+
+// responseHouse answers house in the format negotiated with the client,
+// which also takes the hypermedia representations. Only the fields asked for
+// are answered, none answers them all.
+func responseHouse(c httpRouter.Context, statusCode int, house entities.House, fields []string) {
+	hypermedia := entities.NewHypermedia(c.GetRequestReader().URL.Path, "houses")
+
+	c.Respond(statusCode, entities.Project(house, fields),
+		httpRouter.Representation{ContentType: entities.HALContentType, Data: func() any { return entities.Project(hypermedia.HALHouse(house), fields) }},
+		httpRouter.Representation{ContentType: entities.JSONLDContentType, Data: func() any { return entities.Project(hypermedia.LDHouse(house), fields) }},
+	)
+}
+
+// responseHouses answers a page of the list in the format negotiated with
+// the client, the hypermedia representations link the pages around it.
+func responseHouses(c httpRouter.Context, houses []entities.House, f entities.Filter) {
+	request := c.GetRequestReader()
+	hypermedia := entities.NewHypermedia(request.URL.Path, "houses")
+
+	c.Respond(http.StatusOK, entities.Project(houses, f.Fields),
+		httpRouter.Representation{ContentType: entities.HALContentType, Data: func() any { return entities.Project(hypermedia.HALHouses(houses, request.URL.Query(), f), f.Fields) }},
+		httpRouter.Representation{ContentType: entities.JSONLDContentType, Data: func() any { return entities.Project(hypermedia.LDHouses(houses, request.URL.Query()), f.Fields) }},
+	)
+}
+
+// responseHouseBatch answers the houses found by id, only the fields asked for
+// of each one. There is no hypermedia representation of a batch.
+func responseHouseBatch(c httpRouter.Context, batch entities.HouseBatch, fields []string) {
+	c.Respond(http.StatusOK, struct {
+		Houses  any      `json:"houses"`
+		Missing []string `json:"missing"`
+	}{entities.Project(batch.Houses, fields), batch.Missing})
+}
+
+func bulkResponse(ctx context.Context, c httpRouter.Context, atomic bool, results []entities.BulkResult) {
+	_, span := tracer.Span(ctx, "controllers.houses.bulkResponse")
+	defer span.End()
+
+	for i := range results {
+		if results[i].Err == nil {
+			continue
+		}
+		results[i].Status = bulkStatus(results[i].Err)
+		results[i].Error = results[i].Err.Error()
+	}
+
+	resp := entities.NewBulkResponse(atomic, results)
+	if resp.Failed > 0 {
+		c.Respond(http.StatusMultiStatus, resp)
 		return
-	default:
-		f(http.StatusInternalServerError, err.Error())
 	}
+
+	c.Respond(http.StatusOK, resp)
+}
+
+func bulkStatus(err error) int {
+	if errors.Is(err, entities.ErrBulkRolledBack) {
+		return http.StatusFailedDependency
+	}
+	return entities.StatusCode(err)
 }
@@ -0,0 +1,173 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderReplayed = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+
+	// DefaultMaxBodySize is used when no maximum size is configured.
+	DefaultMaxBodySize = 32 << 20
+)
+
+type (
+	IController interface {
+		// Handle must be registered before the handler of the route it protects.
+		Handle(c httpRouter.Context)
+	}
+
+	// Options holds the maximum size of the bodies hashed with their key, in
+	// bytes.
+	Options struct {
+		MaxBodySize int64
+	}
+
+	controllers struct {
+		srv  *services.Container
+		log  logger.Logger
+		opts Options
+	}
+
+	// detached keeps the values of a context, as its span, without its
+	// cancelation: the key is stored even when the client went away.
+	detached struct {
+		context.Context
+	}
+)
+
+func New(srv *services.Container, log logger.Logger, opts Options) IController {
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = DefaultMaxBodySize
+	}
+	return &controllers{srv: srv, log: log, opts: opts}
+}
+
+// Handle replays the stored response when the Idempotency-Key header was
+// already used, otherwise it runs the route and stores its response. The keys
+// are scoped to the principal, a client can't replay the response of another.
+func (ctrl *controllers) Handle(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.idempotency.handle")
+	defer span.End()
+
+	key := c.GetHeader(HeaderKey)
+	if len(key) == 0 {
+		return
+	}
+
+	if len(key) > maxKeyLength {
+		responseErr(ctx, c, ErrKeyTooLong)
+		c.Abort()
+		return
+	}
+
+	req := c.GetRequestReader()
+	body, err := io.ReadAll(http.MaxBytesReader(c.GetResponseWriter(), req.Body, ctrl.opts.MaxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			err = ErrBodyTooLarge
+		} else {
+			err = entities.ErrDecode
+		}
+		responseErr(ctx, c, err)
+		c.Abort()
+		return
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	hash := sha256.New()
+	hash.Write([]byte(req.URL.RawQuery))
+	hash.Write(body)
+
+	stored, err := ctrl.srv.Idempotency.Begin(ctx, entities.IdempotencyKey{
+		Key:         key,
+		Scope:       scope(c),
+		RequestHash: hex.EncodeToString(hash.Sum(nil)),
+	})
+	if err != nil {
+		ctrl.log.Error("Ctrl.Handle: ", "Error on begin idempotency key: ", key, err)
+		responseErr(ctx, c, err)
+		c.Abort()
+		return
+	}
+
+	if stored.Completed() {
+		c.SetHeader(HeaderReplayed, "true")
+		// the body is replayed in the format negotiated by the first request
+		c.Data(stored.StatusCode, stored.ContentType, stored.Response)
+		c.Abort()
+		return
+	}
+
+	ctx = detached{ctx}
+	defer func() {
+		// a panic of the route is a server error, the retry runs it again
+		if r := recover(); r != nil {
+			ctrl.release(ctx, stored)
+			panic(r)
+		}
+	}()
+
+	var response bytes.Buffer
+	c.TeeResponse(&response)
+	c.Next()
+
+	stored.StatusCode = c.Status()
+	if stored.StatusCode >= http.StatusInternalServerError {
+		ctrl.release(ctx, stored)
+		return
+	}
+
+	stored.Response = response.Bytes()
+	stored.ContentType = c.GetResponseWriter().Header().Get("Content-Type")
+	if err := ctrl.srv.Idempotency.Complete(ctx, stored); err != nil {
+		ctrl.log.Error("Ctrl.Handle: ", "Error on complete idempotency key: ", key, err)
+	}
+}
+
+func (ctrl *controllers) release(ctx context.Context, stored entities.IdempotencyKey) {
+	if err := ctrl.srv.Idempotency.Release(ctx, stored); err != nil {
+		ctrl.log.Error("Ctrl.Handle: ", "Error on release idempotency key: ", stored.Key, err)
+	}
+}
+
+// scope is the route of the request, prefixed by the id of its principal.
+func scope(c httpRouter.Context) string {
+	req := c.GetRequestReader()
+	route := req.Method + " " + req.URL.Path
+
+	principal, ok := httpRouter.PrincipalFromContext(c.Context())
+	if !ok {
+		return route
+	}
+	return principal.ID + " " + route
+}
+
+func (detached) Deadline() (deadline time.Time, ok bool) {
+	return deadline, false
+}
+
+func (detached) Done() <-chan struct{} {
+	return nil
+}
+
+func (detached) Err() error {
+	return nil
+}
@@ -0,0 +1,238 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/idempotency"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Handle(t *testing.T) {
+	endpoint := "/houses"
+	body := `{"name":"house Patrick","region":"sao paulo","foundation_year":"2023"}`
+	hash := "67db3fee36dc737ca63d4f288be1191b54c9ad54b1669ba657b5be47379c1b6a"
+	claimed := entities.IdempotencyKey{Key: "key_1", Scope: "POST /houses", RequestHash: hash}
+
+	cases := map[string]struct {
+		inputKey       string
+		inputPrincipal string
+		maxBodySize    int64
+		handlerCode    int
+		handlerPanic   bool
+		expectedCode   int
+		expectedData   string
+		expectedReplay string
+		prepareMock    func(mock *idempotency.MockIService)
+	}{
+		"Should run the route without key": {
+			handlerCode:  http.StatusCreated,
+			expectedCode: http.StatusCreated,
+			expectedData: `{"id":"id_1"}`,
+			prepareMock:  func(mock *idempotency.MockIService) {},
+		},
+		"Should scope the key to the principal": {
+			inputKey:       "key_1",
+			inputPrincipal: "apikey_1",
+			handlerCode:    http.StatusCreated,
+			expectedCode:   http.StatusCreated,
+			expectedData:   `{"id":"id_1"}`,
+			prepareMock: func(mock *idempotency.MockIService) {
+				scoped := entities.IdempotencyKey{Key: "key_1", Scope: "apikey_1 POST /houses", RequestHash: hash}
+				mock.EXPECT().
+					Begin(gomock.Any(), scoped).
+					Times(1).
+					Return(scoped, nil)
+				mock.EXPECT().
+					Complete(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+		},
+		"Should run the route and store the response": {
+			inputKey:     "key_1",
+			handlerCode:  http.StatusCreated,
+			expectedCode: http.StatusCreated,
+			expectedData: `{"id":"id_1"}`,
+			prepareMock: func(mock *idempotency.MockIService) {
+				mock.EXPECT().
+					Begin(gomock.Any(), entities.IdempotencyKey{Key: "key_1", Scope: "POST /houses", RequestHash: hash}).
+					Times(1).
+					Return(claimed, nil)
+
+				completed := claimed
+				completed.StatusCode = http.StatusCreated
+				completed.Response = []byte(`{"id":"id_1"}`)
+				completed.ContentType = "application/json"
+				mock.EXPECT().
+					Complete(gomock.Any(), completed).
+					Times(1).
+					Return(nil)
+			},
+		},
+		"Should replay the stored response": {
+			inputKey:       "key_1",
+			expectedCode:   http.StatusCreated,
+			expectedData:   `{"id":"id_0"}`,
+			expectedReplay: "true",
+			prepareMock: func(mock *idempotency.MockIService) {
+				completed := claimed
+				completed.StatusCode = http.StatusCreated
+				completed.Response = []byte(`{"id":"id_0"}`)
+				completed.ContentType = "application/json"
+				mock.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(completed, nil)
+			},
+		},
+		"Should replay the stored response in its format": {
+			inputKey:       "key_1",
+			expectedCode:   http.StatusCreated,
+			expectedData:   "id\nid_0\n",
+			expectedReplay: "true",
+			prepareMock: func(mock *idempotency.MockIService) {
+				completed := claimed
+				completed.StatusCode = http.StatusCreated
+				completed.Response = []byte("id\nid_0\n")
+				completed.ContentType = "text/csv; charset=utf-8"
+				mock.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(completed, nil)
+			},
+		},
+		"Should release the key when the route fails": {
+			inputKey:     "key_1",
+			handlerCode:  http.StatusInternalServerError,
+			expectedCode: http.StatusInternalServerError,
+			expectedData: `{"id":"id_1"}`,
+			prepareMock: func(mock *idempotency.MockIService) {
+				mock.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(claimed, nil)
+
+				released := claimed
+				released.StatusCode = http.StatusInternalServerError
+				mock.EXPECT().
+					Release(gomock.Any(), released).
+					Times(1).
+					Return(nil)
+			},
+		},
+		"Should release the key when the route panics": {
+			inputKey:     "key_1",
+			handlerPanic: true,
+			expectedCode: http.StatusInternalServerError,
+			expectedData: "",
+			prepareMock: func(mock *idempotency.MockIService) {
+				mock.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(claimed, nil)
+				mock.EXPECT().
+					Release(gomock.Any(), claimed).
+					Times(1).
+					Return(nil)
+			},
+		},
+		"Should return error body too large": {
+			inputKey:     "key_1",
+			maxBodySize:  10,
+			expectedCode: http.StatusRequestEntityTooLarge,
+			expectedData: `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"the request body is too large","instance":"/houses"}`,
+			prepareMock:  func(mock *idempotency.MockIService) {},
+		},
+		"Should return error key reused": {
+			inputKey:     "key_1",
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedData: `{"type":"/problems/validation","title":"Unprocessable Entity","status":422,"detail":"idempotency key already used with a different payload","instance":"/houses"}`,
+			prepareMock: func(mock *idempotency.MockIService) {
+				mock.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(entities.IdempotencyKey{}, idempotency.ErrKeyReused)
+			},
+		},
+		"Should return error key in progress": {
+			inputKey:     "key_1",
+			expectedCode: http.StatusConflict,
+			expectedData: `{"type":"/problems/conflict","title":"Conflict","status":409,"detail":"a request with this idempotency key is still in progress","instance":"/houses"}`,
+			prepareMock: func(mock *idempotency.MockIService) {
+				mock.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(entities.IdempotencyKey{}, idempotency.ErrKeyInProgress)
+			},
+		},
+		"Should return error service": {
+			inputKey:     "key_1",
+			expectedCode: http.StatusInternalServerError,
+			expectedData: `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"problem to create idempotency key","instance":"/houses"}`,
+			prepareMock: func(mock *idempotency.MockIService) {
+				mock.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(entities.IdempotencyKey{}, errors.New("problem to create idempotency key"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := idempotency.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Idempotency: mock},
+				logger.NewLogrusLogger(),
+				Options{MaxBodySize: cs.maxBodySize},
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.Handle, func(c httpRouter.Context) {
+				if cs.handlerPanic {
+					panic("problem to create house")
+				}
+				c.Respond(cs.handlerCode, map[string]any{"id": "id_1"})
+			})
+
+			// ============ START MOCK REQUEST ============
+			if len(cs.inputPrincipal) > 0 {
+				ctx = httpRouter.ContextWithPrincipal(ctx, httpRouter.Principal{ID: cs.inputPrincipal})
+			}
+			request := httptest.NewRequest(http.MethodPost, endpoint, bytes.NewReader([]byte(body))).WithContext(ctx)
+			writer := httptest.NewRecorder()
+			request.Header.Set("Content-Type", "application/json")
+			if cs.inputKey != "" {
+				request.Header.Set(HeaderKey, cs.inputKey)
+			}
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData, string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+			assert.Equal(t, cs.expectedReplay, writer.Header().Get(HeaderReplayed))
+		})
+	}
+}
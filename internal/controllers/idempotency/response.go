@@ -0,0 +1,25 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+var (
+	ErrKeyTooLong   = entities.NewHttpErr(http.StatusBadRequest, "idempotency key must have at most 255 characters", nil)
+	ErrBodyTooLarge = entities.NewHttpErr(http.StatusRequestEntityTooLarge, "the request body is too large", nil)
+)
+
+// responseErr answers err as a problem, domain errors take the status of
+// their kind.
+func responseErr(ctx context.Context, c httpRouter.Context, err error) {
+	ctx, span := tracer.Span(ctx, "controllers.idempotency.responseErr")
+	defer span.End()
+
+	problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+	c.Problem(problem.Status, problem)
+}
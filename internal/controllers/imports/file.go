@@ -0,0 +1,234 @@
+package imports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+)
+
+const (
+	formatCSV  = "csv"
+	formatJSON = "json"
+
+	// listSeparator splits a CSV cell of a list field, e.g. tv_series, as the
+	// CSV responses join them
+	listSeparator = ";"
+
+	// maxMemory is kept in memory when a multipart form is parsed, the rest of
+	// the file goes to disk
+	maxMemory = 8 << 20
+)
+
+// row is a line of the file keyed by the json names of the fields.
+type row map[string]any
+
+// file opens the file of the request, the file field of a multipart form or
+// the body itself, and tells its format. No more than MaxImportSize bytes are
+// read from the body.
+func file(w http.ResponseWriter, req *http.Request) (io.ReadCloser, string, error) {
+	req.Body = http.MaxBytesReader(w, req.Body, entities.MaxImportSize)
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		format, err := formatOf(mediaType, "")
+		return req.Body, format, err
+	}
+
+	if err := req.ParseMultipartForm(maxMemory); err != nil {
+		return nil, "", readErr(err)
+	}
+	f, header, err := req.FormFile("file")
+	if err != nil {
+		return nil, "", entities.ErrDecode
+	}
+
+	mediaType, _, _ = mime.ParseMediaType(header.Header.Get("Content-Type"))
+	format, err := formatOf(mediaType, header.Filename)
+	if err != nil {
+		f.Close()
+		return nil, "", err
+	}
+
+	return f, format, nil
+}
+
+// formatOf is the format of a file, by its media type or, for the generic ones
+// browsers send, by the extension of its name.
+func formatOf(mediaType, filename string) (string, error) {
+	switch mediaType {
+	case "text/csv", "application/csv":
+		return formatCSV, nil
+	case "application/json":
+		return formatJSON, nil
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return formatCSV, nil
+	case ".json":
+		return formatJSON, nil
+	}
+
+	return "", entities.ErrImportFormat
+}
+
+// parseMapping reads the source:field pairs of the mapping query parameter,
+// the columns not listed keep their names. Every field must be a field of the
+// rows.
+func parseMapping(raw string, fields map[string]reflect.Kind) (map[string]string, error) {
+	mapping := map[string]string{}
+	if len(strings.TrimSpace(raw)) == 0 {
+		return mapping, nil
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		source, field, ok := strings.Cut(pair, ":")
+		source, field = strings.TrimSpace(source), strings.TrimSpace(field)
+		if _, known := fields[field]; !ok || !known || source == "" {
+			return nil, entities.ErrImportMapping
+		}
+		mapping[source] = field
+	}
+
+	return mapping, nil
+}
+
+// fieldsOf returns the kind of each json field of v.
+func fieldsOf(v any) map[string]reflect.Kind {
+	t := reflect.TypeOf(v)
+	kinds := make(map[string]reflect.Kind, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		kinds[name] = t.Field(i).Type.Kind()
+	}
+	return kinds
+}
+
+// readRows reads every row of the file, renaming its columns by the mapping.
+func readRows(r io.Reader, format string, mapping map[string]string, fields map[string]reflect.Kind) ([]row, error) {
+	var (
+		rows []row
+		err  error
+	)
+	if format == formatCSV {
+		rows, err = readCSV(r, mapping, fields)
+	} else {
+		rows, err = readJSON(r, mapping)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, entities.ErrImportEmpty
+	}
+	return rows, nil
+}
+
+func readCSV(r io.Reader, mapping map[string]string, fields map[string]reflect.Kind) ([]row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, entities.ErrImportEmpty
+	}
+	if err != nil {
+		return nil, readErr(err)
+	}
+
+	columns := make([]string, len(header))
+	for i, name := range header {
+		// spreadsheets save the CSV with a byte order mark
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		columns[i] = column(name, mapping)
+	}
+
+	var rows []row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, readErr(err)
+		}
+		if len(rows) == entities.MaxImportRows {
+			return nil, entities.ErrImportTooLarge
+		}
+
+		line := row{}
+		for i, value := range record {
+			// empty cells are left out, so the required fields are reported
+			if len(value) == 0 {
+				continue
+			}
+			if fields[columns[i]] == reflect.Slice {
+				line[columns[i]] = strings.Split(value, listSeparator)
+				continue
+			}
+			line[columns[i]] = value
+		}
+		rows = append(rows, line)
+	}
+}
+
+// readJSON decodes the objects of the array one at a time, a file with too
+// many rows fails before the rest of it is read.
+func readJSON(r io.Reader, mapping map[string]string) ([]row, error) {
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, readErr(err)
+	}
+
+	var rows []row
+	for decoder.More() {
+		if len(rows) == entities.MaxImportRows {
+			return nil, entities.ErrImportTooLarge
+		}
+
+		var object map[string]any
+		if err := decoder.Decode(&object); err != nil {
+			return nil, readErr(err)
+		}
+
+		line := row{}
+		for name, value := range object {
+			line[column(name, mapping)] = value
+		}
+		rows = append(rows, line)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, readErr(err)
+	}
+	return rows, nil
+}
+
+func column(name string, mapping map[string]string) string {
+	if field, ok := mapping[name]; ok {
+		return field
+	}
+	return name
+}
+
+// readErr is the error of a file that can't be read, a body over
+// MaxImportSize or one that doesn't decode.
+func readErr(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return entities.ErrImportSize
+	}
+	return entities.ErrDecode
+}
@@ -0,0 +1,199 @@
+package imports
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/validator"
+)
+
+type (
+	IController interface {
+		Houses(c httpRouter.Context)
+		Characters(c httpRouter.Context)
+	}
+	controllers struct {
+		srv       *services.Container
+		log       logger.Logger
+		validator validator.Validator
+	}
+)
+
+func New(srv *services.Container, log logger.Logger) IController {
+	return &controllers{srv: srv, log: log, validator: validator.New()}
+}
+
+// import swagger document
+// @Description Import houses from a CSV or JSON file, the body itself or the file field of a form
+// @Tags import
+// @Accept text/csv,json,mpfd
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	mode	query	string	false	"insert, the default, or upsert-by-name to update the houses with the name of a row"	Enums(insert, upsert-by-name)
+// @Param	dry_run	query	bool	false	"only report what would be imported"
+// @Param	mapping	query	string	false	"source:field pairs renaming the columns, e.g. Nome:name,Regiao:region"
+// @Param	file	formData	file	false	"CSV or JSON file"
+// @Param Content-Encoding header string false "gzip to send the body compressed"
+// @Param X-Actor header string false "who makes the change, recorded in the history"
+// @Success 200 {object} entities.ImportReport
+// @Success 207 {object} entities.ImportReport
+// @Failure 400 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 413 {object} entities.HttpErr
+// @Failure 415 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /import/houses [post]
+func (ctrl *controllers) Houses(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.imports.houses")
+	defer span.End()
+
+	opts, rows, err := ctrl.read(c, entities.HouseRequest{})
+	if err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	items, positions, results := decodeRows[entities.HouseRequest](ctrl.validator, rows)
+	if len(items) > 0 {
+		processed, err := ctrl.srv.House.Import(ctx, items, opts)
+		if err != nil {
+			ctrl.log.Error("Ctrl.Houses: ", "Error on import houses: ", err)
+			responseErr(ctx, c, err)
+			return
+		}
+		merge(results, processed, positions)
+	}
+
+	responseReport(ctx, c, opts, results)
+}
+
+// import swagger document
+// @Description Import characters from a CSV or JSON file, the body itself or the file field of a form. The tv_series of a CSV are separated by ;
+// @Tags import
+// @Accept text/csv,json,mpfd
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	mode	query	string	false	"only insert, characters have no unique name"	Enums(insert)
+// @Param	dry_run	query	bool	false	"only report what would be imported"
+// @Param	mapping	query	string	false	"source:field pairs renaming the columns, e.g. Nome:name,Temporadas:tv_series"
+// @Param	file	formData	file	false	"CSV or JSON file"
+// @Param Content-Encoding header string false "gzip to send the body compressed"
+// @Param X-Actor header string false "who makes the change, recorded in the history"
+// @Success 200 {object} entities.ImportReport
+// @Success 207 {object} entities.ImportReport
+// @Failure 400 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 413 {object} entities.HttpErr
+// @Failure 415 {object} entities.HttpErr
+// @Failure 422 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /import/characters [post]
+func (ctrl *controllers) Characters(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.imports.characters")
+	defer span.End()
+
+	opts, rows, err := ctrl.read(c, entities.CharacterRequest{})
+	if err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	items, positions, results := decodeRows[entities.CharacterRequest](ctrl.validator, rows)
+	if len(items) > 0 {
+		processed, err := ctrl.srv.Character.Import(ctx, items, opts)
+		if err != nil {
+			ctrl.log.Error("Ctrl.Characters: ", "Error on import characters: ", err)
+			responseErr(ctx, c, err)
+			return
+		}
+		merge(results, processed, positions)
+	}
+
+	responseReport(ctx, c, opts, results)
+}
+
+// read parses the options of the import and the rows of its file, named by
+// the fields of request.
+func (ctrl *controllers) read(c httpRouter.Context, request any) (opts entities.ImportOptions, rows []row, err error) {
+	opts = entities.ImportOptions{
+		Mode:   c.GetQuery("mode"),
+		DryRun: c.GetQuery("dry_run") == "true",
+	}
+	switch opts.Mode {
+	case "":
+		opts.Mode = entities.ImportInsert
+	case entities.ImportInsert, entities.ImportUpsertByName:
+	default:
+		return opts, nil, entities.ErrImportMode
+	}
+
+	fields := fieldsOf(request)
+	mapping, err := parseMapping(c.GetQuery("mapping"), fields)
+	if err != nil {
+		return opts, nil, err
+	}
+
+	f, format, err := file(c.GetResponseWriter(), c.GetRequestReader())
+	if err != nil {
+		return opts, nil, err
+	}
+	defer f.Close()
+
+	rows, err = readRows(f, format, mapping, fields)
+	return opts, rows, err
+}
+
+// decodeRows turns the rows into items, the rows that don't decode or don't
+// pass the validation are reported in results and left out of items.
+// positions holds the row of each item.
+func decodeRows[T any](v validator.Validator, rows []row) (items []T, positions []int, results []entities.BulkResult) {
+	results = make([]entities.BulkResult, len(rows))
+
+	for i, r := range rows {
+		results[i].Index = i
+
+		var item T
+		b, _ := json.Marshal(r)
+		if err := json.Unmarshal(b, &item); err != nil {
+			results[i].Err = entities.ErrBulkInvalidItem
+			results[i].Detail = typeViolations(err)
+			continue
+		}
+
+		if err := v.Validate(item); err != nil {
+			results[i].Err = entities.ErrBulkInvalidItem
+			results[i].Detail = err.Violations
+			continue
+		}
+
+		items = append(items, item)
+		positions = append(positions, i)
+	}
+
+	return items, positions, results
+}
+
+// typeViolations reports a value of the wrong type, e.g. a number where the
+// field takes a string, as the validator reports its rules.
+func typeViolations(err error) []validator.Violation {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return []validator.Violation{{FieldJSON: typeErr.Field, Tag: typeErr.Type.String(), Value: typeErr.Value}}
+	}
+	return []validator.Violation{{Tag: err.Error()}}
+}
+
+// merge places the results of the items imported in the rows they came from.
+func merge(results, processed []entities.BulkResult, positions []int) {
+	for i, result := range processed {
+		result.Index = positions[i]
+		results[positions[i]] = result
+	}
+}
@@ -0,0 +1,274 @@
+package imports
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/characters"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/houses"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+// form returns a multipart form with content in its file field.
+func form(filename, content string) (io.Reader, string) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", filename)
+	part.Write([]byte(content))
+	writer.Close()
+	return &body, writer.FormDataContentType()
+}
+
+func Test_Houses(t *testing.T) {
+	endpoint := "/import/houses"
+	stark := entities.HouseRequest{Name: "house Stark", Region: "winterfell", FoundationYear: "1"}
+	cases := map[string]struct {
+		inputPath    string
+		inputBody    func() (io.Reader, string)
+		expectedCode int
+		expectedData string
+		prepareMock  func(mock *houses.MockIService)
+	}{
+		"Should import a CSV renaming its columns": {
+			inputPath: "?mapping=Nome:name,Regiao:region",
+			inputBody: func() (io.Reader, string) {
+				return bytes.NewReader([]byte("\ufeffNome,Regiao,foundation_year\nhouse Stark,winterfell,1\n")), "text/csv"
+			},
+			expectedCode: http.StatusOK,
+			expectedData: `{"dry_run":false,"mode":"insert","succeeded":1,"failed":0,"rows":[{"index":0,"status":201,"id":"id_1","action":"created"}]}`,
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Import(gomock.Any(), []entities.HouseRequest{stark}, entities.ImportOptions{Mode: entities.ImportInsert}).
+					Times(1).
+					Return([]entities.BulkResult{{Index: 0, ID: "id_1", Action: entities.BulkCreated}}, nil)
+			},
+		},
+		"Should report the invalid rows and import the others": {
+			inputBody: func() (io.Reader, string) {
+				return bytes.NewReader([]byte("name,region,foundation_year\nhouse Patrick,,2023\nhouse Stark,winterfell,1\n")), "text/csv; charset=utf-8"
+			},
+			expectedCode: http.StatusMultiStatus,
+			expectedData: `{"dry_run":false,"mode":"insert","succeeded":1,"failed":1,"rows":[{"index":0,"status":422,"error":"item is invalid","detail":[{"field":"region","error":"required","value":""}]},{"index":1,"status":201,"id":"id_1","action":"created"}]}`,
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Import(gomock.Any(), []entities.HouseRequest{stark}, entities.ImportOptions{Mode: entities.ImportInsert}).
+					Times(1).
+					Return([]entities.BulkResult{{Index: 0, ID: "id_1", Action: entities.BulkCreated}}, nil)
+			},
+		},
+		"Should dry run the upsert of the JSON file of a form": {
+			inputPath: "?mode=upsert-by-name&dry_run=true",
+			inputBody: func() (io.Reader, string) {
+				return form("houses.json", `[{"name":"house Stark","region":"winterfell","foundation_year":"1"}]`)
+			},
+			expectedCode: http.StatusOK,
+			expectedData: `{"dry_run":true,"mode":"upsert-by-name","succeeded":1,"failed":0,"rows":[{"index":0,"status":200,"id":"id_1","action":"updated"}]}`,
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Import(gomock.Any(), []entities.HouseRequest{stark}, entities.ImportOptions{Mode: entities.ImportUpsertByName, DryRun: true}).
+					Times(1).
+					Return([]entities.BulkResult{{Index: 0, ID: "id_1", Action: entities.BulkUpdated}}, nil)
+			},
+		},
+		"Should report the values of a wrong type": {
+			inputBody: func() (io.Reader, string) {
+				return bytes.NewReader([]byte(`[{"name":"house Stark","region":"winterfell","foundation_year":1}]`)), "application/json"
+			},
+			expectedCode: http.StatusMultiStatus,
+			expectedData: `{"dry_run":false,"mode":"insert","succeeded":0,"failed":1,"rows":[{"index":0,"status":422,"error":"item is invalid","detail":[{"field":"foundation_year","error":"string","value":"number"}]}]}`,
+			prepareMock:  func(mock *houses.MockIService) {},
+		},
+		"Should return error format": {
+			inputBody: func() (io.Reader, string) {
+				return bytes.NewReader([]byte(`<houses/>`)), "application/xml"
+			},
+			expectedCode: http.StatusUnsupportedMediaType,
+			expectedData: `{"type":"about:blank","title":"Unsupported Media Type","status":415,"detail":"the file must be CSV or JSON","instance":"/import/houses"}`,
+			prepareMock:  func(mock *houses.MockIService) {},
+		},
+		"Should return error mode": {
+			inputPath: "?mode=replace",
+			inputBody: func() (io.Reader, string) {
+				return bytes.NewReader([]byte("name\n")), "text/csv"
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedData: `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"mode must be insert or upsert-by-name","instance":"/import/houses"}`,
+			prepareMock:  func(mock *houses.MockIService) {},
+		},
+		"Should return error mapping": {
+			inputPath: "?mapping=Nome:nome",
+			inputBody: func() (io.Reader, string) {
+				return bytes.NewReader([]byte("Nome\n")), "text/csv"
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedData: `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"mapping must list source:field pairs of known fields","instance":"/import/houses"}`,
+			prepareMock:  func(mock *houses.MockIService) {},
+		},
+		"Should return error empty": {
+			inputBody: func() (io.Reader, string) {
+				return bytes.NewReader([]byte("name,region,foundation_year\n")), "text/csv"
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedData: `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"the file has no rows","instance":"/import/houses"}`,
+			prepareMock:  func(mock *houses.MockIService) {},
+		},
+		"Should return error too many rows": {
+			inputBody: func() (io.Reader, string) {
+				rows := strings.Repeat(`{"name":"house Stark"},`, entities.MaxImportRows)
+				return bytes.NewReader([]byte("[" + rows + `{"name":"house Stark"}]`)), "application/json"
+			},
+			expectedCode: http.StatusRequestEntityTooLarge,
+			expectedData: `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"the file has more than 1000 rows","instance":"/import/houses"}`,
+			prepareMock:  func(mock *houses.MockIService) {},
+		},
+		"Should return error body too large": {
+			inputBody: func() (io.Reader, string) {
+				return bytes.NewReader([]byte(`[{"name":"` + strings.Repeat("a", entities.MaxImportSize) + `"}]`)), "application/json"
+			},
+			expectedCode: http.StatusRequestEntityTooLarge,
+			expectedData: `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"the request body is larger than 16MB","instance":"/import/houses"}`,
+			prepareMock:  func(mock *houses.MockIService) {},
+		},
+		"Should return error form too large": {
+			inputBody: func() (io.Reader, string) {
+				return form("houses.csv", "name\n"+strings.Repeat("a", entities.MaxImportSize))
+			},
+			expectedCode: http.StatusRequestEntityTooLarge,
+			expectedData: `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"the request body is larger than 16MB","instance":"/import/houses"}`,
+			prepareMock:  func(mock *houses.MockIService) {},
+		},
+		"Should return error service": {
+			inputBody: func() (io.Reader, string) {
+				return bytes.NewReader([]byte("name,region,foundation_year\nhouse Stark,winterfell,1\n")), "text/csv"
+			},
+			expectedCode: http.StatusInternalServerError,
+			expectedData: `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"problem to begin transaction","instance":"/import/houses"}`,
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().
+					Import(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, errors.New("problem to begin transaction"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := houses.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{House: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.Houses)
+
+			// ============ START MOCK REQUEST ============
+			body, contentType := cs.inputBody()
+			request := httptest.NewRequest(http.MethodPost, endpoint+cs.inputPath, body).WithContext(ctx)
+			writer := httptest.NewRecorder()
+			request.Header.Set("Content-Type", contentType)
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData, string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
+
+func Test_Characters(t *testing.T) {
+	endpoint := "/import/characters"
+	jon := entities.CharacterRequest{Name: "Jon Snow", TVSeries: pq.StringArray{"Season 1", "Season 2"}}
+	cases := map[string]struct {
+		inputPath    string
+		inputBody    string
+		expectedCode int
+		expectedData string
+		prepareMock  func(mock *characters.MockIService)
+	}{
+		"Should import a CSV splitting the tv series": {
+			inputPath:    "?mapping=Nome:name,Temporadas:tv_series",
+			inputBody:    "Nome,Temporadas\nJon Snow,Season 1;Season 2\n",
+			expectedCode: http.StatusOK,
+			expectedData: `{"dry_run":false,"mode":"insert","succeeded":1,"failed":0,"rows":[{"index":0,"status":201,"id":"id_1","action":"created"}]}`,
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					Import(gomock.Any(), []entities.CharacterRequest{jon}, entities.ImportOptions{Mode: entities.ImportInsert}).
+					Times(1).
+					Return([]entities.BulkResult{{Index: 0, ID: "id_1", Action: entities.BulkCreated}}, nil)
+			},
+		},
+		"Should return error mode": {
+			inputPath:    "?mode=upsert-by-name",
+			inputBody:    "name,tv_series\nJon Snow,Season 1;Season 2\n",
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedData: `{"type":"/problems/validation","title":"Unprocessable Entity","status":422,"detail":"characters have no unique name, they are only imported with the insert mode","instance":"/import/characters"}`,
+			prepareMock: func(mock *characters.MockIService) {
+				mock.EXPECT().
+					Import(gomock.Any(), []entities.CharacterRequest{jon}, entities.ImportOptions{Mode: entities.ImportUpsertByName}).
+					Times(1).
+					Return(nil, characters.ErrImportMode)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := characters.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Character: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.Characters)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodPost, endpoint+cs.inputPath, bytes.NewReader([]byte(cs.inputBody))).WithContext(ctx)
+			writer := httptest.NewRecorder()
+			request.Header.Set("Content-Type", "text/csv")
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData, string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package imports
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+// responseErr answers err as a problem, domain errors take the status of
+// their kind.
+func responseErr(ctx context.Context, c httpRouter.Context, err error) {
+	ctx, span := tracer.Span(ctx, "controllers.imports.responseErr")
+	defer span.End()
+
+	problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+	c.Problem(problem.Status, problem)
+}
+
+// responseReport answers the report of the rows, a multi-status when one of
+// them failed.
+func responseReport(ctx context.Context, c httpRouter.Context, opts entities.ImportOptions, results []entities.BulkResult) {
+	_, span := tracer.Span(ctx, "controllers.imports.responseReport")
+	defer span.End()
+
+	report := entities.NewImportReport(opts, results)
+	if report.Failed > 0 {
+		c.Respond(http.StatusMultiStatus, report)
+		return
+	}
+
+	c.Respond(http.StatusOK, report)
+}
@@ -0,0 +1,43 @@
+package inflate
+
+import (
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+// DefaultMaxSize is used when no maximum size is configured.
+const DefaultMaxSize = 32 << 20
+
+type (
+	IController interface {
+		// Handle is the middleware of the bulk and import routes, it inflates
+		// their gzip bodies.
+		Handle(c httpRouter.Context)
+	}
+
+	// Options holds the maximum size of a body once inflated, in bytes.
+	Options struct {
+		MaxSize int64
+	}
+
+	controllers struct {
+		inflater httpRouter.Inflater
+	}
+)
+
+// New returns the controller of the compressed bodies, deny answers the ones
+// that don't inflate.
+func New(opts Options, deny func(c httpRouter.Context, err error)) IController {
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = DefaultMaxSize
+	}
+	return &controllers{inflater: httpRouter.Inflater{MaxSize: opts.MaxSize, Deny: deny}}
+}
+
+func (ctrl *controllers) Handle(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.inflate.handle")
+	defer span.End()
+
+	c.SetContext(ctx)
+	ctrl.inflater.Handle(c)
+}
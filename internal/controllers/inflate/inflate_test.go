@@ -0,0 +1,88 @@
+package inflate
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/problem"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func gzipped(s string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte(s))
+	w.Close()
+	return buf.Bytes()
+}
+
+func Test_Handle(t *testing.T) {
+	endpoint := "/houses/bulk"
+
+	cases := map[string]struct {
+		input        []byte
+		encoding     string
+		expectedCode int
+		expectedData string
+	}{
+		"Should return the inflated body": {
+			input:        gzipped(`{"houses":[{"name":"House Stark"}]}`),
+			encoding:     "gzip",
+			expectedCode: http.StatusOK,
+			expectedData: `{"houses":[{"name":"House Stark"}]}`,
+		},
+		"Should return payload too large": {
+			input:        gzipped(strings.Repeat(" ", 2048)),
+			encoding:     "gzip",
+			expectedCode: http.StatusRequestEntityTooLarge,
+			expectedData: `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"the request body is too large once inflated","instance":"/houses/bulk"}`,
+		},
+		"Should return error of a corrupt body": {
+			input:        []byte(`{"houses":[]}`),
+			encoding:     "gzip",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Should return unsupported media type": {
+			input:        []byte(`{"houses":[]}`),
+			encoding:     "zstd",
+			expectedCode: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ START CONTROLLER ============
+			ctr := New(Options{MaxSize: 1024}, problem.New().Denied)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.Handle, func(c httpRouter.Context) {
+				body, _ := io.ReadAll(c.GetRequestReader().Body)
+				c.Data(http.StatusOK, httpRouter.JSONContentType, body)
+			})
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodPost, endpoint, bytes.NewReader(cs.input))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Content-Encoding", cs.encoding)
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			if cs.expectedData != "" {
+				assert.Equal(t, cs.expectedData, string(responseData))
+			}
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
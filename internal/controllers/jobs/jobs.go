@@ -0,0 +1,219 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/jobs"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+type (
+	IController interface {
+		Submit(c httpRouter.Context)
+		FindByID(c httpRouter.Context)
+		Cancel(c httpRouter.Context)
+		Export(c httpRouter.Context)
+	}
+	controllers struct {
+		srv *services.Container
+		log logger.Logger
+	}
+)
+
+// kindRoles is the role each kind of job needs, the purge and the export
+// need the same role as their routes on /admin.
+var kindRoles = map[string]string{
+	entities.JobPurge:            httpRouter.RoleAdmin,
+	entities.JobExport:           httpRouter.RoleAdmin,
+	entities.JobImportHouses:     httpRouter.RoleEditor,
+	entities.JobImportCharacters: httpRouter.RoleEditor,
+}
+
+func New(srv *services.Container, log logger.Logger) IController {
+	return &controllers{srv: srv, log: log}
+}
+
+// job swagger document
+// @Description Submit a job run in the background, answered with its id at once. The params depend on the kind: purge takes dry_run, export include_deleted and the imports mode, dry_run and the items to import. The imports need the editor role, the purge and the export the admin role. An export is downloaded on GET /jobs/{id}/export
+// @Tags job
+// @Accept json
+// @Produce json,application/yaml,application/msgpack,application/problem+json
+// @Param job body entities.JobRequest true "kind and params of the job"
+// @Success 202 {object} entities.Job
+// @Header 202 {string} Location "path of the job"
+// @Failure 400 {object} entities.HttpErr
+// @Failure 403 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 422 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /jobs [post]
+func (ctrl *controllers) Submit(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.jobs.submit")
+	defer span.End()
+
+	var request entities.JobRequest
+	if err := c.Decode(&request); err != nil {
+		responseErr(ctx, c, entities.ErrDecode)
+		return
+	}
+
+	if err := c.Validate(request); err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	params, err := request.DecodeParams()
+	if err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	principal, _ := httpRouter.PrincipalFromContext(ctx)
+	if !principal.HasRole(kindRoles[request.Kind]) {
+		responseErr(ctx, c, entities.ErrJobRole)
+		return
+	}
+	request.SubmittedBy = principal.ID
+
+	if err := c.Validate(params); err != nil {
+		responseErr(ctx, c, err)
+		return
+	}
+
+	job, err := ctrl.srv.Jobs.Submit(ctx, request)
+	if err != nil {
+		ctrl.log.Error("Ctrl.Submit: ", "Error on submit job: ", request.Kind, err)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	c.SetHeader("Location", c.GetRequestReader().URL.Path+"/"+job.ID)
+	c.Respond(http.StatusAccepted, job)
+}
+
+// job swagger document
+// @Description Find a job, with its status, progress and the result once it succeeded. Only the principal that submitted it and the admins find it
+// @Tags job
+// @Accept json
+// @Produce json,application/yaml,application/msgpack,application/problem+json
+// @Param	id	path	string	true	"id job"
+// @Success 200 {object} entities.Job
+// @Failure 404 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /jobs/{id} [get]
+func (ctrl *controllers) FindByID(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.jobs.findbyid")
+	defer span.End()
+
+	job, err := ctrl.find(ctx, c.GetParam("id"))
+	if err != nil {
+		ctrl.log.Error("Ctrl.FindByID: ", "Error on find job: ", c.GetParam("id"), err)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	c.Respond(http.StatusOK, job)
+}
+
+// job swagger document
+// @Description Cancel a job. A queued job is canceled at once, a running one is asked to stop and answered with 202 until its work returns
+// @Tags job
+// @Accept json
+// @Produce json,application/yaml,application/msgpack,application/problem+json
+// @Param	id	path	string	true	"id job"
+// @Success 200 {object} entities.Job
+// @Success 202 {object} entities.Job
+// @Failure 404 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 409 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /jobs/{id} [delete]
+func (ctrl *controllers) Cancel(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.jobs.cancel")
+	defer span.End()
+
+	job, err := ctrl.srv.Jobs.Cancel(ctx, c.GetParam("id"))
+	if err != nil {
+		ctrl.log.Error("Ctrl.Cancel: ", "Error on cancel job: ", c.GetParam("id"), err)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	if job.Pending() {
+		c.Respond(http.StatusAccepted, job)
+		return
+	}
+	c.Respond(http.StatusOK, job)
+}
+
+// job swagger document
+// @Description Download the snapshot written by a succeeded export job, as NDJSON. Only the principal that submitted it and the admins download it, the file is deleted with the job once past the retention
+// @Tags job
+// @Produce application/x-ndjson,application/problem+json
+// @Param	id	path	string	true	"id job"
+// @Success 200 {object} entities.SnapshotRecord
+// @Failure 404 {object} entities.HttpErr
+// @Failure 409 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /jobs/{id}/export [get]
+func (ctrl *controllers) Export(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.jobs.export")
+	defer span.End()
+
+	if _, err := ctrl.find(ctx, c.GetParam("id")); err != nil {
+		ctrl.log.Error("Ctrl.Export: ", "Error on find job: ", c.GetParam("id"), err)
+		responseErr(ctx, c, err)
+		return
+	}
+
+	file, err := ctrl.srv.Jobs.ExportFile(ctx, c.GetParam("id"))
+	if err != nil {
+		ctrl.log.Error("Ctrl.Export: ", "Error on open export of job: ", c.GetParam("id"), err)
+		responseErr(ctx, c, err)
+		return
+	}
+	defer file.Close()
+
+	writer := c.GetResponseWriter()
+	writer.Header().Set("Content-Type", entities.NDJSONContentType)
+	writer.Header().Set(entities.SnapshotVersionHeader, strconv.Itoa(entities.SnapshotVersion))
+	writer.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filepath.Base(file.Name())))
+	writer.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(writer, file); err != nil {
+		ctrl.log.Error("Ctrl.Export: ", "Error on download export of job: ", c.GetParam("id"), err)
+	}
+}
+
+// find returns the job with id when the principal of ctx submitted it or is
+// an admin, the others don't learn it exists.
+func (ctrl *controllers) find(ctx context.Context, id string) (job entities.Job, err error) {
+	job, err = ctrl.srv.Jobs.FindByID(ctx, id)
+	if err != nil {
+		return job, err
+	}
+
+	principal, _ := httpRouter.PrincipalFromContext(ctx)
+	if principal.HasRole(httpRouter.RoleAdmin) || (len(job.SubmittedBy) > 0 && job.SubmittedBy == principal.ID) {
+		return job, nil
+	}
+	return entities.Job{}, jobs.ErrJobNotFound
+}
@@ -0,0 +1,374 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/jobs"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var created = time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+
+func Test_Submit(t *testing.T) {
+	endpoint := "/jobs"
+	queued := entities.Job{ID: "id_1", Kind: entities.JobPurge, Status: entities.JobQueued,
+		Params: json.RawMessage(`{"dry_run":true}`), Result: json.RawMessage(`null`), CreatedAt: created}
+
+	cases := map[string]struct {
+		input            string
+		inputRole        string
+		expectedCode     int
+		expectedData     string
+		expectedLocation string
+		prepareMock      func(mock *jobs.MockIService)
+	}{
+		"Should return accepted": {
+			input:            `{"kind":"purge","params":{"dry_run":true}}`,
+			expectedCode:     http.StatusAccepted,
+			expectedData:     `{"id":"id_1","kind":"purge","status":"queued","params":{"dry_run":true},"progress":0,"result":null,"cancel_requested":false,"attempts":0,"created_at":"2023-01-02T15:04:05Z","started_at":null,"finished_at":null}`,
+			expectedLocation: "/jobs/id_1",
+			prepareMock: func(mock *jobs.MockIService) {
+				mock.EXPECT().
+					Submit(gomock.Any(), entities.JobRequest{Kind: entities.JobPurge, Params: json.RawMessage(`{"dry_run":true}`), SubmittedBy: "key_1"}).
+					Times(1).
+					Return(queued, nil)
+			},
+		},
+		"Should return accepted an import of an editor": {
+			input:            `{"kind":"import.houses","params":{"items":[{"name":"house Stark","region":"winterfell","foundation_year":"1"}]}}`,
+			inputRole:        httpRouter.RoleEditor,
+			expectedCode:     http.StatusAccepted,
+			expectedLocation: "/jobs/id_1",
+			prepareMock: func(mock *jobs.MockIService) {
+				mock.EXPECT().
+					Submit(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(queued, nil)
+			},
+		},
+		"Should return error role of the kind": {
+			input:        `{"kind":"export"}`,
+			inputRole:    httpRouter.RoleEditor,
+			expectedCode: http.StatusForbidden,
+			expectedData: `{"type":"/problems/forbidden","title":"Forbidden","status":403,"detail":"the credentials don't have the role of this kind of job","instance":"/jobs"}`,
+			prepareMock:  func(mock *jobs.MockIService) {},
+		},
+		"Should return error kind": {
+			input:        `{"kind":"backup"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedData: `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"kind must be purge, export, import.houses or import.characters","instance":"/jobs"}`,
+			prepareMock:  func(mock *jobs.MockIService) {},
+		},
+		"Should return error params": {
+			input:        `{"kind":"export","params":{"dry_run":true}}`,
+			expectedCode: http.StatusBadRequest,
+			expectedData: `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"params don't match the kind of the job","instance":"/jobs"}`,
+			prepareMock:  func(mock *jobs.MockIService) {},
+		},
+		"Should return error validation of the params": {
+			input:        `{"kind":"import.houses","params":{"items":[]}}`,
+			expectedCode: http.StatusBadRequest,
+			prepareMock:  func(mock *jobs.MockIService) {},
+		},
+		"Should return error service": {
+			input:        `{"kind":"purge"}`,
+			expectedCode: http.StatusInternalServerError,
+			expectedData: `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"problem to create job","instance":"/jobs"}`,
+			prepareMock: func(mock *jobs.MockIService) {
+				mock.EXPECT().
+					Submit(gomock.Any(), entities.JobRequest{Kind: entities.JobPurge, SubmittedBy: "key_1"}).
+					Times(1).
+					Return(entities.Job{}, errors.New("problem to create job"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := jobs.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Jobs: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.Submit)
+
+			// ============ START MOCK REQUEST ============
+			role := cs.inputRole
+			if len(role) == 0 {
+				role = httpRouter.RoleAdmin
+			}
+			ctx = httpRouter.ContextWithPrincipal(ctx, httpRouter.Principal{ID: "key_1", Roles: []string{role}})
+			request := httptest.NewRequest(http.MethodPost, endpoint, strings.NewReader(cs.input)).WithContext(ctx)
+			request.Header.Set("Content-Type", "application/json")
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			if cs.expectedData != "" {
+				assert.Equal(t, cs.expectedData, string(responseData))
+			}
+			assert.Equal(t, cs.expectedCode, writer.Code)
+			assert.Equal(t, cs.expectedLocation, writer.Header().Get("Location"))
+		})
+	}
+}
+
+func Test_FindByID(t *testing.T) {
+	endpoint := "/jobs/:id"
+
+	submitted := entities.Job{ID: "id_1", Kind: entities.JobImportHouses, Status: entities.JobQueued, Params: json.RawMessage(`{}`),
+		Result: json.RawMessage(`null`), CreatedAt: created, SubmittedBy: "key_1"}
+
+	cases := map[string]struct {
+		inputRole    string
+		expectedCode int
+		expectedData string
+		prepareMock  func(mock *jobs.MockIService)
+	}{
+		"Should return success": {
+			expectedCode: http.StatusOK,
+			expectedData: `{"id":"id_1","kind":"export","status":"succeeded","params":{},"progress":100,"result":[],"cancel_requested":false,"attempts":1,"created_at":"2023-01-02T15:04:05Z","started_at":"2023-01-02T15:04:05Z","finished_at":"2023-01-02T15:04:05Z"}`,
+			prepareMock: func(mock *jobs.MockIService) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), "id_1").
+					Times(1).
+					Return(entities.Job{ID: "id_1", Kind: entities.JobExport, Status: entities.JobSucceeded, Params: json.RawMessage(`{}`),
+						Progress: 100, Result: json.RawMessage(`[]`), Attempts: 1, CreatedAt: created, StartedAt: &created, FinishedAt: &created}, nil)
+			},
+		},
+		"Should return the job to the principal that submitted it": {
+			inputRole:    httpRouter.RoleEditor,
+			expectedCode: http.StatusOK,
+			expectedData: `{"id":"id_1","kind":"import.houses","status":"queued","params":{},"progress":0,"result":null,"cancel_requested":false,"attempts":0,"created_at":"2023-01-02T15:04:05Z","started_at":null,"finished_at":null,"submitted_by":"key_1"}`,
+			prepareMock: func(mock *jobs.MockIService) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), "id_1").
+					Times(1).
+					Return(submitted, nil)
+			},
+		},
+		"Should return not found to another principal": {
+			inputRole:    httpRouter.RoleEditor,
+			expectedCode: http.StatusNotFound,
+			expectedData: `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"job not found","instance":"/jobs/id_1"}`,
+			prepareMock: func(mock *jobs.MockIService) {
+				other := submitted
+				other.SubmittedBy = "key_2"
+				mock.EXPECT().
+					FindByID(gomock.Any(), "id_1").
+					Times(1).
+					Return(other, nil)
+			},
+		},
+		"Should return not found": {
+			expectedCode: http.StatusNotFound,
+			expectedData: `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"job not found","instance":"/jobs/id_1"}`,
+			prepareMock: func(mock *jobs.MockIService) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), "id_1").
+					Times(1).
+					Return(entities.Job{}, jobs.ErrJobNotFound)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := jobs.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Jobs: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Get(endpoint, ctr.FindByID)
+
+			// ============ START MOCK REQUEST ============
+			role := cs.inputRole
+			if len(role) == 0 {
+				role = httpRouter.RoleAdmin
+			}
+			ctx = httpRouter.ContextWithPrincipal(ctx, httpRouter.Principal{ID: "key_1", Roles: []string{role}})
+			request := httptest.NewRequest(http.MethodGet, "/jobs/id_1", nil).WithContext(ctx)
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData, string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
+
+func Test_Cancel(t *testing.T) {
+	endpoint := "/jobs/:id"
+
+	cases := map[string]struct {
+		expectedCode int
+		prepareMock  func(mock *jobs.MockIService)
+	}{
+		"Should return ok when canceled": {
+			expectedCode: http.StatusOK,
+			prepareMock: func(mock *jobs.MockIService) {
+				mock.EXPECT().Cancel(gomock.Any(), "id_1").Times(1).
+					Return(entities.Job{ID: "id_1", Status: entities.JobCanceled, CancelRequested: true}, nil)
+			},
+		},
+		"Should return accepted when running": {
+			expectedCode: http.StatusAccepted,
+			prepareMock: func(mock *jobs.MockIService) {
+				mock.EXPECT().Cancel(gomock.Any(), "id_1").Times(1).
+					Return(entities.Job{ID: "id_1", Status: entities.JobRunning, CancelRequested: true}, nil)
+			},
+		},
+		"Should return conflict when finished": {
+			expectedCode: http.StatusConflict,
+			prepareMock: func(mock *jobs.MockIService) {
+				mock.EXPECT().Cancel(gomock.Any(), "id_1").Times(1).
+					Return(entities.Job{ID: "id_1", Status: entities.JobSucceeded}, jobs.ErrJobFinished)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := jobs.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Jobs: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Delete(endpoint, ctr.Cancel)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodDelete, "/jobs/id_1", nil).WithContext(ctx)
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
+
+func Test_Export(t *testing.T) {
+	endpoint := "/jobs/:id/export"
+	snapshot := `{"kind":"header","version":1}` + "\n" + `{"kind":"footer","counts":{"characters":0,"houses":0,"removed_lordships":0}}` + "\n"
+	path := filepath.Join(t.TempDir(), "export-id_1.ndjson")
+	os.WriteFile(path, []byte(snapshot), 0o600)
+
+	export := entities.Job{ID: "id_1", Kind: entities.JobExport, Status: entities.JobSucceeded, SubmittedBy: "key_2"}
+
+	cases := map[string]struct {
+		expectedCode        int
+		expectedData        string
+		expectedContentType string
+		prepareMock         func(mock *jobs.MockIService)
+	}{
+		"Should return the file of the export": {
+			expectedCode:        http.StatusOK,
+			expectedData:        snapshot,
+			expectedContentType: entities.NDJSONContentType,
+			prepareMock: func(mock *jobs.MockIService) {
+				mock.EXPECT().FindByID(gomock.Any(), "id_1").Times(1).Return(export, nil)
+				mock.EXPECT().ExportFile(gomock.Any(), "id_1").Times(1).DoAndReturn(func(context.Context, string) (*os.File, error) {
+					return os.Open(path)
+				})
+			},
+		},
+		"Should return error no export": {
+			expectedCode:        http.StatusConflict,
+			expectedData:        `{"type":"/problems/conflict","title":"Conflict","status":409,"detail":"this job has no export to download","instance":"/jobs/id_1/export"}`,
+			expectedContentType: httpRouter.ProblemContentType,
+			prepareMock: func(mock *jobs.MockIService) {
+				mock.EXPECT().FindByID(gomock.Any(), "id_1").Times(1).Return(export, nil)
+				mock.EXPECT().ExportFile(gomock.Any(), "id_1").Times(1).Return(nil, jobs.ErrJobNoExport)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := jobs.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Jobs: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Get(endpoint, ctr.Export)
+
+			// ============ START MOCK REQUEST ============
+			ctx = httpRouter.ContextWithPrincipal(ctx, httpRouter.Principal{ID: "key_1", Roles: []string{httpRouter.RoleAdmin}})
+			request := httptest.NewRequest(http.MethodGet, "/jobs/id_1/export", nil).WithContext(ctx)
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData, string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+			assert.Equal(t, cs.expectedContentType, writer.Header().Get("Content-Type"))
+		})
+	}
+}
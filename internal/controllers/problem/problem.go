@@ -0,0 +1,77 @@
+package problem
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+var (
+	ErrRouteNotFound = entities.NewHttpErr(http.StatusNotFound, "no route matches the request", nil)
+	ErrNotAcceptable = entities.NewHttpErr(http.StatusNotAcceptable, "the resource has no representation in the media types of the Accept header", nil)
+	ErrUnauthorized  = entities.NewHttpErr(http.StatusUnauthorized, "the request needs a valid bearer token or API key", nil)
+	ErrForbidden     = entities.NewHttpErr(http.StatusForbidden, "the credentials don't have the role of the route", nil)
+	ErrRateLimited   = entities.NewHttpErr(http.StatusTooManyRequests, "the client sent too many requests, retry after the seconds of the Retry-After header", nil)
+	ErrBodyEncoding  = entities.NewHttpErr(http.StatusUnsupportedMediaType, "the request body must be sent as is or with Content-Encoding gzip", nil)
+	ErrBodyCorrupt   = entities.NewHttpErr(http.StatusBadRequest, "the request body isn't valid gzip", nil)
+	ErrBodyTooLarge  = entities.NewHttpErr(http.StatusRequestEntityTooLarge, "the request body is too large once inflated", nil)
+)
+
+type (
+	IController interface {
+		// NotFound answers the requests that match no route.
+		NotFound(c httpRouter.Context)
+		// NotAcceptable answers the responses the Accept header rejects.
+		NotAcceptable(c httpRouter.Context)
+		// Denied answers the requests the middlewares reject: failed
+		// authentication, over the rate limit or a body that doesn't inflate.
+		Denied(c httpRouter.Context, err error)
+	}
+	controllers struct{}
+)
+
+func New() IController {
+	return &controllers{}
+}
+
+func (ctrl *controllers) NotFound(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.problem.notfound")
+	defer span.End()
+
+	problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, ErrRouteNotFound)
+	c.Problem(problem.Status, problem)
+}
+
+func (ctrl *controllers) NotAcceptable(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.problem.notacceptable")
+	defer span.End()
+
+	problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, ErrNotAcceptable)
+	c.Problem(problem.Status, problem)
+}
+
+func (ctrl *controllers) Denied(c httpRouter.Context, err error) {
+	ctx, span := tracer.Span(c.Context(), "controllers.problem.denied")
+	defer span.End()
+
+	switch {
+	case errors.Is(err, httpRouter.ErrUnauthenticated):
+		err = ErrUnauthorized
+	case errors.Is(err, httpRouter.ErrForbidden):
+		err = ErrForbidden
+	case errors.Is(err, httpRouter.ErrRateLimited):
+		err = ErrRateLimited
+	case errors.Is(err, httpRouter.ErrBodyEncoding):
+		err = ErrBodyEncoding
+	case errors.Is(err, httpRouter.ErrBodyCorrupt):
+		err = ErrBodyCorrupt
+	case errors.Is(err, httpRouter.ErrBodyTooLarge):
+		err = ErrBodyTooLarge
+	}
+
+	problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+	c.Problem(problem.Status, problem)
+}
@@ -0,0 +1,119 @@
+package problem
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NotFound(t *testing.T) {
+	// ============ START CONTROLLER ============
+	ctr := New()
+
+	// ============ START ROUTER ============
+	router := httpRouter.NewGinRouter()
+	router.NoRoute(ctr.NotFound)
+
+	// ============ START MOCK REQUEST ============
+	request := httptest.NewRequest(http.MethodGet, "/dragons", nil)
+	writer := httptest.NewRecorder()
+
+	// ============ START SERVER HTTP ============
+	router.ServeHTTP(writer, request)
+
+	// ============ START VALIDATION ============
+	responseData, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"no route matches the request","instance":"/dragons"}`, string(responseData))
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+	assert.Equal(t, httpRouter.ProblemContentType, writer.Header().Get("Content-Type"))
+}
+
+func Test_NotAcceptable(t *testing.T) {
+	// ============ START CONTROLLER ============
+	ctr := New()
+
+	// ============ START ROUTER ============
+	router := httpRouter.NewGinRouter()
+	router.NotAcceptable(ctr.NotAcceptable)
+	router.Get("/houses", func(c httpRouter.Context) {
+		c.Respond(http.StatusOK, []string{})
+	})
+
+	// ============ START MOCK REQUEST ============
+	request := httptest.NewRequest(http.MethodGet, "/houses", nil)
+	request.Header.Set("Accept", "application/xml")
+	writer := httptest.NewRecorder()
+
+	// ============ START SERVER HTTP ============
+	router.ServeHTTP(writer, request)
+
+	// ============ START VALIDATION ============
+	responseData, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, `{"type":"/problems/not-acceptable","title":"Not Acceptable","status":406,"detail":"the resource has no representation in the media types of the Accept header","instance":"/houses"}`, string(responseData))
+	assert.Equal(t, http.StatusNotAcceptable, writer.Code)
+	assert.Equal(t, httpRouter.ProblemContentType, writer.Header().Get("Content-Type"))
+}
+
+func Test_Denied(t *testing.T) {
+	cases := map[string]struct {
+		input        error
+		expectedCode int
+		expectedData string
+	}{
+		"Should return unauthorized": {
+			input:        httpRouter.ErrUnauthenticated,
+			expectedCode: http.StatusUnauthorized,
+			expectedData: `{"type":"/problems/unauthorized","title":"Unauthorized","status":401,"detail":"the request needs a valid bearer token or API key","instance":"/houses"}`,
+		},
+		"Should return forbidden": {
+			input:        httpRouter.ErrForbidden,
+			expectedCode: http.StatusForbidden,
+			expectedData: `{"type":"/problems/forbidden","title":"Forbidden","status":403,"detail":"the credentials don't have the role of the route","instance":"/houses"}`,
+		},
+		"Should return too many requests": {
+			input:        httpRouter.ErrRateLimited,
+			expectedCode: http.StatusTooManyRequests,
+			expectedData: `{"type":"/problems/too-many-requests","title":"Too Many Requests","status":429,"detail":"the client sent too many requests, retry after the seconds of the Retry-After header","instance":"/houses"}`,
+		},
+		"Should return payload too large": {
+			input:        httpRouter.ErrBodyTooLarge,
+			expectedCode: http.StatusRequestEntityTooLarge,
+			expectedData: `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"the request body is too large once inflated","instance":"/houses"}`,
+		},
+		"Should return the error of the authentication": {
+			input:        errors.New("problem to find api key"),
+			expectedCode: http.StatusInternalServerError,
+			expectedData: `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"problem to find api key","instance":"/houses"}`,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ START CONTROLLER ============
+			ctr := New()
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Get("/houses", func(c httpRouter.Context) {
+				ctr.Denied(c, cs.input)
+			})
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodGet, "/houses", nil)
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData, string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package purge
+
+import (
+	"net/http"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+type (
+	IController interface {
+		Run(c httpRouter.Context)
+	}
+	controllers struct {
+		srv *services.Container
+		log logger.Logger
+	}
+)
+
+func New(srv *services.Container, log logger.Logger) IController {
+	return &controllers{srv: srv, log: log}
+}
+
+// purge swagger document
+// @Description Hard delete the houses and characters deleted longer than their retention
+// @Tags admin
+// @Accept json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	dry_run	query	bool	false	"only report what would be purged"
+// @Success 200 {object} entities.PurgeReport
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /admin/purge [post]
+func (ctrl *controllers) Run(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.purge.run")
+	defer span.End()
+
+	var (
+		report entities.PurgeReport
+		err    error
+		dryRun = c.GetQuery("dry_run") == "true"
+	)
+
+	report, err = ctrl.srv.Purge.Run(ctx, dryRun)
+	if err != nil {
+		ctrl.log.Error("Ctrl.Run: ", "Error on purge: ", err)
+		problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+		c.Problem(problem.Status, problem)
+		return
+	}
+
+	c.Respond(http.StatusOK, report)
+}
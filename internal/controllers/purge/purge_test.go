@@ -0,0 +1,95 @@
+package purge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/purge"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Run(t *testing.T) {
+	endpoint := "/admin/purge"
+	report := entities.PurgeReport{
+		DryRun:   true,
+		Entities: []entities.PurgeEntityReport{{Entity: "houses", Retention: "720h0m0s", Candidates: 2}},
+	}
+
+	cases := map[string]struct {
+		queryInput   string
+		expectedCode int
+		expectedData func() string
+		prepareMock  func(mock *purge.MockIService)
+	}{
+		"Should return success": {
+			queryInput:   "?dry_run=true",
+			expectedCode: http.StatusOK,
+			expectedData: func() string {
+				data, _ := json.Marshal(report)
+				return string(data)
+			},
+			prepareMock: func(mock *purge.MockIService) {
+				mock.EXPECT().
+					Run(gomock.Any(), true).
+					Times(1).
+					Return(report, nil)
+			},
+		},
+		"Should return error service": {
+			expectedCode: http.StatusInternalServerError,
+			expectedData: func() string {
+				return `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"failed to purge houses","instance":"/admin/purge"}`
+			},
+			prepareMock: func(mock *purge.MockIService) {
+				mock.EXPECT().
+					Run(gomock.Any(), false).
+					Times(1).
+					Return(entities.PurgeReport{}, errors.New("failed to purge houses"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := purge.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Purge: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.Run)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodPost, endpoint+cs.queryInput, nil).WithContext(ctx)
+			writer := httptest.NewRecorder()
+			request.Header.Set("Content-Type", "application/json")
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData(), string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
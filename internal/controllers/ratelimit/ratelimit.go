@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+)
+
+const (
+	// StoreMemory keeps the buckets in each instance.
+	StoreMemory = "memory"
+	// StorePostgres shares the buckets of the instances in the database.
+	StorePostgres = "postgres"
+
+	// DefaultGroup is the limit of the groups without their own.
+	DefaultGroup = "default"
+	// IPGroup is the limit of each IP before the authentication, it doesn't
+	// take the default one.
+	IPGroup = "ip"
+)
+
+type (
+	IController interface {
+		// Limit is the middleware that rate limits the clients of the routes
+		// of group.
+		Limit(group string) httpRouter.HandlerFunc
+		// LimitIP is the middleware that rate limits the IPs by the ip group,
+		// mounted before the authentication.
+		LimitIP() httpRouter.HandlerFunc
+	}
+
+	// Options of the rate limits, a group without limit, nor a default one,
+	// isn't limited.
+	Options struct {
+		Store  string
+		Groups map[string]httpRouter.Limit
+	}
+
+	controllers struct {
+		srv     *services.Container
+		log     logger.Logger
+		opts    Options
+		limiter httpRouter.RateLimiter
+	}
+)
+
+// New returns the controller of the rate limits, deny answers the requests
+// over the limit.
+func New(srv *services.Container, log logger.Logger, opts Options, deny func(c httpRouter.Context, err error)) IController {
+	ctrl := &controllers{srv: srv, log: log, opts: opts}
+	ctrl.limiter = httpRouter.RateLimiter{Store: httpRouter.NewMemoryStore(), Deny: deny}
+	if opts.Store == StorePostgres {
+		ctrl.limiter.Store = ctrl
+	}
+	return ctrl
+}
+
+func (ctrl *controllers) Limit(group string) httpRouter.HandlerFunc {
+	limit, ok := ctrl.opts.Groups[group]
+	if !ok {
+		limit = ctrl.opts.Groups[DefaultGroup]
+	}
+	return ctrl.limiter.Limit(group, limit)
+}
+
+func (ctrl *controllers) LimitIP() httpRouter.HandlerFunc {
+	return ctrl.limiter.LimitIP(IPGroup, ctrl.opts.Groups[IPGroup])
+}
+
+// Take takes the tokens of the buckets shared in the database.
+func (ctrl *controllers) Take(ctx context.Context, key string, limit httpRouter.Limit) (tokens float64, allowed bool, err error) {
+	tokens, allowed, err = ctrl.srv.RateLimit.Take(ctx, key, limit.Rate(), limit.Capacity())
+	if err != nil {
+		ctrl.log.Error("Ctrl.RateLimit: ", "Error on take token, letting the request through: ", err)
+	}
+	return tokens, allowed, err
+}
@@ -0,0 +1,139 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers/problem"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/ratelimit"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Limit(t *testing.T) {
+	groups := map[string]httpRouter.Limit{
+		DefaultGroup: {Requests: 2, Period: time.Minute},
+		"search":     {Requests: 1, Period: time.Minute},
+		IPGroup:      {Requests: 3, Period: time.Minute},
+	}
+
+	cases := map[string]struct {
+		store         string
+		group         string
+		groups        map[string]httpRouter.Limit
+		expectedCodes []int
+		expectedLimit string
+		prepareMock   func(mock *ratelimit.MockIService)
+	}{
+		"Should limit a group by its own limit": {
+			store:         StoreMemory,
+			group:         "search",
+			groups:        groups,
+			expectedCodes: []int{http.StatusOK, http.StatusTooManyRequests},
+			expectedLimit: "1",
+			prepareMock:   func(mock *ratelimit.MockIService) {},
+		},
+		"Should limit a group without limit by the default one": {
+			store:         StoreMemory,
+			group:         "houses",
+			groups:        groups,
+			expectedCodes: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			expectedLimit: "2",
+			prepareMock:   func(mock *ratelimit.MockIService) {},
+		},
+		"Should limit the IPs by the ip group": {
+			store:         StoreMemory,
+			group:         IPGroup,
+			groups:        groups,
+			expectedCodes: []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			expectedLimit: "3",
+			prepareMock:   func(mock *ratelimit.MockIService) {},
+		},
+		"Should not limit the IPs by the default group": {
+			store:         StoreMemory,
+			group:         IPGroup,
+			groups:        map[string]httpRouter.Limit{DefaultGroup: {Requests: 1, Period: time.Minute}},
+			expectedCodes: []int{http.StatusOK, http.StatusOK},
+			prepareMock:   func(mock *ratelimit.MockIService) {},
+		},
+		"Should not limit without limits": {
+			store:         StoreMemory,
+			group:         "houses",
+			expectedCodes: []int{http.StatusOK, http.StatusOK, http.StatusOK},
+			prepareMock:   func(mock *ratelimit.MockIService) {},
+		},
+		"Should take the tokens of the shared buckets": {
+			store:         StorePostgres,
+			group:         "search",
+			groups:        groups,
+			expectedCodes: []int{http.StatusOK, http.StatusTooManyRequests},
+			expectedLimit: "1",
+			prepareMock: func(mock *ratelimit.MockIService) {
+				gomock.InOrder(
+					mock.EXPECT().Take(gomock.Any(), "search:ip:192.0.2.1", 1.0/60, 1).Times(1).Return(0.0, true, nil),
+					mock.EXPECT().Take(gomock.Any(), "search:ip:192.0.2.1", 1.0/60, 1).Times(1).Return(0.0, false, nil),
+				)
+			},
+		},
+		"Should let the requests through when the shared buckets fail": {
+			store:         StorePostgres,
+			group:         "search",
+			groups:        groups,
+			expectedCodes: []int{http.StatusOK, http.StatusOK},
+			prepareMock: func(mock *ratelimit.MockIService) {
+				mock.EXPECT().Take(gomock.Any(), "search:ip:192.0.2.1", 1.0/60, 1).Times(2).
+					Return(0.0, false, errors.New("problem to take rate limit token"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := ratelimit.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{RateLimit: mock},
+				logger.NewLogrusLogger(),
+				Options{Store: cs.store, Groups: cs.groups},
+				problem.New().Denied,
+			)
+
+			// ============ START ROUTER ============
+			limit := ctr.Limit(cs.group)
+			if cs.group == IPGroup {
+				limit = ctr.LimitIP()
+			}
+
+			router := httpRouter.NewGinRouter()
+			router.Get("/search", limit, func(c httpRouter.Context) {
+				c.Respond(http.StatusOK, nil)
+			})
+
+			for i, expectedCode := range cs.expectedCodes {
+				// ============ START MOCK REQUEST ============
+				request := httptest.NewRequest(http.MethodGet, "/search", nil).WithContext(ctx)
+				writer := httptest.NewRecorder()
+
+				// ============ START SERVER HTTP ============
+				router.ServeHTTP(writer, request)
+
+				// ============ START VALIDATION ============
+				assert.Equal(t, expectedCode, writer.Code, i)
+				assert.Equal(t, cs.expectedLimit, writer.Header().Get("RateLimit-Limit"), i)
+			}
+		})
+	}
+}
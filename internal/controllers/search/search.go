@@ -0,0 +1,91 @@
+package search
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+type (
+	IController interface {
+		Search(c httpRouter.Context)
+	}
+	controllers struct {
+		srv *services.Container
+		log logger.Logger
+	}
+)
+
+func New(srv *services.Container, log logger.Logger) IController {
+	return &controllers{srv: srv, log: log}
+}
+
+// search swagger document
+// @Description Search the houses, by name and region, and the characters, by name, in one ranked list. Accents are ignored, each word also matches the words it starts and the names close to it match despite typos
+// @Tags search
+// @Accept json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	q	query	string	true	"text to search"
+// @Param	types	query	string	false	"comma separated types to search, house and character by default"
+// @Param	limit	query	int	false	"most results to return, 20 by default and up to 100"
+// @Success 200 {array} entities.SearchResult
+// @Failure 400 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /search [get]
+func (ctrl *controllers) Search(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.search.search")
+	defer span.End()
+
+	q, err := query(c)
+	if err != nil {
+		problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+		c.Problem(problem.Status, problem)
+		return
+	}
+
+	results, err := ctrl.srv.Search.Search(ctx, q)
+	if err != nil {
+		ctrl.log.Error("Ctrl.Search: ", "Error on search: ", q.Text, err)
+		problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+		c.Problem(problem.Status, problem)
+		return
+	}
+
+	c.Respond(http.StatusOK, results)
+}
+
+// query reads the search of the query parameters.
+func query(c httpRouter.Context) (q entities.SearchQuery, err error) {
+	q.Text = c.GetQuery("q")
+	if len(q.Terms()) == 0 {
+		return q, entities.ErrSearchQuery
+	}
+
+	if raw := c.GetQuery("types"); len(raw) > 0 {
+		for _, t := range strings.Split(raw, ",") {
+			t = strings.TrimSpace(t)
+			if t != entities.SearchHouse && t != entities.SearchCharacter {
+				return q, entities.ErrSearchTypes
+			}
+			q.Types = append(q.Types, t)
+		}
+	}
+
+	if raw := c.GetQuery("limit"); len(raw) > 0 {
+		q.Limit, err = strconv.Atoi(raw)
+		if err != nil || q.Limit < 1 || q.Limit > entities.MaxSearchLimit {
+			return q, entities.ErrSearchLimit
+		}
+	}
+
+	return q, nil
+}
@@ -0,0 +1,106 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/search"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Search(t *testing.T) {
+	endpoint := "/search"
+
+	cases := map[string]struct {
+		inputPath    string
+		expectedCode int
+		expectedData string
+		prepareMock  func(mock *search.MockIService)
+	}{
+		"Should return success": {
+			inputPath:    "?q=st%C3%A4rk&types=house,character&limit=5",
+			expectedCode: http.StatusOK,
+			expectedData: `[{"type":"house","id":"id_1","name":"house Stark","rank":0.6,"highlight":"house \u003cb\u003eStark\u003c/b\u003e - winterfell"},{"type":"character","id":"id_2","name":"Arya Stark","rank":0.5,"highlight":"Arya \u003cb\u003eStark\u003c/b\u003e"}]`,
+			prepareMock: func(mock *search.MockIService) {
+				mock.EXPECT().
+					Search(gomock.Any(), entities.SearchQuery{Text: "stärk", Types: []string{"house", "character"}, Limit: 5}).
+					Times(1).
+					Return([]entities.SearchResult{
+						{Type: entities.SearchHouse, ID: "id_1", Name: "house Stark", Rank: 0.6, Highlight: "house <b>Stark</b> - winterfell"},
+						{Type: entities.SearchCharacter, ID: "id_2", Name: "Arya Stark", Rank: 0.5, Highlight: "Arya <b>Stark</b>"},
+					}, nil)
+			},
+		},
+		"Should return error query": {
+			inputPath:    "?q=%20!",
+			expectedCode: http.StatusBadRequest,
+			expectedData: `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"q must have a letter or a digit to search","instance":"/search"}`,
+			prepareMock:  func(mock *search.MockIService) {},
+		},
+		"Should return error types": {
+			inputPath:    "?q=stark&types=house,dragon",
+			expectedCode: http.StatusBadRequest,
+			expectedData: `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"types must list house or character","instance":"/search"}`,
+			prepareMock:  func(mock *search.MockIService) {},
+		},
+		"Should return error limit": {
+			inputPath:    "?q=stark&limit=500",
+			expectedCode: http.StatusBadRequest,
+			expectedData: `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"limit must be an integer from 1 to 100","instance":"/search"}`,
+			prepareMock:  func(mock *search.MockIService) {},
+		},
+		"Should return error service": {
+			inputPath:    "?q=stark",
+			expectedCode: http.StatusInternalServerError,
+			expectedData: `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"problem to search","instance":"/search"}`,
+			prepareMock: func(mock *search.MockIService) {
+				mock.EXPECT().
+					Search(gomock.Any(), entities.SearchQuery{Text: "stark"}).
+					Times(1).
+					Return(nil, errors.New("problem to search"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := search.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Search: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Get(endpoint, ctr.Search)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodGet, endpoint+cs.inputPath, nil).WithContext(ctx)
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData, string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
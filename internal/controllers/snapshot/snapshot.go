@@ -0,0 +1,116 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+type (
+	IController interface {
+		Export(c httpRouter.Context)
+		Restore(c httpRouter.Context)
+	}
+	controllers struct {
+		srv *services.Container
+		log logger.Logger
+	}
+)
+
+func New(srv *services.Container, log logger.Logger) IController {
+	return &controllers{srv: srv, log: log}
+}
+
+// snapshot swagger document
+// @Description Stream every house and character as NDJSON, a header record with the schema version opens it and a footer with the counts closes it. A snapshot without its footer was cut
+// @Tags admin
+// @Produce application/x-ndjson,application/problem+json
+// @Param	include_deleted	query	bool	false	"also export the soft deleted rows"
+// @Success 200 {object} entities.SnapshotRecord
+// @Header 200 {integer} X-Snapshot-Version "the schema version of the snapshot"
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /admin/snapshot [get]
+func (ctrl *controllers) Export(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.snapshot.export")
+	defer span.End()
+
+	var (
+		writer         = c.GetResponseWriter()
+		encoder        = json.NewEncoder(writer)
+		includeDeleted = c.GetQuery("include_deleted") == "true"
+		started        bool
+	)
+
+	err := ctrl.srv.Snapshot.Export(ctx, includeDeleted, func(record entities.SnapshotRecord) error {
+		if !started {
+			started = true
+			writer.Header().Set("Content-Type", entities.NDJSONContentType)
+			writer.Header().Set(entities.SnapshotVersionHeader, strconv.Itoa(entities.SnapshotVersion))
+			writer.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="snapshot-v%d.ndjson"`, entities.SnapshotVersion))
+			writer.WriteHeader(http.StatusOK)
+		}
+		return encoder.Encode(record)
+	})
+	if err != nil {
+		ctrl.log.Error("Ctrl.Export: ", "Error on export snapshot: ", err)
+		// once streaming the status is sent, the missing footer tells the
+		// snapshot was cut
+		if !started {
+			problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+			c.Problem(problem.Status, problem)
+		}
+	}
+}
+
+// restore swagger document
+// @Description Load a snapshot of GET /admin/snapshot in one transaction, keeping the ids and timestamps. The database must be empty unless replace=true, which drops its houses and characters first
+// @Tags admin
+// @Accept application/x-ndjson
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	replace	query	bool	false	"drop the houses and characters of the database first"
+// @Param	snapshot	body	string	true	"NDJSON snapshot"
+// @Success 200 {object} entities.RestoreReport
+// @Failure 400 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 409 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /admin/restore [post]
+func (ctrl *controllers) Restore(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.snapshot.restore")
+	defer span.End()
+
+	decoder := json.NewDecoder(c.GetRequestReader().Body)
+	next := func() (record entities.SnapshotRecord, err error) {
+		err = decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			return record, io.EOF
+		}
+		if err != nil {
+			return record, entities.ErrDecode
+		}
+		return record, nil
+	}
+
+	report, err := ctrl.srv.Snapshot.Restore(ctx, next, c.GetQuery("replace") == "true")
+	if err != nil {
+		ctrl.log.Error("Ctrl.Restore: ", "Error on restore snapshot: ", err)
+		problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+		c.Problem(problem.Status, problem)
+		return
+	}
+
+	c.Respond(http.StatusOK, report)
+}
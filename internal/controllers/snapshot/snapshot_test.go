@@ -0,0 +1,212 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/snapshot"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Export(t *testing.T) {
+	endpoint := "/admin/snapshot"
+	createdAt := time.Date(2023, 5, 1, 10, 30, 0, 123456000, time.UTC)
+	header := entities.SnapshotRecord{Kind: entities.SnapshotHeader, Version: entities.SnapshotVersion, CreatedAt: &createdAt, IncludeDeleted: true}
+	house := entities.SnapshotRecord{Kind: entities.SnapshotHouse, House: &entities.House{ID: "id_1", Name: "house Stark", CreatedAt: createdAt, DeletedAt: &createdAt}}
+
+	cases := map[string]struct {
+		queryInput     string
+		expectedCode   int
+		expectedHeader string
+		expectedData   string
+		prepareMock    func(mock *snapshot.MockIService)
+	}{
+		"Should stream the records": {
+			queryInput:     "?include_deleted=true",
+			expectedCode:   http.StatusOK,
+			expectedHeader: "1",
+			expectedData: `{"kind":"header","version":1,"created_at":"2023-05-01T10:30:00.123456Z","include_deleted":true}` + "\n" +
+				`{"kind":"house","house":{"id":"id_1","name":"house Stark","region":"","foundation_year":"","current_lord":"","created_at":"2023-05-01T10:30:00.123456Z","updated_at":null,"deleted_at":"2023-05-01T10:30:00.123456Z"}}` + "\n" +
+				`{"kind":"footer","counts":{"characters":0,"houses":1,"removed_lordships":0}}` + "\n",
+			prepareMock: func(mock *snapshot.MockIService) {
+				mock.EXPECT().
+					Export(gomock.Any(), true, gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, includeDeleted bool, fn func(entities.SnapshotRecord) error) error {
+						fn(header)
+						fn(house)
+						return fn(entities.SnapshotRecord{Kind: entities.SnapshotFooter, Counts: &entities.SnapshotCounts{Houses: 1}})
+					})
+			},
+		},
+		"Should stop the stream without footer": {
+			expectedCode:   http.StatusOK,
+			expectedHeader: "1",
+			expectedData:   `{"kind":"header","version":1,"created_at":"2023-05-01T10:30:00.123456Z","include_deleted":true}` + "\n",
+			prepareMock: func(mock *snapshot.MockIService) {
+				mock.EXPECT().
+					Export(gomock.Any(), false, gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, includeDeleted bool, fn func(entities.SnapshotRecord) error) error {
+						fn(header)
+						return errors.New("failed to export snapshot")
+					})
+			},
+		},
+		"Should return error service": {
+			expectedCode: http.StatusInternalServerError,
+			expectedData: `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"failed to export snapshot","instance":"/admin/snapshot"}`,
+			prepareMock: func(mock *snapshot.MockIService) {
+				mock.EXPECT().
+					Export(gomock.Any(), false, gomock.Any()).
+					Times(1).
+					Return(errors.New("failed to export snapshot"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := snapshot.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Snapshot: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Get(endpoint, ctr.Export)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodGet, endpoint+cs.queryInput, nil).WithContext(ctx)
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData, string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+			assert.Equal(t, cs.expectedHeader, writer.Header().Get(entities.SnapshotVersionHeader))
+		})
+	}
+}
+
+func Test_Restore(t *testing.T) {
+	endpoint := "/admin/restore"
+	snapshotBody := `{"kind":"header","version":1}` + "\n" +
+		`{"kind":"character","character":{"id":"id_1","name":"Jon Snow","tv_series":["Season 1"],"created_at":"2023-05-01T10:30:00.123456Z","updated_at":null}}` + "\n" +
+		`{"kind":"footer","counts":{"characters":1,"houses":0,"removed_lordships":0}}` + "\n"
+
+	cases := map[string]struct {
+		queryInput   string
+		inputBody    string
+		expectedCode int
+		expectedData string
+		prepareMock  func(mock *snapshot.MockIService)
+	}{
+		"Should return success": {
+			queryInput:   "?replace=true",
+			inputBody:    snapshotBody,
+			expectedCode: http.StatusOK,
+			expectedData: `{"replaced":true,"restored":{"characters":1,"houses":0,"removed_lordships":0}}`,
+			prepareMock: func(mock *snapshot.MockIService) {
+				mock.EXPECT().
+					Restore(gomock.Any(), gomock.Any(), true).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, next func() (entities.SnapshotRecord, error), replace bool) (entities.RestoreReport, error) {
+						report := entities.RestoreReport{Replaced: true}
+						for {
+							record, err := next()
+							if err != nil {
+								return entities.RestoreReport{}, err
+							}
+							if record.Kind == entities.SnapshotFooter {
+								return report, nil
+							}
+							report.Restored.Add(record)
+						}
+					})
+			},
+		},
+		"Should return error decode": {
+			inputBody:    `{"kind":"header","version":1}` + "\n" + `{"kind":`,
+			expectedCode: http.StatusBadRequest,
+			expectedData: `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"problem to decode your input","instance":"/admin/restore"}`,
+			prepareMock: func(mock *snapshot.MockIService) {
+				mock.EXPECT().
+					Restore(gomock.Any(), gomock.Any(), false).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, next func() (entities.SnapshotRecord, error), replace bool) (entities.RestoreReport, error) {
+						next()
+						_, err := next()
+						return entities.RestoreReport{}, err
+					})
+			},
+		},
+		"Should return error not empty": {
+			inputBody:    snapshotBody,
+			expectedCode: http.StatusConflict,
+			expectedData: `{"type":"/problems/conflict","title":"Conflict","status":409,"detail":"the database has houses or characters, restore with replace=true to drop them","instance":"/admin/restore"}`,
+			prepareMock: func(mock *snapshot.MockIService) {
+				mock.EXPECT().
+					Restore(gomock.Any(), gomock.Any(), false).
+					Times(1).
+					Return(entities.RestoreReport{}, entities.ErrSnapshotNotEmpty)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := snapshot.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Snapshot: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Post(endpoint, ctr.Restore)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodPost, endpoint+cs.queryInput, bytes.NewReader([]byte(cs.inputBody))).WithContext(ctx)
+			writer := httptest.NewRecorder()
+			request.Header.Set("Content-Type", entities.NDJSONContentType)
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData, string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package stats
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+type (
+	IController interface {
+		Stats(c httpRouter.Context)
+		Houses(c httpRouter.Context)
+		Characters(c httpRouter.Context)
+	}
+	controllers struct {
+		srv *services.Container
+		log logger.Logger
+	}
+)
+
+func New(srv *services.Container, log logger.Logger) IController {
+	return &controllers{srv: srv, log: log}
+}
+
+// stats swagger document
+// @Description Statistics of the houses and the characters: totals, soft deleted totals, houses per region and without a lord, characters per tv series and the creations and updates per time bucket
+// @Tags stats
+// @Accept json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	bucket	query	string	false	"time bucket of the creations and updates: day, week, month (default) or year"
+// @Param	since	query	string	false	"counts the creations and updates from this date in RFC 3339, every one when not informed"
+// @Success 200 {object} entities.Stats
+// @Failure 400 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /stats [get]
+func (ctrl *controllers) Stats(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.stats.stats")
+	defer span.End()
+
+	q, err := query(c)
+	if err != nil {
+		problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+		c.Problem(problem.Status, problem)
+		return
+	}
+
+	stats, err := ctrl.srv.Stats.Stats(ctx, q)
+	if err != nil {
+		ctrl.log.Error("Ctrl.Stats: ", "Error on stats: ", err)
+		problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+		c.Problem(problem.Status, problem)
+		return
+	}
+
+	c.Respond(http.StatusOK, stats)
+}
+
+// stats swagger document
+// @Description Statistics of the houses: totals, soft deleted total, houses per region and without a lord and the creations and updates per time bucket
+// @Tags stats
+// @Accept json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	bucket	query	string	false	"time bucket of the creations and updates: day, week, month (default) or year"
+// @Param	since	query	string	false	"counts the creations and updates from this date in RFC 3339, every one when not informed"
+// @Success 200 {object} entities.HouseStats
+// @Failure 400 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /stats/houses [get]
+func (ctrl *controllers) Houses(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.stats.houses")
+	defer span.End()
+
+	q, err := query(c)
+	if err != nil {
+		problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+		c.Problem(problem.Status, problem)
+		return
+	}
+
+	stats, err := ctrl.srv.Stats.Houses(ctx, q)
+	if err != nil {
+		ctrl.log.Error("Ctrl.Houses: ", "Error on houses stats: ", err)
+		problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+		c.Problem(problem.Status, problem)
+		return
+	}
+
+	c.Respond(http.StatusOK, stats)
+}
+
+// stats swagger document
+// @Description Statistics of the characters: totals, soft deleted total, characters per tv series and the creations and updates per time bucket
+// @Tags stats
+// @Accept json
+// @Produce json,text/csv,application/yaml,application/msgpack,application/problem+json
+// @Param	bucket	query	string	false	"time bucket of the creations and updates: day, week, month (default) or year"
+// @Param	since	query	string	false	"counts the creations and updates from this date in RFC 3339, every one when not informed"
+// @Success 200 {object} entities.CharacterStats
+// @Failure 400 {object} entities.HttpErr
+// @Failure 406 {object} entities.HttpErr
+// @Failure 500 {object} entities.HttpErr
+// @Failure 503 {object} entities.HttpErr
+// @Security ApiKeyAuth
+// @Router /stats/characters [get]
+func (ctrl *controllers) Characters(c httpRouter.Context) {
+	ctx, span := tracer.Span(c.Context(), "controllers.stats.characters")
+	defer span.End()
+
+	q, err := query(c)
+	if err != nil {
+		problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+		c.Problem(problem.Status, problem)
+		return
+	}
+
+	stats, err := ctrl.srv.Stats.Characters(ctx, q)
+	if err != nil {
+		ctrl.log.Error("Ctrl.Characters: ", "Error on characters stats: ", err)
+		problem := entities.NewProblem(ctx, c.GetRequestReader().URL.Path, err)
+		c.Problem(problem.Status, problem)
+		return
+	}
+
+	c.Respond(http.StatusOK, stats)
+}
+
+// query reads the time bucket and its start of the query parameters.
+func query(c httpRouter.Context) (q entities.StatsQuery, err error) {
+	q.Bucket = c.GetQuery("bucket")
+	if q.Bucket != "" && !entities.ValidStatsBucket(q.Bucket) {
+		return q, entities.ErrStatsBucket
+	}
+
+	if raw := c.GetQuery("since"); len(raw) > 0 {
+		q.Since, err = time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return q, entities.ErrStatsSince
+		}
+	}
+
+	return q, nil
+}
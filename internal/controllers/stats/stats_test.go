@@ -0,0 +1,109 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/stats"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Stats(t *testing.T) {
+	endpoint := "/stats"
+	since := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		inputPath    string
+		expectedCode int
+		expectedData string
+		prepareMock  func(mock *stats.MockIService)
+	}{
+		"Should return success": {
+			inputPath:    "?bucket=week&since=2023-01-01T00:00:00Z",
+			expectedCode: http.StatusOK,
+			expectedData: `{"houses":{"total":2,"deleted":1,"without_lord":1,"by_region":[{"key":"north","count":2}],"timeline":[{"start":"2023-01-02T00:00:00Z","created":3,"updated":1}]},"characters":{"total":1,"deleted":0,"by_tv_series":[{"key":"Season 1","count":1}],"timeline":[]}}`,
+			prepareMock: func(mock *stats.MockIService) {
+				mock.EXPECT().
+					Stats(gomock.Any(), entities.StatsQuery{Bucket: entities.StatsWeek, Since: since}).
+					Times(1).
+					Return(entities.Stats{
+						Houses: entities.HouseStats{
+							Total: 2, Deleted: 1, WithoutLord: 1,
+							ByRegion: []entities.StatsCount{{Key: "north", Count: 2}},
+							Timeline: []entities.StatsTime{{Start: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), Created: 3, Updated: 1}},
+						},
+						Characters: entities.CharacterStats{
+							Total:      1,
+							ByTVSeries: []entities.StatsCount{{Key: "Season 1", Count: 1}},
+							Timeline:   []entities.StatsTime{},
+						},
+					}, nil)
+			},
+		},
+		"Should return error bucket": {
+			inputPath:    "?bucket=hour",
+			expectedCode: http.StatusBadRequest,
+			expectedData: `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"bucket must be day, week, month or year","instance":"/stats"}`,
+			prepareMock:  func(mock *stats.MockIService) {},
+		},
+		"Should return error since": {
+			inputPath:    "?since=yesterday",
+			expectedCode: http.StatusBadRequest,
+			expectedData: `{"type":"/problems/invalid-request","title":"Bad Request","status":400,"detail":"since must be a date in RFC 3339, e.g. 2023-01-02T15:04:05Z","instance":"/stats"}`,
+			prepareMock:  func(mock *stats.MockIService) {},
+		},
+		"Should return error service": {
+			expectedCode: http.StatusInternalServerError,
+			expectedData: `{"type":"/problems/internal","title":"Internal Server Error","status":500,"detail":"problem to count houses","instance":"/stats"}`,
+			prepareMock: func(mock *stats.MockIService) {
+				mock.EXPECT().
+					Stats(gomock.Any(), entities.StatsQuery{}).
+					Times(1).
+					Return(entities.Stats{}, errors.New("problem to count houses"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ TEST CONTROLLER ============
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			// ============ MOCKS ============
+			mock := stats.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			// ============ START CONTROLLER ============
+			ctr := New(
+				&services.Container{Stats: mock},
+				logger.NewLogrusLogger(),
+			)
+
+			// ============ START ROUTER ============
+			router := httpRouter.NewGinRouter()
+			router.Get(endpoint, ctr.Stats)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodGet, endpoint+cs.inputPath, nil).WithContext(ctx)
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			responseData, _ := ioutil.ReadAll(writer.Body)
+			assert.Equal(t, cs.expectedData, string(responseData))
+			assert.Equal(t, cs.expectedCode, writer.Code)
+		})
+	}
+}
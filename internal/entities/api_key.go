@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// The scopes of a key, each gives the key a role of the routes: read the
+// reader, write the editor and admin the admin.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+	ScopeAdmin = "admin"
+)
+
+// APIKeyPrefix starts every key, so a leaked one is easy to recognize.
+const APIKeyPrefix = "got_"
+
+// apiKeyShown is how much of a key is kept in clear to tell the keys apart.
+const apiKeyShown = len(APIKeyPrefix) + 6
+
+var ErrAPIKeyExpiry = NewHttpErr(http.StatusBadRequest, "expires_at must be in the future", nil)
+
+type (
+	APIKeyRequest struct {
+		Name      string         `json:"name" validate:"required,max=100"`
+		Scopes    pq.StringArray `json:"scopes" validate:"required,min=1,dive,oneof=read write admin" swaggertype:"array,string"`
+		ExpiresAt *time.Time     `json:"expires_at"`
+	}
+
+	// APIKey is a key as stored, only the hash of the secret is kept. The
+	// scopes are cumulative: write also reads and admin also writes.
+	APIKey struct {
+		ID        string         `db:"id" json:"id"`
+		Name      string         `db:"name" json:"name"`
+		Prefix    string         `db:"prefix" json:"prefix"`
+		Hash      string         `db:"key_hash" json:"-"`
+		Scopes    pq.StringArray `db:"scopes" json:"scopes" swaggertype:"array,string"`
+		CreatedAt time.Time      `db:"created_at" json:"created_at"`
+		ExpiresAt *time.Time     `db:"expires_at" json:"expires_at"`
+		RevokedAt *time.Time     `db:"revoked_at" json:"revoked_at"`
+	}
+
+	// CreatedAPIKey answers the creation of a key, the only time its secret is
+	// shown.
+	CreatedAPIKey struct {
+		APIKey
+		Key string `json:"key"`
+	}
+)
+
+// NewAPIKey generates the secret of a key from the request, returning the
+// key to store and the secret to hand out.
+func (r APIKeyRequest) NewAPIKey(ctx context.Context) (key APIKey, secret string, err error) {
+	_, span := tracer.Span(ctx, "entities.apikey.new")
+	defer span.End()
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return key, "", err
+	}
+	secret = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	key = APIKey{
+		ID:        uuid.NewString(),
+		Name:      r.Name,
+		Prefix:    secret[:apiKeyShown],
+		Hash:      HashAPIKey(secret),
+		Scopes:    r.Scopes,
+		CreatedAt: time.Now(),
+		ExpiresAt: r.ExpiresAt,
+	}
+	return key, secret, nil
+}
+
+// HashAPIKey is the hash a key is stored and looked up by.
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Active reports whether the key authenticates requests at now.
+func (k APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
@@ -0,0 +1,139 @@
+package entities
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/google/uuid"
+)
+
+const (
+	AuditHouse     = "house"
+	AuditCharacter = "character"
+
+	AuditCreate        = "create"
+	AuditUpdate        = "update"
+	AuditDelete        = "delete"
+	AuditRestore       = "restore"
+	AuditRemoveLord    = "remove_lord"
+	AuditReinstateLord = "reinstate_lord"
+)
+
+type (
+	AuditEntry struct {
+		ID        string       `db:"id" json:"id"`
+		Entity    string       `db:"entity" json:"entity"`
+		EntityID  string       `db:"entity_id" json:"entity_id"`
+		Action    string       `db:"action" json:"action"`
+		Actor     string       `db:"actor" json:"actor"`
+		RequestID string       `db:"request_id" json:"request_id"`
+		TraceID   string       `db:"trace_id" json:"trace_id"`
+		Changes   AuditChanges `db:"changes" json:"changes"`
+		CreatedAt time.Time    `db:"created_at" json:"created_at"`
+	}
+
+	// AuditChanges maps the json name of each changed field to its values.
+	AuditChanges map[string]AuditChange
+
+	AuditChange struct {
+		Before any `json:"before"`
+		After  any `json:"after"`
+	}
+
+	// AuditMetadata identifies who made a request, it travels in the context
+	// down to the services that write the audit entries.
+	AuditMetadata struct {
+		Actor     string
+		RequestID string
+	}
+
+	auditMetadataKey struct{}
+)
+
+// NewAuditEntry describes a change of one entity, taking the actor and ids
+// from ctx. before is nil on creation.
+func NewAuditEntry(ctx context.Context, entity, entityID, action string, before, after any) AuditEntry {
+	_, span := tracer.Span(ctx, "entities.audit.newauditentry")
+	defer span.End()
+
+	metadata := AuditMetadataFromContext(ctx)
+
+	return AuditEntry{
+		ID:        uuid.NewString(),
+		Entity:    entity,
+		EntityID:  entityID,
+		Action:    action,
+		Actor:     metadata.Actor,
+		RequestID: metadata.RequestID,
+		TraceID:   tracer.TraceID(ctx),
+		Changes:   NewAuditChanges(before, after),
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewAuditChanges compares the json representation of before and after,
+// keeping only the fields whose value differs.
+func NewAuditChanges(before, after any) AuditChanges {
+	beforeFields, afterFields := auditFields(before), auditFields(after)
+
+	changes := make(AuditChanges)
+	for field, value := range afterFields {
+		if !reflect.DeepEqual(beforeFields[field], value) {
+			changes[field] = AuditChange{Before: beforeFields[field], After: value}
+		}
+	}
+	for field, value := range beforeFields {
+		if _, ok := afterFields[field]; !ok && value != nil {
+			changes[field] = AuditChange{Before: value}
+		}
+	}
+
+	return changes
+}
+
+func auditFields(value any) map[string]any {
+	fields := make(map[string]any)
+	if value == nil {
+		return fields
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fields
+	}
+	json.Unmarshal(data, &fields)
+
+	return fields
+}
+
+func (ac AuditChanges) Value() (driver.Value, error) {
+	return json.Marshal(ac)
+}
+
+func (ac *AuditChanges) Scan(src any) error {
+	switch data := src.(type) {
+	case []byte:
+		return json.Unmarshal(data, ac)
+	case string:
+		return json.Unmarshal([]byte(data), ac)
+	case nil:
+		*ac = nil
+		return nil
+	default:
+		return errors.New("audit changes must be json")
+	}
+}
+
+func ContextWithAuditMetadata(ctx context.Context, metadata AuditMetadata) context.Context {
+	return context.WithValue(ctx, auditMetadataKey{}, metadata)
+}
+
+func AuditMetadataFromContext(ctx context.Context) AuditMetadata {
+	metadata, _ := ctx.Value(auditMetadataKey{}).(AuditMetadata)
+	return metadata
+}
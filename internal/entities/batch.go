@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"net/http"
+	"strings"
+)
+
+// MaxBatchIDs is the largest number of ids fetched by one batch request,
+// keep it in sync with the "max" rule of BatchRequest.
+const MaxBatchIDs = 1000
+
+var ErrBatchIDs = NewHttpErr(http.StatusBadRequest, "ids must have from 1 to 1000 ids", nil)
+
+type (
+	// BatchRequest is the body of the batch-get endpoints, for lists of ids
+	// too long for the query string.
+	BatchRequest struct {
+		IDs []string `json:"ids" validate:"required,min=1,max=1000"`
+	}
+
+	// HouseBatch answers a fetch by ids: the houses found, in the order of
+	// the ids asked for, and the ids not found.
+	HouseBatch struct {
+		Houses  []House  `json:"houses"`
+		Missing []string `json:"missing"`
+	}
+
+	// CharacterBatch answers a fetch by ids: the characters found, in the
+	// order of the ids asked for, and the ids not found.
+	CharacterBatch struct {
+		Characters []Character `json:"characters"`
+		Missing    []string    `json:"missing"`
+	}
+)
+
+// ParseIDs reads the comma separated ids of the query parameter, see BatchIDs.
+func ParseIDs(raw string) ([]string, error) {
+	return BatchIDs(strings.Split(raw, ","))
+}
+
+// BatchIDs trims the ids and drops the blank and repeated ones, keeping the
+// order they were first asked for.
+func BatchIDs(ids []string) ([]string, error) {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+
+	if len(unique) == 0 || len(unique) > MaxBatchIDs {
+		return nil, ErrBatchIDs
+	}
+	return unique, nil
+}
+
+func NewHouseBatch(ids []string, houses []House) HouseBatch {
+	found, missing := arrange(ids, houses, func(h House) string { return h.ID })
+	return HouseBatch{Houses: found, Missing: missing}
+}
+
+func NewCharacterBatch(ids []string, characters []Character) CharacterBatch {
+	found, missing := arrange(ids, characters, func(c Character) string { return c.ID })
+	return CharacterBatch{Characters: found, Missing: missing}
+}
+
+// arrange puts the items in the order of ids, which the database doesn't
+// keep, and lists the ids without an item.
+func arrange[T any](ids []string, items []T, id func(T) string) (found []T, missing []string) {
+	byID := make(map[string]T, len(items))
+	for _, item := range items {
+		byID[id(item)] = item
+	}
+
+	found, missing = make([]T, 0, len(items)), make([]string, 0)
+	for _, i := range ids {
+		item, ok := byID[i]
+		if !ok {
+			missing = append(missing, i)
+			continue
+		}
+		found = append(found, item)
+	}
+	return found, missing
+}
@@ -0,0 +1,79 @@
+package entities
+
+import "errors"
+
+// MaxBulkItems is the largest number of items accepted by one bulk request,
+// keep it in sync with the "max" rule of the bulk request types.
+const MaxBulkItems = 100
+
+// The actions an item can take, reported by the imports.
+const (
+	BulkCreated = "created"
+	BulkUpdated = "updated"
+)
+
+var (
+	ErrBulkInvalidItem = NewDomainErr(ErrValidation, "item is invalid", nil)
+	ErrBulkRolledBack  = errors.New("item was not applied because the batch was rolled back")
+)
+
+type (
+	HouseBulkItem struct {
+		ID string `json:"id,omitempty"`
+		HouseRequest
+	}
+
+	HouseBulkRequest struct {
+		Items []HouseBulkItem `json:"items" validate:"required,min=1,max=100"`
+	}
+
+	CharacterBulkItem struct {
+		ID string `json:"id,omitempty"`
+		CharacterRequest
+	}
+
+	CharacterBulkRequest struct {
+		Items []CharacterBulkItem `json:"items" validate:"required,min=1,max=100"`
+	}
+
+	BulkResult struct {
+		Index  int    `json:"index"`
+		Status int    `json:"status"`
+		ID     string `json:"id,omitempty"`
+		Action string `json:"action,omitempty"`
+		Error  string `json:"error,omitempty"`
+		Detail any    `json:"detail,omitempty" swaggerignore:"true"`
+		Err    error  `json:"-"`
+	}
+
+	BulkResponse struct {
+		Atomic    bool         `json:"atomic"`
+		Succeeded int          `json:"succeeded"`
+		Failed    int          `json:"failed"`
+		Results   []BulkResult `json:"results"`
+	}
+)
+
+// NewBulkResponse summarizes the per-item results of a bulk request.
+func NewBulkResponse(atomic bool, results []BulkResult) BulkResponse {
+	resp := BulkResponse{Atomic: atomic, Results: results}
+	for _, result := range results {
+		if result.Err != nil {
+			resp.Failed++
+			continue
+		}
+		resp.Succeeded++
+	}
+	return resp
+}
+
+// Rollback flags every item that succeeded as not applied, used when an
+// atomic batch is aborted.
+func Rollback(results []BulkResult) {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].ID = ""
+			results[i].Err = ErrBulkRolledBack
+		}
+	}
+}
@@ -17,6 +17,12 @@ type (
 		TVSeries  pq.StringArray `db:"tv_series" json:"tv_series"`
 		CreatedAt time.Time      `db:"created_at" json:"created_at"`
 		UpdatedAt *time.Time     `db:"updated_at" json:"updated_at"`
+		DeletedAt *time.Time     `db:"deleted_at" json:"deleted_at,omitempty"`
+	}
+
+	CharacterRestored struct {
+		Character
+		ReinstatedHouses []string `json:"reinstated_houses"`
 	}
 
 	CharacterRequest struct {
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Kinds of DomainErr, compare with errors.Is.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrConflict    = errors.New("conflict")
+	ErrValidation  = errors.New("validation failed")
+	ErrUnavailable = errors.New("unavailable")
+)
+
+// DomainErr is a failure of the domain. Message is safe to show to clients,
+// Kind classifies it (nil for unexpected failures) and Err keeps its cause.
+type DomainErr struct {
+	Kind    error
+	Message string
+	Err     error
+}
+
+func NewDomainErr(kind error, message string, cause error) *DomainErr {
+	return &DomainErr{Kind: kind, Message: message, Err: cause}
+}
+
+func (e *DomainErr) Error() string {
+	return e.Message
+}
+
+func (e *DomainErr) Unwrap() []error {
+	errs := make([]error, 0, 2)
+	if e.Kind != nil {
+		errs = append(errs, e.Kind)
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// Is matches errors of the same kind and message, so a sentinel still matches
+// once Wrap gave it a cause.
+func (e *DomainErr) Is(target error) bool {
+	t, ok := target.(*DomainErr)
+	return ok && t.Kind == e.Kind && t.Message == e.Message
+}
+
+// Wrap returns a copy of e caused by err.
+func (e *DomainErr) Wrap(err error) error {
+	return &DomainErr{Kind: e.Kind, Message: e.Message, Err: err}
+}
+
+// StatusCode is the http status of err, following its chain until an error
+// of a known kind is found.
+func StatusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrValidation):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
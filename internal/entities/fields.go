@@ -0,0 +1,205 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+type (
+	// member is a field of a json object, kept in the order it was written.
+	member struct {
+		Key   string
+		Value json.RawMessage
+	}
+
+	// sparse is a json object left with the fields a client asked for.
+	sparse []member
+)
+
+// ParseFields reads the comma separated json names of the fields query
+// parameter, each one must be a field of resource. An empty parameter selects
+// every field.
+func ParseFields(raw string, resource any) ([]string, error) {
+	if len(strings.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+
+	known := map[string]bool{}
+	for _, field := range fieldsOf(resource) {
+		known[field.json] = true
+	}
+
+	var fields, unknown []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if !known[name] {
+			unknown = append(unknown, name)
+			continue
+		}
+		if !seen[name] {
+			seen[name] = true
+			fields = append(fields, name)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return nil, NewHttpErr(http.StatusBadRequest, "fields has unknown fields: "+strings.Join(unknown, ","), nil)
+	}
+	return fields, nil
+}
+
+// Columns lists the columns of resource to select for fields, every column
+// when fields is empty. The id is always read, it identifies the row and links
+// it in the hypermedia representations.
+func Columns(fields []string, resource any) string {
+	wanted := map[string]bool{"id": true}
+	for _, field := range fields {
+		wanted[field] = true
+	}
+
+	var columns []string
+	for _, field := range fieldsOf(resource) {
+		if len(fields) == 0 || wanted[field.json] {
+			columns = append(columns, field.db)
+		}
+	}
+	return strings.Join(columns, ", ")
+}
+
+type field struct {
+	json string
+	db   string
+}
+
+// fieldsOf returns the fields of resource read from the database, by their
+// json and db names.
+func fieldsOf(resource any) []field {
+	t := reflect.TypeOf(resource)
+	fields := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		db := t.Field(i).Tag.Get("db")
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if db == "" || db == "-" || name == "" || name == "-" {
+			continue
+		}
+		fields = append(fields, field{json: name, db: db})
+	}
+	return fields
+}
+
+// Project leaves only fields in the resources of v, a resource or a list of
+// them, plain or hypermedia. The members of the hypermedia documents, e.g.
+// _links or @id, and their collections are kept. Empty fields keep v as is.
+func Project(v any, fields []string) any {
+	if len(fields) == 0 {
+		return v
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+
+	keep := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		keep[field] = true
+	}
+
+	return project(raw, keep)
+}
+
+func project(raw json.RawMessage, keep map[string]bool) json.RawMessage {
+	switch trimmed := bytes.TrimSpace(raw); {
+	case len(trimmed) > 0 && trimmed[0] == '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return raw
+		}
+		for i := range items {
+			items[i] = project(items[i], keep)
+		}
+		b, _ := json.Marshal(items)
+		return b
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		object, err := members(raw)
+		if err != nil {
+			return raw
+		}
+		b, _ := json.Marshal(projectObject(object, keep))
+		return b
+	}
+	return raw
+}
+
+// projectObject trims a resource, the collections of the hypermedia
+// representations keep their members and have their items trimmed.
+func projectObject(object sparse, keep map[string]bool) sparse {
+	collection := false
+	for _, m := range object {
+		collection = collection || m.Key == "_embedded" || m.Key == "itemListElement"
+	}
+
+	projected := make(sparse, 0, len(object))
+	for _, m := range object {
+		switch {
+		case collection && m.Key == "_embedded":
+			embedded, err := members(m.Value)
+			if err != nil {
+				break
+			}
+			for i := range embedded {
+				embedded[i].Value = project(embedded[i].Value, keep)
+			}
+			m.Value, _ = json.Marshal(embedded)
+		case collection && m.Key == "itemListElement":
+			m.Value = project(m.Value, keep)
+		case collection, keep[m.Key], strings.HasPrefix(m.Key, "_"), strings.HasPrefix(m.Key, "@"):
+		default:
+			continue
+		}
+		projected = append(projected, m)
+	}
+	return projected
+}
+
+// members decodes a json object keeping the order of its fields.
+func members(raw json.RawMessage) (sparse, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	object := sparse{}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		object = append(object, member{Key: key.(string), Value: value})
+	}
+	return object, nil
+}
+
+func (s sparse) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range s {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(m.Key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(m.Value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
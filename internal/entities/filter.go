@@ -0,0 +1,13 @@
+package entities
+
+type (
+	// Filter narrows the records returned by the find methods. Limit and
+	// Offset page the lists, a zero Limit returns every record. Fields are the
+	// json names of the fields to read, none reads them all.
+	Filter struct {
+		IncludeDeleted bool
+		Limit          int
+		Offset         int
+		Fields         []string
+	}
+)
@@ -0,0 +1,19 @@
+package entities
+
+type (
+	// GraphQLRequest is the body of a GraphQL query sent over http. The query
+	// may be left out when the hash of a persisted query is informed.
+	GraphQLRequest struct {
+		Query         string            `json:"query"`
+		OperationName string            `json:"operationName"`
+		Variables     map[string]any    `json:"variables"`
+		Extensions    GraphQLExtensions `json:"extensions"`
+	}
+	GraphQLExtensions struct {
+		PersistedQuery *PersistedQuery `json:"persistedQuery,omitempty"`
+	}
+	PersistedQuery struct {
+		Version    int    `json:"version"`
+		Sha256Hash string `json:"sha256Hash"`
+	}
+)
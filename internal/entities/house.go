@@ -2,10 +2,15 @@ package entities
 
 import (
 	"context"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
 	"github.com/google/uuid"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 type (
@@ -17,6 +22,7 @@ type (
 		CurrentLord    string     `db:"current_lord" json:"current_lord"`
 		CreatedAt      time.Time  `db:"created_at" json:"created_at"`
 		UpdatedAt      *time.Time `db:"updated_at" json:"updated_at"`
+		DeletedAt      *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
 	}
 
 	HouseRequest struct {
@@ -61,3 +67,15 @@ func (h *House) PreUpdate(ctx context.Context, house HouseRequest) {
 	now := time.Now()
 	h.UpdatedAt = &now
 }
+
+// HouseNameKey is the key two house names are told apart by, the one of the
+// house_name_key function of the database: without case, accents or the
+// spaces around and between the words.
+func HouseNameKey(name string) string {
+	unaccent := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	key, _, err := transform.String(unaccent, strings.ToLower(name))
+	if err != nil {
+		key = strings.ToLower(name)
+	}
+	return strings.Join(strings.Fields(key), " ")
+}
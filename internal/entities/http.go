@@ -1,32 +1,85 @@
 package entities
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
 	"github.com/goccy/go-json"
 )
 
 var (
-	ErrDecode = NewHttpErr(http.StatusBadRequest, "problem to decode your input", nil)
+	ErrDecode      = NewHttpErr(http.StatusBadRequest, "problem to decode your input", nil)
+	ErrInvalidPage = NewHttpErr(http.StatusBadRequest, "limit and offset must be positive integers", nil)
+	// ErrIncludeDeleted answers the include_deleted of a principal that isn't an admin.
+	ErrIncludeDeleted = NewHttpErr(http.StatusForbidden, "only the admins can include the deleted records", nil)
 )
 
+// problemTypes identifies the kind of problem of each status, the ones not
+// listed are described by their status alone.
+var problemTypes = map[int]string{
+	http.StatusBadRequest:          "/problems/invalid-request",
+	http.StatusUnauthorized:        "/problems/unauthorized",
+	http.StatusForbidden:           "/problems/forbidden",
+	http.StatusNotFound:            "/problems/not-found",
+	http.StatusNotAcceptable:       "/problems/not-acceptable",
+	http.StatusConflict:            "/problems/conflict",
+	http.StatusUnprocessableEntity: "/problems/validation",
+	http.StatusTooManyRequests:     "/problems/too-many-requests",
+	http.StatusInternalServerError: "/problems/internal",
+	http.StatusServiceUnavailable:  "/problems/unavailable",
+}
+
+// HttpErr is the body of every error response, a problem details object as
+// defined by RFC 7807. TraceID and Violations are extension members, the latter
+// lists the fields of the payload that failed the validation.
 type HttpErr struct {
-	HTTPCode int    `mapstructure:"code" json:"http_code,omitempty"`
-	Message  string `mapstructure:"message" json:"message,omitempty"`
-	Detail   any    `mapstructure:"detail,omitempty" swaggerignore:"true" json:"detail,omitempty"`
+	Type       string `json:"type"`
+	Title      string `json:"title"`
+	Status     int    `json:"status"`
+	Detail     string `json:"detail,omitempty"`
+	Instance   string `json:"instance,omitempty"`
+	TraceID    string `json:"trace_id,omitempty"`
+	Violations any    `json:"violations,omitempty" swaggertype:"array,object"`
 }
 
 func (e *HttpErr) Error() string {
-	return fmt.Sprintf("code: %v - message: %v - detail: %v", e.HTTPCode, e.Message, string(toJSON(e.Detail)))
+	return fmt.Sprintf("code: %v - message: %v - detail: %v", e.Status, e.Detail, string(toJSON(e.Violations)))
 }
 
-func NewHttpErr(httpCode int, message string, detail any) error {
+func NewHttpErr(httpCode int, message string, violations any) error {
+	problemType, ok := problemTypes[httpCode]
+	if !ok {
+		problemType = "about:blank"
+	}
+
 	return &HttpErr{
-		HTTPCode: httpCode,
-		Message:  message,
-		Detail:   detail,
+		Type:       problemType,
+		Title:      http.StatusText(httpCode),
+		Status:     httpCode,
+		Detail:     message,
+		Violations: violations,
+	}
+}
+
+// NewProblem converts err into the problem answered to the request of the
+// instance path. Errors that aren't an HttpErr take the status of their kind.
+func NewProblem(ctx context.Context, instance string, err error) *HttpErr {
+	var problem HttpErr
+
+	var httpErr *HttpErr
+	if errors.As(err, &httpErr) {
+		problem = *httpErr
+	} else {
+		problem = *NewHttpErr(StatusCode(err), err.Error(), nil).(*HttpErr)
 	}
+
+	problem.Instance = instance
+	problem.TraceID = tracer.TraceID(ctx)
+
+	return &problem
 }
 
 func toJSON(v any) []byte {
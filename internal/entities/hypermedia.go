@@ -0,0 +1,233 @@
+package entities
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// The hypermedia media types of houses and characters, offered next to the
+// formats httpRouter encodes.
+const (
+	HALContentType    = "application/hal+json"
+	JSONLDContentType = "application/ld+json"
+)
+
+// schemaOrg is the vocabulary of the JSON-LD documents. The contexts below map
+// the json fields to its terms, so the documents keep the shape of the plain
+// JSON responses.
+const schemaOrg = "https://schema.org/"
+
+var (
+	houseContext = map[string]any{
+		"@vocab":          schemaOrg,
+		"id":              "identifier",
+		"region":          "areaServed",
+		"foundation_year": "foundingDate",
+		"current_lord":    map[string]any{"@id": "member", "@type": "@id"},
+		"created_at":      "dateCreated",
+		"updated_at":      "dateModified",
+	}
+	characterContext = map[string]any{
+		"@vocab":     schemaOrg,
+		"id":         "identifier",
+		"tv_series":  "subjectOf",
+		"created_at": "dateCreated",
+		"updated_at": "dateModified",
+	}
+)
+
+type (
+	Link struct {
+		Href string `json:"href"`
+	}
+
+	// HALLinks are the relations of a HAL resource, the ones that don't apply
+	// are left out.
+	HALLinks struct {
+		Self        Link   `json:"self"`
+		CurrentLord *Link  `json:"current_lord,omitempty"`
+		Members     []Link `json:"members,omitempty"`
+		Next        *Link  `json:"next,omitempty"`
+		Prev        *Link  `json:"prev,omitempty"`
+	}
+
+	HALHouse struct {
+		House
+		Links HALLinks `json:"_links"`
+	}
+
+	HALCharacter struct {
+		Character
+		Links HALLinks `json:"_links"`
+	}
+
+	// HALCollection is a page of a list, its members link to the items
+	// embedded under the name of the resource.
+	HALCollection struct {
+		Links    HALLinks       `json:"_links"`
+		Count    int            `json:"count"`
+		Embedded map[string]any `json:"_embedded"`
+	}
+
+	LDHouse struct {
+		Context any    `json:"@context,omitempty"`
+		ID      string `json:"@id"`
+		Type    string `json:"@type"`
+		House
+	}
+
+	LDCharacter struct {
+		Context any    `json:"@context,omitempty"`
+		ID      string `json:"@id"`
+		Type    string `json:"@type"`
+		Character
+	}
+
+	// LDCollection is a page of a list as a schema.org ItemList, its context
+	// also applies to the items.
+	LDCollection struct {
+		Context       any    `json:"@context"`
+		ID            string `json:"@id"`
+		Type          string `json:"@type"`
+		NumberOfItems int    `json:"numberOfItems"`
+		Items         any    `json:"itemListElement"`
+	}
+
+	// Hypermedia builds the links of the resources served under base, the
+	// prefix of the version of the API the request was sent to.
+	Hypermedia struct {
+		base string
+	}
+)
+
+// NewHypermedia returns the builder of the links for a request to path, a
+// route of resource.
+func NewHypermedia(path, resource string) Hypermedia {
+	base := ""
+	if i := strings.Index(path, "/"+resource); i >= 0 {
+		base = path[:i]
+	}
+	return Hypermedia{base: base}
+}
+
+func (h Hypermedia) HouseURL(id string) string {
+	return h.base + "/houses/" + id
+}
+
+func (h Hypermedia) CharacterURL(id string) string {
+	return h.base + "/characters/" + id
+}
+
+func (h Hypermedia) HALHouse(house House) HALHouse {
+	links := HALLinks{Self: Link{Href: h.HouseURL(house.ID)}}
+	if len(house.CurrentLord) > 0 {
+		links.CurrentLord = &Link{Href: h.CharacterURL(house.CurrentLord)}
+	}
+	return HALHouse{House: house, Links: links}
+}
+
+func (h Hypermedia) HALCharacter(character Character) HALCharacter {
+	return HALCharacter{Character: character, Links: HALLinks{Self: Link{Href: h.CharacterURL(character.ID)}}}
+}
+
+func (h Hypermedia) HALHouses(houses []House, query url.Values, filter Filter) HALCollection {
+	items := make([]HALHouse, len(houses))
+	members := make([]Link, len(houses))
+	for i, house := range houses {
+		items[i] = h.HALHouse(house)
+		members[i] = items[i].Links.Self
+	}
+
+	return HALCollection{
+		Links:    h.page(h.base+"/houses", query, filter, len(houses), members),
+		Count:    len(houses),
+		Embedded: map[string]any{"houses": items},
+	}
+}
+
+func (h Hypermedia) HALCharacters(characters []Character, query url.Values, filter Filter) HALCollection {
+	items := make([]HALCharacter, len(characters))
+	members := make([]Link, len(characters))
+	for i, character := range characters {
+		items[i] = h.HALCharacter(character)
+		members[i] = items[i].Links.Self
+	}
+
+	return HALCollection{
+		Links:    h.page(h.base+"/characters", query, filter, len(characters), members),
+		Count:    len(characters),
+		Embedded: map[string]any{"characters": items},
+	}
+}
+
+// LDHouse describes house as a schema.org Organization, its lord is linked
+// by the url of the character.
+func (h Hypermedia) LDHouse(house House) LDHouse {
+	if len(house.CurrentLord) > 0 {
+		house.CurrentLord = h.CharacterURL(house.CurrentLord)
+	}
+	return LDHouse{Context: houseContext, ID: h.HouseURL(house.ID), Type: "Organization", House: house}
+}
+
+// LDCharacter describes character as a schema.org Person.
+func (h Hypermedia) LDCharacter(character Character) LDCharacter {
+	return LDCharacter{Context: characterContext, ID: h.CharacterURL(character.ID), Type: "Person", Character: character}
+}
+
+func (h Hypermedia) LDHouses(houses []House, query url.Values) LDCollection {
+	items := make([]LDHouse, len(houses))
+	for i, house := range houses {
+		items[i] = h.LDHouse(house)
+		items[i].Context = nil
+	}
+	return LDCollection{Context: houseContext, ID: withQuery(h.base+"/houses", query), Type: "ItemList", NumberOfItems: len(houses), Items: items}
+}
+
+func (h Hypermedia) LDCharacters(characters []Character, query url.Values) LDCollection {
+	items := make([]LDCharacter, len(characters))
+	for i, character := range characters {
+		items[i] = h.LDCharacter(character)
+		items[i].Context = nil
+	}
+	return LDCollection{Context: characterContext, ID: withQuery(h.base+"/characters", query), Type: "ItemList", NumberOfItems: len(characters), Items: items}
+}
+
+// page links a page of the list at path. Only the lists with a limit have
+// pages: next is linked while a page comes full, prev while the offset isn't
+// at the start.
+func (h Hypermedia) page(path string, query url.Values, filter Filter, count int, members []Link) HALLinks {
+	links := HALLinks{Self: Link{Href: withQuery(path, query)}, Members: members}
+	if filter.Limit <= 0 {
+		return links
+	}
+
+	if count == filter.Limit {
+		links.Next = &Link{Href: withOffset(path, query, filter.Offset+filter.Limit)}
+	}
+	if filter.Offset > 0 {
+		prev := filter.Offset - filter.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		links.Prev = &Link{Href: withOffset(path, query, prev)}
+	}
+
+	return links
+}
+
+func withOffset(path string, query url.Values, offset int) string {
+	page := url.Values{}
+	for key, values := range query {
+		page[key] = values
+	}
+	page.Set("offset", strconv.Itoa(offset))
+	return withQuery(path, page)
+}
+
+func withQuery(path string, query url.Values) string {
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"context"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+type (
+	IdempotencyKey struct {
+		Key         string    `db:"key"`
+		Scope       string    `db:"scope"`
+		RequestHash string    `db:"request_hash"`
+		StatusCode  int       `db:"status_code"`
+		Response    []byte    `db:"response"`
+		ContentType string    `db:"content_type"`
+		CreatedAt   time.Time `db:"created_at"`
+		ExpiresAt   time.Time `db:"expires_at"`
+		// LockedUntil is the lease of the request running with the key, a
+		// retry takes the key over once it passed.
+		LockedUntil time.Time `db:"locked_until"`
+	}
+)
+
+func (ik *IdempotencyKey) PreSave(ctx context.Context, ttl, lease time.Duration) {
+	_, span := tracer.Span(ctx, "entities.idempotency.presave")
+	defer span.End()
+
+	ik.CreatedAt = time.Now()
+	ik.ExpiresAt = ik.CreatedAt.Add(ttl)
+	ik.LockedUntil = ik.CreatedAt.Add(lease)
+}
+
+// Completed reports whether the response of the first request was already stored.
+func (ik *IdempotencyKey) Completed() bool {
+	return ik.StatusCode != 0
+}
@@ -0,0 +1,75 @@
+package entities
+
+import (
+	"errors"
+	"net/http"
+)
+
+// The modes of an import. Only houses, whose names are unique, can be
+// upserted by name.
+const (
+	ImportInsert       = "insert"
+	ImportUpsertByName = "upsert-by-name"
+)
+
+// MaxImportRows is the largest number of rows accepted by one import and
+// MaxImportSize the largest body, in bytes, read from its request.
+const (
+	MaxImportRows = 1000
+	MaxImportSize = 16 << 20
+)
+
+var (
+	ErrImportMode     = NewHttpErr(http.StatusBadRequest, "mode must be insert or upsert-by-name", nil)
+	ErrImportFormat   = NewHttpErr(http.StatusUnsupportedMediaType, "the file must be CSV or JSON", nil)
+	ErrImportMapping  = NewHttpErr(http.StatusBadRequest, "mapping must list source:field pairs of known fields", nil)
+	ErrImportEmpty    = NewHttpErr(http.StatusBadRequest, "the file has no rows", nil)
+	ErrImportTooLarge = NewHttpErr(http.StatusRequestEntityTooLarge, "the file has more than 1000 rows", nil)
+	ErrImportSize     = NewHttpErr(http.StatusRequestEntityTooLarge, "the request body is larger than 16MB", nil)
+)
+
+type (
+	ImportOptions struct {
+		Mode   string
+		DryRun bool
+	}
+
+	// ImportReport is the result of each row of the file, in the shape of the
+	// results of a bulk request. The index is the position of the row after
+	// the header.
+	ImportReport struct {
+		DryRun    bool         `json:"dry_run"`
+		Mode      string       `json:"mode"`
+		Succeeded int          `json:"succeeded"`
+		Failed    int          `json:"failed"`
+		Rows      []BulkResult `json:"rows"`
+	}
+)
+
+// NewImportReport summarizes the results of the rows of an import, giving
+// each row the http status of its result.
+func NewImportReport(opts ImportOptions, rows []BulkResult) ImportReport {
+	for i := range rows {
+		switch {
+		case rows[i].Err == nil && rows[i].Action == BulkCreated:
+			rows[i].Status = http.StatusCreated
+		case rows[i].Err == nil:
+			rows[i].Status = http.StatusOK
+		case errors.Is(rows[i].Err, ErrBulkRolledBack):
+			rows[i].Status = http.StatusFailedDependency
+			rows[i].Error = rows[i].Err.Error()
+		default:
+			rows[i].Status = StatusCode(rows[i].Err)
+			rows[i].Error = rows[i].Err.Error()
+		}
+	}
+
+	bulk := NewBulkResponse(false, rows)
+	return ImportReport{
+		DryRun:    opts.DryRun,
+		Mode:      opts.Mode,
+		Succeeded: bulk.Succeeded,
+		Failed:    bulk.Failed,
+		Rows:      rows,
+	}
+}
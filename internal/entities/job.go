@@ -0,0 +1,148 @@
+package entities
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/google/uuid"
+)
+
+// The kinds of work a job runs.
+const (
+	JobPurge            = "purge"
+	JobExport           = "export"
+	JobImportHouses     = "import.houses"
+	JobImportCharacters = "import.characters"
+)
+
+// The statuses of a job. Queued and running jobs are pending, the others are
+// final.
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+	JobCanceled  = "canceled"
+)
+
+var (
+	ErrJobKind   = NewHttpErr(http.StatusBadRequest, "kind must be purge, export, import.houses or import.characters", nil)
+	ErrJobParams = NewHttpErr(http.StatusBadRequest, "params don't match the kind of the job", nil)
+	ErrJobRole   = NewHttpErr(http.StatusForbidden, "the credentials don't have the role of this kind of job", nil)
+)
+
+type (
+	// JobRequest submits a job, the params depend on its kind. SubmittedBy is
+	// the id of the principal of the request, never read from the body.
+	JobRequest struct {
+		Kind        string          `json:"kind" validate:"required"`
+		Params      json.RawMessage `json:"params,omitempty" swaggertype:"object"`
+		SubmittedBy string          `json:"-"`
+	}
+
+	// Job is a long running operation run in the background. Progress goes
+	// from 0 to 100 and Result holds what the work returned once it succeeded.
+	// Actor and RequestID come from the request that submitted it, the work
+	// runs on their behalf, and SubmittedBy is the id of its principal.
+	Job struct {
+		ID              string          `db:"id" json:"id"`
+		Kind            string          `db:"kind" json:"kind"`
+		Status          string          `db:"status" json:"status"`
+		Params          json.RawMessage `db:"params" json:"params" swaggertype:"object"`
+		Progress        int             `db:"progress" json:"progress"`
+		Result          json.RawMessage `db:"result" json:"result" swaggertype:"object"`
+		Error           string          `db:"error" json:"error,omitempty"`
+		CancelRequested bool            `db:"cancel_requested" json:"cancel_requested"`
+		Attempts        int             `db:"attempts" json:"attempts"`
+		CreatedAt       time.Time       `db:"created_at" json:"created_at"`
+		StartedAt       *time.Time      `db:"started_at" json:"started_at"`
+		HeartbeatAt     *time.Time      `db:"heartbeat_at" json:"-"`
+		FinishedAt      *time.Time      `db:"finished_at" json:"finished_at"`
+		Actor           string          `db:"actor" json:"actor,omitempty"`
+		RequestID       string          `db:"request_id" json:"request_id,omitempty"`
+		SubmittedBy     string          `db:"submitted_by" json:"submitted_by,omitempty"`
+	}
+
+	PurgeJobParams struct {
+		DryRun bool `json:"dry_run"`
+	}
+
+	// ExportJobParams export a snapshot to a file, downloaded on
+	// GET /jobs/:id/export.
+	ExportJobParams struct {
+		IncludeDeleted bool `json:"include_deleted"`
+	}
+
+	// ExportJobResult references the file of an export, the job only keeps
+	// its name and size.
+	ExportJobResult struct {
+		File    string `json:"file"`
+		Records int    `json:"records"`
+		Bytes   int64  `json:"bytes"`
+	}
+
+	HouseImportJobParams struct {
+		Mode   string         `json:"mode" validate:"omitempty,oneof=insert upsert-by-name"`
+		DryRun bool           `json:"dry_run"`
+		Items  []HouseRequest `json:"items" validate:"required,min=1,max=1000,dive"`
+	}
+
+	CharacterImportJobParams struct {
+		Mode   string             `json:"mode" validate:"omitempty,oneof=insert"`
+		DryRun bool               `json:"dry_run"`
+		Items  []CharacterRequest `json:"items" validate:"required,min=1,max=1000,dive"`
+	}
+)
+
+// DecodeParams returns the params of the kind of the job, a value of one of
+// the job params types. Missing params take the zero value of each field.
+func (jr JobRequest) DecodeParams() (params any, err error) {
+	switch jr.Kind {
+	case JobPurge:
+		return decodeParams[PurgeJobParams](jr.Params)
+	case JobExport:
+		return decodeParams[ExportJobParams](jr.Params)
+	case JobImportHouses:
+		return decodeParams[HouseImportJobParams](jr.Params)
+	case JobImportCharacters:
+		return decodeParams[CharacterImportJobParams](jr.Params)
+	}
+	return nil, ErrJobKind
+}
+
+func decodeParams[T any](raw json.RawMessage) (params T, err error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return params, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&params); err != nil {
+		return params, ErrJobParams
+	}
+	return params, nil
+}
+
+func (j *Job) PreSave(ctx context.Context) {
+	_, span := tracer.Span(ctx, "entities.job.presave")
+	defer span.End()
+
+	j.ID = uuid.NewString()
+	j.Status = JobQueued
+	j.CreatedAt = time.Now()
+	if len(j.Params) == 0 {
+		j.Params = json.RawMessage(`{}`)
+	}
+	if len(j.Result) == 0 {
+		j.Result = json.RawMessage(`null`)
+	}
+}
+
+// Pending reports whether the job is still to run or running.
+func (j Job) Pending() bool {
+	return j.Status == JobQueued || j.Status == JobRunning
+}
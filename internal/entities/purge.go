@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+type (
+	PurgeReport struct {
+		DryRun   bool                `json:"dry_run"`
+		Entities []PurgeEntityReport `json:"entities"`
+	}
+
+	// PurgeEntityReport describes the rows of one table soft deleted before
+	// DeletedBefore. Purged is always zero on a dry run.
+	PurgeEntityReport struct {
+		Entity        string    `json:"entity"`
+		Retention     string    `json:"retention"`
+		DeletedBefore time.Time `json:"deleted_before"`
+		Candidates    int       `json:"candidates"`
+		Purged        int       `json:"purged"`
+		Batches       int       `json:"batches"`
+	}
+)
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// The types of the results of a search.
+const (
+	SearchHouse     = "house"
+	SearchCharacter = "character"
+)
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+var (
+	ErrSearchQuery = NewHttpErr(http.StatusBadRequest, "q must have a letter or a digit to search", nil)
+	ErrSearchTypes = NewHttpErr(http.StatusBadRequest, "types must list house or character", nil)
+	ErrSearchLimit = NewHttpErr(http.StatusBadRequest, "limit must be an integer from 1 to 100", nil)
+)
+
+type (
+	// SearchQuery is a search of the houses and characters of Types.
+	SearchQuery struct {
+		Text  string
+		Types []string
+		Limit int
+	}
+
+	// SearchResult is a house or a character found by a search, Highlight is
+	// the text it was found by with the matches inside <b></b>.
+	SearchResult struct {
+		Type      string  `db:"type" json:"type"`
+		ID        string  `db:"id" json:"id"`
+		Name      string  `db:"name" json:"name"`
+		Rank      float64 `db:"rank" json:"rank"`
+		Highlight string  `db:"highlight" json:"highlight"`
+	}
+)
+
+// Terms are the words of the text of the search, the rest is dropped so the
+// text can't change the meaning of the query.
+func (sq SearchQuery) Terms() []string {
+	return strings.FieldsFunc(sq.Text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"net/http"
+	"time"
+)
+
+// SnapshotVersion is the version of the schema of the snapshots, bump it when
+// the records change so older snapshots are refused instead of misread.
+const SnapshotVersion = 1
+
+const (
+	SnapshotVersionHeader = "X-Snapshot-Version"
+	NDJSONContentType     = "application/x-ndjson"
+)
+
+// The kinds of the records of a snapshot. The header opens it and the footer,
+// with the counts of the records, closes it so a cut snapshot is detected.
+const (
+	SnapshotHeader          = "header"
+	SnapshotCharacter       = "character"
+	SnapshotHouse           = "house"
+	SnapshotRemovedLordship = "removed_lordship"
+	SnapshotFooter          = "footer"
+)
+
+var (
+	ErrSnapshotVersion   = NewHttpErr(http.StatusBadRequest, "the snapshot has no header of a supported version", nil)
+	ErrSnapshotRecord    = NewHttpErr(http.StatusBadRequest, "the snapshot has a record of an unknown kind", nil)
+	ErrSnapshotTruncated = NewHttpErr(http.StatusBadRequest, "the snapshot ends before its footer or its counts don't match", nil)
+	ErrSnapshotNotEmpty  = NewDomainErr(ErrConflict, "the database has houses or characters, restore with replace=true to drop them", nil)
+)
+
+type (
+	// RemovedLordship is a house whose lord was deleted, kept to give the
+	// house back when the lord is restored.
+	RemovedLordship struct {
+		HouseID   string    `db:"house_id" json:"house_id"`
+		LordID    string    `db:"lord_id" json:"lord_id"`
+		RemovedAt time.Time `db:"removed_at" json:"removed_at"`
+	}
+
+	// SnapshotRecord is a line of a snapshot, the field named by its kind is set.
+	SnapshotRecord struct {
+		Kind            string           `json:"kind"`
+		Version         int              `json:"version,omitempty"`
+		CreatedAt       *time.Time       `json:"created_at,omitempty"`
+		IncludeDeleted  bool             `json:"include_deleted,omitempty"`
+		Character       *Character       `json:"character,omitempty"`
+		House           *House           `json:"house,omitempty"`
+		RemovedLordship *RemovedLordship `json:"removed_lordship,omitempty"`
+		Counts          *SnapshotCounts  `json:"counts,omitempty"`
+	}
+
+	SnapshotCounts struct {
+		Characters       int `db:"characters" json:"characters"`
+		Houses           int `db:"houses" json:"houses"`
+		RemovedLordships int `db:"removed_lordships" json:"removed_lordships"`
+	}
+
+	RestoreReport struct {
+		Replaced bool           `json:"replaced"`
+		Restored SnapshotCounts `json:"restored"`
+	}
+)
+
+// Add counts record when it holds a row.
+func (sc *SnapshotCounts) Add(record SnapshotRecord) {
+	switch record.Kind {
+	case SnapshotCharacter:
+		sc.Characters++
+	case SnapshotHouse:
+		sc.Houses++
+	case SnapshotRemovedLordship:
+		sc.RemovedLordships++
+	}
+}
+
+// Empty reports whether there is no row counted.
+func (sc SnapshotCounts) Empty() bool {
+	return sc.Characters == 0 && sc.Houses == 0 && sc.RemovedLordships == 0
+}
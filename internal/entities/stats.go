@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"net/http"
+	"time"
+)
+
+// The time buckets the creations and updates are counted in, units of the
+// date_trunc of the database.
+const (
+	StatsDay   = "day"
+	StatsWeek  = "week"
+	StatsMonth = "month"
+	StatsYear  = "year"
+)
+
+const DefaultStatsBucket = StatsMonth
+
+var (
+	ErrStatsBucket = NewHttpErr(http.StatusBadRequest, "bucket must be day, week, month or year", nil)
+	ErrStatsSince  = NewHttpErr(http.StatusBadRequest, "since must be a date in RFC 3339, e.g. 2023-01-02T15:04:05Z", nil)
+)
+
+type (
+	// StatsQuery counts the creations and updates in buckets from Since, a
+	// zero Since counts them all.
+	StatsQuery struct {
+		Bucket string
+		Since  time.Time
+	}
+
+	Stats struct {
+		Houses     HouseStats     `json:"houses"`
+		Characters CharacterStats `json:"characters"`
+	}
+
+	// HouseStats are the totals of the houses, the groups only count the
+	// live ones.
+	HouseStats struct {
+		Total       int          `db:"total" json:"total"`
+		Deleted     int          `db:"deleted" json:"deleted"`
+		WithoutLord int          `db:"without_lord" json:"without_lord"`
+		ByRegion    []StatsCount `json:"by_region"`
+		Timeline    []StatsTime  `json:"timeline"`
+	}
+
+	// CharacterStats are the totals of the characters, the groups only count
+	// the live ones.
+	CharacterStats struct {
+		Total      int          `db:"total" json:"total"`
+		Deleted    int          `db:"deleted" json:"deleted"`
+		ByTVSeries []StatsCount `json:"by_tv_series"`
+		Timeline   []StatsTime  `json:"timeline"`
+	}
+
+	StatsCount struct {
+		Key   string `db:"key" json:"key"`
+		Count int    `db:"count" json:"count"`
+	}
+
+	// StatsTime counts the rows created and updated in the bucket that
+	// begins at Start, deleted rows included.
+	StatsTime struct {
+		Start   time.Time `db:"start" json:"start"`
+		Created int       `db:"created" json:"created"`
+		Updated int       `db:"updated" json:"updated"`
+	}
+)
+
+// ValidStatsBucket reports whether bucket is one of the time buckets.
+func ValidStatsBucket(bucket string) bool {
+	switch bucket {
+	case StatsDay, StatsWeek, StatsMonth, StatsYear:
+		return true
+	}
+	return false
+}
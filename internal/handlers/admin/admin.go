@@ -0,0 +1,20 @@
+package admin
+
+import (
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+)
+
+// New registers the administration routes, all of them need the admin role.
+func New(router httpRouter.Router, Ctrl *controllers.Container) {
+	admin := Ctrl.Auth.Require(httpRouter.RoleAdmin)
+
+	router.Post("/admin/purge", admin, Ctrl.Purge.Run)
+	router.Get("/admin/snapshot", admin, Ctrl.Snapshot.Export)
+	router.Post("/admin/restore", admin, Ctrl.Snapshot.Restore)
+
+	router.Post("/admin/keys", admin, Ctrl.APIKey.Create)
+	router.Get("/admin/keys", admin, Ctrl.APIKey.Find)
+	router.Delete("/admin/keys/:id", admin, Ctrl.APIKey.Revoke)
+
+}
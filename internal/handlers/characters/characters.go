@@ -6,11 +6,20 @@ import (
 )
 
 func New(router httpRouter.Router, Ctrl *controllers.Container) {
+	var (
+		reader = Ctrl.Auth.Require(httpRouter.RoleReader)
+		editor = Ctrl.Auth.Require(httpRouter.RoleEditor)
+		admin  = Ctrl.Auth.Require(httpRouter.RoleAdmin)
+	)
 
-	router.Post("/characters", Ctrl.Character.Create)
-	router.Get("/characters", Ctrl.Character.Find)
-	router.Get("/characters/:id", Ctrl.Character.FindByID)
-	router.Put("/characters/:id", Ctrl.Character.Update)
-	router.Delete("/characters/:id", Ctrl.Character.Delete)
+	router.Post("/characters", editor, Ctrl.Idempotency.Handle, Ctrl.Character.Create)
+	router.Post("/characters/bulk", editor, Ctrl.Inflate.Handle, Ctrl.Idempotency.Handle, Ctrl.Character.Bulk)
+	router.Post("/characters/batch-get", reader, Ctrl.Character.BatchGet)
+	router.Get("/characters", reader, Ctrl.Character.Find)
+	router.Get("/characters/:id", reader, Ctrl.Character.FindByID)
+	router.Put("/characters/:id", editor, Ctrl.Character.Update)
+	router.Delete("/characters/:id", admin, Ctrl.Character.Delete)
+	router.Post("/characters/:id/restore", admin, Ctrl.Character.Restore)
+	router.Get("/characters/:id/history", reader, Ctrl.Character.History)
 
 }
@@ -0,0 +1,21 @@
+package graphql
+
+import (
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+)
+
+// New registers the GraphQL endpoint out of the versioned groups, the schema
+// evolves on its own. The queries need the reader role, the mutations check
+// the role of the REST route they mirror. The GraphiQL page, loaded from a
+// CDN, has its own content security policy graphiqlCSP.
+func New(router httpRouter.Router, Ctrl *controllers.Container, graphiql bool, graphiqlCSP string) {
+	reader := Ctrl.Auth.Require(httpRouter.RoleReader)
+
+	router.Post("/graphql", Ctrl.RateLimit.LimitIP(), Ctrl.Auth.Authenticate, Ctrl.RateLimit.Limit("graphql"), reader, Ctrl.Audit.Principal, Ctrl.GraphQL.Query)
+
+	if graphiql {
+		router.Get("/graphql", httpRouter.ContentSecurityPolicy(graphiqlCSP), Ctrl.GraphQL.GraphiQL)
+	}
+
+}
@@ -0,0 +1,32 @@
+package grpc
+
+import (
+	gameofthronesv1 "github.com/PatrickChagastavares/game-of-thrones/api/gameofthrones/v1"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/grpcServer"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+)
+
+// roles of the methods, the same of their http routes.
+var roles = map[string]string{
+	gameofthronesv1.HouseService_CreateHouse_FullMethodName: httpRouter.RoleEditor,
+	gameofthronesv1.HouseService_ListHouses_FullMethodName:  httpRouter.RoleReader,
+	gameofthronesv1.HouseService_GetHouse_FullMethodName:    httpRouter.RoleReader,
+	gameofthronesv1.HouseService_UpdateHouse_FullMethodName: httpRouter.RoleEditor,
+	gameofthronesv1.HouseService_DeleteHouse_FullMethodName: httpRouter.RoleAdmin,
+
+	gameofthronesv1.CharacterService_CreateCharacter_FullMethodName: httpRouter.RoleEditor,
+	gameofthronesv1.CharacterService_ListCharacters_FullMethodName:  httpRouter.RoleReader,
+	gameofthronesv1.CharacterService_GetCharacter_FullMethodName:    httpRouter.RoleReader,
+	gameofthronesv1.CharacterService_UpdateCharacter_FullMethodName: httpRouter.RoleEditor,
+	gameofthronesv1.CharacterService_DeleteCharacter_FullMethodName: httpRouter.RoleAdmin,
+}
+
+func New(server grpcServer.Server, Ctrl *controllers.Container) {
+
+	server.Use(Ctrl.Auth.Unary(roles), Ctrl.Audit.Unary)
+
+	gameofthronesv1.RegisterHouseServiceServer(server, Ctrl.GRPC)
+	gameofthronesv1.RegisterCharacterServiceServer(server, Ctrl.GRPC)
+
+}
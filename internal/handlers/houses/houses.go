@@ -6,11 +6,20 @@ import (
 )
 
 func New(router httpRouter.Router, Ctrl *controllers.Container) {
+	var (
+		reader = Ctrl.Auth.Require(httpRouter.RoleReader)
+		editor = Ctrl.Auth.Require(httpRouter.RoleEditor)
+		admin  = Ctrl.Auth.Require(httpRouter.RoleAdmin)
+	)
 
-	router.Post("/houses", Ctrl.House.Create)
-	router.Get("/houses", Ctrl.House.Find)
-	router.Get("/houses/:id", Ctrl.House.FindByID)
-	router.Put("/houses/:id", Ctrl.House.Update)
-	router.Delete("/houses/:id", Ctrl.House.Delete)
+	router.Post("/houses", editor, Ctrl.Idempotency.Handle, Ctrl.House.Create)
+	router.Post("/houses/bulk", editor, Ctrl.Inflate.Handle, Ctrl.Idempotency.Handle, Ctrl.House.Bulk)
+	router.Post("/houses/batch-get", reader, Ctrl.House.BatchGet)
+	router.Get("/houses", reader, Ctrl.House.Find)
+	router.Get("/houses/:id", reader, Ctrl.House.FindByID)
+	router.Put("/houses/:id", editor, Ctrl.House.Update)
+	router.Delete("/houses/:id", admin, Ctrl.House.Delete)
+	router.Post("/houses/:id/restore", admin, Ctrl.House.Restore)
+	router.Get("/houses/:id/history", reader, Ctrl.House.History)
 
 }
@@ -0,0 +1,14 @@
+package imports
+
+import (
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+)
+
+func New(router httpRouter.Router, Ctrl *controllers.Container) {
+	editor := Ctrl.Auth.Require(httpRouter.RoleEditor)
+
+	router.Post("/import/houses", editor, Ctrl.Inflate.Handle, Ctrl.Import.Houses)
+	router.Post("/import/characters", editor, Ctrl.Inflate.Handle, Ctrl.Import.Characters)
+
+}
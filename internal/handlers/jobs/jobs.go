@@ -0,0 +1,20 @@
+package jobs
+
+import (
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+)
+
+func New(router httpRouter.Router, Ctrl *controllers.Container) {
+	var (
+		reader = Ctrl.Auth.Require(httpRouter.RoleReader)
+		editor = Ctrl.Auth.Require(httpRouter.RoleEditor)
+		admin  = Ctrl.Auth.Require(httpRouter.RoleAdmin)
+	)
+
+	router.Post("/jobs", editor, Ctrl.Jobs.Submit)
+	router.Get("/jobs/:id", reader, Ctrl.Jobs.FindByID)
+	router.Get("/jobs/:id/export", reader, Ctrl.Jobs.Export)
+	router.Delete("/jobs/:id", admin, Ctrl.Jobs.Cancel)
+
+}
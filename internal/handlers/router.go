@@ -2,9 +2,17 @@ package handlers
 
 import (
 	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/handlers/admin"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/handlers/characters"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/handlers/graphql"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/handlers/grpc"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/handlers/houses"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/handlers/imports"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/handlers/jobs"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/handlers/search"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/handlers/stats"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/handlers/swagger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/grpcServer"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
 )
 
@@ -12,11 +20,54 @@ type (
 	Options struct {
 		Ctrl   *controllers.Container
 		Router httpRouter.Router
+		// Root and V1 announce the deprecation of the routes of each version
+		Root httpRouter.Deprecation
+		V1   httpRouter.Deprecation
+		// GraphiQL serves the GraphQL IDE, meant for development only
+		GraphiQL bool
+		// CORS lets the browser apps of other origins call the API
+		CORS     httpRouter.CORS
+		Security httpRouter.SecurityHeaders
+		// SwaggerCSP and GraphiQLCSP replace the content security policy of
+		// the API on those pages
+		SwaggerCSP  string
+		GraphiQLCSP string
 	}
 )
 
 func NewRouter(opts Options) {
-	swagger.New(opts.Router)
-	houses.New(opts.Router, opts.Ctrl)
-	characters.New(opts.Router, opts.Ctrl)
+	// before the audit and the authentication, the preflights carry no credentials
+	opts.Router.Use(httpRouter.CrossOrigin(opts.CORS), httpRouter.Secure(opts.Security))
+	opts.Router.Use(opts.Ctrl.Audit.Handle)
+	opts.Router.NoRoute(opts.Ctrl.Problem.NotFound)
+	opts.Router.NotAcceptable(opts.Ctrl.Problem.NotAcceptable)
+
+	swagger.New(opts.Router, opts.SwaggerCSP)
+	graphql.New(opts.Router, opts.Ctrl, opts.GraphiQL, opts.GraphiQLCSP)
+
+	// the limit of the IPs runs before the authentication, that reaches the database
+	v1 := opts.Router.Group("/v1", httpRouter.Deprecate(opts.V1), opts.Ctrl.RateLimit.LimitIP(), opts.Ctrl.Auth.Authenticate, opts.Ctrl.Audit.Principal)
+	api(v1, opts.Ctrl)
+
+	// the routes registered before the API was versioned, kept as an alias of v1
+	root := opts.Router.Group("", httpRouter.Deprecate(opts.Root), opts.Ctrl.RateLimit.LimitIP(), opts.Ctrl.Auth.Authenticate, opts.Ctrl.Audit.Principal)
+	api(root, opts.Ctrl)
+}
+
+// NewGRPC registers the gRPC services, served on their own port next to the
+// http routes.
+func NewGRPC(server grpcServer.Server, ctrl *controllers.Container) {
+	grpc.New(server, ctrl)
+}
+
+// api registers the routes of a version of the API, each one declares the role
+// it needs. Each group has its rate limit, shared by the versions.
+func api(router httpRouter.Router, ctrl *controllers.Container) {
+	houses.New(router.Group("", ctrl.RateLimit.Limit("houses")), ctrl)
+	characters.New(router.Group("", ctrl.RateLimit.Limit("characters")), ctrl)
+	imports.New(router.Group("", ctrl.RateLimit.Limit("imports")), ctrl)
+	search.New(router.Group("", ctrl.RateLimit.Limit("search")), ctrl)
+	stats.New(router.Group("", ctrl.RateLimit.Limit("stats")), ctrl)
+	jobs.New(router.Group("", ctrl.RateLimit.Limit("jobs")), ctrl)
+	admin.New(router.Group("", ctrl.RateLimit.Limit("admin")), ctrl)
 }
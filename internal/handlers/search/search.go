@@ -0,0 +1,13 @@
+package search
+
+import (
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+)
+
+func New(router httpRouter.Router, Ctrl *controllers.Container) {
+	reader := Ctrl.Auth.Require(httpRouter.RoleReader)
+
+	router.Get("/search", reader, Ctrl.Search.Search)
+
+}
@@ -0,0 +1,15 @@
+package stats
+
+import (
+	"github.com/PatrickChagastavares/game-of-thrones/internal/controllers"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+)
+
+func New(router httpRouter.Router, Ctrl *controllers.Container) {
+	reader := Ctrl.Auth.Require(httpRouter.RoleReader)
+
+	router.Get("/stats", reader, Ctrl.Stats.Stats)
+	router.Get("/stats/houses", reader, Ctrl.Stats.Houses)
+	router.Get("/stats/characters", reader, Ctrl.Stats.Characters)
+
+}
@@ -2,17 +2,30 @@ package swagger
 
 import (
 	"github.com/PatrickChagastavares/game-of-thrones/docs"
+	docsv1 "github.com/PatrickChagastavares/game-of-thrones/docs/v1"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
-func New(router httpRouter.Router) {
+// New serves the swagger UI of each version under the content security policy
+// csp, the page needs its inline script and style.
+func New(router httpRouter.Router, csp string) {
 
-	docs.SwaggerInfo.Title = "Swagger about router of solidAPI"
+	docsv1.SwaggerInfov1.Title = "Swagger about router of solidAPI"
+	docsv1.SwaggerInfov1.Version = "1.0"
+	docsv1.SwaggerInfov1.BasePath = "/v1"
+	docsv1.SwaggerInfov1.Schemes = []string{"http", "https"}
+
+	router.Get("v1/swagger/*any", httpRouter.ContentSecurityPolicy(csp), router.ParseHandler(
+		httpSwagger.Handler(httpSwagger.URL("doc.json"), httpSwagger.InstanceName(docsv1.SwaggerInfov1.InstanceName())),
+	))
+
+	// the routes without version, deprecated in favor of v1
+	docs.SwaggerInfo.Title = "Swagger about router of solidAPI (deprecated, use /v1/swagger)"
 	docs.SwaggerInfo.Version = "1.0"
 	docs.SwaggerInfo.Schemes = []string{"http", "https"}
 
-	router.Get("swagger/*any", router.ParseHandler(
+	router.Get("swagger/*any", httpRouter.ContentSecurityPolicy(csp), router.ParseHandler(
 		httpSwagger.Handler(httpSwagger.URL("doc.json")),
 	))
 }
@@ -0,0 +1,18 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package apikeys
+
+import (
+	"context"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+)
+
+type IRepository interface {
+	Create(ctx context.Context, key entities.APIKey) (err error)
+	Find(ctx context.Context) (keys []entities.APIKey, err error)
+	FindByHash(ctx context.Context, hash string) (key entities.APIKey, err error)
+	// Revoke revokes a key not revoked yet, a not found error means there is
+	// none with id.
+	Revoke(ctx context.Context, id string, now time.Time) (key entities.APIKey, err error)
+}
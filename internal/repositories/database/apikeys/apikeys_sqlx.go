@@ -0,0 +1,94 @@
+package apikeys
+
+import (
+	"context"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/jmoiron/sqlx"
+)
+
+const columns = `id, name, prefix, key_hash, scopes, created_at, expires_at, revoked_at`
+
+type repoSqlx struct {
+	log    logger.Logger
+	writer *sqlx.DB
+}
+
+// NewSqlx keys are always read from the writer, a revoked key must stop
+// working at once.
+func NewSqlx(log logger.Logger, writer *sqlx.DB) IRepository {
+	return &repoSqlx{log: log, writer: writer}
+}
+
+func (repo *repoSqlx) Create(ctx context.Context, key entities.APIKey) (err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.apikeys.create")
+	defer span.End()
+
+	_, err = repo.writer.ExecContext(ctx,
+		`INSERT INTO api_keys
+		(id,name,prefix,key_hash,scopes,created_at,expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);`,
+		key.ID, key.Name, key.Prefix, key.Hash, key.Scopes, key.CreatedAt, key.ExpiresAt)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "apikeys.SqlxRepo.Create", err)
+		return database.Error(err, "problem to create api key")
+	}
+
+	return nil
+}
+
+func (repo *repoSqlx) Find(ctx context.Context) (keys []entities.APIKey, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.apikeys.find")
+	defer span.End()
+
+	query := `
+	SELECT ` + columns + `
+	FROM api_keys
+	ORDER BY created_at;`
+	keys = []entities.APIKey{}
+	err = repo.writer.SelectContext(ctx, &keys, query)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "apikeys.SqlxRepo.Find", "Error on find api keys: ", err)
+		return keys, database.Error(err, "problem to find api keys")
+	}
+
+	return keys, nil
+}
+
+func (repo *repoSqlx) FindByHash(ctx context.Context, hash string) (key entities.APIKey, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.apikeys.findbyhash")
+	defer span.End()
+
+	query := `
+	SELECT ` + columns + `
+	FROM api_keys
+	WHERE key_hash = $1;`
+	err = repo.writer.GetContext(ctx, &key, query, hash)
+	if err != nil {
+		return key, database.Error(err, "api key is not found")
+	}
+
+	return key, nil
+}
+
+func (repo *repoSqlx) Revoke(ctx context.Context, id string, now time.Time) (key entities.APIKey, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.apikeys.revoke")
+	defer span.End()
+
+	query := `
+	UPDATE api_keys
+	SET revoked_at = $2
+	WHERE id = $1 AND revoked_at is null
+	RETURNING ` + columns + `;`
+	err = repo.writer.GetContext(ctx, &key, query, id, now)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "apikeys.SqlxRepo.Revoke", "Error on revoke api key: ", id, err)
+		return key, database.Error(err, "api key is not found or revoked")
+	}
+
+	return key, nil
+}
@@ -0,0 +1,211 @@
+package apikeys
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/test"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var keyColumns = []string{"id", "name", "prefix", "key_hash", "scopes", "created_at", "expires_at", "revoked_at"}
+
+func keyRows(keys ...entities.APIKey) *sqlmock.Rows {
+	rows := test.NewRows(keyColumns...)
+	for _, key := range keys {
+		rows.AddRow(key.ID, key.Name, key.Prefix, key.Hash, "{"+key.Scopes[0]+"}", key.CreatedAt, key.ExpiresAt, key.RevokedAt)
+	}
+	return rows
+}
+
+func Test_Create(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	data := entities.APIKey{
+		ID:        "id_1",
+		Name:      "ci",
+		Prefix:    "got_abcdef",
+		Hash:      "hash",
+		Scopes:    pq.StringArray{"read"},
+		CreatedAt: time.Now(),
+		ExpiresAt: &expires,
+	}
+	query := regexp.QuoteMeta(`INSERT INTO api_keys
+		(id,name,prefix,key_hash,scopes,created_at,expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);`)
+
+	cases := map[string]struct {
+		expectedErr error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(data.ID, data.Name, data.Prefix, data.Hash, data.Scopes, data.CreatedAt, data.ExpiresAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "problem to create api key", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(data.ID, data.Name, data.Prefix, data.Hash, data.Scopes, data.CreatedAt, data.ExpiresAt).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			err := repo.Create(context.Background(), data)
+
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_Find(t *testing.T) {
+	data := entities.APIKey{ID: "id_1", Name: "ci", Prefix: "got_abcdef", Hash: "hash", Scopes: pq.StringArray{"write"}, CreatedAt: time.Now()}
+	query := regexp.QuoteMeta(`
+	SELECT ` + columns + `
+	FROM api_keys
+	ORDER BY created_at;`)
+
+	cases := map[string]struct {
+		expectedData []entities.APIKey
+		expectedErr  error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			expectedData: []entities.APIKey{data},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WillReturnRows(keyRows(data))
+			},
+		},
+		"Should return Error": {
+			expectedData: []entities.APIKey{},
+			expectedErr:  entities.NewDomainErr(nil, "problem to find api keys", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			keys, err := repo.Find(context.Background())
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, keys)
+		})
+	}
+}
+
+func Test_FindByHash(t *testing.T) {
+	data := entities.APIKey{ID: "id_1", Name: "ci", Prefix: "got_abcdef", Hash: "hash", Scopes: pq.StringArray{"admin"}, CreatedAt: time.Now()}
+	query := regexp.QuoteMeta(`
+	SELECT ` + columns + `
+	FROM api_keys
+	WHERE key_hash = $1;`)
+
+	cases := map[string]struct {
+		expectedData entities.APIKey
+		expectedErr  error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			expectedData: data,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("hash").WillReturnRows(keyRows(data))
+			},
+		},
+		"Should return not found": {
+			expectedErr: entities.NewDomainErr(entities.ErrNotFound, "api key is not found", sql.ErrNoRows),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("hash").WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			key, err := repo.FindByHash(context.Background(), "hash")
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, key)
+		})
+	}
+}
+
+func Test_Revoke(t *testing.T) {
+	now := time.Now()
+	data := entities.APIKey{ID: "id_1", Name: "ci", Prefix: "got_abcdef", Hash: "hash", Scopes: pq.StringArray{"read"}, CreatedAt: now, RevokedAt: &now}
+	query := regexp.QuoteMeta(`
+	UPDATE api_keys
+	SET revoked_at = $2
+	WHERE id = $1 AND revoked_at is null
+	RETURNING ` + columns + `;`)
+
+	cases := map[string]struct {
+		expectedData entities.APIKey
+		expectedErr  error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return the revoked key": {
+			expectedData: data,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("id_1", now).WillReturnRows(keyRows(data))
+			},
+		},
+		"Should return not found": {
+			expectedErr: entities.NewDomainErr(entities.ErrNotFound, "api key is not found or revoked", sql.ErrNoRows),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("id_1", now).WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			key, err := repo.Revoke(context.Background(), "id_1", now)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, key)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package audit
+
+import (
+	"context"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+)
+
+type IRepository interface {
+	// Create joins the transaction of ctx, so the entry is only kept when the
+	// change it describes is committed.
+	Create(ctx context.Context, entry entities.AuditEntry) (err error)
+	// Find reads only the columns of fields, every column when it's empty.
+	Find(ctx context.Context, entity, entityID string, fields []string) (entries []entities.AuditEntry, err error)
+}
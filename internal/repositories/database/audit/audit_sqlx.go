@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/transaction"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/jmoiron/sqlx"
+)
+
+type repoSqlx struct {
+	log    logger.Logger
+	writer *sqlx.DB
+	reader *sqlx.DB
+}
+
+func NewSqlx(log logger.Logger, writer, reader *sqlx.DB) IRepository {
+	return &repoSqlx{log: log, writer: writer, reader: reader}
+}
+
+func (repo *repoSqlx) Create(ctx context.Context, entry entities.AuditEntry) (err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.audit.create")
+	defer span.End()
+
+	query := `
+	INSERT INTO audit_entries
+	(id,entity,entity_id,action,actor,request_id,trace_id,changes,created_at)
+	VALUES (:id, :entity, :entity_id, :action, :actor, :request_id, :trace_id, :changes, :created_at);
+	`
+	_, err = sqlx.NamedExecContext(ctx, transaction.Executor(ctx, repo.writer), query, entry)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "audit.SqlxRepo.Create", err)
+		return database.Error(err, "problem to create audit entry")
+	}
+
+	return nil
+}
+
+func (repo *repoSqlx) Find(ctx context.Context, entity, entityID string, fields []string) (entries []entities.AuditEntry, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.audit.find")
+	defer span.End()
+
+	entries = make([]entities.AuditEntry, 0)
+	query := `
+	SELECT ` + entities.Columns(fields, entities.AuditEntry{}) + `
+	FROM audit_entries
+	WHERE entity = $1 AND entity_id = $2
+	ORDER BY created_at DESC;
+	`
+	err = repo.reader.SelectContext(ctx, &entries, query, entity, entityID)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "audit.SqlxRepo.Find", "Error on find audit entries: ", entityID, err)
+		return nil, database.Error(err, "failed to find audit entries")
+	}
+
+	return entries, nil
+}
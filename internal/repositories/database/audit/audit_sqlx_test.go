@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Create(t *testing.T) {
+	entry := entities.AuditEntry{
+		ID:        "33c55a43-f163-4a67-9f6c-75161410f376",
+		Entity:    entities.AuditHouse,
+		EntityID:  "house_1",
+		Action:    entities.AuditUpdate,
+		Actor:     "patrick",
+		RequestID: "request_1",
+		TraceID:   "trace_1",
+		Changes:   entities.AuditChanges{"current_lord": {Before: "lord_1", After: "lord_2"}},
+		CreatedAt: time.Now(),
+	}
+	query := regexp.QuoteMeta(`
+	INSERT INTO audit_entries
+	(id,entity,entity_id,action,actor,request_id,trace_id,changes,created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+	`)
+
+	cases := map[string]struct {
+		expectedErr error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(entry.ID, entry.Entity, entry.EntityID, entry.Action, entry.Actor,
+						entry.RequestID, entry.TraceID, []byte(`{"current_lord":{"before":"lord_1","after":"lord_2"}}`), entry.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "problem to create audit entry", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
+
+			err := repo.Create(context.Background(), entry)
+
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_Find(t *testing.T) {
+	now := time.Now()
+	query := regexp.QuoteMeta(`
+	SELECT id, entity, entity_id, action, actor, request_id, trace_id, changes, created_at
+	FROM audit_entries
+	WHERE entity = $1 AND entity_id = $2
+	ORDER BY created_at DESC;
+	`)
+	columns := []string{"id", "entity", "entity_id", "action", "actor", "request_id", "trace_id", "changes", "created_at"}
+
+	cases := map[string]struct {
+		expectedEntries []entities.AuditEntry
+		expectedErr     error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			expectedEntries: []entities.AuditEntry{{
+				ID:        "entry_1",
+				Entity:    entities.AuditHouse,
+				EntityID:  "house_1",
+				Action:    entities.AuditRemoveLord,
+				Actor:     "patrick",
+				RequestID: "request_1",
+				TraceID:   "trace_1",
+				Changes:   entities.AuditChanges{"current_lord": {Before: "lord_1", After: ""}},
+				CreatedAt: now,
+			}},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(entities.AuditHouse, "house_1").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(
+						"entry_1", entities.AuditHouse, "house_1", entities.AuditRemoveLord, "patrick", "request_1", "trace_1",
+						[]byte(`{"current_lord":{"before":"lord_1","after":""}}`), now,
+					))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "failed to find audit entries", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(entities.AuditHouse, "house_1").
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
+
+			entries, err := repo.Find(context.Background(), entities.AuditHouse, "house_1", nil)
+
+			assert.Equal(t, cs.expectedEntries, entries)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
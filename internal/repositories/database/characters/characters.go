@@ -3,14 +3,23 @@ package characters
 
 import (
 	"context"
+	"time"
 
 	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
 )
 
 type IRepository interface {
 	Create(ctx context.Context, character entities.CharacterRequest) (err error)
-	Find(ctx context.Context) (characters []entities.Character, err error)
-	FindByID(ctx context.Context, id string) (characters entities.Character, err error)
+	Find(ctx context.Context, filter entities.Filter) (characters []entities.Character, err error)
+	FindByID(ctx context.Context, id string, filter entities.Filter) (characters entities.Character, err error)
+	// FindByIDs returns the characters among ids in a single query, in no
+	// particular order.
+	FindByIDs(ctx context.Context, ids []string, filter entities.Filter) (characters []entities.Character, err error)
 	Update(ctx context.Context, character *entities.Character) (err error)
 	Delete(ctx context.Context, id string) (err error)
+	Restore(ctx context.Context, id string) (err error)
+	CountDeleted(ctx context.Context, before time.Time) (total int, err error)
+	// Purge hard deletes up to limit rows soft deleted before the given time
+	// and reports how many were removed.
+	Purge(ctx context.Context, before time.Time, limit int) (purged int, err error)
 }
@@ -7,6 +7,7 @@ package characters
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entities "github.com/PatrickChagastavares/game-of-thrones/internal/entities"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,21 @@ func (m *MockIRepository) EXPECT() *MockIRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountDeleted mocks base method.
+func (m *MockIRepository) CountDeleted(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDeleted", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDeleted indicates an expected call of CountDeleted.
+func (mr *MockIRepositoryMockRecorder) CountDeleted(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDeleted", reflect.TypeOf((*MockIRepository)(nil).CountDeleted), ctx, before)
+}
+
 // Create mocks base method.
 func (m *MockIRepository) Create(ctx context.Context, character entities.CharacterRequest) error {
 	m.ctrl.T.Helper()
@@ -64,33 +80,77 @@ func (mr *MockIRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call
 }
 
 // Find mocks base method.
-func (m *MockIRepository) Find(ctx context.Context) ([]entities.Character, error) {
+func (m *MockIRepository) Find(ctx context.Context, filter entities.Filter) ([]entities.Character, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Find", ctx)
+	ret := m.ctrl.Call(m, "Find", ctx, filter)
 	ret0, _ := ret[0].([]entities.Character)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Find indicates an expected call of Find.
-func (mr *MockIRepositoryMockRecorder) Find(ctx interface{}) *gomock.Call {
+func (mr *MockIRepositoryMockRecorder) Find(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIRepository)(nil).Find), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIRepository)(nil).Find), ctx, filter)
 }
 
 // FindByID mocks base method.
-func (m *MockIRepository) FindByID(ctx context.Context, id string) (entities.Character, error) {
+func (m *MockIRepository) FindByID(ctx context.Context, id string, filter entities.Filter) (entities.Character, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret := m.ctrl.Call(m, "FindByID", ctx, id, filter)
 	ret0, _ := ret[0].(entities.Character)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByID indicates an expected call of FindByID.
-func (mr *MockIRepositoryMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
+func (mr *MockIRepositoryMockRecorder) FindByID(ctx, id, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockIRepository)(nil).FindByID), ctx, id, filter)
+}
+
+// FindByIDs mocks base method.
+func (m *MockIRepository) FindByIDs(ctx context.Context, ids []string, filter entities.Filter) ([]entities.Character, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids, filter)
+	ret0, _ := ret[0].([]entities.Character)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockIRepositoryMockRecorder) FindByIDs(ctx, ids, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockIRepository)(nil).FindByIDs), ctx, ids, filter)
+}
+
+// Purge mocks base method.
+func (m *MockIRepository) Purge(ctx context.Context, before time.Time, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, before, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockIRepositoryMockRecorder) Purge(ctx, before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockIRepository)(nil).Purge), ctx, before, limit)
+}
+
+// Restore mocks base method.
+func (m *MockIRepository) Restore(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockIRepositoryMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockIRepository)(nil).FindByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockIRepository)(nil).Restore), ctx, id)
 }
 
 // Update mocks base method.
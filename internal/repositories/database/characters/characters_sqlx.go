@@ -3,13 +3,15 @@ package characters
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"time"
 
 	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/transaction"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type repoSqlx struct {
@@ -26,72 +28,91 @@ func (repo *repoSqlx) Create(ctx context.Context, character entities.CharacterRe
 	ctx, span := tracer.Span(ctx, "repositories.database.characters.create")
 	defer span.End()
 
-	_, err = repo.writer.ExecContext(ctx,
+	_, err = transaction.Executor(ctx, repo.writer).ExecContext(ctx,
 		`INSERT INTO characters 
-		(id,name,tv_series,created_at)
-		VALUES ($1, $2, $3, $4);`,
+		(id,name,tv_series,created_at,search)
+		VALUES ($1, $2, $3, $4, character_search($2));`,
 		character.ID, character.Name, character.TVSeries, character.CreatedAt)
 	if err != nil {
 		repo.log.ErrorContext(ctx, "characters.SqlxRepo.Create", err)
-		return errors.New("problem to create character")
+		return database.Error(err, "problem to create character")
 	}
 
 	return nil
 }
 
-func (repo *repoSqlx) Find(ctx context.Context) (characters []entities.Character, err error) {
+func (repo *repoSqlx) Find(ctx context.Context, filter entities.Filter) (characters []entities.Character, err error) {
 	ctx, span := tracer.Span(ctx, "repositories.database.characters.find")
 	defer span.End()
 
 	characters = make([]entities.Character, 0)
 	query := `
-	SELECT id, name, tv_series, created_at, updated_at
+	SELECT ` + entities.Columns(filter.Fields, entities.Character{}) + `
 	FROM characters
-	WHERE deleted_at is null
-	ORDER BY created_at DESC;
+	WHERE ($1 OR deleted_at is null)
+	ORDER BY created_at DESC
+	LIMIT NULLIF($2, 0) OFFSET $3;
 	`
-	err = repo.reader.SelectContext(ctx, &characters, query)
+	err = repo.reader.SelectContext(ctx, &characters, query, filter.IncludeDeleted, filter.Limit, filter.Offset)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return characters, nil
 		}
 		repo.log.ErrorContext(ctx, "characters.SqlxRepo.Find", "Error on find characters: ", err)
-		return nil, errors.New("problem to find characters")
+		return nil, database.Error(err, "problem to find characters")
 	}
 
 	return characters, nil
 }
 
-func (repo *repoSqlx) FindByID(ctx context.Context, id string) (character entities.Character, err error) {
+func (repo *repoSqlx) FindByID(ctx context.Context, id string, filter entities.Filter) (character entities.Character, err error) {
 	ctx, span := tracer.Span(ctx, "repositories.database.characters.findbyid")
 	defer span.End()
 
 	query := `
-	SELECT id, name, tv_series, created_at, updated_at
+	SELECT ` + entities.Columns(filter.Fields, entities.Character{}) + `
 	FROM characters
-	WHERE id =$1 AND deleted_at is null;`
-	err = repo.reader.GetContext(ctx, &character, query, id)
+	WHERE id =$1 AND ($2 OR deleted_at is null);`
+	err = repo.reader.GetContext(ctx, &character, query, id, filter.IncludeDeleted)
 	if err != nil {
 		repo.log.ErrorContext(ctx, "characters.SqlxRepo.FindByID", "Error on find character by id: ", id, err)
-		return character, errors.New("character is not found or deleted")
+		return character, database.Error(err, "character is not found or deleted")
 	}
 
 	return character, nil
 }
 
+func (repo *repoSqlx) FindByIDs(ctx context.Context, ids []string, filter entities.Filter) (characters []entities.Character, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.characters.findbyids")
+	defer span.End()
+
+	characters = make([]entities.Character, 0, len(ids))
+	query := `
+	SELECT ` + entities.Columns(filter.Fields, entities.Character{}) + `
+	FROM characters
+	WHERE id = ANY($1) AND ($2 OR deleted_at is null);`
+	err = repo.reader.SelectContext(ctx, &characters, query, pq.StringArray(ids), filter.IncludeDeleted)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "characters.SqlxRepo.FindByIDs", "Error on find characters by ids: ", ids, err)
+		return nil, database.Error(err, "problem to find characters")
+	}
+
+	return characters, nil
+}
+
 func (repo *repoSqlx) Update(ctx context.Context, character *entities.Character) (err error) {
 	ctx, span := tracer.Span(ctx, "repositories.database.characters.update")
 	defer span.End()
 
 	query := `
 	UPDATE characters
-	SET name = :name, tv_series = :tv_series, updated_at = :updated_at
+	SET name = :name, tv_series = :tv_series, updated_at = :updated_at, search = character_search(:name)
 	WHERE id = :id;
 	`
-	_, err = repo.writer.NamedExecContext(ctx, query, character)
+	_, err = sqlx.NamedExecContext(ctx, transaction.Executor(ctx, repo.writer), query, character)
 	if err != nil {
 		repo.log.ErrorContext(ctx, "characters.SqlxRepo.Update", "Error on update character: ", character, err)
-		return errors.New("failed to update character")
+		return database.Error(err, "failed to update character")
 	}
 
 	return nil
@@ -105,14 +126,79 @@ func (repo *repoSqlx) Delete(ctx context.Context, id string) (err error) {
 
 	query := `
 	UPDATE characters
-	SET deleted_at = $1
+	SET deleted_at = $1, search = NULL
 	WHERE id = $2;
 	`
-	_, err = repo.writer.ExecContext(ctx, query, timeNow(), id)
+	_, err = transaction.Executor(ctx, repo.writer).ExecContext(ctx, query, timeNow(), id)
 	if err != nil {
 		repo.log.ErrorContext(ctx, "characters.SqlxRepo.Delete", "Error on delete character: ", id, err)
-		return errors.New("failed to delete character")
+		return database.Error(err, "failed to delete character")
 	}
 
 	return nil
 }
+
+func (repo *repoSqlx) Restore(ctx context.Context, id string) (err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.characters.restore")
+	defer span.End()
+
+	query := `
+	UPDATE characters
+	SET deleted_at = NULL, updated_at = $1, search = character_search(name)
+	WHERE id = $2;
+	`
+	_, err = transaction.Executor(ctx, repo.writer).ExecContext(ctx, query, timeNow(), id)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "characters.SqlxRepo.Restore", "Error on restore character: ", id, err)
+		return database.Error(err, "failed to restore character")
+	}
+
+	return nil
+}
+
+func (repo *repoSqlx) CountDeleted(ctx context.Context, before time.Time) (total int, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.characters.countdeleted")
+	defer span.End()
+
+	query := `
+	SELECT count(*)
+	FROM characters
+	WHERE deleted_at < $1;
+	`
+	err = repo.reader.GetContext(ctx, &total, query, before)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "characters.SqlxRepo.CountDeleted", err)
+		return total, database.Error(err, "failed to count deleted characters")
+	}
+
+	return total, nil
+}
+
+func (repo *repoSqlx) Purge(ctx context.Context, before time.Time, limit int) (purged int, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.characters.purge")
+	defer span.End()
+
+	query := `
+	WITH purged AS (
+		DELETE FROM characters
+		WHERE id IN (
+			SELECT id FROM characters
+			WHERE deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+		)
+		RETURNING id
+	), lordships AS (
+		DELETE FROM removed_lordships
+		WHERE lord_id IN (SELECT id FROM purged)
+	)
+	SELECT count(*) FROM purged;
+	`
+	err = sqlx.GetContext(ctx, transaction.Executor(ctx, repo.writer), &purged, query, before, limit)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "characters.SqlxRepo.Purge", err)
+		return purged, database.Error(err, "failed to purge characters")
+	}
+
+	return purged, nil
+}
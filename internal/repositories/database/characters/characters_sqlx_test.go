@@ -12,6 +12,7 @@ import (
 	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
 	"github.com/PatrickChagastavares/game-of-thrones/test"
+	"github.com/lib/pq"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -34,8 +35,8 @@ func Test_Create(t *testing.T) {
 			input: data,
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`INSERT INTO characters 
-				(id,name,tv_series,created_at)
-				VALUES ($1, $2, $3, $4);`)
+				(id,name,tv_series,created_at,search)
+				VALUES ($1, $2, $3, $4, character_search($2));`)
 				mock.ExpectExec(query).
 					WithArgs(data.ID, data.Name, data.TVSeries, data.CreatedAt).
 					WillReturnResult(sqlmock.NewResult(1, 1))
@@ -43,11 +44,11 @@ func Test_Create(t *testing.T) {
 		},
 		"Should return Error": {
 			input:       data,
-			expectedErr: errors.New("problem to create character"),
+			expectedErr: entities.NewDomainErr(nil, "problem to create character", errors.New("Problem to execute query")),
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`INSERT INTO characters 
-				(id,name,tv_series,created_at)
-				VALUES ($1, $2, $3, $4);`)
+				(id,name,tv_series,created_at,search)
+				VALUES ($1, $2, $3, $4, character_search($2));`)
 				mock.ExpectExec(query).
 					WithArgs(data.ID, data.Name, data.TVSeries, data.CreatedAt).
 					WillReturnError(errors.New("Problem to execute query"))
@@ -86,10 +87,11 @@ func Test_Find(t *testing.T) {
 			expectedData: resp,
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
-				SELECT id, name, tv_series, created_at, updated_at
+				SELECT id, name, tv_series, created_at, updated_at, deleted_at
 				FROM characters
-				WHERE deleted_at is null
-				ORDER BY created_at DESC;
+				WHERE ($1 OR deleted_at is null)
+				ORDER BY created_at DESC
+				LIMIT NULLIF($2, 0) OFFSET $3;
 				`)
 				rows := test.NewRows("id", "name", "tv_series", "created_at", "updated_at").
 					AddRow(resp[0].ID, resp[0].Name, resp[0].TVSeries, resp[0].CreatedAt, nil).
@@ -102,25 +104,27 @@ func Test_Find(t *testing.T) {
 			expectedData: []entities.Character{},
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
-				SELECT id, name, tv_series, created_at, updated_at
+				SELECT id, name, tv_series, created_at, updated_at, deleted_at
 				FROM characters
-				WHERE deleted_at is null
-				ORDER BY created_at DESC;
+				WHERE ($1 OR deleted_at is null)
+				ORDER BY created_at DESC
+				LIMIT NULLIF($2, 0) OFFSET $3;
 				`)
 				mock.ExpectQuery(query).
 					WillReturnError(sql.ErrNoRows)
 			},
 		},
 		"Should return Error": {
-			expectedErr: errors.New("problem to find characters"),
+			expectedErr: entities.NewDomainErr(nil, "problem to find characters", errors.New("Problem to execute query")),
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
-				SELECT id, name, tv_series, created_at, updated_at
+				SELECT id, name, tv_series, created_at, updated_at, deleted_at
 				FROM characters
-				WHERE deleted_at is null
-				ORDER BY created_at DESC;
+				WHERE ($1 OR deleted_at is null)
+				ORDER BY created_at DESC
+				LIMIT NULLIF($2, 0) OFFSET $3;
 				`)
-				mock.ExpectExec(query).
+				mock.ExpectQuery(query).
 					WillReturnError(errors.New("Problem to execute query"))
 			},
 		},
@@ -134,7 +138,7 @@ func Test_Find(t *testing.T) {
 
 			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
 
-			data, err := repo.Find(context.Background())
+			data, err := repo.Find(context.Background(), entities.Filter{})
 
 			assert.Equal(t, cs.expectedErr, err)
 			assert.Equal(t, cs.expectedData, data)
@@ -159,26 +163,26 @@ func Test_FindByID(t *testing.T) {
 			expectedData: resp,
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
-				SELECT id, name, tv_series, created_at, updated_at
+				SELECT id, name, tv_series, created_at, updated_at, deleted_at
 				FROM characters
-				WHERE id =$1 AND deleted_at is null;`)
+				WHERE id =$1 AND ($2 OR deleted_at is null);`)
 				rows := test.NewRows("id", "name", "tv_series", "created_at", "updated_at").
 					AddRow(resp.ID, resp.Name, resp.TVSeries, resp.CreatedAt, nil)
 				mock.ExpectQuery(query).
-					WithArgs(resp.ID).
+					WithArgs(resp.ID, false).
 					WillReturnRows(rows)
 			},
 		},
 		"Should return Error": {
 			input:       resp.ID,
-			expectedErr: errors.New("character is not found or deleted"),
+			expectedErr: entities.NewDomainErr(nil, "character is not found or deleted", errors.New("Problem to execute query")),
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
-				SELECT id, name, tv_series, created_at, updated_at
+				SELECT id, name, tv_series, created_at, updated_at, deleted_at
 				FROM characters
-				WHERE id =$1 AND deleted_at is null;`)
-				mock.ExpectExec(query).
-					WithArgs(resp.ID).
+				WHERE id =$1 AND ($2 OR deleted_at is null);`)
+				mock.ExpectQuery(query).
+					WithArgs(resp.ID, false).
 					WillReturnError(errors.New("Problem to execute query"))
 			},
 		},
@@ -192,7 +196,79 @@ func Test_FindByID(t *testing.T) {
 
 			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
 
-			data, err := repo.FindByID(context.Background(), cs.input)
+			data, err := repo.FindByID(context.Background(), cs.input, entities.Filter{})
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, data)
+		})
+	}
+}
+
+func Test_FindByIDs(t *testing.T) {
+	resp := []entities.Character{
+		{ID: "id_1", Name: "Patrick", TVSeries: []string{"session 1", "session 2"}},
+		{ID: "id_2", Name: "Jon Snow", TVSeries: []string{"session 1"}},
+	}
+	ids := []string{"id_1", "id_2", "id_3"}
+
+	cases := map[string]struct {
+		inputFilter  entities.Filter
+		expectedData []entities.Character
+		expectedErr  error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			expectedData: resp,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				query := regexp.QuoteMeta(`
+				SELECT id, name, tv_series, created_at, updated_at, deleted_at
+				FROM characters
+				WHERE id = ANY($1) AND ($2 OR deleted_at is null);`)
+				rows := test.NewRows("id", "name", "tv_series", "created_at", "updated_at").
+					AddRow(resp[0].ID, resp[0].Name, resp[0].TVSeries, resp[0].CreatedAt, nil).
+					AddRow(resp[1].ID, resp[1].Name, resp[1].TVSeries, resp[1].CreatedAt, nil)
+				mock.ExpectQuery(query).
+					WithArgs(pq.StringArray(ids), false).
+					WillReturnRows(rows)
+			},
+		},
+		"Should return success with fields and deleted": {
+			inputFilter:  entities.Filter{IncludeDeleted: true, Fields: []string{"name"}},
+			expectedData: []entities.Character{{ID: "id_1", Name: "Patrick"}},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				query := regexp.QuoteMeta(`
+				SELECT id, name
+				FROM characters
+				WHERE id = ANY($1) AND ($2 OR deleted_at is null);`)
+				mock.ExpectQuery(query).
+					WithArgs(pq.StringArray(ids), true).
+					WillReturnRows(test.NewRows("id", "name").AddRow("id_1", "Patrick"))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "problem to find characters", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				query := regexp.QuoteMeta(`
+				SELECT id, name, tv_series, created_at, updated_at, deleted_at
+				FROM characters
+				WHERE id = ANY($1) AND ($2 OR deleted_at is null);`)
+				mock.ExpectQuery(query).
+					WithArgs(pq.StringArray(ids), false).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
+
+			data, err := repo.FindByIDs(context.Background(), ids, cs.inputFilter)
 
 			assert.Equal(t, cs.expectedErr, err)
 			assert.Equal(t, cs.expectedData, data)
@@ -221,25 +297,25 @@ func Test_Update(t *testing.T) {
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
 				UPDATE characters
-				SET name = $1, tv_series = $2, updated_at = $3
-				WHERE id = $4;
+				SET name = $1, tv_series = $2, updated_at = $3, search = character_search($4)
+				WHERE id = $5;
 				`)
 				mock.ExpectExec(query).
-					WithArgs(resp.Name, resp.TVSeries, resp.UpdatedAt, resp.ID).
+					WithArgs(resp.Name, resp.TVSeries, resp.UpdatedAt, resp.Name, resp.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
 		"Should return Error": {
 			input:       &resp,
-			expectedErr: errors.New("failed to update character"),
+			expectedErr: entities.NewDomainErr(nil, "failed to update character", errors.New("Problem to execute query")),
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
 				UPDATE characters
-				SET name = $1, tv_series = $2, updated_at = $3
-				WHERE id = $4;
+				SET name = $1, tv_series = $2, updated_at = $3, search = character_search($4)
+				WHERE id = $5;
 				`)
 				mock.ExpectExec(query).
-					WithArgs(resp.Name, resp.TVSeries, resp.UpdatedAt, resp.ID).
+					WithArgs(resp.Name, resp.TVSeries, resp.UpdatedAt, resp.Name, resp.ID).
 					WillReturnError(errors.New("Problem to execute query"))
 			},
 		},
@@ -278,7 +354,7 @@ func Test_Delete(t *testing.T) {
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
 				UPDATE characters
-				SET deleted_at = $1
+				SET deleted_at = $1, search = NULL
 				WHERE id = $2;
 				`)
 				mock.ExpectExec(query).
@@ -288,11 +364,11 @@ func Test_Delete(t *testing.T) {
 		},
 		"Should return Error": {
 			input:       id,
-			expectedErr: errors.New("failed to delete character"),
+			expectedErr: entities.NewDomainErr(nil, "failed to delete character", errors.New("Problem to execute query")),
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
 				UPDATE characters
-				SET deleted_at = $1
+				SET deleted_at = $1, search = NULL
 				WHERE id = $2;
 				`)
 				mock.ExpectExec(query).
@@ -316,3 +392,159 @@ func Test_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_Restore(t *testing.T) {
+	id := "33c55a43-f163-4a67-9f6c-75161410f376"
+	now := time.Now()
+	timeNow = func() time.Time {
+		return now
+	}
+	query := regexp.QuoteMeta(`
+	UPDATE characters
+	SET deleted_at = NULL, updated_at = $1, search = character_search(name)
+	WHERE id = $2;
+	`)
+
+	cases := map[string]struct {
+		expectedErr error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(now, id).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "failed to restore character", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(now, id).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
+
+			err := repo.Restore(context.Background(), id)
+
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_CountDeleted(t *testing.T) {
+	before := time.Now()
+	query := regexp.QuoteMeta(`
+	SELECT count(*)
+	FROM characters
+	WHERE deleted_at < $1;
+	`)
+
+	cases := map[string]struct {
+		expectedTotal int
+		expectedErr   error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			expectedTotal: 3,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(before).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "failed to count deleted characters", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(before).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
+
+			total, err := repo.CountDeleted(context.Background(), before)
+
+			assert.Equal(t, cs.expectedTotal, total)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_Purge(t *testing.T) {
+	before := time.Now()
+	query := regexp.QuoteMeta(`
+	WITH purged AS (
+		DELETE FROM characters
+		WHERE id IN (
+			SELECT id FROM characters
+			WHERE deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+		)
+		RETURNING id
+	), lordships AS (
+		DELETE FROM removed_lordships
+		WHERE lord_id IN (SELECT id FROM purged)
+	)
+	SELECT count(*) FROM purged;
+	`)
+
+	cases := map[string]struct {
+		expectedPurged int
+		expectedErr    error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			expectedPurged: 2,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(before, 100).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "failed to purge characters", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(before, 100).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
+
+			purged, err := repo.Purge(context.Background(), before, 100)
+
+			assert.Equal(t, cs.expectedPurged, purged)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
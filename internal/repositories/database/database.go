@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/lib/pq"
+)
+
+// Error classifies a failure of the database into a domain error with the
+// given message, keeping err as its cause.
+func Error(err error, message string) error {
+	return entities.NewDomainErr(kind(err), message, err)
+}
+
+func kind(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return entities.ErrNotFound
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code == "23505":
+			return entities.ErrConflict
+		// integrity constraint violation and data exception
+		case pqErr.Code.Class() == "23", pqErr.Code.Class() == "22":
+			return entities.ErrValidation
+		// connection exception, transaction rollback, insufficient resources
+		// and operator intervention, retrying later may succeed
+		case pqErr.Code.Class() == "08", pqErr.Code.Class() == "40",
+			pqErr.Code.Class() == "53", pqErr.Code.Class() == "57":
+			return entities.ErrUnavailable
+		default:
+			return nil
+		}
+	}
+
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) {
+		return entities.ErrUnavailable
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Error(t *testing.T) {
+	cases := map[string]struct {
+		input        error
+		expectedKind error
+	}{
+		"Should classify no rows as not found": {
+			input:        sql.ErrNoRows,
+			expectedKind: entities.ErrNotFound,
+		},
+		"Should classify unique violation as conflict": {
+			input:        &pq.Error{Code: "23505"},
+			expectedKind: entities.ErrConflict,
+		},
+		"Should classify not null violation as validation": {
+			input:        &pq.Error{Code: "23502"},
+			expectedKind: entities.ErrValidation,
+		},
+		"Should classify value too long as validation": {
+			input:        &pq.Error{Code: "22001"},
+			expectedKind: entities.ErrValidation,
+		},
+		"Should classify connection failure as unavailable": {
+			input:        &pq.Error{Code: "08006"},
+			expectedKind: entities.ErrUnavailable,
+		},
+		"Should classify bad connection as unavailable": {
+			input:        driver.ErrBadConn,
+			expectedKind: entities.ErrUnavailable,
+		},
+		"Should classify timeout as unavailable": {
+			input:        context.DeadlineExceeded,
+			expectedKind: entities.ErrUnavailable,
+		},
+		"Should not classify unknown errors": {
+			input: errors.New("Problem to execute query"),
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Error(cs.input, "problem to create house")
+
+			assert.Equal(t, entities.NewDomainErr(cs.expectedKind, "problem to create house", cs.input), err)
+			assert.EqualError(t, err, "problem to create house")
+			assert.ErrorIs(t, err, cs.input)
+			if cs.expectedKind != nil {
+				assert.ErrorIs(t, err, cs.expectedKind)
+			}
+		})
+	}
+}
@@ -3,16 +3,28 @@ package houses
 
 import (
 	"context"
+	"time"
 
 	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
 )
 
 type IRepository interface {
 	Create(ctx context.Context, house entities.HouseRequest) (err error)
-	Find(ctx context.Context) (houses []entities.House, err error)
-	FindByID(ctx context.Context, id string) (houses entities.House, err error)
-	FindByName(ctx context.Context, name string) (houses entities.House, err error)
-	RemoveLord(ctx context.Context, lordID string) (err error)
+	Find(ctx context.Context, filter entities.Filter) (houses []entities.House, err error)
+	FindByID(ctx context.Context, id string, filter entities.Filter) (houses entities.House, err error)
+	// FindByIDs returns the houses among ids in a single query, in no
+	// particular order.
+	FindByIDs(ctx context.Context, ids []string, filter entities.Filter) (houses []entities.House, err error)
+	FindByName(ctx context.Context, name string, filter entities.Filter) (houses entities.House, err error)
+	// RemoveLord clears the lord of every house ruled by lordID, remembering
+	// them so ReinstateLord can undo it.
+	RemoveLord(ctx context.Context, lordID string) (houseIDs []string, err error)
+	ReinstateLord(ctx context.Context, lordID string) (houseIDs []string, err error)
 	Update(ctx context.Context, house *entities.House) (err error)
 	Delete(ctx context.Context, id string) (err error)
+	Restore(ctx context.Context, id string) (err error)
+	CountDeleted(ctx context.Context, before time.Time) (total int, err error)
+	// Purge hard deletes up to limit rows soft deleted before the given time
+	// and reports how many were removed.
+	Purge(ctx context.Context, before time.Time, limit int) (purged int, err error)
 }
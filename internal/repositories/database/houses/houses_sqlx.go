@@ -3,13 +3,15 @@ package houses
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"time"
 
 	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/transaction"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"go.opentelemetry.io/otel/codes"
 )
 
@@ -29,93 +31,155 @@ func (repo *repoSqlx) Create(ctx context.Context, house entities.HouseRequest) (
 	ctx, span := tracer.Span(ctx, "repositories.database.houses.create")
 	defer span.End()
 
-	_, err = repo.writer.ExecContext(ctx,
+	_, err = transaction.Executor(ctx, repo.writer).ExecContext(ctx,
 		`INSERT INTO houses 
-		(id,name,region,foundation_year,current_lord,created_at)
-		VALUES ($1, $2, $3, $4, $5, $6);`,
+		(id,name,region,foundation_year,current_lord,created_at,search)
+		VALUES ($1, $2, $3, $4, $5, $6, house_search($2, $3));`,
 		house.ID, house.Name, house.Region, house.FoundationYear, house.CurrentLord, house.CreatedAt)
 	if err != nil {
 		repo.log.ErrorContext(ctx, "houses.SqlxRepo.Create", err)
-		return errors.New("problem to create house")
+		return database.Error(err, "problem to create house")
 	}
 
 	return nil
 }
 
-func (repo *repoSqlx) Find(ctx context.Context) (houses []entities.House, err error) {
+func (repo *repoSqlx) Find(ctx context.Context, filter entities.Filter) (houses []entities.House, err error) {
 	ctx, span := tracer.Span(ctx, "repositories.database.houses.find")
 	defer span.End()
 
 	houses = make([]entities.House, 0)
 	query := `
-	SELECT id, name, region, foundation_year, current_lord, created_at, updated_at
+	SELECT ` + entities.Columns(filter.Fields, entities.House{}) + `
 	FROM houses
-	WHERE deleted_at is null
-	ORDER BY created_at DESC;
+	WHERE ($1 OR deleted_at is null)
+	ORDER BY created_at DESC
+	LIMIT NULLIF($2, 0) OFFSET $3;
 	`
-	err = repo.reader.SelectContext(ctx, &houses, query)
+	err = repo.reader.SelectContext(ctx, &houses, query, filter.IncludeDeleted, filter.Limit, filter.Offset)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return houses, nil
 		}
 		repo.log.ErrorContext(ctx, "houses.SqlxRepo.Find", "Error on find house: ", err)
-		return nil, errors.New("problem to find houses")
+		return nil, database.Error(err, "problem to find houses")
 	}
 
 	return houses, nil
 }
 
-func (repo *repoSqlx) FindByID(ctx context.Context, id string) (houses entities.House, err error) {
+func (repo *repoSqlx) FindByID(ctx context.Context, id string, filter entities.Filter) (houses entities.House, err error) {
 	ctx, span := tracer.Span(ctx, "repositories.database.houses.findbyid")
 	defer span.End()
 
 	query := `
-	SELECT id, name, region, foundation_year, current_lord, created_at, updated_at
+	SELECT ` + entities.Columns(filter.Fields, entities.House{}) + `
 	FROM houses
-	WHERE id =$1 AND deleted_at is null;`
-	err = repo.reader.GetContext(ctx, &houses, query, id)
+	WHERE id =$1 AND ($2 OR deleted_at is null);`
+	err = repo.reader.GetContext(ctx, &houses, query, id, filter.IncludeDeleted)
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		repo.log.ErrorContext(ctx, "houses.SqlxRepo.FindByID", "Error on find house by id: ", id, err)
-		return houses, errors.New("house is not found or deleted")
+		return houses, database.Error(err, "house is not found or deleted")
 	}
 
 	return houses, nil
 }
 
-func (repo *repoSqlx) FindByName(ctx context.Context, name string) (houses entities.House, err error) {
+func (repo *repoSqlx) FindByIDs(ctx context.Context, ids []string, filter entities.Filter) (houses []entities.House, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.houses.findbyids")
+	defer span.End()
+
+	houses = make([]entities.House, 0, len(ids))
+	query := `
+	SELECT ` + entities.Columns(filter.Fields, entities.House{}) + `
+	FROM houses
+	WHERE id = ANY($1) AND ($2 OR deleted_at is null);`
+	err = repo.reader.SelectContext(ctx, &houses, query, pq.StringArray(ids), filter.IncludeDeleted)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		repo.log.ErrorContext(ctx, "houses.SqlxRepo.FindByIDs", "Error on find houses by ids: ", ids, err)
+		return nil, database.Error(err, "problem to find houses")
+	}
+
+	return houses, nil
+}
+
+func (repo *repoSqlx) FindByName(ctx context.Context, name string, filter entities.Filter) (houses entities.House, err error) {
 	ctx, span := tracer.Span(ctx, "repositories.database.houses.findbyname")
 	defer span.End()
 
+	// Deleted houses may share a name, so the live one comes first and then
+	// the last deleted.
 	query := `
-	SELECT id, name, region, foundation_year, current_lord, created_at, updated_at
+	SELECT ` + entities.Columns(filter.Fields, entities.House{}) + `
 	FROM houses
-	WHERE name=$1 AND deleted_at is null;`
-	err = repo.reader.GetContext(ctx, &houses, query, name)
+	WHERE house_name_key(name)=house_name_key($1) AND ($2 OR deleted_at is null)
+	ORDER BY deleted_at DESC NULLS FIRST
+	LIMIT 1;`
+	err = repo.reader.GetContext(ctx, &houses, query, name, filter.IncludeDeleted)
 	if err != nil {
 		repo.log.ErrorContext(ctx, "houses.SqlxRepo.FindByName", "Error on find house by name: ", name, err)
-		return houses, errors.New("house is not found or deleted")
+		return houses, database.Error(err, "house is not found or deleted")
 	}
 
 	return houses, nil
 }
 
-func (repo *repoSqlx) RemoveLord(ctx context.Context, lordID string) (err error) {
+func (repo *repoSqlx) RemoveLord(ctx context.Context, lordID string) (houseIDs []string, err error) {
 	ctx, span := tracer.Span(ctx, "repositories.database.houses.removelord")
 	defer span.End()
 
+	houseIDs = make([]string, 0)
 	query := `
-	UPDATE houses
-	SET current_lord = '', updated_at = $1
-	WHERE  current_lord= $2;
+	WITH removed AS (
+		UPDATE houses
+		SET current_lord = '', updated_at = $1
+		WHERE current_lord = $2
+		RETURNING id
+	), recorded AS (
+		INSERT INTO removed_lordships (house_id, lord_id, removed_at)
+		SELECT id, $2, $1 FROM removed
+		ON CONFLICT (house_id, lord_id) DO UPDATE SET removed_at = EXCLUDED.removed_at
+	)
+	SELECT id FROM removed;
 	`
-	_, err = repo.writer.ExecContext(ctx, query, timeNow(), lordID)
+	err = sqlx.SelectContext(ctx, transaction.Executor(ctx, repo.writer), &houseIDs, query, timeNow(), lordID)
 	if err != nil {
 		repo.log.ErrorContext(ctx, "houses.SqlxRepo.RemoveLord", "Error on remove current_lord by houses: ", lordID, err)
-		return errors.New("failed to remove current_lord by house")
+		return nil, database.Error(err, "failed to remove current_lord by house")
 	}
 
-	return nil
+	return houseIDs, nil
+}
+
+func (repo *repoSqlx) ReinstateLord(ctx context.Context, lordID string) (houseIDs []string, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.houses.reinstatelord")
+	defer span.End()
+
+	// houses that got another lord or were deleted meanwhile are left untouched
+	houseIDs = make([]string, 0)
+	query := `
+	WITH reinstated AS (
+		UPDATE houses
+		SET current_lord = removed_lordships.lord_id, updated_at = $1
+		FROM removed_lordships
+		WHERE removed_lordships.lord_id = $2 AND houses.id = removed_lordships.house_id
+			AND houses.current_lord = '' AND houses.deleted_at is null
+		RETURNING houses.id
+	), cleared AS (
+		DELETE FROM removed_lordships
+		WHERE lord_id = $2
+	)
+	SELECT id FROM reinstated;
+	`
+	err = sqlx.SelectContext(ctx, transaction.Executor(ctx, repo.writer), &houseIDs, query, timeNow(), lordID)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "houses.SqlxRepo.ReinstateLord", "Error on reinstate current_lord by houses: ", lordID, err)
+		return nil, database.Error(err, "failed to reinstate current_lord by house")
+	}
+
+	return houseIDs, nil
 }
 
 func (repo *repoSqlx) Update(ctx context.Context, house *entities.House) (err error) {
@@ -124,13 +188,14 @@ func (repo *repoSqlx) Update(ctx context.Context, house *entities.House) (err er
 
 	query := `
 	UPDATE houses
-	SET name = :name, region = :region, foundation_year = :foundation_year, current_lord = :current_lord, updated_at = :updated_at
+	SET name = :name, region = :region, foundation_year = :foundation_year, current_lord = :current_lord, updated_at = :updated_at,
+		search = house_search(:name, :region)
 	WHERE id = :id;
 	`
-	_, err = repo.writer.NamedExecContext(ctx, query, house)
+	_, err = sqlx.NamedExecContext(ctx, transaction.Executor(ctx, repo.writer), query, house)
 	if err != nil {
 		repo.log.ErrorContext(ctx, "houses.SqlxRepo.Update", "Error on update house: ", house, err)
-		return errors.New("failed to update house")
+		return database.Error(err, "failed to update house")
 	}
 
 	return nil
@@ -142,14 +207,79 @@ func (repo *repoSqlx) Delete(ctx context.Context, id string) (err error) {
 
 	query := `
 	UPDATE houses
-	SET deleted_at = $1
+	SET deleted_at = $1, search = NULL
 	WHERE id = $2;
 	`
-	_, err = repo.writer.ExecContext(ctx, query, timeNow(), id)
+	_, err = transaction.Executor(ctx, repo.writer).ExecContext(ctx, query, timeNow(), id)
 	if err != nil {
 		repo.log.ErrorContext(ctx, "houses.SqlxRepo.Delete", "Error on delete house: ", id, err)
-		return errors.New("failed to delete house")
+		return database.Error(err, "failed to delete house")
 	}
 
 	return nil
 }
+
+func (repo *repoSqlx) Restore(ctx context.Context, id string) (err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.houses.restore")
+	defer span.End()
+
+	query := `
+	UPDATE houses
+	SET deleted_at = NULL, updated_at = $1, search = house_search(name, region)
+	WHERE id = $2;
+	`
+	_, err = transaction.Executor(ctx, repo.writer).ExecContext(ctx, query, timeNow(), id)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "houses.SqlxRepo.Restore", "Error on restore house: ", id, err)
+		return database.Error(err, "failed to restore house")
+	}
+
+	return nil
+}
+
+func (repo *repoSqlx) CountDeleted(ctx context.Context, before time.Time) (total int, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.houses.countdeleted")
+	defer span.End()
+
+	query := `
+	SELECT count(*)
+	FROM houses
+	WHERE deleted_at < $1;
+	`
+	err = repo.reader.GetContext(ctx, &total, query, before)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "houses.SqlxRepo.CountDeleted", err)
+		return total, database.Error(err, "failed to count deleted houses")
+	}
+
+	return total, nil
+}
+
+func (repo *repoSqlx) Purge(ctx context.Context, before time.Time, limit int) (purged int, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.houses.purge")
+	defer span.End()
+
+	query := `
+	WITH purged AS (
+		DELETE FROM houses
+		WHERE id IN (
+			SELECT id FROM houses
+			WHERE deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+		)
+		RETURNING id
+	), lordships AS (
+		DELETE FROM removed_lordships
+		WHERE house_id IN (SELECT id FROM purged)
+	)
+	SELECT count(*) FROM purged;
+	`
+	err = sqlx.GetContext(ctx, transaction.Executor(ctx, repo.writer), &purged, query, before, limit)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "houses.SqlxRepo.Purge", err)
+		return purged, database.Error(err, "failed to purge houses")
+	}
+
+	return purged, nil
+}
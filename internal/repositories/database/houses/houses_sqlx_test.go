@@ -12,6 +12,7 @@ import (
 	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
 	"github.com/PatrickChagastavares/game-of-thrones/test"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -35,8 +36,8 @@ func Test_Create(t *testing.T) {
 			input: data,
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`INSERT INTO houses 
-				(id,name,region,foundation_year,current_lord,created_at)
-				VALUES ($1, $2, $3, $4, $5, $6);`)
+				(id,name,region,foundation_year,current_lord,created_at,search)
+				VALUES ($1, $2, $3, $4, $5, $6, house_search($2, $3));`)
 				mock.ExpectExec(query).
 					WithArgs(data.ID, data.Name, data.Region, data.FoundationYear, data.CurrentLord, data.CreatedAt).
 					WillReturnResult(sqlmock.NewResult(1, 1))
@@ -44,11 +45,11 @@ func Test_Create(t *testing.T) {
 		},
 		"Should return Error": {
 			input:       data,
-			expectedErr: errors.New("problem to create house"),
+			expectedErr: entities.NewDomainErr(nil, "problem to create house", errors.New("Problem to execute query")),
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`INSERT INTO houses 
-				(id,name,region,foundation_year,current_lord,created_at)
-				VALUES ($1, $2, $3, $4, $5, $6);`)
+				(id,name,region,foundation_year,current_lord,created_at,search)
+				VALUES ($1, $2, $3, $4, $5, $6, house_search($2, $3));`)
 				mock.ExpectExec(query).
 					WithArgs(data.ID, data.Name, data.Region, data.FoundationYear, data.CurrentLord, data.CreatedAt).
 					WillReturnError(errors.New("Problem to execute query"))
@@ -78,6 +79,7 @@ func Test_Find(t *testing.T) {
 	}
 
 	cases := map[string]struct {
+		inputFilter  entities.Filter
 		expectedData []entities.House
 		expectedErr  error
 
@@ -87,15 +89,35 @@ func Test_Find(t *testing.T) {
 			expectedData: resp,
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
-				SELECT id, name, region, foundation_year, current_lord, created_at, updated_at
+				SELECT id, name, region, foundation_year, current_lord, created_at, updated_at, deleted_at
 				FROM houses
-				WHERE deleted_at is null
-				ORDER BY created_at DESC;
+				WHERE ($1 OR deleted_at is null)
+				ORDER BY created_at DESC
+				LIMIT NULLIF($2, 0) OFFSET $3;
 				`)
 				rows := test.NewRows("id", "name", "region", "foundation_year", "current_lord", "created_at", "updated_at").
 					AddRow(resp[0].ID, resp[0].Name, resp[0].Region, resp[0].FoundationYear, resp[0].CurrentLord, resp[0].CreatedAt, nil).
 					AddRow(resp[1].ID, resp[1].Name, resp[1].Region, resp[1].FoundationYear, resp[1].CurrentLord, resp[1].CreatedAt, nil)
 				mock.ExpectQuery(query).
+					WithArgs(false, 0, 0).
+					WillReturnRows(rows)
+			},
+		},
+		"Should return success with a page": {
+			inputFilter:  entities.Filter{Limit: 1, Offset: 1},
+			expectedData: resp[1:],
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				query := regexp.QuoteMeta(`
+				SELECT id, name, region, foundation_year, current_lord, created_at, updated_at, deleted_at
+				FROM houses
+				WHERE ($1 OR deleted_at is null)
+				ORDER BY created_at DESC
+				LIMIT NULLIF($2, 0) OFFSET $3;
+				`)
+				rows := test.NewRows("id", "name", "region", "foundation_year", "current_lord", "created_at", "updated_at").
+					AddRow(resp[1].ID, resp[1].Name, resp[1].Region, resp[1].FoundationYear, resp[1].CurrentLord, resp[1].CreatedAt, nil)
+				mock.ExpectQuery(query).
+					WithArgs(false, 1, 1).
 					WillReturnRows(rows)
 			},
 		},
@@ -103,25 +125,27 @@ func Test_Find(t *testing.T) {
 			expectedData: []entities.House{},
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
-				SELECT id, name, region, foundation_year, current_lord, created_at, updated_at
+				SELECT id, name, region, foundation_year, current_lord, created_at, updated_at, deleted_at
 				FROM houses
-				WHERE deleted_at is null
-				ORDER BY created_at DESC;
+				WHERE ($1 OR deleted_at is null)
+				ORDER BY created_at DESC
+				LIMIT NULLIF($2, 0) OFFSET $3;
 				`)
 				mock.ExpectQuery(query).
 					WillReturnError(sql.ErrNoRows)
 			},
 		},
 		"Should return Error": {
-			expectedErr: errors.New("problem to find houses"),
+			expectedErr: entities.NewDomainErr(nil, "problem to find houses", errors.New("Problem to execute query")),
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
-				SELECT id, name, region, foundation_year, current_lord, created_at, updated_at
+				SELECT id, name, region, foundation_year, current_lord, created_at, updated_at, deleted_at
 				FROM houses
-				WHERE deleted_at is null
-				ORDER BY created_at DESC;
+				WHERE ($1 OR deleted_at is null)
+				ORDER BY created_at DESC
+				LIMIT NULLIF($2, 0) OFFSET $3;
 				`)
-				mock.ExpectExec(query).
+				mock.ExpectQuery(query).
 					WillReturnError(errors.New("Problem to execute query"))
 			},
 		},
@@ -135,7 +159,7 @@ func Test_Find(t *testing.T) {
 
 			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
 
-			data, err := repo.Find(context.Background())
+			data, err := repo.Find(context.Background(), cs.inputFilter)
 
 			assert.Equal(t, cs.expectedErr, err)
 			assert.Equal(t, cs.expectedData, data)
@@ -155,6 +179,7 @@ func Test_FindByID(t *testing.T) {
 
 	cases := map[string]struct {
 		input        string
+		filter       entities.Filter
 		expectedData entities.House
 		expectedErr  error
 
@@ -165,26 +190,98 @@ func Test_FindByID(t *testing.T) {
 			expectedData: resp,
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
-				SELECT id, name, region, foundation_year, current_lord, created_at, updated_at
+				SELECT id, name, region, foundation_year, current_lord, created_at, updated_at, deleted_at
 				FROM houses
-				WHERE id =$1 AND deleted_at is null;`)
+				WHERE id =$1 AND ($2 OR deleted_at is null);`)
 				rows := test.NewRows("id", "name", "region", "foundation_year", "current_lord", "created_at", "updated_at").
 					AddRow(resp.ID, resp.Name, resp.Region, resp.FoundationYear, resp.CurrentLord, resp.CreatedAt, nil)
 				mock.ExpectQuery(query).
-					WithArgs(resp.ID).
+					WithArgs(resp.ID, false).
+					WillReturnRows(rows)
+			},
+		},
+		"Should select only the columns of the fields": {
+			input:        resp.ID,
+			filter:       entities.Filter{Fields: []string{"name", "current_lord"}},
+			expectedData: entities.House{ID: resp.ID, Name: resp.Name, CurrentLord: resp.CurrentLord},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				query := regexp.QuoteMeta(`
+				SELECT id, name, current_lord
+				FROM houses
+				WHERE id =$1 AND ($2 OR deleted_at is null);`)
+				rows := test.NewRows("id", "name", "current_lord").
+					AddRow(resp.ID, resp.Name, resp.CurrentLord)
+				mock.ExpectQuery(query).
+					WithArgs(resp.ID, false).
 					WillReturnRows(rows)
 			},
 		},
 		"Should return Error": {
 			input:       resp.ID,
-			expectedErr: errors.New("house is not found or deleted"),
+			expectedErr: entities.NewDomainErr(nil, "house is not found or deleted", errors.New("Problem to execute query")),
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
-				SELECT id, name, region, foundation_year, current_lord, created_at, updated_at
+				SELECT id, name, region, foundation_year, current_lord, created_at, updated_at, deleted_at
 				FROM houses
-				WHERE id =$1 AND deleted_at is null;`)
-				mock.ExpectExec(query).
-					WithArgs(resp.ID).
+				WHERE id =$1 AND ($2 OR deleted_at is null);`)
+				mock.ExpectQuery(query).
+					WithArgs(resp.ID, false).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
+
+			data, err := repo.FindByID(context.Background(), cs.input, cs.filter)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, data)
+		})
+	}
+}
+
+func Test_FindByIDs(t *testing.T) {
+	resp := []entities.House{
+		{ID: "id_1", Name: "house Patrick", Region: "sao paulo", FoundationYear: "2023"},
+		{ID: "id_2", Name: "house Chagas", Region: "sao paulo", FoundationYear: "2023"},
+	}
+	ids := []string{"id_2", "id_1", "id_3"}
+	query := regexp.QuoteMeta(`
+	SELECT id, name, region, foundation_year, current_lord, created_at, updated_at, deleted_at
+	FROM houses
+	WHERE id = ANY($1) AND ($2 OR deleted_at is null);`)
+
+	cases := map[string]struct {
+		filter       entities.Filter
+		expectedData []entities.House
+		expectedErr  error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			filter:       entities.Filter{IncludeDeleted: true},
+			expectedData: resp,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				rows := test.NewRows("id", "name", "region", "foundation_year", "current_lord", "created_at", "updated_at").
+					AddRow(resp[0].ID, resp[0].Name, resp[0].Region, resp[0].FoundationYear, "", resp[0].CreatedAt, nil).
+					AddRow(resp[1].ID, resp[1].Name, resp[1].Region, resp[1].FoundationYear, "", resp[1].CreatedAt, nil)
+				mock.ExpectQuery(query).
+					WithArgs(pq.StringArray(ids), true).
+					WillReturnRows(rows)
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "problem to find houses", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(pq.StringArray(ids), false).
 					WillReturnError(errors.New("Problem to execute query"))
 			},
 		},
@@ -198,7 +295,7 @@ func Test_FindByID(t *testing.T) {
 
 			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
 
-			data, err := repo.FindByID(context.Background(), cs.input)
+			data, err := repo.FindByIDs(context.Background(), ids, cs.filter)
 
 			assert.Equal(t, cs.expectedErr, err)
 			assert.Equal(t, cs.expectedData, data)
@@ -216,8 +313,16 @@ func Test_FindByName(t *testing.T) {
 		CreatedAt:      time.Now(),
 	}
 
+	query := regexp.QuoteMeta(`
+	SELECT id, name, region, foundation_year, current_lord, created_at, updated_at, deleted_at
+	FROM houses
+	WHERE house_name_key(name)=house_name_key($1) AND ($2 OR deleted_at is null)
+	ORDER BY deleted_at DESC NULLS FIRST
+	LIMIT 1;`)
+
 	cases := map[string]struct {
 		input        string
+		filter       entities.Filter
 		expectedData entities.House
 		expectedErr  error
 
@@ -227,27 +332,46 @@ func Test_FindByName(t *testing.T) {
 			input:        resp.Name,
 			expectedData: resp,
 			prepareMock: func(mock sqlmock.Sqlmock) {
-				query := regexp.QuoteMeta(`
-				SELECT id, name, region, foundation_year, current_lord, created_at, updated_at
-				FROM houses
-				WHERE name=$1 AND deleted_at is null;`)
 				rows := test.NewRows("id", "name", "region", "foundation_year", "current_lord", "created_at", "updated_at").
 					AddRow(resp.ID, resp.Name, resp.Region, resp.FoundationYear, resp.CurrentLord, resp.CreatedAt, nil)
 				mock.ExpectQuery(query).
-					WithArgs(resp.Name).
+					WithArgs(resp.Name, false).
 					WillReturnRows(rows)
 			},
 		},
-		"Should return Error": {
-			input:       resp.Name,
-			expectedErr: errors.New("house is not found or deleted"),
+		"Should select the columns of the fields and the deleted houses": {
+			input:        resp.Name,
+			filter:       entities.Filter{Fields: []string{"name"}, IncludeDeleted: true},
+			expectedData: entities.House{ID: resp.ID, Name: resp.Name},
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
-				SELECT id, name, region, foundation_year, current_lord, created_at, updated_at
+				SELECT id, name
 				FROM houses
-				WHERE name=$1 AND deleted_at is null;`)
-				mock.ExpectExec(query).
-					WithArgs(resp.Name).
+				WHERE house_name_key(name)=house_name_key($1) AND ($2 OR deleted_at is null)
+				ORDER BY deleted_at DESC NULLS FIRST
+				LIMIT 1;`)
+				rows := test.NewRows("id", "name").
+					AddRow(resp.ID, resp.Name)
+				mock.ExpectQuery(query).
+					WithArgs(resp.Name, true).
+					WillReturnRows(rows)
+			},
+		},
+		"Should return Error not found": {
+			input:       resp.Name,
+			expectedErr: entities.NewDomainErr(entities.ErrNotFound, "house is not found or deleted", sql.ErrNoRows),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(resp.Name, false).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		"Should return Error": {
+			input:       resp.Name,
+			expectedErr: entities.NewDomainErr(nil, "house is not found or deleted", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(resp.Name, false).
 					WillReturnError(errors.New("Problem to execute query"))
 			},
 		},
@@ -261,7 +385,7 @@ func Test_FindByName(t *testing.T) {
 
 			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
 
-			data, err := repo.FindByName(context.Background(), cs.input)
+			data, err := repo.FindByName(context.Background(), cs.input, cs.filter)
 
 			assert.Equal(t, cs.expectedErr, err)
 			assert.Equal(t, cs.expectedData, data)
@@ -277,34 +401,52 @@ func Test_RemoveLord(t *testing.T) {
 	}
 
 	cases := map[string]struct {
-		input       string
-		expectedErr error
+		input          string
+		expectedHouses []string
+		expectedErr    error
 
 		prepareMock func(mock sqlmock.Sqlmock)
 	}{
 		"Should return success": {
-			input: input,
+			input:          input,
+			expectedHouses: []string{"house_1"},
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
-				UPDATE houses
-				SET current_lord = '', updated_at = $1
-				WHERE  current_lord= $2;
+				WITH removed AS (
+					UPDATE houses
+					SET current_lord = '', updated_at = $1
+					WHERE current_lord = $2
+					RETURNING id
+				), recorded AS (
+					INSERT INTO removed_lordships (house_id, lord_id, removed_at)
+					SELECT id, $2, $1 FROM removed
+					ON CONFLICT (house_id, lord_id) DO UPDATE SET removed_at = EXCLUDED.removed_at
+				)
+				SELECT id FROM removed;
 				`)
-				mock.ExpectExec(query).
+				mock.ExpectQuery(query).
 					WithArgs(now, input).
-					WillReturnResult(sqlmock.NewResult(1, 1))
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("house_1"))
 			},
 		},
 		"Should return Error": {
 			input:       input,
-			expectedErr: errors.New("failed to remove current_lord by house"),
+			expectedErr: entities.NewDomainErr(nil, "failed to remove current_lord by house", errors.New("Problem to execute query")),
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
-				UPDATE houses
-				SET current_lord = '', updated_at = $1
-				WHERE  current_lord= $2;
+				WITH removed AS (
+					UPDATE houses
+					SET current_lord = '', updated_at = $1
+					WHERE current_lord = $2
+					RETURNING id
+				), recorded AS (
+					INSERT INTO removed_lordships (house_id, lord_id, removed_at)
+					SELECT id, $2, $1 FROM removed
+					ON CONFLICT (house_id, lord_id) DO UPDATE SET removed_at = EXCLUDED.removed_at
+				)
+				SELECT id FROM removed;
 				`)
-				mock.ExpectExec(query).
+				mock.ExpectQuery(query).
 					WithArgs(now, input).
 					WillReturnError(errors.New("Problem to execute query"))
 			},
@@ -319,13 +461,78 @@ func Test_RemoveLord(t *testing.T) {
 
 			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
 
-			err := repo.RemoveLord(context.Background(), cs.input)
+			houseIDs, err := repo.RemoveLord(context.Background(), cs.input)
 
+			assert.Equal(t, cs.expectedHouses, houseIDs)
 			assert.Equal(t, cs.expectedErr, err)
 		})
 	}
 }
 
+func Test_ReinstateLord(t *testing.T) {
+	input := "lord_Id_123"
+	now := time.Now()
+	timeNow = func() time.Time {
+		return now
+	}
+	query := regexp.QuoteMeta(`
+	WITH reinstated AS (
+		UPDATE houses
+		SET current_lord = removed_lordships.lord_id, updated_at = $1
+		FROM removed_lordships
+		WHERE removed_lordships.lord_id = $2 AND houses.id = removed_lordships.house_id
+			AND houses.current_lord = '' AND houses.deleted_at is null
+		RETURNING houses.id
+	), cleared AS (
+		DELETE FROM removed_lordships
+		WHERE lord_id = $2
+	)
+	SELECT id FROM reinstated;
+	`)
+
+	cases := map[string]struct {
+		input        string
+		expectedData []string
+		expectedErr  error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			input:        input,
+			expectedData: []string{"id_1", "id_2"},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(now, input).
+					WillReturnRows(test.NewRows("id").AddRow("id_1").AddRow("id_2"))
+			},
+		},
+		"Should return Error": {
+			input:       input,
+			expectedErr: entities.NewDomainErr(nil, "failed to reinstate current_lord by house", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(now, input).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
+
+			data, err := repo.ReinstateLord(context.Background(), cs.input)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, data)
+		})
+	}
+}
+
 func Test_Update(t *testing.T) {
 	now := time.Now()
 	resp := entities.House{
@@ -349,25 +556,27 @@ func Test_Update(t *testing.T) {
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
 				UPDATE houses
-				SET name = $1, region = $2, foundation_year = $3, current_lord = $4, updated_at = $5
-				WHERE id = $6;
+				SET name = $1, region = $2, foundation_year = $3, current_lord = $4, updated_at = $5,
+					search = house_search($6, $7)
+				WHERE id = $8;
 				`)
 				mock.ExpectExec(query).
-					WithArgs(resp.Name, resp.Region, resp.FoundationYear, resp.CurrentLord, resp.UpdatedAt, resp.ID).
+					WithArgs(resp.Name, resp.Region, resp.FoundationYear, resp.CurrentLord, resp.UpdatedAt, resp.Name, resp.Region, resp.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
 		"Should return Error": {
 			input:       &resp,
-			expectedErr: errors.New("failed to update house"),
+			expectedErr: entities.NewDomainErr(nil, "failed to update house", errors.New("Problem to execute query")),
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
 				UPDATE houses
-				SET name = $1, region = $2, foundation_year = $3, current_lord = $4, updated_at = $5
-				WHERE id = $6;
+				SET name = $1, region = $2, foundation_year = $3, current_lord = $4, updated_at = $5,
+					search = house_search($6, $7)
+				WHERE id = $8;
 				`)
 				mock.ExpectExec(query).
-					WithArgs(resp.Name, resp.Region, resp.FoundationYear, resp.CurrentLord, resp.UpdatedAt, resp.ID).
+					WithArgs(resp.Name, resp.Region, resp.FoundationYear, resp.CurrentLord, resp.UpdatedAt, resp.Name, resp.Region, resp.ID).
 					WillReturnError(errors.New("Problem to execute query"))
 			},
 		},
@@ -406,7 +615,7 @@ func Test_Delete(t *testing.T) {
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
 				UPDATE houses
-				SET deleted_at = $1
+				SET deleted_at = $1, search = NULL
 				WHERE id = $2;
 				`)
 				mock.ExpectExec(query).
@@ -416,11 +625,11 @@ func Test_Delete(t *testing.T) {
 		},
 		"Should return Error": {
 			input:       id,
-			expectedErr: errors.New("failed to delete house"),
+			expectedErr: entities.NewDomainErr(nil, "failed to delete house", errors.New("Problem to execute query")),
 			prepareMock: func(mock sqlmock.Sqlmock) {
 				query := regexp.QuoteMeta(`
 				UPDATE houses
-				SET deleted_at = $1
+				SET deleted_at = $1, search = NULL
 				WHERE id = $2;
 				`)
 				mock.ExpectExec(query).
@@ -444,3 +653,159 @@ func Test_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_Restore(t *testing.T) {
+	id := "33c55a43-f163-4a67-9f6c-75161410f376"
+	now := time.Now()
+	timeNow = func() time.Time {
+		return now
+	}
+	query := regexp.QuoteMeta(`
+	UPDATE houses
+	SET deleted_at = NULL, updated_at = $1, search = house_search(name, region)
+	WHERE id = $2;
+	`)
+
+	cases := map[string]struct {
+		expectedErr error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(now, id).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "failed to restore house", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(now, id).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
+
+			err := repo.Restore(context.Background(), id)
+
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_CountDeleted(t *testing.T) {
+	before := time.Now()
+	query := regexp.QuoteMeta(`
+	SELECT count(*)
+	FROM houses
+	WHERE deleted_at < $1;
+	`)
+
+	cases := map[string]struct {
+		expectedTotal int
+		expectedErr   error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			expectedTotal: 3,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(before).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "failed to count deleted houses", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(before).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
+
+			total, err := repo.CountDeleted(context.Background(), before)
+
+			assert.Equal(t, cs.expectedTotal, total)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_Purge(t *testing.T) {
+	before := time.Now()
+	query := regexp.QuoteMeta(`
+	WITH purged AS (
+		DELETE FROM houses
+		WHERE id IN (
+			SELECT id FROM houses
+			WHERE deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+		)
+		RETURNING id
+	), lordships AS (
+		DELETE FROM removed_lordships
+		WHERE house_id IN (SELECT id FROM purged)
+	)
+	SELECT count(*) FROM purged;
+	`)
+
+	cases := map[string]struct {
+		expectedPurged int
+		expectedErr    error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			expectedPurged: 2,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(before, 100).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "failed to purge houses", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(before, 100).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
+
+			purged, err := repo.Purge(context.Background(), before, 100)
+
+			assert.Equal(t, cs.expectedPurged, purged)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
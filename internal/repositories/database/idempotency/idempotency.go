@@ -0,0 +1,22 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+)
+
+type IRepository interface {
+	// Create stores a new key, overwriting an expired one or, for the same
+	// payload, one whose request lost its lease without completing. It
+	// returns false when a live key already exists for the same scope.
+	Create(ctx context.Context, key entities.IdempotencyKey) (created bool, err error)
+	Find(ctx context.Context, key, scope string) (idempotencyKey entities.IdempotencyKey, err error)
+	Complete(ctx context.Context, key entities.IdempotencyKey) (err error)
+	Delete(ctx context.Context, key, scope string) (err error)
+	// DeleteExpired deletes up to limit keys expired before, returning how
+	// many were deleted.
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (deleted int, err error)
+}
@@ -0,0 +1,130 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/jmoiron/sqlx"
+)
+
+type repoSqlx struct {
+	log    logger.Logger
+	writer *sqlx.DB
+}
+
+// NewSqlx keys are always read from the writer, a replica lagging behind would
+// let a retry run the request twice.
+func NewSqlx(log logger.Logger, writer *sqlx.DB) IRepository {
+	return &repoSqlx{log: log, writer: writer}
+}
+
+func (repo *repoSqlx) Create(ctx context.Context, key entities.IdempotencyKey) (created bool, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.idempotency.create")
+	defer span.End()
+
+	result, err := repo.writer.ExecContext(ctx,
+		`INSERT INTO idempotency_keys 
+		(key,scope,request_hash,created_at,expires_at,locked_until)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (key, scope) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, status_code = 0, response = NULL, content_type = DEFAULT,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at, locked_until = EXCLUDED.locked_until
+		WHERE idempotency_keys.expires_at < EXCLUDED.created_at
+			OR (idempotency_keys.status_code = 0 AND idempotency_keys.locked_until < EXCLUDED.created_at
+				AND idempotency_keys.request_hash = EXCLUDED.request_hash);`,
+		key.Key, key.Scope, key.RequestHash, key.CreatedAt, key.ExpiresAt, key.LockedUntil)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "idempotency.SqlxRepo.Create", err)
+		return false, database.Error(err, "problem to create idempotency key")
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		repo.log.ErrorContext(ctx, "idempotency.SqlxRepo.Create", err)
+		return false, database.Error(err, "problem to create idempotency key")
+	}
+
+	return rows > 0, nil
+}
+
+func (repo *repoSqlx) Find(ctx context.Context, key, scope string) (idempotencyKey entities.IdempotencyKey, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.idempotency.find")
+	defer span.End()
+
+	query := `
+	SELECT key, scope, request_hash, status_code, response, content_type, created_at, expires_at, locked_until
+	FROM idempotency_keys
+	WHERE key = $1 AND scope = $2;`
+	err = repo.writer.GetContext(ctx, &idempotencyKey, query, key, scope)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "idempotency.SqlxRepo.Find", "Error on find idempotency key: ", key, err)
+		return idempotencyKey, database.Error(err, "idempotency key is not found")
+	}
+
+	return idempotencyKey, nil
+}
+
+func (repo *repoSqlx) Complete(ctx context.Context, key entities.IdempotencyKey) (err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.idempotency.complete")
+	defer span.End()
+
+	query := `
+	UPDATE idempotency_keys
+	SET status_code = :status_code, response = :response, content_type = :content_type
+	WHERE key = :key AND scope = :scope;
+	`
+	_, err = repo.writer.NamedExecContext(ctx, query, key)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "idempotency.SqlxRepo.Complete", "Error on complete idempotency key: ", key.Key, err)
+		return database.Error(err, "failed to complete idempotency key")
+	}
+
+	return nil
+}
+
+func (repo *repoSqlx) Delete(ctx context.Context, key, scope string) (err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.idempotency.delete")
+	defer span.End()
+
+	query := `
+	DELETE FROM idempotency_keys
+	WHERE key = $1 AND scope = $2;
+	`
+	_, err = repo.writer.ExecContext(ctx, query, key, scope)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "idempotency.SqlxRepo.Delete", "Error on delete idempotency key: ", key, err)
+		return database.Error(err, "failed to delete idempotency key")
+	}
+
+	return nil
+}
+
+func (repo *repoSqlx) DeleteExpired(ctx context.Context, before time.Time, limit int) (deleted int, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.idempotency.deleteexpired")
+	defer span.End()
+
+	query := `
+	WITH deleted AS (
+		DELETE FROM idempotency_keys
+		WHERE (key, scope) IN (
+			SELECT key, scope FROM idempotency_keys
+			WHERE expires_at < $1
+			ORDER BY expires_at
+			LIMIT $2
+		)
+		RETURNING key
+	)
+	SELECT count(*) FROM deleted;
+	`
+	err = repo.writer.GetContext(ctx, &deleted, query, before, limit)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "idempotency.SqlxRepo.DeleteExpired", err)
+		return deleted, database.Error(err, "failed to delete expired idempotency keys")
+	}
+
+	return deleted, nil
+}
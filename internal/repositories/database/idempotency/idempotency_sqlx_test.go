@@ -0,0 +1,295 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const createQuery = `INSERT INTO idempotency_keys 
+		(key,scope,request_hash,created_at,expires_at,locked_until)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (key, scope) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, status_code = 0, response = NULL, content_type = DEFAULT,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at, locked_until = EXCLUDED.locked_until
+		WHERE idempotency_keys.expires_at < EXCLUDED.created_at
+			OR (idempotency_keys.status_code = 0 AND idempotency_keys.locked_until < EXCLUDED.created_at
+				AND idempotency_keys.request_hash = EXCLUDED.request_hash);`
+
+func Test_Create(t *testing.T) {
+	data := entities.IdempotencyKey{
+		Key:         "key_1",
+		Scope:       "POST /houses",
+		RequestHash: "hash",
+		CreatedAt:   time.Now(),
+		ExpiresAt:   time.Now().Add(time.Hour),
+		LockedUntil: time.Now().Add(time.Minute),
+	}
+
+	cases := map[string]struct {
+		input           entities.IdempotencyKey
+		expectedCreated bool
+		expectedErr     error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return created": {
+			input:           data,
+			expectedCreated: true,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(createQuery)).
+					WithArgs(data.Key, data.Scope, data.RequestHash, data.CreatedAt, data.ExpiresAt, data.LockedUntil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		"Should return not created when key is live": {
+			input: data,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(createQuery)).
+					WithArgs(data.Key, data.Scope, data.RequestHash, data.CreatedAt, data.ExpiresAt, data.LockedUntil).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		"Should return Error": {
+			input:       data,
+			expectedErr: entities.NewDomainErr(nil, "problem to create idempotency key", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(createQuery)).
+					WithArgs(data.Key, data.Scope, data.RequestHash, data.CreatedAt, data.ExpiresAt, data.LockedUntil).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			created, err := repo.Create(context.Background(), cs.input)
+
+			assert.Equal(t, cs.expectedCreated, created)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_Find(t *testing.T) {
+	data := entities.IdempotencyKey{
+		Key:         "key_1",
+		Scope:       "POST /houses",
+		RequestHash: "hash",
+		StatusCode:  201,
+		Response:    []byte(`{"id":"id_1"}`),
+		ContentType: "application/json",
+		CreatedAt:   time.Now(),
+		ExpiresAt:   time.Now().Add(time.Hour),
+		LockedUntil: time.Now(),
+	}
+	query := regexp.QuoteMeta(`
+	SELECT key, scope, request_hash, status_code, response, content_type, created_at, expires_at, locked_until
+	FROM idempotency_keys
+	WHERE key = $1 AND scope = $2;`)
+
+	cases := map[string]struct {
+		expectedData entities.IdempotencyKey
+		expectedErr  error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			expectedData: data,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				rows := test.NewRows("key", "scope", "request_hash", "status_code", "response", "content_type", "created_at", "expires_at", "locked_until").
+					AddRow(data.Key, data.Scope, data.RequestHash, data.StatusCode, data.Response, data.ContentType, data.CreatedAt, data.ExpiresAt, data.LockedUntil)
+				mock.ExpectQuery(query).
+					WithArgs(data.Key, data.Scope).
+					WillReturnRows(rows)
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "idempotency key is not found", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(data.Key, data.Scope).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			data, err := repo.Find(context.Background(), "key_1", "POST /houses")
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, data)
+		})
+	}
+}
+
+func Test_Complete(t *testing.T) {
+	data := entities.IdempotencyKey{
+		Key:         "key_1",
+		Scope:       "POST /houses",
+		StatusCode:  201,
+		Response:    []byte(`{"id":"id_1"}`),
+		ContentType: "application/json",
+	}
+	query := regexp.QuoteMeta(`
+	UPDATE idempotency_keys
+	SET status_code = $1, response = $2, content_type = $3
+	WHERE key = $4 AND scope = $5;
+	`)
+
+	cases := map[string]struct {
+		expectedErr error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(data.StatusCode, data.Response, data.ContentType, data.Key, data.Scope).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "failed to complete idempotency key", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(data.StatusCode, data.Response, data.ContentType, data.Key, data.Scope).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			err := repo.Complete(context.Background(), data)
+
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	query := regexp.QuoteMeta(`
+	DELETE FROM idempotency_keys
+	WHERE key = $1 AND scope = $2;
+	`)
+
+	cases := map[string]struct {
+		expectedErr error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs("key_1", "POST /houses").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "failed to delete idempotency key", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs("key_1", "POST /houses").
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			err := repo.Delete(context.Background(), "key_1", "POST /houses")
+
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_DeleteExpired(t *testing.T) {
+	before := time.Now()
+	query := regexp.QuoteMeta(`
+	WITH deleted AS (
+		DELETE FROM idempotency_keys
+		WHERE (key, scope) IN (
+			SELECT key, scope FROM idempotency_keys
+			WHERE expires_at < $1
+			ORDER BY expires_at
+			LIMIT $2
+		)
+		RETURNING key
+	)
+	SELECT count(*) FROM deleted;
+	`)
+
+	cases := map[string]struct {
+		expectedDeleted int
+		expectedErr     error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			expectedDeleted: 2,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(before, 100).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "failed to delete expired idempotency keys", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(before, 100).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			deleted, err := repo.DeleteExpired(context.Background(), before, 100)
+
+			assert.Equal(t, cs.expectedDeleted, deleted)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
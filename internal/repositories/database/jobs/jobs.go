@@ -0,0 +1,29 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+)
+
+type IRepository interface {
+	Create(ctx context.Context, job entities.Job) (err error)
+	FindByID(ctx context.Context, id string) (job entities.Job, err error)
+	// Claim takes the oldest queued job, or a running one whose worker sent no
+	// heartbeat since staleBefore, and marks it running. Concurrent workers
+	// never claim the same job, a not found error means there is none.
+	Claim(ctx context.Context, now, staleBefore time.Time) (job entities.Job, err error)
+	// Heartbeat keeps the claim of a running job and saves its progress. It
+	// reports whether the job was asked to cancel.
+	Heartbeat(ctx context.Context, id string, now time.Time, progress int) (cancelRequested bool, err error)
+	// Finish moves a running job to the status of job, with its progress,
+	// result, error, finished_at and attempts.
+	Finish(ctx context.Context, job entities.Job) (err error)
+	// Cancel asks a pending job to cancel, a queued one is canceled at once.
+	// A not found error means there is no pending job with id.
+	Cancel(ctx context.Context, id string, now time.Time) (job entities.Job, err error)
+	// DeleteFinished deletes up to limit jobs finished before, returning them.
+	DeleteFinished(ctx context.Context, before time.Time, limit int) (jobs []entities.Job, err error)
+}
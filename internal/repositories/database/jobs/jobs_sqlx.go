@@ -0,0 +1,161 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/jmoiron/sqlx"
+)
+
+const columns = `id, kind, status, params, progress, result, error, cancel_requested, attempts, created_at, started_at, heartbeat_at, finished_at, actor, request_id, submitted_by`
+
+type repoSqlx struct {
+	log    logger.Logger
+	writer *sqlx.DB
+}
+
+// NewSqlx jobs are always read from the writer, the workers and the clients
+// polling a job must see its last status.
+func NewSqlx(log logger.Logger, writer *sqlx.DB) IRepository {
+	return &repoSqlx{log: log, writer: writer}
+}
+
+func (repo *repoSqlx) Create(ctx context.Context, job entities.Job) (err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.jobs.create")
+	defer span.End()
+
+	// the json goes as text, pq would send []byte as bytea
+	_, err = repo.writer.ExecContext(ctx,
+		`INSERT INTO jobs
+		(id,kind,status,params,result,created_at,actor,request_id,submitted_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`,
+		job.ID, job.Kind, job.Status, string(job.Params), string(job.Result), job.CreatedAt, job.Actor, job.RequestID, job.SubmittedBy)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "jobs.SqlxRepo.Create", err)
+		return database.Error(err, "problem to create job")
+	}
+
+	return nil
+}
+
+func (repo *repoSqlx) FindByID(ctx context.Context, id string) (job entities.Job, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.jobs.findbyid")
+	defer span.End()
+
+	query := `
+	SELECT ` + columns + `
+	FROM jobs
+	WHERE id = $1;`
+	err = repo.writer.GetContext(ctx, &job, query, id)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "jobs.SqlxRepo.FindByID", "Error on find job by id: ", id, err)
+		return job, database.Error(err, "job is not found")
+	}
+
+	return job, nil
+}
+
+func (repo *repoSqlx) Claim(ctx context.Context, now, staleBefore time.Time) (job entities.Job, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.jobs.claim")
+	defer span.End()
+
+	query := `
+	UPDATE jobs
+	SET status = 'running', started_at = coalesce(started_at, $1), heartbeat_at = $1, attempts = attempts + 1
+	WHERE id = (
+		SELECT id
+		FROM jobs
+		WHERE status = 'queued' OR (status = 'running' AND heartbeat_at < $2)
+		ORDER BY created_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING ` + columns + `;`
+	err = repo.writer.GetContext(ctx, &job, query, now, staleBefore)
+	if err != nil {
+		return job, database.Error(err, "no job to run")
+	}
+
+	return job, nil
+}
+
+func (repo *repoSqlx) Heartbeat(ctx context.Context, id string, now time.Time, progress int) (cancelRequested bool, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.jobs.heartbeat")
+	defer span.End()
+
+	query := `
+	UPDATE jobs
+	SET heartbeat_at = $2, progress = $3
+	WHERE id = $1 AND status = 'running'
+	RETURNING cancel_requested;`
+	err = repo.writer.GetContext(ctx, &cancelRequested, query, id, now, progress)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "jobs.SqlxRepo.Heartbeat", "Error on heartbeat of job: ", id, err)
+		return false, database.Error(err, "job is not running")
+	}
+
+	return cancelRequested, nil
+}
+
+func (repo *repoSqlx) Finish(ctx context.Context, job entities.Job) (err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.jobs.finish")
+	defer span.End()
+
+	_, err = repo.writer.ExecContext(ctx,
+		`UPDATE jobs
+		SET status = $2, progress = $3, result = $4, error = $5, finished_at = $6, attempts = $7
+		WHERE id = $1 AND status = 'running';`,
+		job.ID, job.Status, job.Progress, string(job.Result), job.Error, job.FinishedAt, job.Attempts)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "jobs.SqlxRepo.Finish", "Error on finish job: ", job.ID, err)
+		return database.Error(err, "problem to finish job")
+	}
+
+	return nil
+}
+
+func (repo *repoSqlx) Cancel(ctx context.Context, id string, now time.Time) (job entities.Job, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.jobs.cancel")
+	defer span.End()
+
+	query := `
+	UPDATE jobs
+	SET cancel_requested = true,
+		status = CASE WHEN status = 'queued' THEN 'canceled' ELSE status END,
+		finished_at = CASE WHEN status = 'queued' THEN $2 ELSE finished_at END
+	WHERE id = $1 AND status IN ('queued', 'running')
+	RETURNING ` + columns + `;`
+	err = repo.writer.GetContext(ctx, &job, query, id, now)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "jobs.SqlxRepo.Cancel", "Error on cancel job: ", id, err)
+		return job, database.Error(err, "job is not pending")
+	}
+
+	return job, nil
+}
+
+func (repo *repoSqlx) DeleteFinished(ctx context.Context, before time.Time, limit int) (jobs []entities.Job, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.jobs.deletefinished")
+	defer span.End()
+
+	query := `
+	DELETE FROM jobs
+	WHERE id IN (
+		SELECT id FROM jobs
+		WHERE finished_at < $1
+		ORDER BY finished_at
+		LIMIT $2
+	)
+	RETURNING ` + columns + `;`
+	err = repo.writer.SelectContext(ctx, &jobs, query, before, limit)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "jobs.SqlxRepo.DeleteFinished", err)
+		return nil, database.Error(err, "failed to delete finished jobs")
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,426 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/test"
+	"github.com/stretchr/testify/assert"
+)
+
+var jobColumns = []string{"id", "kind", "status", "params", "progress", "result", "error", "cancel_requested", "attempts", "created_at", "started_at", "heartbeat_at", "finished_at", "actor", "request_id", "submitted_by"}
+
+func jobRows(job entities.Job) *sqlmock.Rows {
+	return test.NewRows(jobColumns...).
+		AddRow(job.ID, job.Kind, job.Status, []byte(job.Params), job.Progress, []byte(job.Result), job.Error,
+			job.CancelRequested, job.Attempts, job.CreatedAt, job.StartedAt, job.HeartbeatAt, job.FinishedAt, job.Actor, job.RequestID, job.SubmittedBy)
+}
+
+func Test_Create(t *testing.T) {
+	data := entities.Job{
+		ID:          "id_1",
+		Kind:        entities.JobPurge,
+		Status:      entities.JobQueued,
+		Params:      json.RawMessage(`{"dry_run":true}`),
+		Result:      json.RawMessage(`null`),
+		CreatedAt:   time.Now(),
+		Actor:       "Patrick",
+		RequestID:   "request_1",
+		SubmittedBy: "key_1",
+	}
+	query := regexp.QuoteMeta(`INSERT INTO jobs
+		(id,kind,status,params,result,created_at,actor,request_id,submitted_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`)
+
+	cases := map[string]struct {
+		expectedErr error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(data.ID, data.Kind, data.Status, `{"dry_run":true}`, `null`, data.CreatedAt, data.Actor, data.RequestID, data.SubmittedBy).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "problem to create job", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(data.ID, data.Kind, data.Status, `{"dry_run":true}`, `null`, data.CreatedAt, data.Actor, data.RequestID, data.SubmittedBy).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			err := repo.Create(context.Background(), data)
+
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_FindByID(t *testing.T) {
+	finished := time.Now()
+	data := entities.Job{
+		ID:         "id_1",
+		Kind:       entities.JobExport,
+		Status:     entities.JobSucceeded,
+		Params:     json.RawMessage(`{}`),
+		Progress:   100,
+		Result:     json.RawMessage(`[]`),
+		Attempts:   1,
+		CreatedAt:  time.Now(),
+		StartedAt:  &finished,
+		FinishedAt: &finished,
+	}
+	query := regexp.QuoteMeta(`
+	SELECT ` + columns + `
+	FROM jobs
+	WHERE id = $1;`)
+
+	cases := map[string]struct {
+		expectedData entities.Job
+		expectedErr  error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			expectedData: data,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(data.ID).
+					WillReturnRows(jobRows(data))
+			},
+		},
+		"Should return not found": {
+			expectedErr: entities.NewDomainErr(entities.ErrNotFound, "job is not found", sql.ErrNoRows),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(data.ID).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			job, err := repo.FindByID(context.Background(), "id_1")
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, job)
+		})
+	}
+}
+
+func Test_Claim(t *testing.T) {
+	now := time.Now()
+	staleBefore := now.Add(-time.Minute)
+	data := entities.Job{
+		ID:          "id_1",
+		Kind:        entities.JobPurge,
+		Status:      entities.JobRunning,
+		Params:      json.RawMessage(`{}`),
+		Result:      json.RawMessage(`null`),
+		Attempts:    1,
+		CreatedAt:   now,
+		StartedAt:   &now,
+		HeartbeatAt: &now,
+	}
+	query := regexp.QuoteMeta(`
+	UPDATE jobs
+	SET status = 'running', started_at = coalesce(started_at, $1), heartbeat_at = $1, attempts = attempts + 1
+	WHERE id = (
+		SELECT id
+		FROM jobs
+		WHERE status = 'queued' OR (status = 'running' AND heartbeat_at < $2)
+		ORDER BY created_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING ` + columns + `;`)
+
+	cases := map[string]struct {
+		expectedData entities.Job
+		expectedErr  error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return the claimed job": {
+			expectedData: data,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(now, staleBefore).
+					WillReturnRows(jobRows(data))
+			},
+		},
+		"Should return not found when no job is pending": {
+			expectedErr: entities.NewDomainErr(entities.ErrNotFound, "no job to run", sql.ErrNoRows),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(now, staleBefore).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			job, err := repo.Claim(context.Background(), now, staleBefore)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, job)
+		})
+	}
+}
+
+func Test_Heartbeat(t *testing.T) {
+	now := time.Now()
+	query := regexp.QuoteMeta(`
+	UPDATE jobs
+	SET heartbeat_at = $2, progress = $3
+	WHERE id = $1 AND status = 'running'
+	RETURNING cancel_requested;`)
+
+	cases := map[string]struct {
+		expectedCancel bool
+		expectedErr    error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return cancel requested": {
+			expectedCancel: true,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs("id_1", now, 50).
+					WillReturnRows(test.NewRows("cancel_requested").AddRow(true))
+			},
+		},
+		"Should return not found when job is not running": {
+			expectedErr: entities.NewDomainErr(entities.ErrNotFound, "job is not running", sql.ErrNoRows),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs("id_1", now, 50).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			cancel, err := repo.Heartbeat(context.Background(), "id_1", now, 50)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedCancel, cancel)
+		})
+	}
+}
+
+func Test_Finish(t *testing.T) {
+	now := time.Now()
+	data := entities.Job{
+		ID:         "id_1",
+		Status:     entities.JobFailed,
+		Progress:   30,
+		Result:     json.RawMessage(`null`),
+		Error:      "boom",
+		FinishedAt: &now,
+		Attempts:   2,
+	}
+	query := regexp.QuoteMeta(`UPDATE jobs
+		SET status = $2, progress = $3, result = $4, error = $5, finished_at = $6, attempts = $7
+		WHERE id = $1 AND status = 'running';`)
+
+	cases := map[string]struct {
+		expectedErr error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(data.ID, data.Status, data.Progress, `null`, data.Error, data.FinishedAt, data.Attempts).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "problem to finish job", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(data.ID, data.Status, data.Progress, `null`, data.Error, data.FinishedAt, data.Attempts).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			err := repo.Finish(context.Background(), data)
+
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_Cancel(t *testing.T) {
+	now := time.Now()
+	data := entities.Job{
+		ID:              "id_1",
+		Kind:            entities.JobPurge,
+		Status:          entities.JobCanceled,
+		Params:          json.RawMessage(`{}`),
+		Result:          json.RawMessage(`null`),
+		CancelRequested: true,
+		CreatedAt:       now,
+		FinishedAt:      &now,
+	}
+	query := regexp.QuoteMeta(`
+	UPDATE jobs
+	SET cancel_requested = true,
+		status = CASE WHEN status = 'queued' THEN 'canceled' ELSE status END,
+		finished_at = CASE WHEN status = 'queued' THEN $2 ELSE finished_at END
+	WHERE id = $1 AND status IN ('queued', 'running')
+	RETURNING ` + columns + `;`)
+
+	cases := map[string]struct {
+		expectedData entities.Job
+		expectedErr  error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return the canceled job": {
+			expectedData: data,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(data.ID, now).
+					WillReturnRows(jobRows(data))
+			},
+		},
+		"Should return not found when job is not pending": {
+			expectedErr: entities.NewDomainErr(entities.ErrNotFound, "job is not pending", sql.ErrNoRows),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(data.ID, now).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			job, err := repo.Cancel(context.Background(), "id_1", now)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, job)
+		})
+	}
+}
+
+func Test_DeleteFinished(t *testing.T) {
+	before := time.Now()
+	finishedAt := before.Add(-time.Hour)
+	data := entities.Job{
+		ID:         "id_1",
+		Kind:       entities.JobExport,
+		Status:     entities.JobSucceeded,
+		Params:     json.RawMessage(`{}`),
+		Result:     json.RawMessage(`{"file":"export-id_1.ndjson","records":2,"bytes":120}`),
+		CreatedAt:  finishedAt,
+		FinishedAt: &finishedAt,
+	}
+	query := regexp.QuoteMeta(`
+	DELETE FROM jobs
+	WHERE id IN (
+		SELECT id FROM jobs
+		WHERE finished_at < $1
+		ORDER BY finished_at
+		LIMIT $2
+	)
+	RETURNING ` + columns + `;`)
+
+	cases := map[string]struct {
+		expectedData []entities.Job
+		expectedErr  error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return the deleted jobs": {
+			expectedData: []entities.Job{data},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(before, 100).
+					WillReturnRows(jobRows(data))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "failed to delete finished jobs", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(before, 100).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			jobs, err := repo.DeleteFinished(context.Background(), before, 100)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, jobs)
+		})
+	}
+}
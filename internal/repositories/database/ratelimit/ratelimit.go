@@ -0,0 +1,16 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package ratelimit
+
+import (
+	"context"
+)
+
+type IRepository interface {
+	// Take takes a token of the bucket of key, created with burst tokens and
+	// refilled with rate tokens by second. It returns the tokens left and
+	// whether there was one to take.
+	Take(ctx context.Context, key string, rate float64, burst int) (tokens float64, allowed bool, err error)
+	// DeleteFull deletes the buckets refilled by now, a missing bucket is a
+	// full one.
+	DeleteFull(ctx context.Context) (deleted int64, err error)
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/jmoiron/sqlx"
+)
+
+type repoSqlx struct {
+	log    logger.Logger
+	writer *sqlx.DB
+}
+
+// NewSqlx buckets are taken on the writer and refilled by its clock, so the
+// instances share them whatever the drift of their own clocks.
+func NewSqlx(log logger.Logger, writer *sqlx.DB) IRepository {
+	return &repoSqlx{log: log, writer: writer}
+}
+
+func (repo *repoSqlx) Take(ctx context.Context, key string, rate float64, burst int) (tokens float64, allowed bool, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.ratelimit.take")
+	defer span.End()
+
+	query := `
+	INSERT INTO rate_limits AS bucket
+	(key,tokens,rate,burst,allowed,updated_at)
+	VALUES ($1, $3::integer - 1, $2, $3, true, clock_timestamp())
+	ON CONFLICT (key) DO UPDATE
+	SET tokens = rate_limit_take(rate_limit_refill(bucket.tokens, EXCLUDED.rate, EXCLUDED.burst, bucket.updated_at, EXCLUDED.updated_at)),
+		allowed = rate_limit_refill(bucket.tokens, EXCLUDED.rate, EXCLUDED.burst, bucket.updated_at, EXCLUDED.updated_at) >= 1,
+		rate = EXCLUDED.rate, burst = EXCLUDED.burst, updated_at = EXCLUDED.updated_at
+	RETURNING tokens, allowed;`
+	err = repo.writer.QueryRowxContext(ctx, query, key, rate, burst).Scan(&tokens, &allowed)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "ratelimit.SqlxRepo.Take", "Error on take token: ", key, err)
+		return 0, false, database.Error(err, "problem to take rate limit token")
+	}
+
+	return tokens, allowed, nil
+}
+
+func (repo *repoSqlx) DeleteFull(ctx context.Context) (deleted int64, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.ratelimit.deletefull")
+	defer span.End()
+
+	query := `
+	DELETE FROM rate_limits
+	WHERE rate_limit_refill(tokens, rate, burst, updated_at, clock_timestamp()::timestamp) >= burst;`
+	result, err := repo.writer.ExecContext(ctx, query)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "ratelimit.SqlxRepo.DeleteFull", err)
+		return 0, database.Error(err, "problem to delete full rate limits")
+	}
+
+	deleted, err = result.RowsAffected()
+	if err != nil {
+		repo.log.ErrorContext(ctx, "ratelimit.SqlxRepo.DeleteFull", err)
+		return 0, database.Error(err, "problem to delete full rate limits")
+	}
+
+	return deleted, nil
+}
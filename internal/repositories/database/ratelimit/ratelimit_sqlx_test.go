@@ -0,0 +1,119 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Take(t *testing.T) {
+	query := regexp.QuoteMeta(`
+	INSERT INTO rate_limits AS bucket
+	(key,tokens,rate,burst,allowed,updated_at)
+	VALUES ($1, $3::integer - 1, $2, $3, true, clock_timestamp())
+	ON CONFLICT (key) DO UPDATE
+	SET tokens = rate_limit_take(rate_limit_refill(bucket.tokens, EXCLUDED.rate, EXCLUDED.burst, bucket.updated_at, EXCLUDED.updated_at)),
+		allowed = rate_limit_refill(bucket.tokens, EXCLUDED.rate, EXCLUDED.burst, bucket.updated_at, EXCLUDED.updated_at) >= 1,
+		rate = EXCLUDED.rate, burst = EXCLUDED.burst, updated_at = EXCLUDED.updated_at
+	RETURNING tokens, allowed;`)
+
+	cases := map[string]struct {
+		expectedTokens  float64
+		expectedAllowed bool
+		expectedErr     error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return the tokens left": {
+			expectedTokens:  4.5,
+			expectedAllowed: true,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs("houses:ip:10.0.0.1", 0.5, 10).
+					WillReturnRows(test.NewRows("tokens", "allowed").AddRow(4.5, true))
+			},
+		},
+		"Should return the bucket is empty": {
+			expectedTokens: 0.5,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs("houses:ip:10.0.0.1", 0.5, 10).
+					WillReturnRows(test.NewRows("tokens", "allowed").AddRow(0.5, false))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "problem to take rate limit token", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs("houses:ip:10.0.0.1", 0.5, 10).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			tokens, allowed, err := repo.Take(context.Background(), "houses:ip:10.0.0.1", 0.5, 10)
+
+			assert.Equal(t, cs.expectedTokens, tokens)
+			assert.Equal(t, cs.expectedAllowed, allowed)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_DeleteFull(t *testing.T) {
+	query := regexp.QuoteMeta(`
+	DELETE FROM rate_limits
+	WHERE rate_limit_refill(tokens, rate, burst, updated_at, clock_timestamp()::timestamp) >= burst;`)
+
+	cases := map[string]struct {
+		expectedDeleted int64
+		expectedErr     error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return the deleted buckets": {
+			expectedDeleted: 3,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "problem to delete full rate limits", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			deleted, err := repo.DeleteFull(context.Background())
+
+			assert.Equal(t, cs.expectedDeleted, deleted)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package search
+
+import (
+	"context"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+)
+
+type IRepository interface {
+	// Search finds the live houses and characters by their search documents,
+	// each term also matching the words it starts, or by the trigram
+	// similarity of their names, which tolerates typos. The best ranked come
+	// first.
+	Search(ctx context.Context, q entities.SearchQuery) (results []entities.SearchResult, err error)
+}
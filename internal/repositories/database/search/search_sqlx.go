@@ -0,0 +1,70 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type repoSqlx struct {
+	log    logger.Logger
+	reader *sqlx.DB
+}
+
+func NewSqlx(log logger.Logger, reader *sqlx.DB) IRepository {
+	return &repoSqlx{log: log, reader: reader}
+}
+
+func (repo *repoSqlx) Search(ctx context.Context, q entities.SearchQuery) (results []entities.SearchResult, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.search.search")
+	defer span.End()
+
+	terms := q.Terms()
+	prefixes := make([]string, len(terms))
+	for i, term := range terms {
+		prefixes[i] = term + ":*"
+	}
+
+	results = make([]entities.SearchResult, 0)
+	query := `
+	WITH query AS (
+		SELECT to_tsquery('simple', search_unaccent($1)) AS terms, search_unaccent($2) AS text
+	)
+	SELECT type, id, name, rank, highlight
+	FROM (
+		SELECT 'house' AS type, houses.id, houses.name,
+			ts_rank(houses.search, query.terms)
+				+ greatest(word_similarity(query.text, search_unaccent(houses.name)), word_similarity(query.text, search_unaccent(houses.region))) AS rank,
+			ts_headline('simple', houses.name || ' - ' || houses.region, query.terms) AS highlight
+		FROM houses, query
+		WHERE 'house' = ANY($3) AND houses.deleted_at is null
+			AND (houses.search @@ query.terms
+				OR query.text <% search_unaccent(houses.name)
+				OR query.text <% search_unaccent(houses.region))
+		UNION ALL
+		SELECT 'character' AS type, characters.id, characters.name,
+			ts_rank(characters.search, query.terms) + word_similarity(query.text, search_unaccent(characters.name)) AS rank,
+			ts_headline('simple', characters.name, query.terms) AS highlight
+		FROM characters, query
+		WHERE 'character' = ANY($3) AND characters.deleted_at is null
+			AND (characters.search @@ query.terms
+				OR query.text <% search_unaccent(characters.name))
+	) results
+	ORDER BY rank DESC, type, name
+	LIMIT $4;
+	`
+	err = repo.reader.SelectContext(ctx, &results, query,
+		strings.Join(prefixes, " & "), strings.Join(terms, " "), pq.StringArray(q.Types), q.Limit)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "search.SqlxRepo.Search", "Error on search: ", q.Text, err)
+		return nil, database.Error(err, "problem to search")
+	}
+
+	return results, nil
+}
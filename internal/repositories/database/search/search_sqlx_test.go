@@ -0,0 +1,66 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/test"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Search(t *testing.T) {
+	query := regexp.QuoteMeta(`
+	WITH query AS (
+		SELECT to_tsquery('simple', search_unaccent($1)) AS terms, search_unaccent($2) AS text
+	)
+	SELECT type, id, name, rank, highlight
+	FROM (`)
+	input := entities.SearchQuery{Text: "house stark!", Types: []string{entities.SearchHouse, entities.SearchCharacter}, Limit: 20}
+
+	cases := map[string]struct {
+		expectedData []entities.SearchResult
+		expectedErr  error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should search by the prefixes of the terms": {
+			expectedData: []entities.SearchResult{
+				{Type: entities.SearchHouse, ID: "id_1", Name: "house Stark", Rank: 1.6, Highlight: "<b>house</b> <b>Stark</b> - winterfell"},
+			},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs("house:* & stark:*", "house stark", pq.StringArray{"house", "character"}, 20).
+					WillReturnRows(test.NewRows("type", "id", "name", "rank", "highlight").
+						AddRow("house", "id_1", "house Stark", 1.6, "<b>house</b> <b>Stark</b> - winterfell"))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "problem to search", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			data, err := repo.Search(context.Background(), input)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, data)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package snapshot
+
+import (
+	"context"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+)
+
+type IRepository interface {
+	// Export calls fn with every character, house and removed lordship, read in
+	// one read only transaction so they are consistent with each other. The
+	// deleted rows, and the lordships their deletion removed, are only read
+	// with includeDeleted.
+	Export(ctx context.Context, includeDeleted bool, fn func(record entities.SnapshotRecord) error) (err error)
+	// Count locks the tables until the end of the transaction of ctx, so no
+	// write slips in between the count and the restore.
+	Count(ctx context.Context) (counts entities.SnapshotCounts, err error)
+	// Clear and Insert join the transaction of ctx.
+	Clear(ctx context.Context) (err error)
+	Insert(ctx context.Context, record entities.SnapshotRecord) (err error)
+}
@@ -0,0 +1,198 @@
+package snapshot
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/transaction"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/jmoiron/sqlx"
+)
+
+type repoSqlx struct {
+	log    logger.Logger
+	writer *sqlx.DB
+	reader *sqlx.DB
+}
+
+func NewSqlx(log logger.Logger, writer, reader *sqlx.DB) IRepository {
+	return &repoSqlx{log: log, writer: writer, reader: reader}
+}
+
+func (repo *repoSqlx) Export(ctx context.Context, includeDeleted bool, fn func(record entities.SnapshotRecord) error) (err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.snapshot.export")
+	defer span.End()
+
+	tx, err := repo.reader.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		repo.log.ErrorContext(ctx, "snapshot.SqlxRepo.Export", "Error on begin transaction: ", err)
+		return database.Error(err, "problem to begin transaction")
+	}
+	defer tx.Rollback()
+
+	query := `
+	SELECT id, name, tv_series, created_at, updated_at, deleted_at
+	FROM characters
+	WHERE $1 OR deleted_at is null
+	ORDER BY created_at, id;
+	`
+	err = repo.each(ctx, tx, query, includeDeleted, func(rows *sqlx.Rows) error {
+		var character entities.Character
+		if err := rows.StructScan(&character); err != nil {
+			return err
+		}
+		return fn(entities.SnapshotRecord{Kind: entities.SnapshotCharacter, Character: &character})
+	})
+	if err != nil {
+		return err
+	}
+
+	query = `
+	SELECT id, name, region, foundation_year, current_lord, created_at, updated_at, deleted_at
+	FROM houses
+	WHERE $1 OR deleted_at is null
+	ORDER BY created_at, id;
+	`
+	err = repo.each(ctx, tx, query, includeDeleted, func(rows *sqlx.Rows) error {
+		var house entities.House
+		if err := rows.StructScan(&house); err != nil {
+			return err
+		}
+		return fn(entities.SnapshotRecord{Kind: entities.SnapshotHouse, House: &house})
+	})
+	if err != nil {
+		return err
+	}
+
+	// the removed lordships only matter to restore a deleted lord
+	query = `
+	SELECT house_id, lord_id, removed_at
+	FROM removed_lordships
+	WHERE $1
+	ORDER BY removed_at, house_id;
+	`
+	return repo.each(ctx, tx, query, includeDeleted, func(rows *sqlx.Rows) error {
+		var lordship entities.RemovedLordship
+		if err := rows.StructScan(&lordship); err != nil {
+			return err
+		}
+		return fn(entities.SnapshotRecord{Kind: entities.SnapshotRemovedLordship, RemovedLordship: &lordship})
+	})
+}
+
+// each streams the rows of query to fn, an error of fn stops it and is
+// returned as is.
+func (repo *repoSqlx) each(ctx context.Context, tx *sqlx.Tx, query string, includeDeleted bool, fn func(rows *sqlx.Rows) error) error {
+	rows, err := tx.QueryxContext(ctx, query, includeDeleted)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "snapshot.SqlxRepo.Export", "Error on export: ", err)
+		return database.Error(err, "failed to export snapshot")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		repo.log.ErrorContext(ctx, "snapshot.SqlxRepo.Export", "Error on export: ", err)
+		return database.Error(err, "failed to export snapshot")
+	}
+
+	return nil
+}
+
+func (repo *repoSqlx) Count(ctx context.Context) (counts entities.SnapshotCounts, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.snapshot.count")
+	defer span.End()
+
+	executor := transaction.Executor(ctx, repo.writer)
+
+	_, err = executor.ExecContext(ctx, `LOCK TABLE characters, houses, removed_lordships IN EXCLUSIVE MODE;`)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "snapshot.SqlxRepo.Count", "Error on lock tables: ", err)
+		return counts, database.Error(err, "failed to lock tables")
+	}
+
+	query := `
+	SELECT
+		(SELECT count(*) FROM characters) AS characters,
+		(SELECT count(*) FROM houses) AS houses,
+		(SELECT count(*) FROM removed_lordships) AS removed_lordships;
+	`
+	err = sqlx.GetContext(ctx, executor, &counts, query)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "snapshot.SqlxRepo.Count", "Error on count rows: ", err)
+		return counts, database.Error(err, "failed to count rows")
+	}
+
+	return counts, nil
+}
+
+func (repo *repoSqlx) Clear(ctx context.Context) (err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.snapshot.clear")
+	defer span.End()
+
+	query := `
+	DELETE FROM removed_lordships;
+	DELETE FROM houses;
+	DELETE FROM characters;
+	`
+	_, err = transaction.Executor(ctx, repo.writer).ExecContext(ctx, query)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "snapshot.SqlxRepo.Clear", err)
+		return database.Error(err, "failed to clear database")
+	}
+
+	return nil
+}
+
+func (repo *repoSqlx) Insert(ctx context.Context, record entities.SnapshotRecord) (err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.snapshot.insert")
+	defer span.End()
+
+	var (
+		query string
+		arg   any
+	)
+	switch {
+	case record.Character != nil:
+		query = `
+		INSERT INTO characters
+		(id,name,tv_series,created_at,updated_at,deleted_at,search)
+		VALUES (:id, :name, :tv_series, :created_at, :updated_at, :deleted_at,
+			CASE WHEN CAST(:deleted_at AS timestamp) IS NULL THEN character_search(:name) END);
+		`
+		arg = record.Character
+	case record.House != nil:
+		query = `
+		INSERT INTO houses
+		(id,name,region,foundation_year,current_lord,created_at,updated_at,deleted_at,search)
+		VALUES (:id, :name, :region, :foundation_year, :current_lord, :created_at, :updated_at, :deleted_at,
+			CASE WHEN CAST(:deleted_at AS timestamp) IS NULL THEN house_search(:name, :region) END);
+		`
+		arg = record.House
+	case record.RemovedLordship != nil:
+		query = `
+		INSERT INTO removed_lordships
+		(house_id,lord_id,removed_at)
+		VALUES (:house_id, :lord_id, :removed_at);
+		`
+		arg = record.RemovedLordship
+	default:
+		return entities.ErrSnapshotRecord
+	}
+
+	_, err = sqlx.NamedExecContext(ctx, transaction.Executor(ctx, repo.writer), query, arg)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "snapshot.SqlxRepo.Insert", "Error on insert ", record.Kind, err)
+		return database.Error(err, "failed to insert "+record.Kind)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/test"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Export(t *testing.T) {
+	now := time.Now()
+	charactersQuery := regexp.QuoteMeta(`
+	SELECT id, name, tv_series, created_at, updated_at, deleted_at
+	FROM characters
+	WHERE $1 OR deleted_at is null
+	ORDER BY created_at, id;
+	`)
+	housesQuery := regexp.QuoteMeta(`
+	SELECT id, name, region, foundation_year, current_lord, created_at, updated_at, deleted_at
+	FROM houses
+	WHERE $1 OR deleted_at is null
+	ORDER BY created_at, id;
+	`)
+	lordshipsQuery := regexp.QuoteMeta(`
+	SELECT house_id, lord_id, removed_at
+	FROM removed_lordships
+	WHERE $1
+	ORDER BY removed_at, house_id;
+	`)
+
+	cases := map[string]struct {
+		includeDeleted  bool
+		expectedRecords []entities.SnapshotRecord
+		expectedErr     error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should export every row": {
+			includeDeleted: true,
+			expectedRecords: []entities.SnapshotRecord{
+				{Kind: entities.SnapshotCharacter, Character: &entities.Character{ID: "id_1", Name: "Jon Snow", TVSeries: pq.StringArray{"Season 1"}, CreatedAt: now, DeletedAt: &now}},
+				{Kind: entities.SnapshotHouse, House: &entities.House{ID: "id_2", Name: "house Stark", Region: "winterfell", FoundationYear: "1", CreatedAt: now}},
+				{Kind: entities.SnapshotRemovedLordship, RemovedLordship: &entities.RemovedLordship{HouseID: "id_2", LordID: "id_1", RemovedAt: now}},
+			},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(charactersQuery).
+					WithArgs(true).
+					WillReturnRows(test.NewRows("id", "name", "tv_series", "created_at", "updated_at", "deleted_at").
+						AddRow("id_1", "Jon Snow", `{"Season 1"}`, now, nil, now))
+				mock.ExpectQuery(housesQuery).
+					WithArgs(true).
+					WillReturnRows(test.NewRows("id", "name", "region", "foundation_year", "current_lord", "created_at", "updated_at", "deleted_at").
+						AddRow("id_2", "house Stark", "winterfell", "1", "", now, nil, nil))
+				mock.ExpectQuery(lordshipsQuery).
+					WithArgs(true).
+					WillReturnRows(test.NewRows("house_id", "lord_id", "removed_at").
+						AddRow("id_2", "id_1", now))
+				mock.ExpectRollback()
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "failed to export snapshot", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(charactersQuery).
+					WithArgs(false).
+					WillReturnError(errors.New("Problem to execute query"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
+
+			var records []entities.SnapshotRecord
+			err := repo.Export(context.Background(), cs.includeDeleted, func(record entities.SnapshotRecord) error {
+				records = append(records, record)
+				return nil
+			})
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedRecords, records)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_Count(t *testing.T) {
+	lock := regexp.QuoteMeta(`LOCK TABLE characters, houses, removed_lordships IN EXCLUSIVE MODE;`)
+	query := regexp.QuoteMeta(`
+	SELECT
+		(SELECT count(*) FROM characters) AS characters,
+		(SELECT count(*) FROM houses) AS houses,
+		(SELECT count(*) FROM removed_lordships) AS removed_lordships;
+	`)
+
+	cases := map[string]struct {
+		expectedCounts entities.SnapshotCounts
+		expectedErr    error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should return success": {
+			expectedCounts: entities.SnapshotCounts{Characters: 2, Houses: 1},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(lock).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(query).
+					WillReturnRows(test.NewRows("characters", "houses", "removed_lordships").AddRow(2, 1, 0))
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "failed to lock tables", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(lock).WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
+
+			counts, err := repo.Count(context.Background())
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedCounts, counts)
+		})
+	}
+}
+
+func Test_Insert(t *testing.T) {
+	now := time.Now()
+	house := entities.House{ID: "id_2", Name: "house Stark", Region: "winterfell", FoundationYear: "1", CurrentLord: "id_1", CreatedAt: now, UpdatedAt: &now}
+	query := regexp.QuoteMeta(`
+		INSERT INTO houses
+		(id,name,region,foundation_year,current_lord,created_at,updated_at,deleted_at,search)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8,
+			CASE WHEN CAST($9 AS timestamp) IS NULL THEN house_search($10, $11) END);
+		`)
+
+	cases := map[string]struct {
+		record      entities.SnapshotRecord
+		expectedErr error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should keep the id and the timestamps": {
+			record: entities.SnapshotRecord{Kind: entities.SnapshotHouse, House: &house},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WithArgs(house.ID, house.Name, house.Region, house.FoundationYear, house.CurrentLord, now, &now, nil, nil, house.Name, house.Region).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		"Should return error record": {
+			record:      entities.SnapshotRecord{Kind: entities.SnapshotHouse},
+			expectedErr: entities.ErrSnapshotRecord,
+			prepareMock: func(mock sqlmock.Sqlmock) {},
+		},
+		"Should return Error": {
+			record:      entities.SnapshotRecord{Kind: entities.SnapshotHouse, House: &house},
+			expectedErr: entities.NewDomainErr(nil, "failed to insert house", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).
+					WillReturnError(errors.New("Problem to execute query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db, db)
+
+			err := repo.Insert(context.Background(), cs.record)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
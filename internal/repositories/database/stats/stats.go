@@ -0,0 +1,17 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package stats
+
+import (
+	"context"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+)
+
+type IRepository interface {
+	// Houses aggregates the houses in a single snapshot of the reader, so the
+	// totals and the groups agree.
+	Houses(ctx context.Context, q entities.StatsQuery) (stats entities.HouseStats, err error)
+	// Characters aggregates the characters in a single snapshot of the reader,
+	// so the totals and the groups agree.
+	Characters(ctx context.Context, q entities.StatsQuery) (stats entities.CharacterStats, err error)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stats.go
+
+// Package stats is a generated GoMock package.
+package stats
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRepository is a mock of IRepository interface.
+type MockIRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRepositoryMockRecorder
+}
+
+// MockIRepositoryMockRecorder is the mock recorder for MockIRepository.
+type MockIRepositoryMockRecorder struct {
+	mock *MockIRepository
+}
+
+// NewMockIRepository creates a new mock instance.
+func NewMockIRepository(ctrl *gomock.Controller) *MockIRepository {
+	mock := &MockIRepository{ctrl: ctrl}
+	mock.recorder = &MockIRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRepository) EXPECT() *MockIRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Characters mocks base method.
+func (m *MockIRepository) Characters(ctx context.Context, q entities.StatsQuery) (entities.CharacterStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Characters", ctx, q)
+	ret0, _ := ret[0].(entities.CharacterStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Characters indicates an expected call of Characters.
+func (mr *MockIRepositoryMockRecorder) Characters(ctx, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Characters", reflect.TypeOf((*MockIRepository)(nil).Characters), ctx, q)
+}
+
+// Houses mocks base method.
+func (m *MockIRepository) Houses(ctx context.Context, q entities.StatsQuery) (entities.HouseStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Houses", ctx, q)
+	ret0, _ := ret[0].(entities.HouseStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Houses indicates an expected call of Houses.
+func (mr *MockIRepositoryMockRecorder) Houses(ctx, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Houses", reflect.TypeOf((*MockIRepository)(nil).Houses), ctx, q)
+}
@@ -0,0 +1,129 @@
+package stats
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/jmoiron/sqlx"
+)
+
+type repoSqlx struct {
+	log    logger.Logger
+	reader *sqlx.DB
+}
+
+func NewSqlx(log logger.Logger, reader *sqlx.DB) IRepository {
+	return &repoSqlx{log: log, reader: reader}
+}
+
+func (repo *repoSqlx) Houses(ctx context.Context, q entities.StatsQuery) (stats entities.HouseStats, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.stats.houses")
+	defer span.End()
+
+	tx, err := repo.begin(ctx)
+	if err != nil {
+		return stats, err
+	}
+	defer tx.Rollback()
+
+	query := `
+	SELECT
+		count(*) FILTER (WHERE deleted_at is null) AS total,
+		count(*) FILTER (WHERE deleted_at is not null) AS deleted,
+		count(*) FILTER (WHERE deleted_at is null AND coalesce(current_lord, '') = '') AS without_lord
+	FROM houses;
+	`
+	if err = tx.GetContext(ctx, &stats, query); err != nil {
+		repo.log.ErrorContext(ctx, "stats.SqlxRepo.Houses", "Error on count houses: ", err)
+		return stats, database.Error(err, "problem to count houses")
+	}
+
+	stats.ByRegion = make([]entities.StatsCount, 0)
+	query = `
+	SELECT region AS key, count(*) AS count
+	FROM houses
+	WHERE deleted_at is null
+	GROUP BY region
+	ORDER BY count DESC, key;
+	`
+	if err = tx.SelectContext(ctx, &stats.ByRegion, query); err != nil {
+		repo.log.ErrorContext(ctx, "stats.SqlxRepo.Houses", "Error on count houses by region: ", err)
+		return stats, database.Error(err, "problem to count houses")
+	}
+
+	stats.Timeline, err = repo.timeline(ctx, tx, "houses", q)
+	return stats, err
+}
+
+func (repo *repoSqlx) Characters(ctx context.Context, q entities.StatsQuery) (stats entities.CharacterStats, err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.stats.characters")
+	defer span.End()
+
+	tx, err := repo.begin(ctx)
+	if err != nil {
+		return stats, err
+	}
+	defer tx.Rollback()
+
+	query := `
+	SELECT
+		count(*) FILTER (WHERE deleted_at is null) AS total,
+		count(*) FILTER (WHERE deleted_at is not null) AS deleted
+	FROM characters;
+	`
+	if err = tx.GetContext(ctx, &stats, query); err != nil {
+		repo.log.ErrorContext(ctx, "stats.SqlxRepo.Characters", "Error on count characters: ", err)
+		return stats, database.Error(err, "problem to count characters")
+	}
+
+	stats.ByTVSeries = make([]entities.StatsCount, 0)
+	query = `
+	SELECT series AS key, count(DISTINCT characters.id) AS count
+	FROM characters, unnest(characters.tv_series) AS series
+	WHERE deleted_at is null
+	GROUP BY series
+	ORDER BY count DESC, key;
+	`
+	if err = tx.SelectContext(ctx, &stats.ByTVSeries, query); err != nil {
+		repo.log.ErrorContext(ctx, "stats.SqlxRepo.Characters", "Error on count characters by tv series: ", err)
+		return stats, database.Error(err, "problem to count characters")
+	}
+
+	stats.Timeline, err = repo.timeline(ctx, tx, "characters", q)
+	return stats, err
+}
+
+// begin opens the read only transaction the aggregates of an entity share.
+func (repo *repoSqlx) begin(ctx context.Context) (*sqlx.Tx, error) {
+	tx, err := repo.reader.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		repo.log.ErrorContext(ctx, "stats.SqlxRepo.begin", "Error on begin transaction: ", err)
+		return nil, database.Error(err, "problem to begin transaction")
+	}
+	return tx, nil
+}
+
+// timeline counts the rows of table created and updated in each bucket since
+// q.Since, the buckets without any are left out. table is never user input.
+func (repo *repoSqlx) timeline(ctx context.Context, tx *sqlx.Tx, table string, q entities.StatsQuery) ([]entities.StatsTime, error) {
+	timeline := make([]entities.StatsTime, 0)
+	query := `
+	SELECT start, count(*) FILTER (WHERE created) AS created, count(*) FILTER (WHERE NOT created) AS updated
+	FROM (
+		SELECT date_trunc($1, created_at) AS start, true AS created FROM ` + table + ` WHERE created_at >= $2
+		UNION ALL
+		SELECT date_trunc($1, updated_at) AS start, false AS created FROM ` + table + ` WHERE updated_at >= $2
+	) changes
+	GROUP BY start
+	ORDER BY start;
+	`
+	if err := tx.SelectContext(ctx, &timeline, query, q.Bucket, q.Since); err != nil {
+		repo.log.ErrorContext(ctx, "stats.SqlxRepo.timeline", "Error on count ", table, " by ", q.Bucket, ": ", err)
+		return nil, database.Error(err, "problem to count "+table)
+	}
+	return timeline, nil
+}
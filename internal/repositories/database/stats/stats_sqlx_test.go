@@ -0,0 +1,131 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Houses(t *testing.T) {
+	month := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := entities.StatsQuery{Bucket: entities.StatsMonth, Since: month}
+	totals := regexp.QuoteMeta(`
+	SELECT
+		count(*) FILTER (WHERE deleted_at is null) AS total,
+		count(*) FILTER (WHERE deleted_at is not null) AS deleted,
+		count(*) FILTER (WHERE deleted_at is null AND coalesce(current_lord, '') = '') AS without_lord
+	FROM houses;
+	`)
+	regions := regexp.QuoteMeta(`
+	SELECT region AS key, count(*) AS count
+	FROM houses
+	WHERE deleted_at is null
+	GROUP BY region
+	ORDER BY count DESC, key;
+	`)
+	timeline := regexp.QuoteMeta(`
+	SELECT start, count(*) FILTER (WHERE created) AS created, count(*) FILTER (WHERE NOT created) AS updated
+	FROM (
+		SELECT date_trunc($1, created_at) AS start, true AS created FROM houses WHERE created_at >= $2
+		UNION ALL
+		SELECT date_trunc($1, updated_at) AS start, false AS created FROM houses WHERE updated_at >= $2
+	) changes
+	GROUP BY start
+	ORDER BY start;
+	`)
+
+	cases := map[string]struct {
+		expectedData entities.HouseStats
+		expectedErr  error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should aggregate in one snapshot": {
+			expectedData: entities.HouseStats{
+				Total: 3, Deleted: 1, WithoutLord: 1,
+				ByRegion: []entities.StatsCount{{Key: "north", Count: 2}, {Key: "reach", Count: 1}},
+				Timeline: []entities.StatsTime{{Start: month, Created: 4, Updated: 2}},
+			},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(totals).
+					WillReturnRows(test.NewRows("total", "deleted", "without_lord").AddRow(3, 1, 1))
+				mock.ExpectQuery(regions).
+					WillReturnRows(test.NewRows("key", "count").AddRow("north", 2).AddRow("reach", 1))
+				mock.ExpectQuery(timeline).
+					WithArgs(entities.StatsMonth, month).
+					WillReturnRows(test.NewRows("start", "created", "updated").AddRow(month, 4, 2))
+				mock.ExpectRollback()
+			},
+		},
+		"Should return Error": {
+			expectedErr: entities.NewDomainErr(nil, "problem to count houses", errors.New("Problem to execute query")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(totals).
+					WillReturnError(errors.New("Problem to execute query"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			data, err := repo.Houses(context.Background(), q)
+
+			assert.Equal(t, cs.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, cs.expectedData, data)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_Characters(t *testing.T) {
+	q := entities.StatsQuery{Bucket: entities.StatsDay}
+	series := regexp.QuoteMeta(`
+	SELECT series AS key, count(DISTINCT characters.id) AS count
+	FROM characters, unnest(characters.tv_series) AS series
+	WHERE deleted_at is null
+	GROUP BY series
+	ORDER BY count DESC, key;
+	`)
+
+	db, mock := test.GetDB()
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM characters;`)).
+		WillReturnRows(test.NewRows("total", "deleted").AddRow(2, 0))
+	mock.ExpectQuery(series).
+		WillReturnRows(test.NewRows("key", "count").AddRow("Season 1", 2))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM characters WHERE created_at >= $2`)).
+		WithArgs(entities.StatsDay, time.Time{}).
+		WillReturnRows(test.NewRows("start", "created", "updated"))
+	mock.ExpectRollback()
+
+	repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+	data, err := repo.Characters(context.Background(), q)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.CharacterStats{
+		Total:      2,
+		ByTVSeries: []entities.StatsCount{{Key: "Season 1", Count: 2}},
+		Timeline:   []entities.StatsTime{},
+	}, data)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
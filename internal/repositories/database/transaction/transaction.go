@@ -0,0 +1,17 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package transaction
+
+import (
+	"context"
+)
+
+type IRepository interface {
+	// Run executes fn inside a database transaction, committing when fn returns nil
+	// and rolling back otherwise. Repositories called with the ctx received by fn
+	// take part in the same transaction.
+	Run(ctx context.Context, fn func(ctx context.Context) error) (err error)
+	// Savepoint executes fn inside a savepoint of the transaction carried by
+	// ctx, an error of fn rolls back only what fn did and the transaction
+	// stays usable. Without a transaction it is the same as Run.
+	Savepoint(ctx context.Context, fn func(ctx context.Context) error) (err error)
+}
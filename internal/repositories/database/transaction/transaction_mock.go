@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: transaction.go
+
+// Package transaction is a generated GoMock package.
+package transaction
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRepository is a mock of IRepository interface.
+type MockIRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRepositoryMockRecorder
+}
+
+// MockIRepositoryMockRecorder is the mock recorder for MockIRepository.
+type MockIRepositoryMockRecorder struct {
+	mock *MockIRepository
+}
+
+// NewMockIRepository creates a new mock instance.
+func NewMockIRepository(ctrl *gomock.Controller) *MockIRepository {
+	mock := &MockIRepository{ctrl: ctrl}
+	mock.recorder = &MockIRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRepository) EXPECT() *MockIRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockIRepository) Run(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIRepositoryMockRecorder) Run(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIRepository)(nil).Run), ctx, fn)
+}
+
+// Savepoint mocks base method.
+func (m *MockIRepository) Savepoint(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Savepoint", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Savepoint indicates an expected call of Savepoint.
+func (mr *MockIRepositoryMockRecorder) Savepoint(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Savepoint", reflect.TypeOf((*MockIRepository)(nil).Savepoint), ctx, fn)
+}
@@ -0,0 +1,96 @@
+package transaction
+
+import (
+	"context"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"github.com/jmoiron/sqlx"
+)
+
+type txKey struct{}
+
+type repoSqlx struct {
+	log    logger.Logger
+	writer *sqlx.DB
+}
+
+func NewSqlx(log logger.Logger, writer *sqlx.DB) IRepository {
+	return &repoSqlx{log: log, writer: writer}
+}
+
+func (repo *repoSqlx) Run(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.transaction.run")
+	defer span.End()
+
+	// a nested call joins the transaction already opened by the caller
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := repo.writer.BeginTxx(ctx, nil)
+	if err != nil {
+		repo.log.ErrorContext(ctx, "transaction.SqlxRepo.Run", "Error on begin transaction: ", err)
+		return database.Error(err, "problem to begin transaction")
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			repo.log.ErrorContext(ctx, "transaction.SqlxRepo.Run", "Error on rollback transaction: ", rbErr)
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		repo.log.ErrorContext(ctx, "transaction.SqlxRepo.Run", "Error on commit transaction: ", err)
+		return database.Error(err, "problem to commit transaction")
+	}
+
+	return nil
+}
+
+func (repo *repoSqlx) Savepoint(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	ctx, span := tracer.Span(ctx, "repositories.database.transaction.savepoint")
+	defer span.End()
+
+	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	if !ok {
+		return repo.Run(ctx, fn)
+	}
+
+	// the nested savepoints share the name, each statement acts on the latest
+	if _, err = tx.ExecContext(ctx, "SAVEPOINT nested_run;"); err != nil {
+		repo.log.ErrorContext(ctx, "transaction.SqlxRepo.Savepoint", "Error on create savepoint: ", err)
+		return database.Error(err, "problem to create savepoint")
+	}
+
+	if err = fn(ctx); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT nested_run;"); rbErr != nil {
+			repo.log.ErrorContext(ctx, "transaction.SqlxRepo.Savepoint", "Error on rollback savepoint: ", rbErr)
+		}
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT nested_run;"); err != nil {
+		repo.log.ErrorContext(ctx, "transaction.SqlxRepo.Savepoint", "Error on release savepoint: ", err)
+		return database.Error(err, "problem to release savepoint")
+	}
+
+	return nil
+}
+
+// Executor returns the transaction carried by ctx, or db when there is none.
+func Executor(ctx context.Context, db *sqlx.DB) sqlx.ExtContext {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,164 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/test"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Run(t *testing.T) {
+	cases := map[string]struct {
+		inputFn     func(ctx context.Context) error
+		expectedErr error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should commit on success": {
+			inputFn: func(ctx context.Context) error {
+				_, ok := Executor(ctx, nil).(*sqlx.Tx)
+				assert.True(t, ok)
+				return nil
+			},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+		},
+		"Should rollback on error": {
+			inputFn: func(ctx context.Context) error {
+				return errors.New("problem to save")
+			},
+			expectedErr: errors.New("problem to save"),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+		},
+		"Should return error on begin": {
+			inputFn: func(ctx context.Context) error {
+				return nil
+			},
+			expectedErr: entities.NewDomainErr(nil, "problem to begin transaction", errors.New("connection refused")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin().WillReturnError(errors.New("connection refused"))
+			},
+		},
+		"Should return error on commit": {
+			inputFn: func(ctx context.Context) error {
+				return nil
+			},
+			expectedErr: entities.NewDomainErr(nil, "problem to commit transaction", errors.New("connection refused")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectCommit().WillReturnError(errors.New("connection refused"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			err := repo.Run(context.Background(), cs.inputFn)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_RunNested(t *testing.T) {
+	db, mock := test.GetDB()
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+	err := repo.Run(context.Background(), func(ctx context.Context) error {
+		outer := Executor(ctx, db)
+		return repo.Run(ctx, func(ctx context.Context) error {
+			assert.Equal(t, outer, Executor(ctx, db))
+			return nil
+		})
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_Savepoint(t *testing.T) {
+	cases := map[string]struct {
+		inputFn     func(ctx context.Context) error
+		expectedErr error
+
+		prepareMock func(mock sqlmock.Sqlmock)
+	}{
+		"Should release on success": {
+			inputFn: func(ctx context.Context) error {
+				return nil
+			},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT nested_run;")).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("RELEASE SAVEPOINT nested_run;")).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		"Should rollback to the savepoint and keep the transaction on error": {
+			inputFn: func(ctx context.Context) error {
+				return errors.New("problem to save")
+			},
+			expectedErr: errors.New("problem to save"),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT nested_run;")).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("ROLLBACK TO SAVEPOINT nested_run;")).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		"Should return error on savepoint": {
+			inputFn: func(ctx context.Context) error {
+				return nil
+			},
+			expectedErr: entities.NewDomainErr(nil, "problem to create savepoint", errors.New("connection refused")),
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT nested_run;")).WillReturnError(errors.New("connection refused"))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock := test.GetDB()
+
+			cs.prepareMock(mock)
+
+			repo := NewSqlx(logger.NewLogrusLogger(), db)
+
+			// the transaction commits whatever the savepoint returns
+			var err error
+			txErr := repo.Run(context.Background(), func(ctx context.Context) error {
+				err = repo.Savepoint(ctx, cs.inputFn)
+				return nil
+			})
+
+			assert.NoError(t, txErr)
+			assert.Equal(t, cs.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -1,8 +1,17 @@
 package repositories
 
 import (
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/apikeys"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/audit"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/characters"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/houses"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/idempotency"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/jobs"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/ratelimit"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/search"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/snapshot"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/stats"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/transaction"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
 	"github.com/jmoiron/sqlx"
 )
@@ -14,8 +23,17 @@ type (
 	}
 
 	SqlContainer struct {
-		House     houses.IRepository
-		Character characters.IRepository
+		House       houses.IRepository
+		Character   characters.IRepository
+		Transaction transaction.IRepository
+		Idempotency idempotency.IRepository
+		Audit       audit.IRepository
+		Snapshot    snapshot.IRepository
+		Search      search.IRepository
+		Stats       stats.IRepository
+		Job         jobs.IRepository
+		APIKey      apikeys.IRepository
+		RateLimit   ratelimit.IRepository
 	}
 
 	// Options struct of options to create a new repositories
@@ -30,8 +48,17 @@ type (
 func New(opts Options) *Container {
 	return &Container{
 		Database: SqlContainer{
-			House:     houses.NewSqlx(opts.Log, opts.WriterSqlx, opts.ReaderSqlx),
-			Character: characters.NewSqlx(opts.Log, opts.WriterSqlx, opts.ReaderSqlx),
+			House:       houses.NewSqlx(opts.Log, opts.WriterSqlx, opts.ReaderSqlx),
+			Character:   characters.NewSqlx(opts.Log, opts.WriterSqlx, opts.ReaderSqlx),
+			Transaction: transaction.NewSqlx(opts.Log, opts.WriterSqlx),
+			Idempotency: idempotency.NewSqlx(opts.Log, opts.WriterSqlx),
+			Audit:       audit.NewSqlx(opts.Log, opts.WriterSqlx, opts.ReaderSqlx),
+			Snapshot:    snapshot.NewSqlx(opts.Log, opts.WriterSqlx, opts.ReaderSqlx),
+			Search:      search.NewSqlx(opts.Log, opts.ReaderSqlx),
+			Stats:       stats.NewSqlx(opts.Log, opts.ReaderSqlx),
+			Job:         jobs.NewSqlx(opts.Log, opts.WriterSqlx),
+			APIKey:      apikeys.NewSqlx(opts.Log, opts.WriterSqlx),
+			RateLimit:   ratelimit.NewSqlx(opts.Log, opts.WriterSqlx),
 		},
 	}
 }
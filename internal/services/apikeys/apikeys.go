@@ -0,0 +1,127 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package apikeys
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+var timeNow = time.Now
+
+// BootstrapID is the id of the bootstrap key, which is never stored.
+const BootstrapID = "bootstrap"
+
+type (
+	IService interface {
+		// Create stores a new key, its secret is only returned here.
+		Create(ctx context.Context, request entities.APIKeyRequest) (created entities.CreatedAPIKey, err error)
+		Find(ctx context.Context) (keys []entities.APIKey, err error)
+		Revoke(ctx context.Context, id string) (key entities.APIKey, err error)
+		// Authenticate returns the active key of secret, ErrInvalidAPIKey when
+		// there is none.
+		Authenticate(ctx context.Context, secret string) (key entities.APIKey, err error)
+	}
+
+	// Options holds the bootstrap key, an admin key kept out of the database
+	// to create the first keys. An empty one is disabled.
+	Options struct {
+		BootstrapKey string
+	}
+
+	services struct {
+		repositories *repositories.Container
+		log          logger.Logger
+		bootstrap    string
+	}
+)
+
+func New(repo *repositories.Container, log logger.Logger, opts Options) IService {
+	srv := &services{repositories: repo, log: log}
+	if len(opts.BootstrapKey) > 0 {
+		srv.bootstrap = entities.HashAPIKey(opts.BootstrapKey)
+	}
+	return srv
+}
+
+func (srv *services) Create(ctx context.Context, request entities.APIKeyRequest) (created entities.CreatedAPIKey, err error) {
+	ctx, span := tracer.Span(ctx, "services.apikeys.create")
+	defer span.End()
+
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(timeNow()) {
+		return created, entities.ErrAPIKeyExpiry
+	}
+
+	key, secret, err := request.NewAPIKey(ctx)
+	if err != nil {
+		srv.log.ErrorContext(ctx, "apikeys.Service.NewAPIKey", err)
+		return created, err
+	}
+
+	if err = srv.repositories.Database.APIKey.Create(ctx, key); err != nil {
+		srv.log.ErrorContext(ctx, "apikeys.Service.database.Create", err)
+		return created, err
+	}
+
+	return entities.CreatedAPIKey{APIKey: key, Key: secret}, nil
+}
+
+func (srv *services) Find(ctx context.Context) (keys []entities.APIKey, err error) {
+	ctx, span := tracer.Span(ctx, "services.apikeys.find")
+	defer span.End()
+
+	keys, err = srv.repositories.Database.APIKey.Find(ctx)
+	if err != nil {
+		srv.log.ErrorContext(ctx, "apikeys.Service.database.Find", err)
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (srv *services) Revoke(ctx context.Context, id string) (key entities.APIKey, err error) {
+	ctx, span := tracer.Span(ctx, "services.apikeys.revoke")
+	defer span.End()
+
+	key, err = srv.repositories.Database.APIKey.Revoke(ctx, id, timeNow())
+	if err != nil {
+		srv.log.ErrorContext(ctx, "apikeys.Service.database.Revoke", err)
+		if errors.Is(err, entities.ErrNotFound) {
+			return key, ErrAPIKeyNotFound.Wrap(err)
+		}
+		return key, err
+	}
+
+	return key, nil
+}
+
+func (srv *services) Authenticate(ctx context.Context, secret string) (key entities.APIKey, err error) {
+	ctx, span := tracer.Span(ctx, "services.apikeys.authenticate")
+	defer span.End()
+
+	hash := entities.HashAPIKey(secret)
+	if len(srv.bootstrap) > 0 && subtle.ConstantTimeCompare([]byte(hash), []byte(srv.bootstrap)) == 1 {
+		return entities.APIKey{ID: BootstrapID, Name: "bootstrap", Scopes: []string{entities.ScopeAdmin}}, nil
+	}
+
+	key, err = srv.repositories.Database.APIKey.FindByHash(ctx, hash)
+	if errors.Is(err, entities.ErrNotFound) {
+		return key, ErrInvalidAPIKey
+	}
+	if err != nil {
+		srv.log.ErrorContext(ctx, "apikeys.Service.database.FindByHash", err)
+		return key, err
+	}
+
+	if !key.Active(timeNow()) {
+		return entities.APIKey{}, ErrInvalidAPIKey
+	}
+
+	return key, nil
+}
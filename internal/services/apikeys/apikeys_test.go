@@ -0,0 +1,187 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/apikeys"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Create(t *testing.T) {
+	now := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+	past := now.Add(-time.Hour)
+
+	cases := map[string]struct {
+		input       entities.APIKeyRequest
+		expectedErr error
+		prepareMock func(mock *apikeys.MockIRepository)
+	}{
+		"Should return the key with its secret": {
+			input: entities.APIKeyRequest{Name: "ci", Scopes: []string{entities.ScopeRead}},
+			prepareMock: func(mock *apikeys.MockIRepository) {
+				mock.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		"Should return error expiry": {
+			input:       entities.APIKeyRequest{Name: "ci", Scopes: []string{entities.ScopeRead}, ExpiresAt: &past},
+			expectedErr: entities.ErrAPIKeyExpiry,
+			prepareMock: func(mock *apikeys.MockIRepository) {},
+		},
+		"Should return error create": {
+			input:       entities.APIKeyRequest{Name: "ci", Scopes: []string{entities.ScopeRead}},
+			expectedErr: errors.New("problem to create api key"),
+			prepareMock: func(mock *apikeys.MockIRepository) {
+				mock.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("problem to create api key"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mock := apikeys.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{APIKey: mock}},
+				logger.NewLogrusLogger(),
+				Options{},
+			)
+
+			created, err := srv.Create(ctx, cs.input)
+
+			assert.Equal(t, cs.expectedErr, err)
+			if err == nil {
+				assert.True(t, strings.HasPrefix(created.Key, entities.APIKeyPrefix))
+				assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+				assert.Equal(t, entities.HashAPIKey(created.Key), created.Hash)
+			}
+		})
+	}
+}
+
+func Test_Authenticate(t *testing.T) {
+	now := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+	past := now.Add(-time.Hour)
+
+	errNotFound := entities.NewDomainErr(entities.ErrNotFound, "api key is not found", nil)
+	hash := entities.HashAPIKey("got_secret")
+
+	cases := map[string]struct {
+		input        string
+		expectedName string
+		expectedErr  error
+		prepareMock  func(mock *apikeys.MockIRepository)
+	}{
+		"Should return the active key": {
+			input:        "got_secret",
+			expectedName: "ci",
+			prepareMock: func(mock *apikeys.MockIRepository) {
+				mock.EXPECT().FindByHash(gomock.Any(), hash).Times(1).Return(entities.APIKey{Name: "ci"}, nil)
+			},
+		},
+		"Should return the bootstrap key": {
+			input:        "got_bootstrap",
+			expectedName: "bootstrap",
+			prepareMock:  func(mock *apikeys.MockIRepository) {},
+		},
+		"Should return error unknown key": {
+			input:       "got_secret",
+			expectedErr: ErrInvalidAPIKey,
+			prepareMock: func(mock *apikeys.MockIRepository) {
+				mock.EXPECT().FindByHash(gomock.Any(), hash).Times(1).Return(entities.APIKey{}, errNotFound)
+			},
+		},
+		"Should return error expired key": {
+			input:       "got_secret",
+			expectedErr: ErrInvalidAPIKey,
+			prepareMock: func(mock *apikeys.MockIRepository) {
+				mock.EXPECT().FindByHash(gomock.Any(), hash).Times(1).Return(entities.APIKey{Name: "ci", ExpiresAt: &past}, nil)
+			},
+		},
+		"Should return error revoked key": {
+			input:       "got_secret",
+			expectedErr: ErrInvalidAPIKey,
+			prepareMock: func(mock *apikeys.MockIRepository) {
+				mock.EXPECT().FindByHash(gomock.Any(), hash).Times(1).Return(entities.APIKey{Name: "ci", RevokedAt: &past}, nil)
+			},
+		},
+		"Should return error database": {
+			input:       "got_secret",
+			expectedErr: errors.New("problem to find api key"),
+			prepareMock: func(mock *apikeys.MockIRepository) {
+				mock.EXPECT().FindByHash(gomock.Any(), hash).Times(1).Return(entities.APIKey{}, errors.New("problem to find api key"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mock := apikeys.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{APIKey: mock}},
+				logger.NewLogrusLogger(),
+				Options{BootstrapKey: "got_bootstrap"},
+			)
+
+			key, err := srv.Authenticate(ctx, cs.input)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedName, key.Name)
+		})
+	}
+}
+
+func Test_Revoke(t *testing.T) {
+	errNotFound := entities.NewDomainErr(entities.ErrNotFound, "api key is not found or revoked", nil)
+
+	cases := map[string]struct {
+		expectedErr error
+		prepareMock func(mock *apikeys.MockIRepository)
+	}{
+		"Should return the revoked key": {
+			prepareMock: func(mock *apikeys.MockIRepository) {
+				mock.EXPECT().Revoke(gomock.Any(), "id_1", gomock.Any()).Times(1).Return(entities.APIKey{ID: "id_1"}, nil)
+			},
+		},
+		"Should return error not found": {
+			expectedErr: ErrAPIKeyNotFound.Wrap(errNotFound),
+			prepareMock: func(mock *apikeys.MockIRepository) {
+				mock.EXPECT().Revoke(gomock.Any(), "id_1", gomock.Any()).Times(1).Return(entities.APIKey{}, errNotFound)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mock := apikeys.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{APIKey: mock}},
+				logger.NewLogrusLogger(),
+				Options{},
+			)
+
+			_, err := srv.Revoke(ctx, "id_1")
+
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package apikeys
+
+import "github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+
+var (
+	ErrAPIKeyNotFound = entities.NewDomainErr(entities.ErrNotFound, "api key not found or already revoked", nil)
+	// ErrInvalidAPIKey the key is unknown, expired or revoked
+	ErrInvalidAPIKey = entities.NewDomainErr(nil, "api key is unknown, expired or revoked", nil)
+)
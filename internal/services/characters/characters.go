@@ -3,6 +3,8 @@ package characters
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
@@ -13,10 +15,19 @@ import (
 type (
 	IService interface {
 		Create(ctx context.Context, newCharacter entities.CharacterRequest) (id string, err error)
-		Find(ctx context.Context) (characters []entities.Character, err error)
-		FindByID(ctx context.Context, id string) (character entities.Character, err error)
+		Find(ctx context.Context, filter entities.Filter) (characters []entities.Character, err error)
+		FindByID(ctx context.Context, id string, filter entities.Filter) (character entities.Character, err error)
+		// FindByIDs loads the characters among ids in a single query, in the
+		// order of ids, and reports the ones not found.
+		FindByIDs(ctx context.Context, ids []string, filter entities.Filter) (batch entities.CharacterBatch, err error)
 		Update(ctx context.Context, updateCharacter entities.CharacterRequest) (character entities.Character, err error)
 		Delete(ctx context.Context, id string) (err error)
+		// Restore undoes a delete, when reinstateLordships is true the character
+		// becomes again the lord of the houses it ruled before being deleted.
+		Restore(ctx context.Context, id string, reinstateLordships bool) (restored entities.CharacterRestored, err error)
+		Bulk(ctx context.Context, items []entities.CharacterRequest, atomic bool) (results []entities.BulkResult, err error)
+		Import(ctx context.Context, items []entities.CharacterRequest, opts entities.ImportOptions) (results []entities.BulkResult, err error)
+		History(ctx context.Context, id string, fields []string) (entries []entities.AuditEntry, err error)
 	}
 
 	services struct {
@@ -35,55 +46,84 @@ func (srv *services) Create(ctx context.Context, newCharacter entities.Character
 
 	newCharacter.PreSave(ctx)
 
-	err = srv.repositories.Database.Character.Create(ctx, newCharacter)
+	err = srv.repositories.Database.Transaction.Run(ctx, func(ctx context.Context) error {
+		if err := srv.repositories.Database.Character.Create(ctx, newCharacter); err != nil {
+			srv.log.ErrorContext(ctx, "character.Service.database.Create", err, ", playload: ", newCharacter)
+			return err
+		}
+
+		return srv.audit(ctx, entities.AuditCharacter, newCharacter.ID, entities.AuditCreate, nil, newCharacter)
+	})
 	if err != nil {
-		srv.log.ErrorContext(ctx, "character.Service.database.Create", err, ", playload: ", newCharacter)
 		return id, err
 	}
 
 	return newCharacter.ID, nil
 }
 
-func (srv *services) Find(ctx context.Context) (characters []entities.Character, err error) {
+func (srv *services) Find(ctx context.Context, filter entities.Filter) (characters []entities.Character, err error) {
 	ctx, span := tracer.Span(ctx, "services.characters.find")
 	defer span.End()
 
-	characters, err = srv.repositories.Database.Character.Find(ctx)
+	characters, err = srv.repositories.Database.Character.Find(ctx, filter)
 	if err != nil {
 		srv.log.ErrorContext(ctx, "character.Service.database.Find", err)
-		return nil, ErrFind
+		return nil, ErrFind.Wrap(err)
 	}
 
 	return characters, nil
 }
 
-func (srv *services) FindByID(ctx context.Context, id string) (character entities.Character, err error) {
+func (srv *services) FindByID(ctx context.Context, id string, filter entities.Filter) (character entities.Character, err error) {
 	ctx, span := tracer.Span(ctx, "services.characters.findbyid")
 	defer span.End()
 
-	character, err = srv.repositories.Database.Character.FindByID(ctx, id)
+	character, err = srv.repositories.Database.Character.FindByID(ctx, id, filter)
 	if err != nil {
 		srv.log.ErrorContext(ctx, "character.Service.database.FindByID", err)
-		return character, ErrCharacterNotFound
+		if errors.Is(err, entities.ErrNotFound) {
+			return character, ErrCharacterNotFound.Wrap(err)
+		}
+		return character, err
 	}
 
 	return character, nil
 }
 
+func (srv *services) FindByIDs(ctx context.Context, ids []string, filter entities.Filter) (batch entities.CharacterBatch, err error) {
+	ctx, span := tracer.Span(ctx, "services.characters.findbyids")
+	defer span.End()
+
+	characters, err := srv.repositories.Database.Character.FindByIDs(ctx, ids, filter)
+	if err != nil {
+		srv.log.ErrorContext(ctx, "character.Service.database.FindByIDs", err)
+		return batch, err
+	}
+
+	return entities.NewCharacterBatch(ids, characters), nil
+}
+
 func (srv *services) Update(ctx context.Context, updateCharacter entities.CharacterRequest) (character entities.Character, err error) {
 	ctx, span := tracer.Span(ctx, "services.characters.update")
 	defer span.End()
 
-	character, err = srv.FindByID(ctx, updateCharacter.ID)
+	character, err = srv.FindByID(ctx, updateCharacter.ID, entities.Filter{})
 	if err != nil {
 		return
 	}
 
+	before := character
 	character.PreUpdate(ctx, updateCharacter)
 
-	err = srv.repositories.Database.Character.Update(ctx, &character)
+	err = srv.repositories.Database.Transaction.Run(ctx, func(ctx context.Context) error {
+		if err := srv.repositories.Database.Character.Update(ctx, &character); err != nil {
+			srv.log.ErrorContext(ctx, "character.Service.database.Update", err)
+			return err
+		}
+
+		return srv.audit(ctx, entities.AuditCharacter, character.ID, entities.AuditUpdate, before, character)
+	})
 	if err != nil {
-		srv.log.ErrorContext(ctx, "character.Service.database.Update", err)
 		return character, err
 	}
 
@@ -94,21 +134,241 @@ func (srv *services) Delete(ctx context.Context, id string) (err error) {
 	ctx, span := tracer.Span(ctx, "services.characters.delete")
 	defer span.End()
 
-	_, err = srv.FindByID(ctx, id)
+	character, err := srv.FindByID(ctx, id, entities.Filter{})
 	if err != nil {
 		return
 	}
 
-	err = srv.repositories.Database.Character.Delete(ctx, id)
+	return srv.repositories.Database.Transaction.Run(ctx, func(ctx context.Context) error {
+		if err := srv.repositories.Database.Character.Delete(ctx, id); err != nil {
+			srv.log.ErrorContext(ctx, "character.Service.database.Delete", err)
+			return err
+		}
+
+		deleted := character
+		now := time.Now()
+		deleted.DeletedAt = &now
+
+		if err := srv.audit(ctx, entities.AuditCharacter, id, entities.AuditDelete, character, deleted); err != nil {
+			return err
+		}
+
+		houseIDs, err := srv.repositories.Database.House.RemoveLord(ctx, id)
+		if err != nil {
+			srv.log.ErrorContext(ctx, "character.Service.database.House.RemoveLord", err)
+			return err
+		}
+
+		for _, houseID := range houseIDs {
+			if err := srv.audit(ctx, entities.AuditHouse, houseID, entities.AuditRemoveLord, lordship(id), lordship("")); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (srv *services) Restore(ctx context.Context, id string, reinstateLordships bool) (restored entities.CharacterRestored, err error) {
+	ctx, span := tracer.Span(ctx, "services.characters.restore")
+	defer span.End()
+
+	restored.Character, err = srv.FindByID(ctx, id, entities.Filter{IncludeDeleted: true})
 	if err != nil {
-		srv.log.ErrorContext(ctx, "character.Service.database.Delete", err)
-		return err
+		return
+	}
+
+	if restored.DeletedAt == nil {
+		return restored, ErrCharacterNotDeleted
+	}
+
+	before := restored.Character
+	now := time.Now()
+	after := restored.Character
+	after.UpdatedAt = &now
+	after.DeletedAt = nil
+
+	restored.ReinstatedHouses = make([]string, 0)
+	err = srv.repositories.Database.Transaction.Run(ctx, func(ctx context.Context) error {
+		if err := srv.repositories.Database.Character.Restore(ctx, id); err != nil {
+			srv.log.ErrorContext(ctx, "character.Service.database.Restore", err)
+			return err
+		}
+
+		if err := srv.audit(ctx, entities.AuditCharacter, id, entities.AuditRestore, before, after); err != nil {
+			return err
+		}
+
+		if !reinstateLordships {
+			return nil
+		}
+
+		houseIDs, err := srv.repositories.Database.House.ReinstateLord(ctx, id)
+		if err != nil {
+			srv.log.ErrorContext(ctx, "character.Service.database.House.ReinstateLord", err)
+			return err
+		}
+		restored.ReinstatedHouses = houseIDs
+
+		for _, houseID := range houseIDs {
+			if err := srv.audit(ctx, entities.AuditHouse, houseID, entities.AuditReinstateLord, lordship(""), lordship(id)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return restored, err
+	}
+
+	restored.Character = after
+
+	return restored, nil
+}
+
+func (srv *services) History(ctx context.Context, id string, fields []string) (entries []entities.AuditEntry, err error) {
+	ctx, span := tracer.Span(ctx, "services.characters.history")
+	defer span.End()
+
+	if _, err = srv.FindByID(ctx, id, entities.Filter{IncludeDeleted: true}); err != nil {
+		return nil, err
 	}
 
-	if err := srv.repositories.Database.House.RemoveLord(ctx, id); err != nil {
-		srv.log.ErrorContext(ctx, "character.Service.database.House.RemoveLord", err)
+	entries, err = srv.repositories.Database.Audit.Find(ctx, entities.AuditCharacter, id, fields)
+	if err != nil {
+		srv.log.ErrorContext(ctx, "character.Service.database.Audit.Find", err)
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// audit records a change of a character or of a house it rules, it must run
+// in the transaction of the change so both are committed together.
+func (srv *services) audit(ctx context.Context, entity, id, action string, before, after any) error {
+	entry := entities.NewAuditEntry(ctx, entity, id, action, before, after)
+	if err := srv.repositories.Database.Audit.Create(ctx, entry); err != nil {
+		srv.log.ErrorContext(ctx, "character.Service.database.Audit.Create", err)
 		return err
 	}
 
 	return nil
 }
+
+// lordship is the part of a house changed when its lord is removed or reinstated.
+func lordship(lordID string) map[string]string {
+	return map[string]string{"current_lord": lordID}
+}
+
+// Bulk creates the items without ID and updates the others. By default every
+// item is applied on its own; when atomic is true the whole batch runs in one
+// transaction and is rolled back on the first failure.
+func (srv *services) Bulk(ctx context.Context, items []entities.CharacterRequest, atomic bool) (results []entities.BulkResult, err error) {
+	ctx, span := tracer.Span(ctx, "services.characters.bulk")
+	defer span.End()
+
+	if !atomic {
+		return srv.bulk(ctx, items, false), nil
+	}
+
+	err = srv.repositories.Database.Transaction.Run(ctx, func(ctx context.Context) error {
+		results = srv.bulk(ctx, items, true)
+		for _, result := range results {
+			if result.Err != nil {
+				return result.Err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if results == nil {
+			srv.log.ErrorContext(ctx, "character.Service.Bulk", err)
+			return nil, err
+		}
+		entities.Rollback(results)
+	}
+
+	return results, nil
+}
+
+// Import creates the rows of an import in one transaction, each row on its
+// own. A dry run is rolled back, so its results tell what the import would do.
+func (srv *services) Import(ctx context.Context, items []entities.CharacterRequest, opts entities.ImportOptions) (results []entities.BulkResult, err error) {
+	ctx, span := tracer.Span(ctx, "services.characters.import")
+	defer span.End()
+
+	if opts.Mode != entities.ImportInsert {
+		return nil, ErrImportMode
+	}
+
+	err = srv.repositories.Database.Transaction.Run(ctx, func(ctx context.Context) error {
+		for i := range items {
+			items[i].ID = ""
+		}
+
+		results = srv.bulk(ctx, items, false)
+		for i := range results {
+			if results[i].Err != nil {
+				continue
+			}
+			results[i].Action = entities.BulkCreated
+			if opts.DryRun {
+				// the character is not kept, neither is its id
+				results[i].ID = ""
+			}
+		}
+
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		if results == nil {
+			srv.log.ErrorContext(ctx, "character.Service.Import", err)
+			return nil, err
+		}
+		entities.Rollback(results)
+	}
+
+	return results, nil
+}
+
+func (srv *services) bulk(ctx context.Context, items []entities.CharacterRequest, atomic bool) []entities.BulkResult {
+	results := make([]entities.BulkResult, len(items))
+
+	for i, item := range items {
+		results[i].Index = i
+		results[i].ID, results[i].Err = srv.save(ctx, item)
+
+		if results[i].Err != nil && atomic {
+			for j := i + 1; j < len(items); j++ {
+				results[j] = entities.BulkResult{Index: j, Err: entities.ErrBulkRolledBack}
+			}
+			break
+		}
+	}
+
+	return results
+}
+
+// save creates or updates item inside a savepoint, a failed item leaves the
+// transaction of an import usable by the next ones.
+func (srv *services) save(ctx context.Context, item entities.CharacterRequest) (id string, err error) {
+	err = srv.repositories.Database.Transaction.Savepoint(ctx, func(ctx context.Context) error {
+		if len(item.ID) == 0 {
+			id, err = srv.Create(ctx, item)
+			return err
+		}
+
+		character, err := srv.Update(ctx, item)
+		id = character.ID
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
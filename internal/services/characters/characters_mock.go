@@ -35,6 +35,21 @@ func (m *MockIService) EXPECT() *MockIServiceMockRecorder {
 	return m.recorder
 }
 
+// Bulk mocks base method.
+func (m *MockIService) Bulk(ctx context.Context, items []entities.CharacterRequest, atomic bool) ([]entities.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", ctx, items, atomic)
+	ret0, _ := ret[0].([]entities.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockIServiceMockRecorder) Bulk(ctx, items, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockIService)(nil).Bulk), ctx, items, atomic)
+}
+
 // Create mocks base method.
 func (m *MockIService) Create(ctx context.Context, newCharacter entities.CharacterRequest) (string, error) {
 	m.ctrl.T.Helper()
@@ -65,33 +80,93 @@ func (mr *MockIServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
 }
 
 // Find mocks base method.
-func (m *MockIService) Find(ctx context.Context) ([]entities.Character, error) {
+func (m *MockIService) Find(ctx context.Context, filter entities.Filter) ([]entities.Character, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Find", ctx)
+	ret := m.ctrl.Call(m, "Find", ctx, filter)
 	ret0, _ := ret[0].([]entities.Character)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Find indicates an expected call of Find.
-func (mr *MockIServiceMockRecorder) Find(ctx interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) Find(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIService)(nil).Find), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIService)(nil).Find), ctx, filter)
 }
 
 // FindByID mocks base method.
-func (m *MockIService) FindByID(ctx context.Context, id string) (entities.Character, error) {
+func (m *MockIService) FindByID(ctx context.Context, id string, filter entities.Filter) (entities.Character, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret := m.ctrl.Call(m, "FindByID", ctx, id, filter)
 	ret0, _ := ret[0].(entities.Character)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByID indicates an expected call of FindByID.
-func (mr *MockIServiceMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) FindByID(ctx, id, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockIService)(nil).FindByID), ctx, id, filter)
+}
+
+// FindByIDs mocks base method.
+func (m *MockIService) FindByIDs(ctx context.Context, ids []string, filter entities.Filter) (entities.CharacterBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids, filter)
+	ret0, _ := ret[0].(entities.CharacterBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockIServiceMockRecorder) FindByIDs(ctx, ids, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockIService)(nil).FindByIDs), ctx, ids, filter)
+}
+
+// History mocks base method.
+func (m *MockIService) History(ctx context.Context, id string, fields []string) ([]entities.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, id, fields)
+	ret0, _ := ret[0].([]entities.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockIServiceMockRecorder) History(ctx, id, fields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockIService)(nil).History), ctx, id, fields)
+}
+
+// Import mocks base method.
+func (m *MockIService) Import(ctx context.Context, items []entities.CharacterRequest, opts entities.ImportOptions) ([]entities.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, items, opts)
+	ret0, _ := ret[0].([]entities.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockIServiceMockRecorder) Import(ctx, items, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockIService)(nil).Import), ctx, items, opts)
+}
+
+// Restore mocks base method.
+func (m *MockIService) Restore(ctx context.Context, id string, reinstateLordships bool) (entities.CharacterRestored, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, reinstateLordships)
+	ret0, _ := ret[0].(entities.CharacterRestored)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockIServiceMockRecorder) Restore(ctx, id, reinstateLordships interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockIService)(nil).FindByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockIService)(nil).Restore), ctx, id, reinstateLordships)
 }
 
 // Update mocks base method.
@@ -2,19 +2,25 @@ package characters
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/audit"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/characters"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/houses"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/transaction"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
 	"github.com/golang/mock/gomock"
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
+var errNotFound = entities.NewDomainErr(entities.ErrNotFound, "character is not found or deleted", sql.ErrNoRows)
+
 var (
 	characterID   string
 	characterTime string
@@ -61,7 +67,11 @@ func Test_Create(t *testing.T) {
 
 			cs.prepareMock(mock)
 
-			srv := New(&repositories.Container{Database: repositories.SqlContainer{Character: mock}}, logger.NewLogrusLogger())
+			mockTx := transaction.NewMockIRepository(ctrl)
+			mockAudit := audit.NewMockIRepository(ctrl)
+			passThrough(mockTx, mockAudit)
+
+			srv := New(&repositories.Container{Database: repositories.SqlContainer{Character: mock, Transaction: mockTx, Audit: mockAudit}}, logger.NewLogrusLogger())
 
 			_, err := srv.Create(ctx, cs.input)
 
@@ -85,16 +95,69 @@ func Test_Find(t *testing.T) {
 			expectedData: data,
 			prepareMock: func(mock *characters.MockIRepository) {
 				mock.EXPECT().
-					Find(gomock.Any()).
+					Find(gomock.Any(), entities.Filter{}).
+					Times(1).
+					Return(data, nil)
+			},
+		},
+		"Should return error": {
+			expectedErr: ErrFind.Wrap(errors.New("problem to query")),
+			prepareMock: func(mock *characters.MockIRepository) {
+				mock.EXPECT().
+					Find(gomock.Any(), entities.Filter{}).
+					Times(1).
+					Return(nil, errors.New("problem to query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := characters.NewMockIRepository(ctrl)
+
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{Database: repositories.SqlContainer{Character: mock}}, logger.NewLogrusLogger())
+
+			data, err := srv.Find(ctx, entities.Filter{})
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, data)
+		})
+	}
+}
+
+func Test_FindByIDs(t *testing.T) {
+	data := []entities.Character{
+		{ID: "id_1", Name: "character Patrick", TVSeries: pq.StringArray{"session 1", "session 2"}},
+		{ID: "id_3", Name: "character Chagas"},
+	}
+	ids := []string{"id_3", "id_2", "id_1"}
+
+	cases := map[string]struct {
+		expectedData entities.CharacterBatch
+		expectedErr  error
+		prepareMock  func(mock *characters.MockIRepository)
+	}{
+		"Should return success in the order of the ids": {
+			expectedData: entities.CharacterBatch{
+				Characters: []entities.Character{data[1], data[0]},
+				Missing:    []string{"id_2"},
+			},
+			prepareMock: func(mock *characters.MockIRepository) {
+				mock.EXPECT().
+					FindByIDs(gomock.Any(), ids, entities.Filter{}).
 					Times(1).
 					Return(data, nil)
 			},
 		},
 		"Should return error": {
-			expectedErr: ErrFind,
+			expectedErr: errors.New("problem to query"),
 			prepareMock: func(mock *characters.MockIRepository) {
 				mock.EXPECT().
-					Find(gomock.Any()).
+					FindByIDs(gomock.Any(), ids, entities.Filter{}).
 					Times(1).
 					Return(nil, errors.New("problem to query"))
 			},
@@ -111,7 +174,7 @@ func Test_Find(t *testing.T) {
 
 			srv := New(&repositories.Container{Database: repositories.SqlContainer{Character: mock}}, logger.NewLogrusLogger())
 
-			data, err := srv.Find(ctx)
+			data, err := srv.FindByIDs(ctx, ids, entities.Filter{})
 
 			assert.Equal(t, cs.expectedErr, err)
 			assert.Equal(t, cs.expectedData, data)
@@ -137,19 +200,19 @@ func Test_FindByID(t *testing.T) {
 			expectedData: data,
 			prepareMock: func(mock *characters.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), data.ID).
+					FindByID(gomock.Any(), data.ID, entities.Filter{}).
 					Times(1).
 					Return(data, nil)
 			},
 		},
 		"Should return error": {
 			input:       data.ID,
-			expectedErr: ErrCharacterNotFound,
+			expectedErr: ErrCharacterNotFound.Wrap(errNotFound),
 			prepareMock: func(mock *characters.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), data.ID).
+					FindByID(gomock.Any(), data.ID, entities.Filter{}).
 					Times(1).
-					Return(entities.Character{}, errors.New("problem to query"))
+					Return(entities.Character{}, errNotFound)
 			},
 		},
 	}
@@ -164,7 +227,7 @@ func Test_FindByID(t *testing.T) {
 
 			srv := New(&repositories.Container{Database: repositories.SqlContainer{Character: mock}}, logger.NewLogrusLogger())
 
-			data, err := srv.FindByID(ctx, cs.input)
+			data, err := srv.FindByID(ctx, cs.input, entities.Filter{})
 
 			assert.Equal(t, cs.expectedErr, err)
 			assert.Equal(t, cs.expectedData, data)
@@ -189,7 +252,7 @@ func Test_Update(t *testing.T) {
 			input: req,
 			prepareMock: func(mock *characters.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), req.ID).
+					FindByID(gomock.Any(), req.ID, entities.Filter{}).
 					Times(1).
 					Return(entities.Character{
 						ID:       "id_1",
@@ -205,12 +268,12 @@ func Test_Update(t *testing.T) {
 		},
 		"Should return error find": {
 			input:       req,
-			expectedErr: ErrCharacterNotFound,
+			expectedErr: ErrCharacterNotFound.Wrap(errNotFound),
 			prepareMock: func(mock *characters.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), req.ID).
+					FindByID(gomock.Any(), req.ID, entities.Filter{}).
 					Times(1).
-					Return(entities.Character{}, ErrCharacterNotFound)
+					Return(entities.Character{}, errNotFound)
 			},
 		},
 		"Should return error update": {
@@ -218,7 +281,7 @@ func Test_Update(t *testing.T) {
 			expectedErr: errors.New("problem to query"),
 			prepareMock: func(mock *characters.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), req.ID).
+					FindByID(gomock.Any(), req.ID, entities.Filter{}).
 					Times(1).
 					Return(entities.Character{
 						ID:       "id_1",
@@ -242,7 +305,11 @@ func Test_Update(t *testing.T) {
 
 			cs.prepareMock(mock)
 
-			srv := New(&repositories.Container{Database: repositories.SqlContainer{Character: mock}}, logger.NewLogrusLogger())
+			mockTx := transaction.NewMockIRepository(ctrl)
+			mockAudit := audit.NewMockIRepository(ctrl)
+			passThrough(mockTx, mockAudit)
+
+			srv := New(&repositories.Container{Database: repositories.SqlContainer{Character: mock, Transaction: mockTx, Audit: mockAudit}}, logger.NewLogrusLogger())
 
 			_, err := srv.Update(ctx, cs.input)
 
@@ -262,7 +329,7 @@ func Test_Delete(t *testing.T) {
 			input: id,
 			prepareMock: func(mock *characters.MockIRepository, mockHouse *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), id).
+					FindByID(gomock.Any(), id, entities.Filter{}).
 					Times(1).
 					Return(entities.Character{}, nil)
 
@@ -273,17 +340,17 @@ func Test_Delete(t *testing.T) {
 
 				mockHouse.EXPECT().RemoveLord(gomock.Any(), id).
 					Times(1).
-					Return(nil)
+					Return([]string{"house_1"}, nil)
 			},
 		},
 		"Should return error find": {
 			input:       id,
-			expectedErr: ErrCharacterNotFound,
+			expectedErr: ErrCharacterNotFound.Wrap(errNotFound),
 			prepareMock: func(mock *characters.MockIRepository, mockHouse *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), id).
+					FindByID(gomock.Any(), id, entities.Filter{}).
 					Times(1).
-					Return(entities.Character{}, ErrCharacterNotFound)
+					Return(entities.Character{}, errNotFound)
 			},
 		},
 		"Should return error delete": {
@@ -291,7 +358,7 @@ func Test_Delete(t *testing.T) {
 			expectedErr: errors.New("problem to query"),
 			prepareMock: func(mock *characters.MockIRepository, mockHouse *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), id).
+					FindByID(gomock.Any(), id, entities.Filter{}).
 					Times(1).
 					Return(entities.Character{}, nil)
 
@@ -306,7 +373,7 @@ func Test_Delete(t *testing.T) {
 			expectedErr: errors.New("problem to query"),
 			prepareMock: func(mock *characters.MockIRepository, mockHouse *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), id).
+					FindByID(gomock.Any(), id, entities.Filter{}).
 					Times(1).
 					Return(entities.Character{}, nil)
 
@@ -317,7 +384,7 @@ func Test_Delete(t *testing.T) {
 
 				mockHouse.EXPECT().RemoveLord(gomock.Any(), id).
 					Times(1).
-					Return(errors.New("problem to query"))
+					Return(nil, errors.New("problem to query"))
 			},
 		},
 	}
@@ -331,10 +398,16 @@ func Test_Delete(t *testing.T) {
 
 			cs.prepareMock(mock, mockHouse)
 
+			mockTx := transaction.NewMockIRepository(ctrl)
+			mockAudit := audit.NewMockIRepository(ctrl)
+			passThrough(mockTx, mockAudit)
+
 			srv := New(&repositories.Container{
 				Database: repositories.SqlContainer{
-					Character: mock,
-					House:     mockHouse,
+					Character:   mock,
+					House:       mockHouse,
+					Transaction: mockTx,
+					Audit:       mockAudit,
 				}},
 				logger.NewLogrusLogger(),
 			)
@@ -345,3 +418,459 @@ func Test_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_Bulk(t *testing.T) {
+	items := []entities.CharacterRequest{
+		{Name: "Jon Snow", TVSeries: pq.StringArray{"Season 1"}},
+		{ID: "id_1", Name: "Arya Stark", TVSeries: pq.StringArray{"Season 1"}},
+	}
+
+	cases := map[string]struct {
+		inputAtomic bool
+
+		expectedErrs []error
+		expectedErr  error
+		prepareMock  func(mock *characters.MockIRepository, mockTx *transaction.MockIRepository)
+	}{
+		"Should apply each item": {
+			expectedErrs: []error{nil, ErrCharacterNotFound.Wrap(errNotFound)},
+			prepareMock: func(mock *characters.MockIRepository, mockTx *transaction.MockIRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.CharacterRequest{})).
+					Times(1).
+					Return(nil)
+
+				mock.EXPECT().
+					FindByID(gomock.Any(), "id_1", entities.Filter{}).
+					Times(1).
+					Return(entities.Character{}, errNotFound)
+			},
+		},
+		"Should roll back the batch when atomic": {
+			inputAtomic:  true,
+			expectedErrs: []error{entities.ErrBulkRolledBack, ErrCharacterNotFound.Wrap(errNotFound)},
+			prepareMock: func(mock *characters.MockIRepository, mockTx *transaction.MockIRepository) {
+				mockTx.EXPECT().
+					Run(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.CharacterRequest{})).
+					Times(1).
+					Return(nil)
+
+				mock.EXPECT().
+					FindByID(gomock.Any(), "id_1", entities.Filter{}).
+					Times(1).
+					Return(entities.Character{}, errNotFound)
+			},
+		},
+		"Should return error when the transaction can't start": {
+			inputAtomic: true,
+			expectedErr: errors.New("problem to begin transaction"),
+			prepareMock: func(mock *characters.MockIRepository, mockTx *transaction.MockIRepository) {
+				mockTx.EXPECT().
+					Run(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("problem to begin transaction"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := characters.NewMockIRepository(ctrl)
+			mockTx := transaction.NewMockIRepository(ctrl)
+
+			cs.prepareMock(mock, mockTx)
+
+			mockAudit := audit.NewMockIRepository(ctrl)
+			passThrough(mockTx, mockAudit)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{Character: mock, Transaction: mockTx, Audit: mockAudit}},
+				logger.NewLogrusLogger(),
+			)
+
+			results, err := srv.Bulk(ctx, items, cs.inputAtomic)
+
+			assert.Equal(t, cs.expectedErr, err)
+			if cs.expectedErrs == nil {
+				assert.Nil(t, results)
+				return
+			}
+			for i, result := range results {
+				assert.Equal(t, i, result.Index)
+				assert.Equal(t, cs.expectedErrs[i], result.Err)
+				assert.Equal(t, result.Err == nil, len(result.ID) > 0)
+			}
+		})
+	}
+}
+
+func Test_Import(t *testing.T) {
+	items := func() []entities.CharacterRequest {
+		return []entities.CharacterRequest{
+			{Name: "Jon Snow", TVSeries: pq.StringArray{"Season 1"}},
+			{ID: "id_1", Name: "Arya Stark", TVSeries: pq.StringArray{"Season 1"}},
+		}
+	}
+
+	cases := map[string]struct {
+		inputOpts entities.ImportOptions
+
+		expectedIDs []bool
+		expectedErr error
+		prepareMock func(mock *characters.MockIRepository)
+	}{
+		"Should create every row, ignoring their ids": {
+			inputOpts:   entities.ImportOptions{Mode: entities.ImportInsert},
+			expectedIDs: []bool{true, true},
+			prepareMock: func(mock *characters.MockIRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.CharacterRequest{})).
+					Times(2).
+					Return(nil)
+			},
+		},
+		"Should keep no id on a dry run": {
+			inputOpts:   entities.ImportOptions{Mode: entities.ImportInsert, DryRun: true},
+			expectedIDs: []bool{false, false},
+			prepareMock: func(mock *characters.MockIRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.CharacterRequest{})).
+					Times(2).
+					Return(nil)
+			},
+		},
+		"Should return error mode": {
+			inputOpts:   entities.ImportOptions{Mode: entities.ImportUpsertByName},
+			expectedErr: ErrImportMode,
+			prepareMock: func(mock *characters.MockIRepository) {},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := characters.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			mockTx := transaction.NewMockIRepository(ctrl)
+			mockAudit := audit.NewMockIRepository(ctrl)
+			passThrough(mockTx, mockAudit)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{Character: mock, Transaction: mockTx, Audit: mockAudit}},
+				logger.NewLogrusLogger(),
+			)
+
+			results, err := srv.Import(ctx, items(), cs.inputOpts)
+
+			assert.Equal(t, cs.expectedErr, err)
+			if cs.expectedIDs == nil {
+				assert.Nil(t, results)
+				return
+			}
+			for i, result := range results {
+				assert.Equal(t, i, result.Index)
+				assert.Nil(t, result.Err)
+				assert.Equal(t, entities.BulkCreated, result.Action)
+				assert.Equal(t, cs.expectedIDs[i], len(result.ID) > 0)
+			}
+		})
+	}
+}
+
+func Test_Restore(t *testing.T) {
+	id := "33c55a43-f163-4a67-9f6c-75161410f376"
+	deletedAt := time.Now()
+	deleted := entities.Character{ID: id, Name: "Jon Snow", DeletedAt: &deletedAt}
+	runTx := func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	}
+
+	cases := map[string]struct {
+		inputReinstate bool
+
+		expectedHouses []string
+		expectedErr    error
+		prepareMock    func(mock *characters.MockIRepository, mockHouse *houses.MockIRepository, mockTx *transaction.MockIRepository)
+	}{
+		"Should return success": {
+			expectedHouses: []string{},
+			prepareMock: func(mock *characters.MockIRepository, mockHouse *houses.MockIRepository, mockTx *transaction.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(deleted, nil)
+
+				mockTx.EXPECT().Run(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(runTx)
+
+				mock.EXPECT().
+					Restore(gomock.Any(), id).
+					Times(1).
+					Return(nil)
+			},
+		},
+		"Should return success reinstating lordships": {
+			inputReinstate: true,
+			expectedHouses: []string{"house_1"},
+			prepareMock: func(mock *characters.MockIRepository, mockHouse *houses.MockIRepository, mockTx *transaction.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(deleted, nil)
+
+				mockTx.EXPECT().Run(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(runTx)
+
+				mock.EXPECT().
+					Restore(gomock.Any(), id).
+					Times(1).
+					Return(nil)
+
+				mockHouse.EXPECT().
+					ReinstateLord(gomock.Any(), id).
+					Times(1).
+					Return([]string{"house_1"}, nil)
+			},
+		},
+		"Should return error not deleted": {
+			expectedErr: ErrCharacterNotDeleted,
+			prepareMock: func(mock *characters.MockIRepository, mockHouse *houses.MockIRepository, mockTx *transaction.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(entities.Character{ID: id}, nil)
+			},
+		},
+		"Should return error find": {
+			expectedErr: ErrCharacterNotFound.Wrap(errNotFound),
+			prepareMock: func(mock *characters.MockIRepository, mockHouse *houses.MockIRepository, mockTx *transaction.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(entities.Character{}, errNotFound)
+			},
+		},
+		"Should return error reinstate lordships": {
+			inputReinstate: true,
+			expectedErr:    errors.New("failed to reinstate current_lord by house"),
+			prepareMock: func(mock *characters.MockIRepository, mockHouse *houses.MockIRepository, mockTx *transaction.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(deleted, nil)
+
+				mockTx.EXPECT().Run(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(runTx)
+
+				mock.EXPECT().
+					Restore(gomock.Any(), id).
+					Times(1).
+					Return(nil)
+
+				mockHouse.EXPECT().
+					ReinstateLord(gomock.Any(), id).
+					Times(1).
+					Return(nil, errors.New("failed to reinstate current_lord by house"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := characters.NewMockIRepository(ctrl)
+			mockHouse := houses.NewMockIRepository(ctrl)
+			mockTx := transaction.NewMockIRepository(ctrl)
+
+			cs.prepareMock(mock, mockHouse, mockTx)
+
+			mockAudit := audit.NewMockIRepository(ctrl)
+			passThrough(mockTx, mockAudit)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{
+					Character:   mock,
+					House:       mockHouse,
+					Transaction: mockTx,
+					Audit:       mockAudit,
+				}},
+				logger.NewLogrusLogger(),
+			)
+
+			restored, err := srv.Restore(ctx, id, cs.inputReinstate)
+
+			assert.Equal(t, cs.expectedErr, err)
+			if err == nil {
+				assert.Nil(t, restored.DeletedAt)
+				assert.Equal(t, cs.expectedHouses, restored.ReinstatedHouses)
+			}
+		})
+	}
+}
+
+// passThrough runs the writes in a fake transaction and accepts their audit
+// entries, for the cases that don't assert them.
+func passThrough(mockTx *transaction.MockIRepository, mockAudit *audit.MockIRepository) {
+	mockTx.EXPECT().
+		Run(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	mockTx.EXPECT().
+		Savepoint(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockAudit.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(nil)
+}
+
+func Test_History(t *testing.T) {
+	id := "33c55a43-f163-4a67-9f6c-75161410f376"
+	entries := []entities.AuditEntry{{ID: "entry_1", Entity: entities.AuditCharacter, EntityID: id, Action: entities.AuditCreate}}
+
+	cases := map[string]struct {
+		expectedEntries []entities.AuditEntry
+		expectedErr     error
+		prepareMock     func(mock *characters.MockIRepository, mockAudit *audit.MockIRepository)
+	}{
+		"Should return success": {
+			expectedEntries: entries,
+			prepareMock: func(mock *characters.MockIRepository, mockAudit *audit.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(entities.Character{ID: id}, nil)
+
+				mockAudit.EXPECT().
+					Find(gomock.Any(), entities.AuditCharacter, id, nil).
+					Times(1).
+					Return(entries, nil)
+			},
+		},
+		"Should return error not found": {
+			expectedErr: ErrCharacterNotFound.Wrap(errNotFound),
+			prepareMock: func(mock *characters.MockIRepository, mockAudit *audit.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(entities.Character{}, errNotFound)
+			},
+		},
+		"Should return error audit": {
+			expectedErr: errors.New("failed to find audit entries"),
+			prepareMock: func(mock *characters.MockIRepository, mockAudit *audit.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(entities.Character{ID: id}, nil)
+
+				mockAudit.EXPECT().
+					Find(gomock.Any(), entities.AuditCharacter, id, nil).
+					Times(1).
+					Return(nil, errors.New("failed to find audit entries"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := characters.NewMockIRepository(ctrl)
+			mockAudit := audit.NewMockIRepository(ctrl)
+
+			cs.prepareMock(mock, mockAudit)
+
+			srv := New(&repositories.Container{Database: repositories.SqlContainer{Character: mock, Audit: mockAudit}}, logger.NewLogrusLogger())
+
+			entries, err := srv.History(ctx, id, nil)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedEntries, entries)
+		})
+	}
+}
+
+func Test_DeleteAudit(t *testing.T) {
+	id := "33c55a43-f163-4a67-9f6c-75161410f376"
+	metadata := entities.AuditMetadata{Actor: "patrick", RequestID: "request_1"}
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	ctx = entities.ContextWithAuditMetadata(ctx, metadata)
+
+	mock := characters.NewMockIRepository(ctrl)
+	mockHouse := houses.NewMockIRepository(ctrl)
+	mockTx := transaction.NewMockIRepository(ctrl)
+	mockAudit := audit.NewMockIRepository(ctrl)
+
+	mock.EXPECT().
+		FindByID(gomock.Any(), id, entities.Filter{}).
+		Times(1).
+		Return(entities.Character{ID: id, Name: "Roose Bolton"}, nil)
+
+	mock.EXPECT().
+		Delete(gomock.Any(), id).
+		Times(1).
+		Return(nil)
+
+	mockHouse.EXPECT().
+		RemoveLord(gomock.Any(), id).
+		Times(1).
+		Return([]string{"house_1"}, nil)
+
+	mockTx.EXPECT().
+		Run(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	var entries []entities.AuditEntry
+	mockAudit.EXPECT().
+		Create(gomock.Any(), gomock.AssignableToTypeOf(entities.AuditEntry{})).
+		Times(2).
+		DoAndReturn(func(ctx context.Context, entry entities.AuditEntry) error {
+			entries = append(entries, entry)
+			return nil
+		})
+
+	srv := New(&repositories.Container{
+		Database: repositories.SqlContainer{
+			Character:   mock,
+			House:       mockHouse,
+			Transaction: mockTx,
+			Audit:       mockAudit,
+		}},
+		logger.NewLogrusLogger(),
+	)
+
+	err := srv.Delete(ctx, id)
+
+	assert.Nil(t, err)
+	assert.Len(t, entries, 2)
+
+	assert.Equal(t, entities.AuditCharacter, entries[0].Entity)
+	assert.Equal(t, entities.AuditDelete, entries[0].Action)
+	assert.Equal(t, metadata.Actor, entries[0].Actor)
+	assert.Contains(t, entries[0].Changes, "deleted_at")
+
+	assert.Equal(t, entities.AuditHouse, entries[1].Entity)
+	assert.Equal(t, "house_1", entries[1].EntityID)
+	assert.Equal(t, entities.AuditRemoveLord, entries[1].Action)
+	assert.Equal(t, entities.AuditChanges{"current_lord": {Before: id, After: ""}}, entries[1].Changes)
+}
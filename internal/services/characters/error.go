@@ -1,8 +1,17 @@
 package characters
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+)
 
 var (
-	ErrFind              = errors.New("failed to find character")
-	ErrCharacterNotFound = errors.New("this character is not found or deleted")
+	ErrFind                = entities.NewDomainErr(nil, "failed to find character", nil)
+	ErrCharacterNotFound   = entities.NewDomainErr(entities.ErrNotFound, "this character is not found or deleted", nil)
+	ErrCharacterNotDeleted = entities.NewDomainErr(entities.ErrConflict, "this character is not deleted", nil)
+	ErrImportMode          = entities.NewDomainErr(entities.ErrValidation, "characters have no unique name, they are only imported with the insert mode", nil)
+
+	// errDryRun rolls back the transaction of a dry run import
+	errDryRun = errors.New("dry run import is rolled back")
 )
@@ -1,9 +1,17 @@
 package houses
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+)
 
 var (
-	ErrNameUsed      = errors.New("name informed already used in another house")
-	ErrFind          = errors.New("house not found")
-	ErrHouseNotFound = errors.New("this house is not found or deleted")
+	ErrNameUsed        = entities.NewDomainErr(entities.ErrConflict, "name informed already used in another house", nil)
+	ErrFind            = entities.NewDomainErr(entities.ErrNotFound, "house not found", nil)
+	ErrHouseNotFound   = entities.NewDomainErr(entities.ErrNotFound, "this house is not found or deleted", nil)
+	ErrHouseNotDeleted = entities.NewDomainErr(entities.ErrConflict, "this house is not deleted", nil)
+
+	// errDryRun rolls back the transaction of a dry run import
+	errDryRun = errors.New("dry run import is rolled back")
 )
@@ -3,6 +3,8 @@ package houses
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
@@ -13,10 +15,17 @@ import (
 type (
 	IService interface {
 		Create(ctx context.Context, newHouse entities.HouseRequest) (id string, err error)
-		Find(ctx context.Context, name string) (houses []entities.House, err error)
-		FindByID(ctx context.Context, id string) (house entities.House, err error)
+		Find(ctx context.Context, name string, filter entities.Filter) (houses []entities.House, err error)
+		FindByID(ctx context.Context, id string, filter entities.Filter) (house entities.House, err error)
+		// FindByIDs loads the houses among ids in a single query, in the order
+		// of ids, and reports the ones not found.
+		FindByIDs(ctx context.Context, ids []string, filter entities.Filter) (batch entities.HouseBatch, err error)
 		Update(ctx context.Context, updateHouse entities.HouseRequest) (house entities.House, err error)
 		Delete(ctx context.Context, id string) (err error)
+		Restore(ctx context.Context, id string) (house entities.House, err error)
+		Bulk(ctx context.Context, items []entities.HouseRequest, atomic bool) (results []entities.BulkResult, err error)
+		Import(ctx context.Context, items []entities.HouseRequest, opts entities.ImportOptions) (results []entities.BulkResult, err error)
+		History(ctx context.Context, id string, fields []string) (entries []entities.AuditEntry, err error)
 	}
 
 	services struct {
@@ -33,73 +42,105 @@ func (srv *services) Create(ctx context.Context, newHouse entities.HouseRequest)
 	ctx, span := tracer.Span(ctx, "services.houses.create")
 	defer span.End()
 
-	if _, err := srv.repositories.Database.House.FindByName(ctx, newHouse.Name); err == nil {
-		return id, ErrNameUsed
+	if err := srv.nameFree(ctx, newHouse.Name, ""); err != nil {
+		return id, err
 	}
 
 	newHouse.PreSave(ctx)
 
-	err = srv.repositories.Database.House.Create(ctx, newHouse)
+	err = srv.repositories.Database.Transaction.Run(ctx, func(ctx context.Context) error {
+		if err := srv.repositories.Database.House.Create(ctx, newHouse); err != nil {
+			srv.log.Error("Srv.Find: ", "create house ", err, ", playload: ", newHouse)
+			return nameUsed(err)
+		}
+
+		return srv.audit(ctx, newHouse.ID, entities.AuditCreate, nil, newHouse)
+	})
 	if err != nil {
-		srv.log.Error("Srv.Find: ", "create house ", err, ", playload: ", newHouse)
 		return id, err
 	}
 
 	return newHouse.ID, nil
 }
 
-func (srv *services) Find(ctx context.Context, name string) (houses []entities.House, err error) {
+func (srv *services) Find(ctx context.Context, name string, filter entities.Filter) (houses []entities.House, err error) {
 	ctx, span := tracer.Span(ctx, "services.houses.find")
 	defer span.End()
 
 	if len(name) > 0 {
-		house, err := srv.repositories.Database.House.FindByName(ctx, name)
+		house, err := srv.repositories.Database.House.FindByName(ctx, name, filter)
 		if err != nil {
 			srv.log.Error("Srv.Find: ", "House not found by name ", name)
-			return nil, ErrFind
+			if errors.Is(err, entities.ErrNotFound) {
+				return nil, ErrFind.Wrap(err)
+			}
+			return nil, err
 		}
 
 		return []entities.House{house}, nil
 	}
 
-	houses, err = srv.repositories.Database.House.Find(ctx)
+	houses, err = srv.repositories.Database.House.Find(ctx, filter)
 	if err != nil {
 		srv.log.Error("Srv.Find: ", "Houses not found ", err)
-		return nil, ErrFind
+		return nil, err
 	}
 
 	return houses, nil
 }
 
-func (srv *services) FindByID(ctx context.Context, id string) (house entities.House, err error) {
+func (srv *services) FindByID(ctx context.Context, id string, filter entities.Filter) (house entities.House, err error) {
 	ctx, span := tracer.Span(ctx, "services.houses.findbyid")
 	defer span.End()
 
-	house, err = srv.repositories.Database.House.FindByID(ctx, id)
+	house, err = srv.repositories.Database.House.FindByID(ctx, id, filter)
 	if err != nil {
 		srv.log.Error("Srv.FindByID: ", "House not found ", id)
-		return house, ErrHouseNotFound
+		if errors.Is(err, entities.ErrNotFound) {
+			return house, ErrHouseNotFound.Wrap(err)
+		}
+		return house, err
 	}
 
 	return house, nil
 }
 
+func (srv *services) FindByIDs(ctx context.Context, ids []string, filter entities.Filter) (batch entities.HouseBatch, err error) {
+	ctx, span := tracer.Span(ctx, "services.houses.findbyids")
+	defer span.End()
+
+	houses, err := srv.repositories.Database.House.FindByIDs(ctx, ids, filter)
+	if err != nil {
+		srv.log.ErrorContext(ctx, "houses.Service.database.FindByIDs", err)
+		return batch, err
+	}
+
+	return entities.NewHouseBatch(ids, houses), nil
+}
+
 func (srv *services) Update(ctx context.Context, updateHouse entities.HouseRequest) (house entities.House, err error) {
 	ctx, span := tracer.Span(ctx, "services.houses.update")
 	defer span.End()
 
-	house, err = srv.FindByID(ctx, updateHouse.ID)
+	house, err = srv.FindByID(ctx, updateHouse.ID, entities.Filter{})
 	if err != nil {
 		return
 	}
 
-	if _, err := srv.repositories.Database.House.FindByName(ctx, updateHouse.Name); err == nil {
-		return house, ErrNameUsed
+	if err := srv.nameFree(ctx, updateHouse.Name, house.ID); err != nil {
+		return house, err
 	}
 
+	before := house
 	house.PreUpdate(ctx, updateHouse)
 
-	err = srv.repositories.Database.House.Update(ctx, &house)
+	err = srv.repositories.Database.Transaction.Run(ctx, func(ctx context.Context) error {
+		if err := srv.repositories.Database.House.Update(ctx, &house); err != nil {
+			return nameUsed(err)
+		}
+
+		return srv.audit(ctx, house.ID, entities.AuditUpdate, before, house)
+	})
 	if err != nil {
 		return house, err
 	}
@@ -111,15 +152,253 @@ func (srv *services) Delete(ctx context.Context, id string) (err error) {
 	ctx, span := tracer.Span(ctx, "services.houses.delete")
 	defer span.End()
 
-	_, err = srv.FindByID(ctx, id)
+	house, err := srv.FindByID(ctx, id, entities.Filter{})
 	if err != nil {
 		return
 	}
 
-	err = srv.repositories.Database.House.Delete(ctx, id)
+	return srv.repositories.Database.Transaction.Run(ctx, func(ctx context.Context) error {
+		if err := srv.repositories.Database.House.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		deleted := house
+		now := time.Now()
+		deleted.DeletedAt = &now
+
+		return srv.audit(ctx, id, entities.AuditDelete, house, deleted)
+	})
+}
+
+func (srv *services) Restore(ctx context.Context, id string) (house entities.House, err error) {
+	ctx, span := tracer.Span(ctx, "services.houses.restore")
+	defer span.End()
+
+	house, err = srv.FindByID(ctx, id, entities.Filter{IncludeDeleted: true})
 	if err != nil {
+		return
+	}
+
+	if house.DeletedAt == nil {
+		return house, ErrHouseNotDeleted
+	}
+
+	// the name may have been taken by a live house after this one was deleted
+	if err := srv.nameFree(ctx, house.Name, house.ID); err != nil {
+		return house, err
+	}
+
+	before := house
+	now := time.Now()
+	house.UpdatedAt = &now
+	house.DeletedAt = nil
+
+	err = srv.repositories.Database.Transaction.Run(ctx, func(ctx context.Context) error {
+		if err := srv.repositories.Database.House.Restore(ctx, id); err != nil {
+			srv.log.ErrorContext(ctx, "houses.Service.database.Restore", err)
+			return nameUsed(err)
+		}
+
+		return srv.audit(ctx, id, entities.AuditRestore, before, house)
+	})
+	if err != nil {
+		return before, err
+	}
+
+	return house, nil
+}
+
+func (srv *services) History(ctx context.Context, id string, fields []string) (entries []entities.AuditEntry, err error) {
+	ctx, span := tracer.Span(ctx, "services.houses.history")
+	defer span.End()
+
+	if _, err = srv.FindByID(ctx, id, entities.Filter{IncludeDeleted: true}); err != nil {
+		return nil, err
+	}
+
+	entries, err = srv.repositories.Database.Audit.Find(ctx, entities.AuditHouse, id, fields)
+	if err != nil {
+		srv.log.ErrorContext(ctx, "houses.Service.database.Audit.Find", err)
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// nameFree checks that no live house other than the one with id uses name.
+// Only a not found answer means the name is free, any other failure of the
+// lookup is returned.
+func (srv *services) nameFree(ctx context.Context, name, id string) error {
+	house, err := srv.repositories.Database.House.FindByName(ctx, name, entities.Filter{})
+	if err == nil {
+		if id != "" && house.ID == id {
+			return nil
+		}
+		return ErrNameUsed
+	}
+
+	if !errors.Is(err, entities.ErrNotFound) {
+		srv.log.ErrorContext(ctx, "houses.Service.database.FindByName", err)
 		return err
 	}
 
 	return nil
 }
+
+// nameUsed answers a unique violation of the write as ErrNameUsed, the name is
+// the only unique column a house is written with. nameFree can't stop two
+// requests checking the same name before either is written, the index does.
+func nameUsed(err error) error {
+	if errors.Is(err, entities.ErrConflict) {
+		return ErrNameUsed.Wrap(err)
+	}
+	return err
+}
+
+// audit records a change of the house, it must run in the transaction of the
+// change so both are committed together.
+func (srv *services) audit(ctx context.Context, id, action string, before, after any) error {
+	entry := entities.NewAuditEntry(ctx, entities.AuditHouse, id, action, before, after)
+	if err := srv.repositories.Database.Audit.Create(ctx, entry); err != nil {
+		srv.log.ErrorContext(ctx, "houses.Service.database.Audit.Create", err)
+		return err
+	}
+
+	return nil
+}
+
+// Bulk creates the items without ID and updates the others. By default every
+// item is applied on its own; when atomic is true the whole batch runs in one
+// transaction and is rolled back on the first failure.
+func (srv *services) Bulk(ctx context.Context, items []entities.HouseRequest, atomic bool) (results []entities.BulkResult, err error) {
+	ctx, span := tracer.Span(ctx, "services.houses.bulk")
+	defer span.End()
+
+	if !atomic {
+		return srv.bulk(ctx, items, false), nil
+	}
+
+	err = srv.repositories.Database.Transaction.Run(ctx, func(ctx context.Context) error {
+		results = srv.bulk(ctx, items, true)
+		for _, result := range results {
+			if result.Err != nil {
+				return result.Err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if results == nil {
+			srv.log.ErrorContext(ctx, "houses.Service.Bulk", err)
+			return nil, err
+		}
+		entities.Rollback(results)
+	}
+
+	return results, nil
+}
+
+// Import applies the rows of an import in one transaction, each row on its
+// own. In upsert-by-name mode the rows naming a live house update it. A dry run
+// is rolled back, so its results tell what the import would do.
+func (srv *services) Import(ctx context.Context, items []entities.HouseRequest, opts entities.ImportOptions) (results []entities.BulkResult, err error) {
+	ctx, span := tracer.Span(ctx, "services.houses.import")
+	defer span.End()
+
+	err = srv.repositories.Database.Transaction.Run(ctx, func(ctx context.Context) error {
+		for i := range items {
+			items[i].ID = ""
+			if opts.Mode != entities.ImportUpsertByName {
+				continue
+			}
+
+			house, err := srv.repositories.Database.House.FindByName(ctx, items[i].Name, entities.Filter{})
+			if err == nil {
+				items[i].ID = house.ID
+				continue
+			}
+			if !errors.Is(err, entities.ErrNotFound) {
+				srv.log.ErrorContext(ctx, "houses.Service.database.FindByName", err)
+				return err
+			}
+		}
+
+		results = srv.bulk(ctx, items, false)
+		for i := range results {
+			if results[i].Err != nil {
+				continue
+			}
+			results[i].Action = entities.BulkCreated
+			if len(items[i].ID) > 0 {
+				results[i].Action = entities.BulkUpdated
+			} else if opts.DryRun {
+				// the house is not kept, neither is its id
+				results[i].ID = ""
+			}
+		}
+
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		if results == nil {
+			srv.log.ErrorContext(ctx, "houses.Service.Import", err)
+			return nil, err
+		}
+		entities.Rollback(results)
+	}
+
+	return results, nil
+}
+
+func (srv *services) bulk(ctx context.Context, items []entities.HouseRequest, atomic bool) []entities.BulkResult {
+	results := make([]entities.BulkResult, len(items))
+	names := make(map[string]struct{}, len(items))
+
+	for i, item := range items {
+		results[i].Index = i
+
+		// the name check against the database can't see the other items of
+		// the batch, only the saved ones keep their name
+		key := entities.HouseNameKey(item.Name)
+		if _, used := names[key]; used {
+			results[i].Err = ErrNameUsed
+		} else {
+			results[i].ID, results[i].Err = srv.save(ctx, item)
+			if results[i].Err == nil {
+				names[key] = struct{}{}
+			}
+		}
+
+		if results[i].Err != nil && atomic {
+			for j := i + 1; j < len(items); j++ {
+				results[j] = entities.BulkResult{Index: j, Err: entities.ErrBulkRolledBack}
+			}
+			break
+		}
+	}
+
+	return results
+}
+
+// save creates or updates item inside a savepoint, a failed item leaves the
+// transaction of an import usable by the next ones.
+func (srv *services) save(ctx context.Context, item entities.HouseRequest) (id string, err error) {
+	err = srv.repositories.Database.Transaction.Savepoint(ctx, func(ctx context.Context) error {
+		if len(item.ID) == 0 {
+			id, err = srv.Create(ctx, item)
+			return err
+		}
+
+		house, err := srv.Update(ctx, item)
+		id = house.ID
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
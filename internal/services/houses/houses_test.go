@@ -2,17 +2,24 @@ package houses
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/audit"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/houses"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/transaction"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
 	gomock "github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
+var errNotFound = entities.NewDomainErr(entities.ErrNotFound, "house is not found or deleted", sql.ErrNoRows)
+
 func Test_Create(t *testing.T) {
 	data := entities.HouseRequest{
 		Name:           "house Patrick",
@@ -31,9 +38,9 @@ func Test_Create(t *testing.T) {
 			input: data,
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByName(gomock.Any(), data.Name).
+					FindByName(gomock.Any(), data.Name, entities.Filter{}).
 					Times(1).
-					Return(entities.House{}, errors.New("not found"))
+					Return(entities.House{}, errNotFound)
 
 				mock.EXPECT().
 					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.HouseRequest{})).
@@ -46,19 +53,44 @@ func Test_Create(t *testing.T) {
 			expectedErr: ErrNameUsed,
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByName(gomock.Any(), data.Name).
+					FindByName(gomock.Any(), data.Name, entities.Filter{}).
 					Times(1).
 					Return(entities.House{}, nil)
 			},
 		},
+		"Should return error name taken after the check": {
+			input:       data,
+			expectedErr: ErrNameUsed.Wrap(entities.NewDomainErr(entities.ErrConflict, "problem to create house", &pq.Error{Code: "23505"})),
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByName(gomock.Any(), data.Name, entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, errNotFound)
+
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.HouseRequest{})).
+					Times(1).
+					Return(entities.NewDomainErr(entities.ErrConflict, "problem to create house", &pq.Error{Code: "23505"}))
+			},
+		},
+		"Should return error when name lookup fails": {
+			input:       data,
+			expectedErr: entities.NewDomainErr(entities.ErrUnavailable, "house is not found or deleted", sql.ErrConnDone),
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByName(gomock.Any(), data.Name, entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, entities.NewDomainErr(entities.ErrUnavailable, "house is not found or deleted", sql.ErrConnDone))
+			},
+		},
 		"Should return error": {
 			input:       data,
 			expectedErr: errors.New("problem to create house"),
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByName(gomock.Any(), data.Name).
+					FindByName(gomock.Any(), data.Name, entities.Filter{}).
 					Times(1).
-					Return(entities.House{}, errors.New("not found"))
+					Return(entities.House{}, errNotFound)
 
 				mock.EXPECT().
 					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.HouseRequest{})).
@@ -76,8 +108,12 @@ func Test_Create(t *testing.T) {
 
 			cs.prepareMock(mock)
 
+			mockTx := transaction.NewMockIRepository(ctrl)
+			mockAudit := audit.NewMockIRepository(ctrl)
+			passThrough(mockTx, mockAudit)
+
 			srv := New(&repositories.Container{
-				Database: repositories.SqlContainer{House: mock}},
+				Database: repositories.SqlContainer{House: mock, Transaction: mockTx, Audit: mockAudit}},
 				logger.NewLogrusLogger(),
 			)
 
@@ -96,6 +132,7 @@ func Test_Find(t *testing.T) {
 
 	cases := map[string]struct {
 		input        string
+		filter       entities.Filter
 		expectedData []entities.House
 		expectedErr  error
 		prepareMock  func(mock *houses.MockIRepository)
@@ -105,35 +142,46 @@ func Test_Find(t *testing.T) {
 			expectedData: []entities.House{data[0]},
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByName(gomock.Any(), data[0].Name).
+					FindByName(gomock.Any(), data[0].Name, entities.Filter{}).
 					Times(1).
 					Return(data[0], nil)
 			},
 		},
+		"Should return success with name and filter": {
+			input:        "house Patrick",
+			filter:       entities.Filter{Fields: []string{"name"}, IncludeDeleted: true},
+			expectedData: []entities.House{{ID: data[0].ID, Name: data[0].Name}},
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByName(gomock.Any(), data[0].Name, entities.Filter{Fields: []string{"name"}, IncludeDeleted: true}).
+					Times(1).
+					Return(entities.House{ID: data[0].ID, Name: data[0].Name}, nil)
+			},
+		},
 		"Should return success without name": {
 			expectedData: data,
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					Find(gomock.Any()).
+					Find(gomock.Any(), entities.Filter{}).
 					Times(1).
 					Return(data, nil)
 			},
 		},
 		"Should return error on FindByName": {
 			input:       "house Patrick",
-			expectedErr: ErrFind,
+			expectedErr: ErrFind.Wrap(errNotFound),
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByName(gomock.Any(), data[0].Name).
+					FindByName(gomock.Any(), data[0].Name, entities.Filter{}).
 					Times(1).
-					Return(entities.House{}, errors.New("problem to query"))
+					Return(entities.House{}, errNotFound)
 			},
 		},
 		"Should return error on Find": {
-			expectedErr: ErrFind,
+			expectedErr: errors.New("problem to query"),
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					Find(gomock.Any()).
+					Find(gomock.Any(), entities.Filter{}).
 					Times(1).
 					Return(nil, errors.New("problem to query"))
 			},
@@ -153,7 +201,7 @@ func Test_Find(t *testing.T) {
 				logger.NewLogrusLogger(),
 			)
 
-			data, err := srv.Find(ctx, cs.input)
+			data, err := srv.Find(ctx, cs.input, cs.filter)
 
 			assert.Equal(t, cs.expectedErr, err)
 			assert.Equal(t, cs.expectedData, data)
@@ -181,19 +229,29 @@ func Test_FindByID(t *testing.T) {
 			expectedData: data,
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), data.ID).
+					FindByID(gomock.Any(), data.ID, entities.Filter{}).
 					Times(1).
 					Return(data, nil)
 			},
 		},
 		"Should return error": {
 			input:       data.ID,
-			expectedErr: ErrHouseNotFound,
+			expectedErr: ErrHouseNotFound.Wrap(errNotFound),
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), data.ID, entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, errNotFound)
+			},
+		},
+		"Should return error unavailable": {
+			input:       data.ID,
+			expectedErr: entities.NewDomainErr(entities.ErrUnavailable, "house is not found or deleted", sql.ErrConnDone),
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), data.ID).
+					FindByID(gomock.Any(), data.ID, entities.Filter{}).
 					Times(1).
-					Return(entities.House{}, errors.New("problem to query"))
+					Return(entities.House{}, entities.NewDomainErr(entities.ErrUnavailable, "house is not found or deleted", sql.ErrConnDone))
 			},
 		},
 	}
@@ -211,7 +269,58 @@ func Test_FindByID(t *testing.T) {
 				logger.NewLogrusLogger(),
 			)
 
-			data, err := srv.FindByID(ctx, cs.input)
+			data, err := srv.FindByID(ctx, cs.input, entities.Filter{})
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, data)
+		})
+	}
+}
+
+func Test_FindByIDs(t *testing.T) {
+	data := []entities.House{{ID: "id_1", Name: "Patrick"}, {ID: "id_3", Name: "Chagas"}}
+	ids := []string{"id_3", "id_2", "id_1"}
+	filter := entities.Filter{Fields: []string{"name"}}
+
+	cases := map[string]struct {
+		expectedData entities.HouseBatch
+		expectedErr  error
+		prepareMock  func(mock *houses.MockIRepository)
+	}{
+		"Should return success in the order of the ids": {
+			expectedData: entities.HouseBatch{Houses: []entities.House{data[1], data[0]}, Missing: []string{"id_2"}},
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByIDs(gomock.Any(), ids, filter).
+					Times(1).
+					Return(data, nil)
+			},
+		},
+		"Should return error": {
+			expectedErr: errors.New("problem to query"),
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByIDs(gomock.Any(), ids, filter).
+					Times(1).
+					Return(nil, errors.New("problem to query"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := houses.NewMockIRepository(ctrl)
+
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{House: mock}},
+				logger.NewLogrusLogger(),
+			)
+
+			data, err := srv.FindByIDs(ctx, ids, filter)
 
 			assert.Equal(t, cs.expectedErr, err)
 			assert.Equal(t, cs.expectedData, data)
@@ -238,7 +347,7 @@ func Test_Update(t *testing.T) {
 			input: req,
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), req.ID).
+					FindByID(gomock.Any(), req.ID, entities.Filter{}).
 					Times(1).
 					Return(entities.House{
 						ID:             "id_1",
@@ -249,9 +358,9 @@ func Test_Update(t *testing.T) {
 					}, nil)
 
 				mock.EXPECT().
-					FindByName(gomock.Any(), req.Name).
+					FindByName(gomock.Any(), req.Name, entities.Filter{}).
 					Times(1).
-					Return(entities.House{}, errors.New("not found"))
+					Return(entities.House{}, errNotFound)
 
 				mock.EXPECT().
 					Update(gomock.Any(), gomock.AssignableToTypeOf(&entities.House{})).
@@ -261,12 +370,12 @@ func Test_Update(t *testing.T) {
 		},
 		"Should return error find": {
 			input:       req,
-			expectedErr: ErrHouseNotFound,
+			expectedErr: ErrHouseNotFound.Wrap(errNotFound),
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), req.ID).
+					FindByID(gomock.Any(), req.ID, entities.Filter{}).
 					Times(1).
-					Return(entities.House{}, ErrHouseNotFound)
+					Return(entities.House{}, errNotFound)
 			},
 		},
 		"Should return error name already used": {
@@ -274,7 +383,7 @@ func Test_Update(t *testing.T) {
 			expectedErr: ErrNameUsed,
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), req.ID).
+					FindByID(gomock.Any(), req.ID, entities.Filter{}).
 					Times(1).
 					Return(entities.House{
 						ID:             "id_1",
@@ -285,7 +394,7 @@ func Test_Update(t *testing.T) {
 					}, nil)
 
 				mock.EXPECT().
-					FindByName(gomock.Any(), req.Name).
+					FindByName(gomock.Any(), req.Name, entities.Filter{}).
 					Times(1).
 					Return(entities.House{}, nil)
 			},
@@ -295,7 +404,7 @@ func Test_Update(t *testing.T) {
 			expectedErr: errors.New("problem to query"),
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), req.ID).
+					FindByID(gomock.Any(), req.ID, entities.Filter{}).
 					Times(1).
 					Return(entities.House{
 						ID:             "id_1",
@@ -306,9 +415,9 @@ func Test_Update(t *testing.T) {
 					}, nil)
 
 				mock.EXPECT().
-					FindByName(gomock.Any(), req.Name).
+					FindByName(gomock.Any(), req.Name, entities.Filter{}).
 					Times(1).
-					Return(entities.House{}, errors.New("not found"))
+					Return(entities.House{}, errNotFound)
 
 				mock.EXPECT().
 					Update(gomock.Any(), gomock.AssignableToTypeOf(&entities.House{})).
@@ -326,8 +435,12 @@ func Test_Update(t *testing.T) {
 
 			cs.prepareMock(mock)
 
+			mockTx := transaction.NewMockIRepository(ctrl)
+			mockAudit := audit.NewMockIRepository(ctrl)
+			passThrough(mockTx, mockAudit)
+
 			srv := New(&repositories.Container{
-				Database: repositories.SqlContainer{House: mock}},
+				Database: repositories.SqlContainer{House: mock, Transaction: mockTx, Audit: mockAudit}},
 				logger.NewLogrusLogger(),
 			)
 
@@ -349,7 +462,7 @@ func Test_Delete(t *testing.T) {
 			input: id,
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), id).
+					FindByID(gomock.Any(), id, entities.Filter{}).
 					Times(1).
 					Return(entities.House{}, nil)
 
@@ -361,12 +474,12 @@ func Test_Delete(t *testing.T) {
 		},
 		"Should return error find": {
 			input:       id,
-			expectedErr: ErrHouseNotFound,
+			expectedErr: ErrHouseNotFound.Wrap(errNotFound),
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), id).
+					FindByID(gomock.Any(), id, entities.Filter{}).
 					Times(1).
-					Return(entities.House{}, ErrHouseNotFound)
+					Return(entities.House{}, errNotFound)
 			},
 		},
 		"Should return error delete": {
@@ -374,7 +487,7 @@ func Test_Delete(t *testing.T) {
 			expectedErr: errors.New("problem to query"),
 			prepareMock: func(mock *houses.MockIRepository) {
 				mock.EXPECT().
-					FindByID(gomock.Any(), id).
+					FindByID(gomock.Any(), id, entities.Filter{}).
 					Times(1).
 					Return(entities.House{}, nil)
 
@@ -394,8 +507,12 @@ func Test_Delete(t *testing.T) {
 
 			cs.prepareMock(mock)
 
+			mockTx := transaction.NewMockIRepository(ctrl)
+			mockAudit := audit.NewMockIRepository(ctrl)
+			passThrough(mockTx, mockAudit)
+
 			srv := New(&repositories.Container{
-				Database: repositories.SqlContainer{House: mock}},
+				Database: repositories.SqlContainer{House: mock, Transaction: mockTx, Audit: mockAudit}},
 				logger.NewLogrusLogger(),
 			)
 
@@ -405,3 +522,558 @@ func Test_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_Bulk(t *testing.T) {
+	items := []entities.HouseRequest{
+		{Name: "house Patrick", Region: "sao paulo", FoundationYear: "2023"},
+		{Name: " House  Pátrick", Region: "rio de janeiro", FoundationYear: "2022"},
+		{ID: "id_1", Name: "house Chagas", Region: "sao paulo", FoundationYear: "2023"},
+	}
+
+	cases := map[string]struct {
+		inputItems  []entities.HouseRequest
+		inputAtomic bool
+
+		expectedErrs []error
+		expectedErr  error
+		prepareMock  func(mock *houses.MockIRepository, mockTx *transaction.MockIRepository)
+	}{
+		"Should apply each item and reject names repeated in the batch": {
+			expectedErrs: []error{nil, ErrNameUsed, nil},
+			prepareMock: func(mock *houses.MockIRepository, mockTx *transaction.MockIRepository) {
+				mock.EXPECT().
+					FindByName(gomock.Any(), "house Patrick", entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, errNotFound)
+
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.HouseRequest{})).
+					Times(1).
+					Return(nil)
+
+				mock.EXPECT().
+					FindByID(gomock.Any(), "id_1", entities.Filter{}).
+					Times(1).
+					Return(entities.House{ID: "id_1", Name: "house Stark"}, nil)
+
+				mock.EXPECT().
+					FindByName(gomock.Any(), "house Chagas", entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, errNotFound)
+
+				mock.EXPECT().
+					Update(gomock.Any(), gomock.AssignableToTypeOf(&entities.House{})).
+					Times(1).
+					Return(nil)
+			},
+		},
+		"Should keep the name of an item that failed for the next ones": {
+			inputItems:   items[:2],
+			expectedErrs: []error{errors.New("problem to create house"), nil},
+			prepareMock: func(mock *houses.MockIRepository, mockTx *transaction.MockIRepository) {
+				mock.EXPECT().
+					FindByName(gomock.Any(), "house Patrick", entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, errNotFound)
+
+				mock.EXPECT().
+					FindByName(gomock.Any(), " House  Pátrick", entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, errNotFound)
+
+				gomock.InOrder(
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.AssignableToTypeOf(entities.HouseRequest{})).
+						Times(1).
+						Return(errors.New("problem to create house")),
+					mock.EXPECT().
+						Create(gomock.Any(), gomock.AssignableToTypeOf(entities.HouseRequest{})).
+						Times(1).
+						Return(nil),
+				)
+			},
+		},
+		"Should roll back the batch when atomic": {
+			inputAtomic:  true,
+			expectedErrs: []error{entities.ErrBulkRolledBack, ErrNameUsed, entities.ErrBulkRolledBack},
+			prepareMock: func(mock *houses.MockIRepository, mockTx *transaction.MockIRepository) {
+				mockTx.EXPECT().
+					Run(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+						return fn(ctx)
+					})
+
+				mock.EXPECT().
+					FindByName(gomock.Any(), "house Patrick", entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, errNotFound)
+
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.HouseRequest{})).
+					Times(1).
+					Return(nil)
+			},
+		},
+		"Should return error when the transaction can't start": {
+			inputAtomic: true,
+			expectedErr: errors.New("problem to begin transaction"),
+			prepareMock: func(mock *houses.MockIRepository, mockTx *transaction.MockIRepository) {
+				mockTx.EXPECT().
+					Run(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("problem to begin transaction"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := houses.NewMockIRepository(ctrl)
+			mockTx := transaction.NewMockIRepository(ctrl)
+
+			cs.prepareMock(mock, mockTx)
+
+			mockAudit := audit.NewMockIRepository(ctrl)
+			passThrough(mockTx, mockAudit)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{House: mock, Transaction: mockTx, Audit: mockAudit}},
+				logger.NewLogrusLogger(),
+			)
+
+			input := items
+			if cs.inputItems != nil {
+				input = cs.inputItems
+			}
+			results, err := srv.Bulk(ctx, input, cs.inputAtomic)
+
+			assert.Equal(t, cs.expectedErr, err)
+			if cs.expectedErrs == nil {
+				assert.Nil(t, results)
+				return
+			}
+			for i, result := range results {
+				assert.Equal(t, i, result.Index)
+				assert.Equal(t, cs.expectedErrs[i], result.Err)
+				assert.Equal(t, result.Err == nil, len(result.ID) > 0)
+			}
+		})
+	}
+}
+
+func Test_Import(t *testing.T) {
+	items := func() []entities.HouseRequest {
+		return []entities.HouseRequest{
+			{Name: "house Stark", Region: "winterfell", FoundationYear: "1"},
+			{Name: "house Patrick", Region: "sao paulo", FoundationYear: "2023"},
+		}
+	}
+	stark := entities.House{ID: "id_1", Name: "house Stark"}
+
+	cases := map[string]struct {
+		inputOpts entities.ImportOptions
+
+		expectedErrs    []error
+		expectedActions []string
+		expectedIDs     []bool
+		expectedErr     error
+		prepareMock     func(mock *houses.MockIRepository)
+	}{
+		"Should update the houses with the name of a row and create the others": {
+			inputOpts:       entities.ImportOptions{Mode: entities.ImportUpsertByName},
+			expectedErrs:    []error{nil, nil},
+			expectedActions: []string{entities.BulkUpdated, entities.BulkCreated},
+			expectedIDs:     []bool{true, true},
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByName(gomock.Any(), "house Stark", entities.Filter{}).
+					Times(2).
+					Return(stark, nil)
+
+				mock.EXPECT().
+					FindByName(gomock.Any(), "house Patrick", entities.Filter{}).
+					Times(2).
+					Return(entities.House{}, errNotFound)
+
+				mock.EXPECT().
+					FindByID(gomock.Any(), "id_1", entities.Filter{}).
+					Times(1).
+					Return(stark, nil)
+
+				mock.EXPECT().
+					Update(gomock.Any(), gomock.AssignableToTypeOf(&entities.House{})).
+					Times(1).
+					Return(nil)
+
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.HouseRequest{})).
+					Times(1).
+					Return(nil)
+			},
+		},
+		"Should report the names used when inserting": {
+			inputOpts:       entities.ImportOptions{Mode: entities.ImportInsert},
+			expectedErrs:    []error{ErrNameUsed, nil},
+			expectedActions: []string{"", entities.BulkCreated},
+			expectedIDs:     []bool{false, true},
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByName(gomock.Any(), "house Stark", entities.Filter{}).
+					Times(1).
+					Return(stark, nil)
+
+				mock.EXPECT().
+					FindByName(gomock.Any(), "house Patrick", entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, errNotFound)
+
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.HouseRequest{})).
+					Times(1).
+					Return(nil)
+			},
+		},
+		"Should keep no id on a dry run": {
+			inputOpts:       entities.ImportOptions{Mode: entities.ImportInsert, DryRun: true},
+			expectedErrs:    []error{nil, nil},
+			expectedActions: []string{entities.BulkCreated, entities.BulkCreated},
+			expectedIDs:     []bool{false, false},
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByName(gomock.Any(), gomock.Any(), entities.Filter{}).
+					Times(2).
+					Return(entities.House{}, errNotFound)
+
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.HouseRequest{})).
+					Times(2).
+					Return(nil)
+			},
+		},
+		"Should return error on the lookup by name": {
+			inputOpts:   entities.ImportOptions{Mode: entities.ImportUpsertByName},
+			expectedErr: errors.New("problem to find house"),
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByName(gomock.Any(), "house Stark", entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, errors.New("problem to find house"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := houses.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			mockTx := transaction.NewMockIRepository(ctrl)
+			mockAudit := audit.NewMockIRepository(ctrl)
+			passThrough(mockTx, mockAudit)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{House: mock, Transaction: mockTx, Audit: mockAudit}},
+				logger.NewLogrusLogger(),
+			)
+
+			results, err := srv.Import(ctx, items(), cs.inputOpts)
+
+			assert.Equal(t, cs.expectedErr, err)
+			if cs.expectedErrs == nil {
+				assert.Nil(t, results)
+				return
+			}
+			for i, result := range results {
+				assert.Equal(t, i, result.Index)
+				assert.Equal(t, cs.expectedErrs[i], result.Err)
+				assert.Equal(t, cs.expectedActions[i], result.Action)
+				assert.Equal(t, cs.expectedIDs[i], len(result.ID) > 0)
+			}
+		})
+	}
+}
+
+func Test_Restore(t *testing.T) {
+	id := "33c55a43-f163-4a67-9f6c-75161410f376"
+	deletedAt := time.Now()
+	deleted := entities.House{ID: id, Name: "house Patrick", DeletedAt: &deletedAt}
+
+	cases := map[string]struct {
+		input       string
+		expectedErr error
+		prepareMock func(mock *houses.MockIRepository)
+	}{
+		"Should return success": {
+			input: id,
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(deleted, nil)
+
+				mock.EXPECT().
+					FindByName(gomock.Any(), deleted.Name, entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, errNotFound)
+
+				mock.EXPECT().
+					Restore(gomock.Any(), id).
+					Times(1).
+					Return(nil)
+			},
+		},
+		"Should return error find": {
+			input:       id,
+			expectedErr: ErrHouseNotFound.Wrap(errNotFound),
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(entities.House{}, errNotFound)
+			},
+		},
+		"Should return error not deleted": {
+			input:       id,
+			expectedErr: ErrHouseNotDeleted,
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(entities.House{ID: id}, nil)
+			},
+		},
+		"Should return error name already used": {
+			input:       id,
+			expectedErr: ErrNameUsed,
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(deleted, nil)
+
+				mock.EXPECT().
+					FindByName(gomock.Any(), deleted.Name, entities.Filter{}).
+					Times(1).
+					Return(entities.House{ID: "id_2"}, nil)
+			},
+		},
+		"Should return error restore": {
+			input:       id,
+			expectedErr: errors.New("failed to restore house"),
+			prepareMock: func(mock *houses.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(deleted, nil)
+
+				mock.EXPECT().
+					FindByName(gomock.Any(), deleted.Name, entities.Filter{}).
+					Times(1).
+					Return(entities.House{}, errNotFound)
+
+				mock.EXPECT().
+					Restore(gomock.Any(), id).
+					Times(1).
+					Return(errors.New("failed to restore house"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := houses.NewMockIRepository(ctrl)
+
+			cs.prepareMock(mock)
+
+			mockTx := transaction.NewMockIRepository(ctrl)
+			mockAudit := audit.NewMockIRepository(ctrl)
+			passThrough(mockTx, mockAudit)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{House: mock, Transaction: mockTx, Audit: mockAudit}},
+				logger.NewLogrusLogger(),
+			)
+
+			house, err := srv.Restore(ctx, cs.input)
+
+			assert.Equal(t, cs.expectedErr, err)
+			if err == nil {
+				assert.Nil(t, house.DeletedAt)
+			}
+		})
+	}
+}
+
+// passThrough runs the writes in a fake transaction and accepts their audit
+// entries, for the cases that don't assert them.
+func passThrough(mockTx *transaction.MockIRepository, mockAudit *audit.MockIRepository) {
+	mockTx.EXPECT().
+		Run(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	mockTx.EXPECT().
+		Savepoint(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockAudit.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(nil)
+}
+
+func Test_History(t *testing.T) {
+	id := "33c55a43-f163-4a67-9f6c-75161410f376"
+	entries := []entities.AuditEntry{{ID: "entry_1", Entity: entities.AuditHouse, EntityID: id, Action: entities.AuditCreate}}
+
+	cases := map[string]struct {
+		expectedEntries []entities.AuditEntry
+		expectedErr     error
+		prepareMock     func(mock *houses.MockIRepository, mockAudit *audit.MockIRepository)
+	}{
+		"Should return success": {
+			expectedEntries: entries,
+			prepareMock: func(mock *houses.MockIRepository, mockAudit *audit.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(entities.House{ID: id}, nil)
+
+				mockAudit.EXPECT().
+					Find(gomock.Any(), entities.AuditHouse, id, nil).
+					Times(1).
+					Return(entries, nil)
+			},
+		},
+		"Should return error not found": {
+			expectedErr: ErrHouseNotFound.Wrap(errNotFound),
+			prepareMock: func(mock *houses.MockIRepository, mockAudit *audit.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(entities.House{}, errNotFound)
+			},
+		},
+		"Should return error audit": {
+			expectedErr: errors.New("failed to find audit entries"),
+			prepareMock: func(mock *houses.MockIRepository, mockAudit *audit.MockIRepository) {
+				mock.EXPECT().
+					FindByID(gomock.Any(), id, entities.Filter{IncludeDeleted: true}).
+					Times(1).
+					Return(entities.House{ID: id}, nil)
+
+				mockAudit.EXPECT().
+					Find(gomock.Any(), entities.AuditHouse, id, nil).
+					Times(1).
+					Return(nil, errors.New("failed to find audit entries"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := houses.NewMockIRepository(ctrl)
+			mockAudit := audit.NewMockIRepository(ctrl)
+
+			cs.prepareMock(mock, mockAudit)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{House: mock, Audit: mockAudit}},
+				logger.NewLogrusLogger(),
+			)
+
+			entries, err := srv.History(ctx, id, nil)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedEntries, entries)
+		})
+	}
+}
+
+func Test_UpdateAudit(t *testing.T) {
+	id := "33c55a43-f163-4a67-9f6c-75161410f376"
+	input := entities.HouseRequest{ID: id, Name: "house Bolton", Region: "north", FoundationYear: "2023", CurrentLord: "lord_2"}
+	metadata := entities.AuditMetadata{Actor: "patrick", RequestID: "request_1"}
+
+	cases := map[string]struct {
+		auditErr    error
+		expectedErr error
+	}{
+		"Should record the changed fields": {},
+		"Should return error when the audit entry can't be written": {
+			auditErr:    errors.New("problem to create audit entry"),
+			expectedErr: errors.New("problem to create audit entry"),
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			ctx = entities.ContextWithAuditMetadata(ctx, metadata)
+
+			mock := houses.NewMockIRepository(ctrl)
+			mockTx := transaction.NewMockIRepository(ctrl)
+			mockAudit := audit.NewMockIRepository(ctrl)
+
+			mock.EXPECT().
+				FindByID(gomock.Any(), id, entities.Filter{}).
+				Times(1).
+				Return(entities.House{ID: id, Name: "house Bolton", Region: "north", FoundationYear: "2023", CurrentLord: "lord_1"}, nil)
+
+			mock.EXPECT().
+				FindByName(gomock.Any(), input.Name, entities.Filter{}).
+				Times(1).
+				Return(entities.House{}, errNotFound)
+
+			mock.EXPECT().
+				Update(gomock.Any(), gomock.AssignableToTypeOf(&entities.House{})).
+				Times(1).
+				Return(nil)
+
+			mockTx.EXPECT().
+				Run(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+
+			var entry entities.AuditEntry
+			mockAudit.EXPECT().
+				Create(gomock.Any(), gomock.AssignableToTypeOf(entities.AuditEntry{})).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, e entities.AuditEntry) error {
+					entry = e
+					return cs.auditErr
+				})
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{House: mock, Transaction: mockTx, Audit: mockAudit}},
+				logger.NewLogrusLogger(),
+			)
+
+			_, err := srv.Update(ctx, input)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, entities.AuditHouse, entry.Entity)
+			assert.Equal(t, id, entry.EntityID)
+			assert.Equal(t, entities.AuditUpdate, entry.Action)
+			assert.Equal(t, metadata.Actor, entry.Actor)
+			assert.Equal(t, metadata.RequestID, entry.RequestID)
+			assert.Equal(t, entities.AuditChange{Before: "lord_1", After: "lord_2"}, entry.Changes["current_lord"])
+			assert.NotContains(t, entry.Changes, "name")
+		})
+	}
+}
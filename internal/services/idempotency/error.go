@@ -0,0 +1,8 @@
+package idempotency
+
+import "github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+
+var (
+	ErrKeyReused     = entities.NewDomainErr(entities.ErrValidation, "idempotency key already used with a different payload", nil)
+	ErrKeyInProgress = entities.NewDomainErr(entities.ErrConflict, "a request with this idempotency key is still in progress", nil)
+)
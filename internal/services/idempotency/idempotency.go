@@ -0,0 +1,110 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+const (
+	// DefaultTTL is used when no ttl is configured.
+	DefaultTTL = 24 * time.Hour
+	// DefaultLease is used when no lease is configured, it must outlast the
+	// slowest request.
+	DefaultLease = time.Minute
+)
+
+type (
+	IService interface {
+		// Begin claims the key for a new request, for the lease. When the key
+		// was already used with the same payload it returns the stored key,
+		// whose response must be replayed once Completed. A key whose request
+		// lost its lease without completing is claimed again.
+		Begin(ctx context.Context, key entities.IdempotencyKey) (stored entities.IdempotencyKey, err error)
+		Complete(ctx context.Context, key entities.IdempotencyKey) (err error)
+		Release(ctx context.Context, key entities.IdempotencyKey) (err error)
+	}
+
+	services struct {
+		repositories *repositories.Container
+		log          logger.Logger
+		ttl          time.Duration
+		lease        time.Duration
+	}
+)
+
+func New(repo *repositories.Container, log logger.Logger, ttl, lease time.Duration) IService {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if lease <= 0 {
+		lease = DefaultLease
+	}
+	return &services{repositories: repo, log: log, ttl: ttl, lease: lease}
+}
+
+func (srv *services) Begin(ctx context.Context, key entities.IdempotencyKey) (stored entities.IdempotencyKey, err error) {
+	ctx, span := tracer.Span(ctx, "services.idempotency.begin")
+	defer span.End()
+
+	key.PreSave(ctx, srv.ttl, srv.lease)
+
+	created, err := srv.repositories.Database.Idempotency.Create(ctx, key)
+	if err != nil {
+		srv.log.ErrorContext(ctx, "idempotency.Service.database.Create", err)
+		return stored, err
+	}
+
+	if created {
+		return key, nil
+	}
+
+	stored, err = srv.repositories.Database.Idempotency.Find(ctx, key.Key, key.Scope)
+	if err != nil {
+		srv.log.ErrorContext(ctx, "idempotency.Service.database.Find", err)
+		return stored, err
+	}
+
+	if stored.RequestHash != key.RequestHash {
+		return stored, ErrKeyReused
+	}
+
+	if !stored.Completed() {
+		return stored, ErrKeyInProgress
+	}
+
+	return stored, nil
+}
+
+func (srv *services) Complete(ctx context.Context, key entities.IdempotencyKey) (err error) {
+	ctx, span := tracer.Span(ctx, "services.idempotency.complete")
+	defer span.End()
+
+	err = srv.repositories.Database.Idempotency.Complete(ctx, key)
+	if err != nil {
+		srv.log.ErrorContext(ctx, "idempotency.Service.database.Complete", err)
+		return err
+	}
+
+	return nil
+}
+
+// Release forgets the key so the request can be retried, used when it failed
+// with a server error.
+func (srv *services) Release(ctx context.Context, key entities.IdempotencyKey) (err error) {
+	ctx, span := tracer.Span(ctx, "services.idempotency.release")
+	defer span.End()
+
+	err = srv.repositories.Database.Idempotency.Delete(ctx, key.Key, key.Scope)
+	if err != nil {
+		srv.log.ErrorContext(ctx, "idempotency.Service.database.Delete", err)
+		return err
+	}
+
+	return nil
+}
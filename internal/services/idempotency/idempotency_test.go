@@ -0,0 +1,216 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/idempotency"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Begin(t *testing.T) {
+	data := entities.IdempotencyKey{Key: "key_1", Scope: "POST /houses", RequestHash: "hash"}
+	completed := entities.IdempotencyKey{Key: "key_1", Scope: "POST /houses", RequestHash: "hash", StatusCode: 201, Response: []byte(`{"id":"id_1"}`)}
+
+	cases := map[string]struct {
+		input entities.IdempotencyKey
+
+		expectedData entities.IdempotencyKey
+		expectedErr  error
+		prepareMock  func(mock *idempotency.MockIRepository)
+	}{
+		"Should claim a new key": {
+			input: data,
+			prepareMock: func(mock *idempotency.MockIRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.IdempotencyKey{})).
+					Times(1).
+					Return(true, nil)
+			},
+		},
+		"Should claim the key for the lease": {
+			input: data,
+			prepareMock: func(mock *idempotency.MockIRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.IdempotencyKey{})).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, key entities.IdempotencyKey) (bool, error) {
+						assert.Equal(t, DefaultLease, key.LockedUntil.Sub(key.CreatedAt))
+						assert.Equal(t, DefaultTTL, key.ExpiresAt.Sub(key.CreatedAt))
+						return true, nil
+					})
+			},
+		},
+		"Should return the stored response": {
+			input:        data,
+			expectedData: completed,
+			prepareMock: func(mock *idempotency.MockIRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.IdempotencyKey{})).
+					Times(1).
+					Return(false, nil)
+
+				mock.EXPECT().
+					Find(gomock.Any(), data.Key, data.Scope).
+					Times(1).
+					Return(completed, nil)
+			},
+		},
+		"Should return error key reused with another payload": {
+			input:        entities.IdempotencyKey{Key: "key_1", Scope: "POST /houses", RequestHash: "other"},
+			expectedData: completed,
+			expectedErr:  ErrKeyReused,
+			prepareMock: func(mock *idempotency.MockIRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.IdempotencyKey{})).
+					Times(1).
+					Return(false, nil)
+
+				mock.EXPECT().
+					Find(gomock.Any(), data.Key, data.Scope).
+					Times(1).
+					Return(completed, nil)
+			},
+		},
+		"Should return error key in progress": {
+			input:        data,
+			expectedData: data,
+			expectedErr:  ErrKeyInProgress,
+			prepareMock: func(mock *idempotency.MockIRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.IdempotencyKey{})).
+					Times(1).
+					Return(false, nil)
+
+				mock.EXPECT().
+					Find(gomock.Any(), data.Key, data.Scope).
+					Times(1).
+					Return(data, nil)
+			},
+		},
+		"Should return error create": {
+			input:       data,
+			expectedErr: errors.New("problem to create idempotency key"),
+			prepareMock: func(mock *idempotency.MockIRepository) {
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(entities.IdempotencyKey{})).
+					Times(1).
+					Return(false, errors.New("problem to create idempotency key"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := idempotency.NewMockIRepository(ctrl)
+
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{Idempotency: mock}},
+				logger.NewLogrusLogger(),
+				0,
+				0,
+			)
+
+			stored, err := srv.Begin(ctx, cs.input)
+
+			assert.Equal(t, cs.expectedErr, err)
+			if cs.expectedData.Key == "" {
+				return
+			}
+			assert.Equal(t, cs.expectedData, stored)
+		})
+	}
+}
+
+func Test_Complete(t *testing.T) {
+	data := entities.IdempotencyKey{Key: "key_1", Scope: "POST /houses", StatusCode: 201}
+
+	cases := map[string]struct {
+		expectedErr error
+		prepareMock func(mock *idempotency.MockIRepository)
+	}{
+		"Should return success": {
+			prepareMock: func(mock *idempotency.MockIRepository) {
+				mock.EXPECT().Complete(gomock.Any(), data).Times(1).Return(nil)
+			},
+		},
+		"Should return error": {
+			expectedErr: errors.New("failed to complete idempotency key"),
+			prepareMock: func(mock *idempotency.MockIRepository) {
+				mock.EXPECT().Complete(gomock.Any(), data).Times(1).Return(errors.New("failed to complete idempotency key"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := idempotency.NewMockIRepository(ctrl)
+
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{Idempotency: mock}},
+				logger.NewLogrusLogger(),
+				0,
+				0,
+			)
+
+			err := srv.Complete(ctx, data)
+
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_Release(t *testing.T) {
+	data := entities.IdempotencyKey{Key: "key_1", Scope: "POST /houses"}
+
+	cases := map[string]struct {
+		expectedErr error
+		prepareMock func(mock *idempotency.MockIRepository)
+	}{
+		"Should return success": {
+			prepareMock: func(mock *idempotency.MockIRepository) {
+				mock.EXPECT().Delete(gomock.Any(), data.Key, data.Scope).Times(1).Return(nil)
+			},
+		},
+		"Should return error": {
+			expectedErr: errors.New("failed to delete idempotency key"),
+			prepareMock: func(mock *idempotency.MockIRepository) {
+				mock.EXPECT().Delete(gomock.Any(), data.Key, data.Scope).Times(1).Return(errors.New("failed to delete idempotency key"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := idempotency.NewMockIRepository(ctrl)
+
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{Idempotency: mock}},
+				logger.NewLogrusLogger(),
+				0,
+				0,
+			)
+
+			err := srv.Release(ctx, data)
+
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package jobs
+
+import "github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+
+var (
+	ErrJobNotFound = entities.NewDomainErr(entities.ErrNotFound, "job not found", nil)
+	ErrJobFinished = entities.NewDomainErr(entities.ErrConflict, "this job is already finished", nil)
+	// ErrJobNoExport is the download of a job that isn't a succeeded export.
+	ErrJobNoExport    = entities.NewDomainErr(entities.ErrConflict, "this job has no export to download", nil)
+	ErrExportNotFound = entities.NewDomainErr(entities.ErrNotFound, "the file of this export is not found", nil)
+)
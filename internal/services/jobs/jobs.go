@@ -0,0 +1,505 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+// Used when the options leave them out.
+const (
+	DefaultWorkers         = 2
+	DefaultPollInterval    = 2 * time.Second
+	DefaultHeartbeat       = 10 * time.Second
+	DefaultMaxAttempts     = 3
+	DefaultCleanupInterval = time.Hour
+	DefaultExportDir       = "exports"
+)
+
+// cleanupBatchSize is how many jobs each statement of the cleanup deletes.
+const cleanupBatchSize = 500
+
+var timeNow = time.Now
+
+type (
+	IService interface {
+		// Submit queues a job of request.Kind, its params must be valid.
+		Submit(ctx context.Context, request entities.JobRequest) (job entities.Job, err error)
+		FindByID(ctx context.Context, id string) (job entities.Job, err error)
+		// Cancel cancels a queued job at once and asks a running one to stop,
+		// its status turns canceled once its work returns.
+		Cancel(ctx context.Context, id string) (job entities.Job, err error)
+		// ExportFile opens the file written by a succeeded export job.
+		ExportFile(ctx context.Context, id string) (file *os.File, err error)
+		// Cleanup deletes the jobs finished longer than the retention ago,
+		// with the files of the exports, returning how many were deleted.
+		Cleanup(ctx context.Context) (deleted int, err error)
+		// Start runs the workers and the cleanup until ctx is done. A job
+		// running at that moment is canceled and queued again for the next
+		// start.
+		Start(ctx context.Context)
+	}
+
+	// Runner does the work of a kind of job with its params, reporting its
+	// progress from 0 to 100. The result is kept as json in the job.
+	Runner func(ctx context.Context, params json.RawMessage, progress func(percent int)) (result any, err error)
+
+	// Options of the worker pool. A job whose worker sent no heartbeat for
+	// three heartbeats is taken by another worker, its worker is gone, and a
+	// job taken MaxAttempts times fails instead of running again. The
+	// exports are written to ExportDir, shared by the instances so any of
+	// them serves the downloads. Every CleanupInterval the jobs finished
+	// longer than Retention ago are deleted, a zero retention keeps them.
+	Options struct {
+		Workers         int
+		PollInterval    time.Duration
+		Heartbeat       time.Duration
+		MaxAttempts     int
+		ExportDir       string
+		Retention       time.Duration
+		CleanupInterval time.Duration
+	}
+
+	jobIDKey struct{}
+
+	services struct {
+		repositories *repositories.Container
+		log          logger.Logger
+		opts         Options
+		runners      map[string]Runner
+		// wake tells an idle worker a job was submitted
+		wake chan struct{}
+
+		mu      sync.Mutex
+		running map[string]context.CancelFunc
+	}
+)
+
+func New(repo *repositories.Container, log logger.Logger, opts Options, runners map[string]Runner) IService {
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultWorkers
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.Heartbeat <= 0 {
+		opts.Heartbeat = DefaultHeartbeat
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+	if opts.CleanupInterval <= 0 {
+		opts.CleanupInterval = DefaultCleanupInterval
+	}
+	if len(opts.ExportDir) == 0 {
+		opts.ExportDir = DefaultExportDir
+	}
+	return &services{
+		repositories: repo,
+		log:          log,
+		opts:         opts,
+		runners:      runners,
+		wake:         make(chan struct{}, 1),
+		running:      make(map[string]context.CancelFunc),
+	}
+}
+
+func (srv *services) Submit(ctx context.Context, request entities.JobRequest) (job entities.Job, err error) {
+	ctx, span := tracer.Span(ctx, "services.jobs.submit")
+	defer span.End()
+
+	if _, ok := srv.runners[request.Kind]; !ok {
+		return job, entities.ErrJobKind
+	}
+
+	metadata := entities.AuditMetadataFromContext(ctx)
+	job = entities.Job{
+		Kind:        request.Kind,
+		Params:      request.Params,
+		Actor:       metadata.Actor,
+		RequestID:   metadata.RequestID,
+		SubmittedBy: request.SubmittedBy,
+	}
+	job.PreSave(ctx)
+
+	if err = srv.repositories.Database.Job.Create(ctx, job); err != nil {
+		srv.log.ErrorContext(ctx, "jobs.Service.database.Create", err)
+		return entities.Job{}, err
+	}
+	srv.transition(ctx, job)
+
+	select {
+	case srv.wake <- struct{}{}:
+	default:
+	}
+
+	return job, nil
+}
+
+func (srv *services) FindByID(ctx context.Context, id string) (job entities.Job, err error) {
+	ctx, span := tracer.Span(ctx, "services.jobs.findbyid")
+	defer span.End()
+
+	job, err = srv.repositories.Database.Job.FindByID(ctx, id)
+	if err != nil {
+		srv.log.ErrorContext(ctx, "jobs.Service.database.FindByID", err)
+		if errors.Is(err, entities.ErrNotFound) {
+			return job, ErrJobNotFound.Wrap(err)
+		}
+		return job, err
+	}
+
+	return job, nil
+}
+
+func (srv *services) Cancel(ctx context.Context, id string) (job entities.Job, err error) {
+	ctx, span := tracer.Span(ctx, "services.jobs.cancel")
+	defer span.End()
+
+	job, err = srv.repositories.Database.Job.Cancel(ctx, id, timeNow())
+	if errors.Is(err, entities.ErrNotFound) {
+		// the job is finished or doesn't exist
+		if job, err = srv.FindByID(ctx, id); err != nil {
+			return job, err
+		}
+		return job, ErrJobFinished
+	}
+	if err != nil {
+		srv.log.ErrorContext(ctx, "jobs.Service.database.Cancel", err)
+		return job, err
+	}
+
+	if job.Status == entities.JobCanceled {
+		srv.transition(ctx, job)
+		return job, nil
+	}
+
+	// running on this instance stops now, on another one at its next heartbeat
+	srv.mu.Lock()
+	if cancel, ok := srv.running[id]; ok {
+		cancel()
+	}
+	srv.mu.Unlock()
+
+	return job, nil
+}
+
+func (srv *services) ExportFile(ctx context.Context, id string) (file *os.File, err error) {
+	ctx, span := tracer.Span(ctx, "services.jobs.exportfile")
+	defer span.End()
+
+	job, err := srv.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	path, ok := srv.exportPath(job)
+	if !ok || job.Status != entities.JobSucceeded {
+		return nil, ErrJobNoExport
+	}
+
+	file, err = os.Open(path)
+	if err != nil {
+		srv.log.ErrorContext(ctx, "jobs.Service.ExportFile", err)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrExportNotFound.Wrap(err)
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (srv *services) Cleanup(ctx context.Context) (deleted int, err error) {
+	ctx, span := tracer.Span(ctx, "services.jobs.cleanup")
+	defer span.End()
+
+	if srv.opts.Retention <= 0 {
+		return 0, nil
+	}
+
+	before := timeNow().Add(-srv.opts.Retention)
+	for {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+
+		jobs, err := srv.repositories.Database.Job.DeleteFinished(ctx, before, cleanupBatchSize)
+		if err != nil {
+			srv.log.ErrorContext(ctx, "jobs.Service.database.DeleteFinished", err)
+			return deleted, err
+		}
+
+		for _, job := range jobs {
+			srv.removeExport(ctx, job)
+		}
+		deleted += len(jobs)
+
+		if len(jobs) < cleanupBatchSize {
+			break
+		}
+	}
+
+	srv.log.InfoContext(ctx, "jobs: deleted ", deleted, " jobs finished before ", before.Format(time.RFC3339))
+	return deleted, nil
+}
+
+func (srv *services) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < srv.opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			srv.work(ctx)
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		srv.cleanup(ctx)
+	}()
+
+	wg.Wait()
+}
+
+// cleanup runs Cleanup every interval until ctx is done.
+func (srv *services) cleanup(ctx context.Context) {
+	if srv.opts.Retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(srv.opts.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// failures are already logged by Cleanup, the next tick retries
+			srv.Cleanup(ctx)
+		}
+	}
+}
+
+// exportPath is the path of the file of an export job, false for the other
+// jobs and the exports without a file.
+func (srv *services) exportPath(job entities.Job) (path string, ok bool) {
+	var result entities.ExportJobResult
+	if job.Kind != entities.JobExport || json.Unmarshal(job.Result, &result) != nil || len(result.File) == 0 {
+		return "", false
+	}
+	return filepath.Join(srv.opts.ExportDir, filepath.Base(result.File)), true
+}
+
+// removeExport removes the file of a deleted export job, if there is one.
+func (srv *services) removeExport(ctx context.Context, job entities.Job) {
+	path, ok := srv.exportPath(job)
+	if !ok {
+		return
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		srv.log.ErrorContext(ctx, "jobs.Service.removeExport: ", "Error on remove export of job ", job.ID, ": ", err)
+	}
+}
+
+// work runs the queued jobs one after the other, then waits for a submit or
+// the next poll, which also finds the jobs submitted on other instances.
+func (srv *services) work(ctx context.Context) {
+	ticker := time.NewTicker(srv.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil && srv.runNext(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-srv.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// runNext claims a job and runs it, reporting whether there was one.
+func (srv *services) runNext(ctx context.Context) bool {
+	now := timeNow()
+	job, err := srv.repositories.Database.Job.Claim(ctx, now, now.Add(-3*srv.opts.Heartbeat))
+	if err != nil {
+		if !errors.Is(err, entities.ErrNotFound) && ctx.Err() == nil {
+			srv.log.ErrorContext(ctx, "jobs.Service.database.Claim", err)
+		}
+		return false
+	}
+	srv.transition(ctx, job)
+
+	if job.Attempts > srv.opts.MaxAttempts {
+		srv.giveUp(ctx, job)
+		return true
+	}
+
+	srv.run(ctx, job)
+	return true
+}
+
+// giveUp fails a job claimed more than MaxAttempts times without running
+// it, its workers were lost every time it ran.
+func (srv *services) giveUp(ctx context.Context, job entities.Job) {
+	now := timeNow()
+	job.Status = entities.JobFailed
+	job.Result = json.RawMessage(`null`)
+	job.Error = fmt.Sprintf("job gave up after %d attempts", srv.opts.MaxAttempts)
+	job.FinishedAt = &now
+
+	if err := srv.repositories.Database.Job.Finish(context.Background(), job); err != nil {
+		srv.log.ErrorContext(ctx, "jobs.Service.database.Finish", err)
+		return
+	}
+	srv.transition(ctx, job)
+}
+
+// run does the work of a claimed job and saves its outcome. The job context
+// is canceled by Cancel, by a cancel requested to another instance, seen on
+// a heartbeat, or when ctx is done. It carries the actor and request id of
+// the submit, for the audit entries written by the work.
+func (srv *services) run(ctx context.Context, job entities.Job) {
+	metadata := entities.AuditMetadata{Actor: job.Actor, RequestID: job.RequestID}
+	jobCtx, cancel := context.WithCancel(entities.ContextWithAuditMetadata(ctx, metadata))
+	defer cancel()
+
+	srv.mu.Lock()
+	srv.running[job.ID] = cancel
+	srv.mu.Unlock()
+	defer func() {
+		srv.mu.Lock()
+		delete(srv.running, job.ID)
+		srv.mu.Unlock()
+	}()
+
+	if job.CancelRequested {
+		cancel()
+	}
+
+	var progress atomic.Int32
+	progress.Store(int32(job.Progress))
+
+	done := make(chan struct{})
+	defer close(done)
+	go srv.heartbeat(jobCtx, job.ID, &progress, cancel, done)
+
+	result, err := srv.execute(jobCtx, job, func(percent int) {
+		if percent >= 0 && percent <= 100 {
+			progress.Store(int32(percent))
+		}
+	})
+
+	now := timeNow()
+	job.Progress = int(progress.Load())
+	job.Result = json.RawMessage(`null`)
+	switch {
+	case err == nil:
+		raw, err := json.Marshal(result)
+		if err != nil {
+			job.Status = entities.JobFailed
+			job.Error = err.Error()
+		} else {
+			job.Status = entities.JobSucceeded
+			job.Progress = 100
+			job.Result = raw
+		}
+		job.FinishedAt = &now
+	case ctx.Err() != nil:
+		// shutting down, the next start runs it again and this run doesn't
+		// count as an attempt
+		job.Status = entities.JobQueued
+		job.Attempts--
+	case jobCtx.Err() != nil:
+		job.Status = entities.JobCanceled
+		job.FinishedAt = &now
+	default:
+		job.Status = entities.JobFailed
+		job.Error = err.Error()
+		job.FinishedAt = &now
+	}
+
+	// saved even when ctx is done, so the job isn't left running
+	if err := srv.repositories.Database.Job.Finish(context.Background(), job); err != nil {
+		srv.log.ErrorContext(ctx, "jobs.Service.database.Finish", err)
+		return
+	}
+	srv.transition(ctx, job)
+}
+
+// execute calls the runner of the job in a span of its own, a panic fails
+// the job instead of the worker.
+func (srv *services) execute(ctx context.Context, job entities.Job, progress func(percent int)) (result any, err error) {
+	ctx, span := tracer.Span(ctx, "services.jobs.execute",
+		tracer.SpanStartOption{Key: "job.id", Value: job.ID},
+		tracer.SpanStartOption{Key: "job.kind", Value: job.Kind},
+	)
+	defer span.End()
+	ctx = context.WithValue(ctx, jobIDKey{}, job.ID)
+
+	defer func() {
+		if r := recover(); r != nil {
+			srv.log.ErrorContext(ctx, "jobs.Service.execute: ", "panic on job ", job.ID, ": ", r)
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	runner, ok := srv.runners[job.Kind]
+	if !ok {
+		return nil, entities.ErrJobKind
+	}
+	return runner(ctx, job.Params, progress)
+}
+
+// heartbeat keeps the claim of the job until done, saving its progress and
+// canceling it when a cancel was requested.
+func (srv *services) heartbeat(ctx context.Context, id string, progress *atomic.Int32, cancel context.CancelFunc, done <-chan struct{}) {
+	ticker := time.NewTicker(srv.opts.Heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			cancelRequested, err := srv.repositories.Database.Job.Heartbeat(context.Background(), id, timeNow(), int(progress.Load()))
+			if err != nil {
+				srv.log.ErrorContext(ctx, "jobs.Service.database.Heartbeat", err)
+				continue
+			}
+			if cancelRequested {
+				cancel()
+			}
+		}
+	}
+}
+
+// transition traces a job reaching its status.
+func (srv *services) transition(ctx context.Context, job entities.Job) {
+	_, span := tracer.Span(ctx, "services.jobs.transition",
+		tracer.SpanStartOption{Key: "job.id", Value: job.ID},
+		tracer.SpanStartOption{Key: "job.kind", Value: job.Kind},
+		tracer.SpanStartOption{Key: "job.status", Value: job.Status},
+		tracer.SpanStartOption{Key: "job.attempts", Value: job.Attempts},
+	)
+	span.End()
+}
@@ -0,0 +1,401 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/jobs"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newService(mock *jobs.MockIRepository, runners map[string]Runner) *services {
+	return New(&repositories.Container{
+		Database: repositories.SqlContainer{Job: mock}},
+		logger.NewLogrusLogger(),
+		Options{Heartbeat: time.Hour},
+		runners,
+	).(*services)
+}
+
+func noop(ctx context.Context, params json.RawMessage, progress func(int)) (any, error) {
+	return nil, nil
+}
+
+func Test_Submit(t *testing.T) {
+	cases := map[string]struct {
+		input       entities.JobRequest
+		expectedErr error
+		prepareMock func(mock *jobs.MockIRepository)
+	}{
+		"Should return a queued job": {
+			input: entities.JobRequest{Kind: entities.JobPurge, Params: json.RawMessage(`{"dry_run":true}`), SubmittedBy: "key_1"},
+			prepareMock: func(mock *jobs.MockIRepository) {
+				mock.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context, job entities.Job) error {
+					assert.Equal(t, entities.JobQueued, job.Status)
+					assert.Equal(t, json.RawMessage(`{"dry_run":true}`), job.Params)
+					assert.Equal(t, "Patrick", job.Actor)
+					assert.Equal(t, "request_1", job.RequestID)
+					assert.Equal(t, "key_1", job.SubmittedBy)
+					return nil
+				})
+			},
+		},
+		"Should return error kind": {
+			input:       entities.JobRequest{Kind: "unknown"},
+			expectedErr: entities.ErrJobKind,
+			prepareMock: func(mock *jobs.MockIRepository) {},
+		},
+		"Should return error create": {
+			input:       entities.JobRequest{Kind: entities.JobPurge},
+			expectedErr: errors.New("problem to create job"),
+			prepareMock: func(mock *jobs.MockIRepository) {
+				mock.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("problem to create job"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mock := jobs.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			srv := newService(mock, map[string]Runner{entities.JobPurge: noop})
+			ctx = entities.ContextWithAuditMetadata(ctx, entities.AuditMetadata{Actor: "Patrick", RequestID: "request_1"})
+
+			job, err := srv.Submit(ctx, cs.input)
+
+			assert.Equal(t, cs.expectedErr, err)
+			if err == nil {
+				assert.NotEmpty(t, job.ID)
+				assert.Len(t, srv.wake, 1)
+			}
+		})
+	}
+}
+
+func Test_Cancel(t *testing.T) {
+	errNotFound := entities.NewDomainErr(entities.ErrNotFound, "job is not pending", nil)
+
+	cases := map[string]struct {
+		expectedStatus string
+		expectedErr    error
+		running        bool
+		prepareMock    func(mock *jobs.MockIRepository)
+	}{
+		"Should return canceled when queued": {
+			expectedStatus: entities.JobCanceled,
+			prepareMock: func(mock *jobs.MockIRepository) {
+				mock.EXPECT().Cancel(gomock.Any(), "id_1", gomock.Any()).Times(1).
+					Return(entities.Job{ID: "id_1", Status: entities.JobCanceled, CancelRequested: true}, nil)
+			},
+		},
+		"Should cancel the context of a running job": {
+			expectedStatus: entities.JobRunning,
+			running:        true,
+			prepareMock: func(mock *jobs.MockIRepository) {
+				mock.EXPECT().Cancel(gomock.Any(), "id_1", gomock.Any()).Times(1).
+					Return(entities.Job{ID: "id_1", Status: entities.JobRunning, CancelRequested: true}, nil)
+			},
+		},
+		"Should return error finished": {
+			expectedStatus: entities.JobSucceeded,
+			expectedErr:    ErrJobFinished,
+			prepareMock: func(mock *jobs.MockIRepository) {
+				mock.EXPECT().Cancel(gomock.Any(), "id_1", gomock.Any()).Times(1).Return(entities.Job{}, errNotFound)
+				mock.EXPECT().FindByID(gomock.Any(), "id_1").Times(1).Return(entities.Job{ID: "id_1", Status: entities.JobSucceeded}, nil)
+			},
+		},
+		"Should return error not found": {
+			expectedErr: ErrJobNotFound.Wrap(errNotFound),
+			prepareMock: func(mock *jobs.MockIRepository) {
+				mock.EXPECT().Cancel(gomock.Any(), "id_1", gomock.Any()).Times(1).Return(entities.Job{}, errNotFound)
+				mock.EXPECT().FindByID(gomock.Any(), "id_1").Times(1).Return(entities.Job{}, errNotFound)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mock := jobs.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			srv := newService(mock, nil)
+			jobCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			if cs.running {
+				srv.running["id_1"] = cancel
+			}
+
+			job, err := srv.Cancel(ctx, "id_1")
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedStatus, job.Status)
+			assert.Equal(t, cs.running, jobCtx.Err() != nil)
+		})
+	}
+}
+
+func Test_Run(t *testing.T) {
+	now := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	cases := map[string]struct {
+		runner      Runner
+		shutdown    bool
+		cancel      bool
+		expectedJob entities.Job
+	}{
+		"Should succeed with the result": {
+			runner: func(ctx context.Context, params json.RawMessage, progress func(int)) (any, error) {
+				assert.Equal(t, "Patrick", entities.AuditMetadataFromContext(ctx).Actor)
+				progress(50)
+				return map[string]int{"purged": 2}, nil
+			},
+			expectedJob: entities.Job{ID: "id_1", Kind: "test", Actor: "Patrick", Status: entities.JobSucceeded, Attempts: 1, Progress: 100,
+				Result: json.RawMessage(`{"purged":2}`), FinishedAt: &now},
+		},
+		"Should fail with the error": {
+			runner: func(ctx context.Context, params json.RawMessage, progress func(int)) (any, error) {
+				progress(30)
+				return nil, errors.New("boom")
+			},
+			expectedJob: entities.Job{ID: "id_1", Kind: "test", Actor: "Patrick", Status: entities.JobFailed, Attempts: 1, Progress: 30,
+				Result: json.RawMessage(`null`), Error: "boom", FinishedAt: &now},
+		},
+		"Should fail on panic": {
+			runner: func(ctx context.Context, params json.RawMessage, progress func(int)) (any, error) {
+				panic("boom")
+			},
+			expectedJob: entities.Job{ID: "id_1", Kind: "test", Actor: "Patrick", Status: entities.JobFailed, Attempts: 1,
+				Result: json.RawMessage(`null`), Error: "job panicked: boom", FinishedAt: &now},
+		},
+		"Should cancel when the cancel was requested": {
+			cancel: true,
+			runner: func(ctx context.Context, params json.RawMessage, progress func(int)) (any, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+			expectedJob: entities.Job{ID: "id_1", Kind: "test", Actor: "Patrick", Status: entities.JobCanceled, Attempts: 1, CancelRequested: true,
+				Result: json.RawMessage(`null`), FinishedAt: &now},
+		},
+		"Should queue again on shutdown": {
+			shutdown: true,
+			runner: func(ctx context.Context, params json.RawMessage, progress func(int)) (any, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+			expectedJob: entities.Job{ID: "id_1", Kind: "test", Actor: "Patrick", Status: entities.JobQueued, Attempts: 0,
+				Result: json.RawMessage(`null`)},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mock := jobs.NewMockIRepository(ctrl)
+			mock.EXPECT().Finish(gomock.Any(), cs.expectedJob).Times(1).Return(nil)
+
+			srv := newService(mock, map[string]Runner{"test": cs.runner})
+
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			if cs.shutdown {
+				cancel()
+			}
+
+			srv.run(ctx, entities.Job{ID: "id_1", Kind: "test", Actor: "Patrick", Status: entities.JobRunning, Attempts: 1, CancelRequested: cs.cancel})
+
+			assert.Empty(t, srv.running)
+		})
+	}
+}
+
+func Test_RunNext(t *testing.T) {
+	now := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	mock := jobs.NewMockIRepository(ctrl)
+
+	job := entities.Job{ID: "id_1", Kind: "test", Status: entities.JobRunning, Attempts: DefaultMaxAttempts + 1}
+	mock.EXPECT().Claim(gomock.Any(), now, now.Add(-3*time.Hour)).Times(1).Return(job, nil)
+	mock.EXPECT().Finish(gomock.Any(), entities.Job{ID: "id_1", Kind: "test", Status: entities.JobFailed, Attempts: DefaultMaxAttempts + 1,
+		Result: json.RawMessage(`null`), Error: "job gave up after 3 attempts", FinishedAt: &now}).Times(1).Return(nil)
+
+	srv := newService(mock, map[string]Runner{"test": func(ctx context.Context, params json.RawMessage, progress func(int)) (any, error) {
+		t.Error("the job should not run")
+		return nil, nil
+	}})
+
+	assert.True(t, srv.runNext(ctx))
+}
+
+func Test_Start(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	mock := jobs.NewMockIRepository(ctrl)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	job := entities.Job{ID: "id_1", Kind: "test", Status: entities.JobRunning, Attempts: 1}
+	gomock.InOrder(
+		mock.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(job, nil),
+		mock.EXPECT().Finish(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, job entities.Job) error {
+			assert.Equal(t, entities.JobSucceeded, job.Status)
+			return nil
+		}),
+		mock.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(context.Context, time.Time, time.Time) (entities.Job, error) {
+			cancel()
+			return entities.Job{}, entities.NewDomainErr(entities.ErrNotFound, "no job to run", nil)
+		}),
+	)
+
+	srv := New(&repositories.Container{
+		Database: repositories.SqlContainer{Job: mock}},
+		logger.NewLogrusLogger(),
+		Options{Workers: 1, PollInterval: time.Hour, Heartbeat: time.Hour},
+		map[string]Runner{"test": noop},
+	)
+
+	srv.Start(ctx)
+}
+
+func Test_ExportFile(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "export-id_1.ndjson"), []byte(`{"kind":"header","version":1}`+"\n"), 0o600)
+
+	export := entities.Job{ID: "id_1", Kind: entities.JobExport, Status: entities.JobSucceeded,
+		Result: json.RawMessage(`{"file":"export-id_1.ndjson","records":0,"bytes":30}`)}
+
+	cases := map[string]struct {
+		expectedErr error
+		prepareMock func(mock *jobs.MockIRepository)
+	}{
+		"Should open the file of the export": {
+			prepareMock: func(mock *jobs.MockIRepository) {
+				mock.EXPECT().FindByID(gomock.Any(), "id_1").Times(1).Return(export, nil)
+			},
+		},
+		"Should return error not an export": {
+			expectedErr: ErrJobNoExport,
+			prepareMock: func(mock *jobs.MockIRepository) {
+				mock.EXPECT().FindByID(gomock.Any(), "id_1").Times(1).
+					Return(entities.Job{ID: "id_1", Kind: entities.JobPurge, Status: entities.JobSucceeded, Result: json.RawMessage(`{}`)}, nil)
+			},
+		},
+		"Should return error export running": {
+			expectedErr: ErrJobNoExport,
+			prepareMock: func(mock *jobs.MockIRepository) {
+				mock.EXPECT().FindByID(gomock.Any(), "id_1").Times(1).
+					Return(entities.Job{ID: "id_1", Kind: entities.JobExport, Status: entities.JobRunning, Result: json.RawMessage(`null`)}, nil)
+			},
+		},
+		"Should return error file gone": {
+			expectedErr: ErrExportNotFound,
+			prepareMock: func(mock *jobs.MockIRepository) {
+				gone := export
+				gone.Result = json.RawMessage(`{"file":"export-id_2.ndjson","records":0,"bytes":30}`)
+				mock.EXPECT().FindByID(gomock.Any(), "id_1").Times(1).Return(gone, nil)
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mock := jobs.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{Job: mock}},
+				logger.NewLogrusLogger(),
+				Options{ExportDir: dir},
+				nil,
+			)
+
+			file, err := srv.ExportFile(ctx, "id_1")
+
+			assert.ErrorIs(t, err, cs.expectedErr)
+			if cs.expectedErr == nil {
+				assert.Equal(t, filepath.Join(dir, "export-id_1.ndjson"), file.Name())
+				file.Close()
+			}
+		})
+	}
+}
+
+func Test_Cleanup(t *testing.T) {
+	now := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+	before := now.Add(-24 * time.Hour)
+
+	cases := map[string]struct {
+		retention       time.Duration
+		expectedDeleted int
+		expectedErr     error
+		expectedFiles   int
+		prepareMock     func(mock *jobs.MockIRepository)
+	}{
+		"Should delete the jobs and the files of the exports": {
+			retention:       24 * time.Hour,
+			expectedDeleted: 2,
+			prepareMock: func(mock *jobs.MockIRepository) {
+				mock.EXPECT().DeleteFinished(gomock.Any(), before, cleanupBatchSize).Times(1).Return([]entities.Job{
+					{ID: "id_1", Kind: entities.JobExport, Result: json.RawMessage(`{"file":"export-id_1.ndjson","records":0,"bytes":30}`)},
+					{ID: "id_2", Kind: entities.JobPurge, Result: json.RawMessage(`{}`)},
+				}, nil)
+			},
+		},
+		"Should keep the jobs without retention": {
+			expectedFiles: 1,
+			prepareMock:   func(mock *jobs.MockIRepository) {},
+		},
+		"Should return error": {
+			retention:     24 * time.Hour,
+			expectedErr:   errors.New("failed to delete finished jobs"),
+			expectedFiles: 1,
+			prepareMock: func(mock *jobs.MockIRepository) {
+				mock.EXPECT().DeleteFinished(gomock.Any(), before, cleanupBatchSize).Times(1).Return(nil, errors.New("failed to delete finished jobs"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mock := jobs.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			dir := t.TempDir()
+			os.WriteFile(filepath.Join(dir, "export-id_1.ndjson"), []byte(`{"kind":"header","version":1}`+"\n"), 0o600)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{Job: mock}},
+				logger.NewLogrusLogger(),
+				Options{ExportDir: dir, Retention: cs.retention},
+				nil,
+			)
+
+			deleted, err := srv.Cleanup(ctx)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedDeleted, deleted)
+			files, _ := os.ReadDir(dir)
+			assert.Len(t, files, cs.expectedFiles)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package jobs
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/characters"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/houses"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/purge"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/snapshot"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/stats"
+)
+
+// Services are the services doing the work of the jobs.
+type Services struct {
+	House     houses.IService
+	Character characters.IService
+	Purge     purge.IService
+	Snapshot  snapshot.IService
+	Stats     stats.IService
+}
+
+// Runners returns the runner of each kind of job. The params were validated
+// by Submit, they are only decoded here. The exports are written to
+// exportDir, the ExportDir of the options.
+func Runners(srv Services, exportDir string) map[string]Runner {
+	if len(exportDir) == 0 {
+		exportDir = DefaultExportDir
+	}
+	return map[string]Runner{
+		entities.JobPurge:            purgeRunner(srv.Purge),
+		entities.JobExport:           exportRunner(srv.Snapshot, srv.Stats, exportDir),
+		entities.JobImportHouses:     houseImportRunner(srv.House),
+		entities.JobImportCharacters: characterImportRunner(srv.Character),
+	}
+}
+
+func purgeRunner(srv purge.IService) Runner {
+	return func(ctx context.Context, raw json.RawMessage, progress func(int)) (any, error) {
+		var params entities.PurgeJobParams
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, err
+		}
+		return srv.Run(ctx, params.DryRun)
+	}
+}
+
+// exportRunner writes the records of the snapshot to a file of dir named
+// after the job, the job only keeps a reference to it. The progress is
+// estimated from the statistics, which may be cached.
+func exportRunner(srv snapshot.IService, statsSrv stats.IService, dir string) Runner {
+	return func(ctx context.Context, raw json.RawMessage, progress func(int)) (any, error) {
+		var params entities.ExportJobParams
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, err
+		}
+
+		var expected int
+		if stats, err := statsSrv.Stats(ctx, entities.StatsQuery{}); err == nil {
+			expected = stats.Houses.Total + stats.Characters.Total
+			if params.IncludeDeleted {
+				expected += stats.Houses.Deleted + stats.Characters.Deleted
+			}
+		}
+
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, err
+		}
+
+		// written aside and renamed once complete, a run again after a
+		// crash replaces the file instead of leaving a cut one
+		name := "export-" + jobID(ctx) + ".ndjson"
+		file, err := os.CreateTemp(dir, name+".*.tmp")
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(file.Name())
+
+		var (
+			writer  = bufio.NewWriter(file)
+			encoder = json.NewEncoder(writer)
+			result  = entities.ExportJobResult{File: name}
+		)
+		err = srv.Export(ctx, params.IncludeDeleted, func(record entities.SnapshotRecord) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+
+			if record.Kind == entities.SnapshotHouse || record.Kind == entities.SnapshotCharacter {
+				result.Records++
+				if expected > 0 && result.Records < expected {
+					progress(result.Records * 100 / expected)
+				}
+			}
+			return nil
+		})
+		if err == nil {
+			err = writer.Flush()
+		}
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		info, err := os.Stat(file.Name())
+		if err != nil {
+			return nil, err
+		}
+		result.Bytes = info.Size()
+
+		if err := os.Rename(file.Name(), filepath.Join(dir, name)); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+}
+
+// jobID is the id of the job whose runner gets ctx.
+func jobID(ctx context.Context) string {
+	id, _ := ctx.Value(jobIDKey{}).(string)
+	return id
+}
+
+func houseImportRunner(srv houses.IService) Runner {
+	return func(ctx context.Context, raw json.RawMessage, progress func(int)) (any, error) {
+		var params entities.HouseImportJobParams
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, err
+		}
+
+		opts := importOptions(params.Mode, params.DryRun)
+		results, err := srv.Import(ctx, params.Items, opts)
+		if err != nil {
+			return nil, err
+		}
+		return entities.NewImportReport(opts, results), nil
+	}
+}
+
+func characterImportRunner(srv characters.IService) Runner {
+	return func(ctx context.Context, raw json.RawMessage, progress func(int)) (any, error) {
+		var params entities.CharacterImportJobParams
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, err
+		}
+
+		opts := importOptions(params.Mode, params.DryRun)
+		results, err := srv.Import(ctx, params.Items, opts)
+		if err != nil {
+			return nil, err
+		}
+		return entities.NewImportReport(opts, results), nil
+	}
+}
+
+func importOptions(mode string, dryRun bool) entities.ImportOptions {
+	if mode == "" {
+		mode = entities.ImportInsert
+	}
+	return entities.ImportOptions{Mode: mode, DryRun: dryRun}
+}
@@ -0,0 +1,146 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/houses"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/purge"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/snapshot"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/stats"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PurgeRunner(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	mock := purge.NewMockIService(ctrl)
+	report := entities.PurgeReport{DryRun: true}
+	mock.EXPECT().Run(gomock.Any(), true).Times(1).Return(report, nil)
+
+	result, err := Runners(Services{Purge: mock}, "")[entities.JobPurge](ctx, json.RawMessage(`{"dry_run":true}`), func(int) {})
+
+	assert.NoError(t, err)
+	assert.Equal(t, report, result)
+}
+
+func Test_ExportRunner(t *testing.T) {
+	records := []entities.SnapshotRecord{
+		{Kind: entities.SnapshotHeader, Version: entities.SnapshotVersion},
+		{Kind: entities.SnapshotHouse, House: &entities.House{ID: "id_1", Name: "house Stark"}},
+		{Kind: entities.SnapshotCharacter, Character: &entities.Character{ID: "id_2", Name: "Arya"}},
+		{Kind: entities.SnapshotFooter, Counts: &entities.SnapshotCounts{Houses: 1, Characters: 1}},
+	}
+
+	cases := map[string]struct {
+		expectedResult any
+		expectedFile   bool
+		expectedErr    error
+		prepareMock    func(mock *snapshot.MockIService)
+	}{
+		"Should write the snapshot to the file of the job": {
+			expectedResult: entities.ExportJobResult{File: "export-id_1.ndjson", Records: 2, Bytes: 404},
+			expectedFile:   true,
+			prepareMock: func(mock *snapshot.MockIService) {
+				mock.EXPECT().Export(gomock.Any(), false, gomock.Any()).Times(1).
+					DoAndReturn(func(ctx context.Context, includeDeleted bool, fn func(entities.SnapshotRecord) error) error {
+						for _, record := range records {
+							if err := fn(record); err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+			},
+		},
+		"Should leave no file on error": {
+			expectedErr: errors.New("problem to export"),
+			prepareMock: func(mock *snapshot.MockIService) {
+				mock.EXPECT().Export(gomock.Any(), false, gomock.Any()).Times(1).
+					DoAndReturn(func(ctx context.Context, includeDeleted bool, fn func(entities.SnapshotRecord) error) error {
+						fn(records[0])
+						return errors.New("problem to export")
+					})
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mock := snapshot.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			mockStats := stats.NewMockIService(ctrl)
+			mockStats.EXPECT().Stats(gomock.Any(), entities.StatsQuery{}).Times(1).
+				Return(entities.Stats{Houses: entities.HouseStats{Total: 1}, Characters: entities.CharacterStats{Total: 1}}, nil)
+
+			dir := t.TempDir()
+			ctx = context.WithValue(ctx, jobIDKey{}, "id_1")
+
+			result, err := Runners(Services{Snapshot: mock, Stats: mockStats}, dir)[entities.JobExport](ctx, json.RawMessage(`{}`), func(int) {})
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedResult, result)
+
+			files, _ := os.ReadDir(dir)
+			if !cs.expectedFile {
+				assert.Empty(t, files)
+				return
+			}
+			assert.Len(t, files, 1)
+			data, _ := os.ReadFile(filepath.Join(dir, "export-id_1.ndjson"))
+			assert.Equal(t, len(records), bytes.Count(data, []byte("\n")))
+		})
+	}
+}
+
+func Test_HouseImportRunner(t *testing.T) {
+	items := []entities.HouseRequest{{Name: "Stark", Region: "North"}}
+	params, _ := json.Marshal(entities.HouseImportJobParams{Items: items})
+
+	cases := map[string]struct {
+		expectedResult any
+		expectedErr    error
+		prepareMock    func(mock *houses.MockIService)
+	}{
+		"Should return the report of the import": {
+			expectedResult: entities.ImportReport{
+				Mode:      entities.ImportInsert,
+				Succeeded: 1,
+				Rows:      []entities.BulkResult{{Index: 0, Status: http.StatusCreated, ID: "id_1", Action: entities.BulkCreated}},
+			},
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().Import(gomock.Any(), items, entities.ImportOptions{Mode: entities.ImportInsert}).Times(1).
+					Return([]entities.BulkResult{{Index: 0, ID: "id_1", Action: entities.BulkCreated}}, nil)
+			},
+		},
+		"Should return error import": {
+			expectedErr: errors.New("problem to import"),
+			prepareMock: func(mock *houses.MockIService) {
+				mock.EXPECT().Import(gomock.Any(), items, entities.ImportOptions{Mode: entities.ImportInsert}).Times(1).
+					Return(nil, errors.New("problem to import"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mock := houses.NewMockIService(ctrl)
+			cs.prepareMock(mock)
+
+			result, err := Runners(Services{House: mock}, "")[entities.JobImportHouses](ctx, params, func(int) {})
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedResult, result)
+		})
+	}
+}
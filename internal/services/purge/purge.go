@@ -0,0 +1,169 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package purge
+
+import (
+	"context"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+// DefaultBatchSize is used when no batch size is configured.
+const DefaultBatchSize = 500
+
+var timeNow = time.Now
+
+type (
+	IService interface {
+		// Run hard deletes the rows soft deleted longer than the retention of
+		// their entity. With dryRun it only reports what would be purged.
+		Run(ctx context.Context, dryRun bool) (report entities.PurgeReport, err error)
+		// Expire deletes the expired idempotency keys, returning how many
+		// were deleted.
+		Expire(ctx context.Context) (expired int, err error)
+		// Schedule runs the purge and expires the idempotency keys every
+		// interval until ctx is done, a zero interval disables it.
+		Schedule(ctx context.Context, interval time.Duration)
+	}
+
+	// Options holds the retention of each entity, a zero retention keeps its
+	// deleted rows forever.
+	Options struct {
+		HousesRetention     time.Duration
+		CharactersRetention time.Duration
+		BatchSize           int
+	}
+
+	purger  func(ctx context.Context, before time.Time, limit int) (purged int, err error)
+	counter func(ctx context.Context, before time.Time) (total int, err error)
+
+	services struct {
+		repositories *repositories.Container
+		log          logger.Logger
+		opts         Options
+	}
+)
+
+func New(repo *repositories.Container, log logger.Logger, opts Options) IService {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	return &services{repositories: repo, log: log, opts: opts}
+}
+
+func (srv *services) Run(ctx context.Context, dryRun bool) (report entities.PurgeReport, err error) {
+	ctx, span := tracer.Span(ctx, "services.purge.run")
+	defer span.End()
+
+	report = entities.PurgeReport{DryRun: dryRun, Entities: make([]entities.PurgeEntityReport, 0, 2)}
+	now := timeNow()
+
+	// characters first, so the lordships they held are cleaned before the houses go
+	tables := []struct {
+		entity    string
+		retention time.Duration
+		count     counter
+		purge     purger
+	}{
+		{"characters", srv.opts.CharactersRetention, srv.repositories.Database.Character.CountDeleted, srv.repositories.Database.Character.Purge},
+		{"houses", srv.opts.HousesRetention, srv.repositories.Database.House.CountDeleted, srv.repositories.Database.House.Purge},
+	}
+
+	for _, table := range tables {
+		if table.retention <= 0 {
+			continue
+		}
+
+		entityReport := entities.PurgeEntityReport{
+			Entity:        table.entity,
+			Retention:     table.retention.String(),
+			DeletedBefore: now.Add(-table.retention),
+		}
+
+		entityReport.Candidates, err = table.count(ctx, entityReport.DeletedBefore)
+		if err != nil {
+			srv.log.ErrorContext(ctx, "purge.Service.database.CountDeleted", err)
+			return report, err
+		}
+
+		if !dryRun {
+			err = srv.purge(ctx, table.purge, &entityReport)
+			srv.log.InfoContext(ctx, "purge: ", entityReport.Entity, " purged ", entityReport.Purged,
+				" rows deleted before ", entityReport.DeletedBefore.Format(time.RFC3339),
+				" in ", entityReport.Batches, " batches")
+			if err != nil {
+				srv.log.ErrorContext(ctx, "purge.Service.database.Purge", err)
+				return report, err
+			}
+		} else {
+			srv.log.InfoContext(ctx, "purge dry run: ", entityReport.Entity, " has ", entityReport.Candidates,
+				" rows deleted before ", entityReport.DeletedBefore.Format(time.RFC3339))
+		}
+
+		report.Entities = append(report.Entities, entityReport)
+	}
+
+	return report, nil
+}
+
+func (srv *services) Expire(ctx context.Context) (expired int, err error) {
+	ctx, span := tracer.Span(ctx, "services.purge.expire")
+	defer span.End()
+
+	report := entities.PurgeEntityReport{Entity: "idempotency_keys", DeletedBefore: timeNow()}
+	err = srv.purge(ctx, srv.repositories.Database.Idempotency.DeleteExpired, &report)
+	srv.log.InfoContext(ctx, "purge: ", report.Entity, " deleted ", report.Purged,
+		" keys expired before ", report.DeletedBefore.Format(time.RFC3339),
+		" in ", report.Batches, " batches")
+	if err != nil {
+		srv.log.ErrorContext(ctx, "purge.Service.database.DeleteExpired", err)
+		return report.Purged, err
+	}
+
+	return report.Purged, nil
+}
+
+// purge deletes in batches so each statement only holds its locks briefly,
+// stopping once a batch comes back short.
+func (srv *services) purge(ctx context.Context, purge purger, report *entities.PurgeEntityReport) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		purged, err := purge(ctx, report.DeletedBefore, srv.opts.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		report.Batches++
+		report.Purged += purged
+
+		if purged < srv.opts.BatchSize {
+			return nil
+		}
+	}
+}
+
+func (srv *services) Schedule(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// failures are already logged by Run and Expire, the next tick retries
+			srv.Run(ctx, false)
+			srv.Expire(ctx)
+		}
+	}
+}
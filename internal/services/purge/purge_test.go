@@ -0,0 +1,166 @@
+package purge
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/characters"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/houses"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/idempotency"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Run(t *testing.T) {
+	now := time.Now()
+	timeNow = func() time.Time {
+		return now
+	}
+	retention := 720 * time.Hour
+	before := now.Add(-retention)
+
+	cases := map[string]struct {
+		inputDryRun bool
+		opts        Options
+
+		expectedReport entities.PurgeReport
+		expectedErr    error
+		prepareMock    func(mockHouse *houses.MockIRepository, mockCharacter *characters.MockIRepository)
+	}{
+		"Should return success": {
+			opts: Options{HousesRetention: retention, CharactersRetention: retention, BatchSize: 2},
+			expectedReport: entities.PurgeReport{Entities: []entities.PurgeEntityReport{
+				{Entity: "characters", Retention: "720h0m0s", DeletedBefore: before, Candidates: 1, Purged: 1, Batches: 1},
+				{Entity: "houses", Retention: "720h0m0s", DeletedBefore: before, Candidates: 3, Purged: 3, Batches: 2},
+			}},
+			prepareMock: func(mockHouse *houses.MockIRepository, mockCharacter *characters.MockIRepository) {
+				mockCharacter.EXPECT().CountDeleted(gomock.Any(), before).Times(1).Return(1, nil)
+				mockCharacter.EXPECT().Purge(gomock.Any(), before, 2).Times(1).Return(1, nil)
+
+				mockHouse.EXPECT().CountDeleted(gomock.Any(), before).Times(1).Return(3, nil)
+				gomock.InOrder(
+					mockHouse.EXPECT().Purge(gomock.Any(), before, 2).Times(1).Return(2, nil),
+					mockHouse.EXPECT().Purge(gomock.Any(), before, 2).Times(1).Return(1, nil),
+				)
+			},
+		},
+		"Should return success dry run": {
+			inputDryRun: true,
+			opts:        Options{HousesRetention: retention, CharactersRetention: retention},
+			expectedReport: entities.PurgeReport{DryRun: true, Entities: []entities.PurgeEntityReport{
+				{Entity: "characters", Retention: "720h0m0s", DeletedBefore: before, Candidates: 1},
+				{Entity: "houses", Retention: "720h0m0s", DeletedBefore: before, Candidates: 3},
+			}},
+			prepareMock: func(mockHouse *houses.MockIRepository, mockCharacter *characters.MockIRepository) {
+				mockCharacter.EXPECT().CountDeleted(gomock.Any(), before).Times(1).Return(1, nil)
+				mockHouse.EXPECT().CountDeleted(gomock.Any(), before).Times(1).Return(3, nil)
+			},
+		},
+		"Should skip entity without retention": {
+			opts: Options{HousesRetention: retention},
+			expectedReport: entities.PurgeReport{Entities: []entities.PurgeEntityReport{
+				{Entity: "houses", Retention: "720h0m0s", DeletedBefore: before, Candidates: 0, Purged: 0, Batches: 1},
+			}},
+			prepareMock: func(mockHouse *houses.MockIRepository, mockCharacter *characters.MockIRepository) {
+				mockHouse.EXPECT().CountDeleted(gomock.Any(), before).Times(1).Return(0, nil)
+				mockHouse.EXPECT().Purge(gomock.Any(), before, DefaultBatchSize).Times(1).Return(0, nil)
+			},
+		},
+		"Should return error count": {
+			opts:           Options{CharactersRetention: retention},
+			expectedReport: entities.PurgeReport{Entities: []entities.PurgeEntityReport{}},
+			expectedErr:    errors.New("failed to count deleted characters"),
+			prepareMock: func(mockHouse *houses.MockIRepository, mockCharacter *characters.MockIRepository) {
+				mockCharacter.EXPECT().CountDeleted(gomock.Any(), before).Times(1).Return(0, errors.New("failed to count deleted characters"))
+			},
+		},
+		"Should return error purge": {
+			opts:           Options{CharactersRetention: retention},
+			expectedReport: entities.PurgeReport{Entities: []entities.PurgeEntityReport{}},
+			expectedErr:    errors.New("failed to purge characters"),
+			prepareMock: func(mockHouse *houses.MockIRepository, mockCharacter *characters.MockIRepository) {
+				mockCharacter.EXPECT().CountDeleted(gomock.Any(), before).Times(1).Return(1, nil)
+				mockCharacter.EXPECT().Purge(gomock.Any(), before, DefaultBatchSize).Times(1).Return(0, errors.New("failed to purge characters"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mockHouse := houses.NewMockIRepository(ctrl)
+			mockCharacter := characters.NewMockIRepository(ctrl)
+
+			cs.prepareMock(mockHouse, mockCharacter)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{
+					House:     mockHouse,
+					Character: mockCharacter,
+				}},
+				logger.NewLogrusLogger(),
+				cs.opts,
+			)
+
+			report, err := srv.Run(ctx, cs.inputDryRun)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedReport, report)
+		})
+	}
+}
+
+func Test_Expire(t *testing.T) {
+	now := time.Now()
+	timeNow = func() time.Time {
+		return now
+	}
+
+	cases := map[string]struct {
+		expectedExpired int
+		expectedErr     error
+		prepareMock     func(mock *idempotency.MockIRepository)
+	}{
+		"Should return success": {
+			expectedExpired: 3,
+			prepareMock: func(mock *idempotency.MockIRepository) {
+				gomock.InOrder(
+					mock.EXPECT().DeleteExpired(gomock.Any(), now, 2).Times(1).Return(2, nil),
+					mock.EXPECT().DeleteExpired(gomock.Any(), now, 2).Times(1).Return(1, nil),
+				)
+			},
+		},
+		"Should return error": {
+			expectedErr: errors.New("failed to delete expired idempotency keys"),
+			prepareMock: func(mock *idempotency.MockIRepository) {
+				mock.EXPECT().DeleteExpired(gomock.Any(), now, 2).Times(1).Return(0, errors.New("failed to delete expired idempotency keys"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := idempotency.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{Idempotency: mock}},
+				logger.NewLogrusLogger(),
+				Options{BatchSize: 2},
+			)
+
+			expired, err := srv.Expire(ctx)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedExpired, expired)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+type (
+	IService interface {
+		// Take takes a token of the shared bucket of key, refilled with rate
+		// tokens by second up to burst. It returns the tokens left and
+		// whether there was one to take.
+		Take(ctx context.Context, key string, rate float64, burst int) (tokens float64, allowed bool, err error)
+		// Cleanup deletes the full buckets, the next take recreates them.
+		Cleanup(ctx context.Context) (deleted int64, err error)
+		// Schedule runs the cleanup every interval until ctx is done, a zero
+		// interval disables it.
+		Schedule(ctx context.Context, interval time.Duration)
+	}
+
+	services struct {
+		repositories *repositories.Container
+		log          logger.Logger
+	}
+)
+
+func New(repo *repositories.Container, log logger.Logger) IService {
+	return &services{repositories: repo, log: log}
+}
+
+func (srv *services) Take(ctx context.Context, key string, rate float64, burst int) (tokens float64, allowed bool, err error) {
+	ctx, span := tracer.Span(ctx, "services.ratelimit.take")
+	defer span.End()
+
+	return srv.repositories.Database.RateLimit.Take(ctx, key, rate, burst)
+}
+
+func (srv *services) Cleanup(ctx context.Context) (deleted int64, err error) {
+	ctx, span := tracer.Span(ctx, "services.ratelimit.cleanup")
+	defer span.End()
+
+	deleted, err = srv.repositories.Database.RateLimit.DeleteFull(ctx)
+	if err != nil {
+		srv.log.ErrorContext(ctx, "ratelimit.Service.database.DeleteFull", err)
+		return 0, err
+	}
+
+	srv.log.InfoContext(ctx, "rate limit cleanup: deleted ", deleted, " full buckets")
+	return deleted, nil
+}
+
+func (srv *services) Schedule(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// failures are already logged by Cleanup, the next tick retries
+			srv.Cleanup(ctx)
+		}
+	}
+}
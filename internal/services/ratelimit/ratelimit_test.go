@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/ratelimit"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Take(t *testing.T) {
+	cases := map[string]struct {
+		expectedTokens  float64
+		expectedAllowed bool
+		expectedErr     error
+		prepareMock     func(mock *ratelimit.MockIRepository)
+	}{
+		"Should return success": {
+			expectedTokens:  4,
+			expectedAllowed: true,
+			prepareMock: func(mock *ratelimit.MockIRepository) {
+				mock.EXPECT().Take(gomock.Any(), "houses:ip:10.0.0.1", 0.5, 5).Times(1).Return(4.0, true, nil)
+			},
+		},
+		"Should return error": {
+			expectedErr: errors.New("problem to take rate limit token"),
+			prepareMock: func(mock *ratelimit.MockIRepository) {
+				mock.EXPECT().Take(gomock.Any(), "houses:ip:10.0.0.1", 0.5, 5).Times(1).
+					Return(0.0, false, errors.New("problem to take rate limit token"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mock := ratelimit.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{RateLimit: mock},
+			}, logger.NewLogrusLogger())
+
+			tokens, allowed, err := srv.Take(context.Background(), "houses:ip:10.0.0.1", 0.5, 5)
+
+			assert.Equal(t, cs.expectedTokens, tokens)
+			assert.Equal(t, cs.expectedAllowed, allowed)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
+
+func Test_Cleanup(t *testing.T) {
+	cases := map[string]struct {
+		expectedDeleted int64
+		expectedErr     error
+		prepareMock     func(mock *ratelimit.MockIRepository)
+	}{
+		"Should return success": {
+			expectedDeleted: 3,
+			prepareMock: func(mock *ratelimit.MockIRepository) {
+				mock.EXPECT().DeleteFull(gomock.Any()).Times(1).Return(int64(3), nil)
+			},
+		},
+		"Should return error": {
+			expectedErr: errors.New("problem to delete full rate limits"),
+			prepareMock: func(mock *ratelimit.MockIRepository) {
+				mock.EXPECT().DeleteFull(gomock.Any()).Times(1).
+					Return(int64(0), errors.New("problem to delete full rate limits"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mock := ratelimit.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{RateLimit: mock},
+			}, logger.NewLogrusLogger())
+
+			deleted, err := srv.Cleanup(context.Background())
+
+			assert.Equal(t, cs.expectedDeleted, deleted)
+			assert.Equal(t, cs.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package search
+
+import (
+	"context"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+type (
+	IService interface {
+		// Search finds the houses and characters of q.Types in one ranked list,
+		// every type when none is given.
+		Search(ctx context.Context, q entities.SearchQuery) (results []entities.SearchResult, err error)
+	}
+
+	services struct {
+		repositories *repositories.Container
+		log          logger.Logger
+	}
+)
+
+func New(repo *repositories.Container, log logger.Logger) IService {
+	return &services{repositories: repo, log: log}
+}
+
+func (srv *services) Search(ctx context.Context, q entities.SearchQuery) (results []entities.SearchResult, err error) {
+	ctx, span := tracer.Span(ctx, "services.search.search")
+	defer span.End()
+
+	if len(q.Types) == 0 {
+		q.Types = []string{entities.SearchHouse, entities.SearchCharacter}
+	}
+	if q.Limit <= 0 {
+		q.Limit = entities.DefaultSearchLimit
+	}
+
+	results, err = srv.repositories.Database.Search.Search(ctx, q)
+	if err != nil {
+		srv.log.ErrorContext(ctx, "search.Service.database.Search", err)
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,65 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/search"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Search(t *testing.T) {
+	results := []entities.SearchResult{{Type: entities.SearchHouse, ID: "id_1", Name: "house Stark", Rank: 0.6}}
+
+	cases := map[string]struct {
+		input        entities.SearchQuery
+		expectedData []entities.SearchResult
+		expectedErr  error
+		prepareMock  func(mock *search.MockIRepository)
+	}{
+		"Should search every type by default": {
+			input:        entities.SearchQuery{Text: "stark"},
+			expectedData: results,
+			prepareMock: func(mock *search.MockIRepository) {
+				mock.EXPECT().
+					Search(gomock.Any(), entities.SearchQuery{Text: "stark", Types: []string{"house", "character"}, Limit: 20}).
+					Times(1).
+					Return(results, nil)
+			},
+		},
+		"Should return error": {
+			input:       entities.SearchQuery{Text: "stark", Types: []string{"house"}, Limit: 5},
+			expectedErr: errors.New("problem to search"),
+			prepareMock: func(mock *search.MockIRepository) {
+				mock.EXPECT().
+					Search(gomock.Any(), entities.SearchQuery{Text: "stark", Types: []string{"house"}, Limit: 5}).
+					Times(1).
+					Return(nil, errors.New("problem to search"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+			mock := search.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{Search: mock}},
+				logger.NewLogrusLogger(),
+			)
+
+			data, err := srv.Search(ctx, cs.input)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedData, data)
+		})
+	}
+}
@@ -1,27 +1,71 @@
 package services
 
 import (
+	"time"
+
 	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/apikeys"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/services/characters"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/services/houses"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/idempotency"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/jobs"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/purge"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/ratelimit"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/search"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/snapshot"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/stats"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
 )
 
 type (
 	Container struct {
-		House     houses.IService
-		Character characters.IService
+		House       houses.IService
+		Character   characters.IService
+		Idempotency idempotency.IService
+		Purge       purge.IService
+		Snapshot    snapshot.IService
+		Search      search.IService
+		Stats       stats.IService
+		Jobs        jobs.IService
+		APIKey      apikeys.IService
+		RateLimit   ratelimit.IService
 	}
 
 	Options struct {
-		Repo *repositories.Container
-		Log  logger.Logger
+		Repo           *repositories.Container
+		Log            logger.Logger
+		IdempotencyTTL time.Duration
+		// IdempotencyLease is how long a request holds its key before a retry
+		// may take it over
+		IdempotencyLease time.Duration
+		Purge            purge.Options
+		// StatsCacheTTL keeps the statistics for a while, zero doesn't cache them
+		StatsCacheTTL time.Duration
+		Jobs          jobs.Options
+		APIKeys       apikeys.Options
 	}
 )
 
 func New(opts Options) *Container {
-	return &Container{
-		House:     houses.New(opts.Repo, opts.Log),
-		Character: characters.New(opts.Repo, opts.Log),
+	container := &Container{
+		House:       houses.New(opts.Repo, opts.Log),
+		Character:   characters.New(opts.Repo, opts.Log),
+		Idempotency: idempotency.New(opts.Repo, opts.Log, opts.IdempotencyTTL, opts.IdempotencyLease),
+		Purge:       purge.New(opts.Repo, opts.Log, opts.Purge),
+		Snapshot:    snapshot.New(opts.Repo, opts.Log),
+		Search:      search.New(opts.Repo, opts.Log),
+		Stats:       stats.New(opts.Repo, opts.Log, opts.StatsCacheTTL),
+		APIKey:      apikeys.New(opts.Repo, opts.Log, opts.APIKeys),
+		RateLimit:   ratelimit.New(opts.Repo, opts.Log),
 	}
+
+	container.Jobs = jobs.New(opts.Repo, opts.Log, opts.Jobs, jobs.Runners(jobs.Services{
+		House:     container.House,
+		Character: container.Character,
+		Purge:     container.Purge,
+		Snapshot:  container.Snapshot,
+		Stats:     container.Stats,
+	}, opts.Jobs.ExportDir))
+
+	return container
 }
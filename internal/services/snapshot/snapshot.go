@@ -0,0 +1,132 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+var timeNow = time.Now
+
+type (
+	IService interface {
+		// Export calls fn with the header of the snapshot, every row and a footer
+		// with the counts of the rows.
+		Export(ctx context.Context, includeDeleted bool, fn func(record entities.SnapshotRecord) error) (err error)
+		// Restore loads the records returned by next, until it returns io.EOF, in
+		// one transaction. The database must be empty unless replace, which drops
+		// its rows first. Nothing is kept when the snapshot is cut or invalid.
+		Restore(ctx context.Context, next func() (entities.SnapshotRecord, error), replace bool) (report entities.RestoreReport, err error)
+	}
+
+	services struct {
+		repositories *repositories.Container
+		log          logger.Logger
+	}
+)
+
+func New(repo *repositories.Container, log logger.Logger) IService {
+	return &services{repositories: repo, log: log}
+}
+
+func (srv *services) Export(ctx context.Context, includeDeleted bool, fn func(record entities.SnapshotRecord) error) (err error) {
+	ctx, span := tracer.Span(ctx, "services.snapshot.export")
+	defer span.End()
+
+	now := timeNow()
+	err = fn(entities.SnapshotRecord{
+		Kind:           entities.SnapshotHeader,
+		Version:        entities.SnapshotVersion,
+		CreatedAt:      &now,
+		IncludeDeleted: includeDeleted,
+	})
+	if err != nil {
+		return err
+	}
+
+	var counts entities.SnapshotCounts
+	err = srv.repositories.Database.Snapshot.Export(ctx, includeDeleted, func(record entities.SnapshotRecord) error {
+		counts.Add(record)
+		return fn(record)
+	})
+	if err != nil {
+		srv.log.ErrorContext(ctx, "snapshot.Service.database.Export", err)
+		return err
+	}
+
+	return fn(entities.SnapshotRecord{Kind: entities.SnapshotFooter, Counts: &counts})
+}
+
+func (srv *services) Restore(ctx context.Context, next func() (entities.SnapshotRecord, error), replace bool) (report entities.RestoreReport, err error) {
+	ctx, span := tracer.Span(ctx, "services.snapshot.restore")
+	defer span.End()
+
+	err = srv.repositories.Database.Transaction.Run(ctx, func(ctx context.Context) error {
+		report = entities.RestoreReport{}
+
+		header, err := next()
+		if errors.Is(err, io.EOF) {
+			return entities.ErrSnapshotVersion
+		}
+		if err != nil {
+			return err
+		}
+		if header.Kind != entities.SnapshotHeader || header.Version != entities.SnapshotVersion {
+			return entities.ErrSnapshotVersion
+		}
+
+		counts, err := srv.repositories.Database.Snapshot.Count(ctx)
+		if err != nil {
+			srv.log.ErrorContext(ctx, "snapshot.Service.database.Count", err)
+			return err
+		}
+		if !counts.Empty() {
+			if !replace {
+				return entities.ErrSnapshotNotEmpty
+			}
+			if err := srv.repositories.Database.Snapshot.Clear(ctx); err != nil {
+				srv.log.ErrorContext(ctx, "snapshot.Service.database.Clear", err)
+				return err
+			}
+			report.Replaced = true
+		}
+
+		for {
+			record, err := next()
+			if errors.Is(err, io.EOF) {
+				return entities.ErrSnapshotTruncated
+			}
+			if err != nil {
+				return err
+			}
+
+			switch record.Kind {
+			case entities.SnapshotFooter:
+				if record.Counts == nil || *record.Counts != report.Restored {
+					return entities.ErrSnapshotTruncated
+				}
+				return nil
+			case entities.SnapshotCharacter, entities.SnapshotHouse, entities.SnapshotRemovedLordship:
+				if err := srv.repositories.Database.Snapshot.Insert(ctx, record); err != nil {
+					srv.log.ErrorContext(ctx, "snapshot.Service.database.Insert", err)
+					return err
+				}
+				report.Restored.Add(record)
+			default:
+				return entities.ErrSnapshotRecord
+			}
+		}
+	})
+	if err != nil {
+		return entities.RestoreReport{}, err
+	}
+
+	return report, nil
+}
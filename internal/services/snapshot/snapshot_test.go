@@ -0,0 +1,196 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/snapshot"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/transaction"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Export(t *testing.T) {
+	now := time.Now()
+	timeNow = func() time.Time {
+		return now
+	}
+	house := entities.SnapshotRecord{Kind: entities.SnapshotHouse, House: &entities.House{ID: "id_1"}}
+
+	cases := map[string]struct {
+		expectedRecords []entities.SnapshotRecord
+		expectedErr     error
+		prepareMock     func(mock *snapshot.MockIRepository)
+	}{
+		"Should return success": {
+			expectedRecords: []entities.SnapshotRecord{
+				{Kind: entities.SnapshotHeader, Version: entities.SnapshotVersion, CreatedAt: &now, IncludeDeleted: true},
+				house,
+				{Kind: entities.SnapshotFooter, Counts: &entities.SnapshotCounts{Houses: 1}},
+			},
+			prepareMock: func(mock *snapshot.MockIRepository) {
+				mock.EXPECT().
+					Export(gomock.Any(), true, gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, includeDeleted bool, fn func(entities.SnapshotRecord) error) error {
+						return fn(house)
+					})
+			},
+		},
+		"Should return error without footer": {
+			expectedRecords: []entities.SnapshotRecord{
+				{Kind: entities.SnapshotHeader, Version: entities.SnapshotVersion, CreatedAt: &now, IncludeDeleted: true},
+			},
+			expectedErr: errors.New("failed to export snapshot"),
+			prepareMock: func(mock *snapshot.MockIRepository) {
+				mock.EXPECT().
+					Export(gomock.Any(), true, gomock.Any()).
+					Times(1).
+					Return(errors.New("failed to export snapshot"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mock := snapshot.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{Snapshot: mock}},
+				logger.NewLogrusLogger(),
+			)
+
+			var records []entities.SnapshotRecord
+			err := srv.Export(ctx, true, func(record entities.SnapshotRecord) error {
+				records = append(records, record)
+				return nil
+			})
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedRecords, records)
+		})
+	}
+}
+
+func Test_Restore(t *testing.T) {
+	header := entities.SnapshotRecord{Kind: entities.SnapshotHeader, Version: entities.SnapshotVersion}
+	character := entities.SnapshotRecord{Kind: entities.SnapshotCharacter, Character: &entities.Character{ID: "id_1"}}
+	house := entities.SnapshotRecord{Kind: entities.SnapshotHouse, House: &entities.House{ID: "id_2", CurrentLord: "id_1"}}
+	footer := entities.SnapshotRecord{Kind: entities.SnapshotFooter, Counts: &entities.SnapshotCounts{Characters: 1, Houses: 1}}
+
+	cases := map[string]struct {
+		records []entities.SnapshotRecord
+		replace bool
+
+		expectedReport entities.RestoreReport
+		expectedErr    error
+		prepareMock    func(mock *snapshot.MockIRepository)
+	}{
+		"Should restore into an empty database": {
+			records:        []entities.SnapshotRecord{header, character, house, footer},
+			expectedReport: entities.RestoreReport{Restored: entities.SnapshotCounts{Characters: 1, Houses: 1}},
+			prepareMock: func(mock *snapshot.MockIRepository) {
+				mock.EXPECT().Count(gomock.Any()).Times(1).Return(entities.SnapshotCounts{}, nil)
+				gomock.InOrder(
+					mock.EXPECT().Insert(gomock.Any(), character).Times(1).Return(nil),
+					mock.EXPECT().Insert(gomock.Any(), house).Times(1).Return(nil),
+				)
+			},
+		},
+		"Should replace the rows": {
+			records:        []entities.SnapshotRecord{header, character, house, footer},
+			replace:        true,
+			expectedReport: entities.RestoreReport{Replaced: true, Restored: entities.SnapshotCounts{Characters: 1, Houses: 1}},
+			prepareMock: func(mock *snapshot.MockIRepository) {
+				mock.EXPECT().Count(gomock.Any()).Times(1).Return(entities.SnapshotCounts{Houses: 3}, nil)
+				mock.EXPECT().Clear(gomock.Any()).Times(1).Return(nil)
+				mock.EXPECT().Insert(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+			},
+		},
+		"Should return error not empty": {
+			records:     []entities.SnapshotRecord{header, character, house, footer},
+			expectedErr: entities.ErrSnapshotNotEmpty,
+			prepareMock: func(mock *snapshot.MockIRepository) {
+				mock.EXPECT().Count(gomock.Any()).Times(1).Return(entities.SnapshotCounts{Houses: 3}, nil)
+			},
+		},
+		"Should return error version": {
+			records:     []entities.SnapshotRecord{{Kind: entities.SnapshotHeader, Version: 2}, character, footer},
+			expectedErr: entities.ErrSnapshotVersion,
+			prepareMock: func(mock *snapshot.MockIRepository) {},
+		},
+		"Should return error without footer": {
+			records:     []entities.SnapshotRecord{header, character},
+			expectedErr: entities.ErrSnapshotTruncated,
+			prepareMock: func(mock *snapshot.MockIRepository) {
+				mock.EXPECT().Count(gomock.Any()).Times(1).Return(entities.SnapshotCounts{}, nil)
+				mock.EXPECT().Insert(gomock.Any(), character).Times(1).Return(nil)
+			},
+		},
+		"Should return error counts": {
+			records:     []entities.SnapshotRecord{header, character, footer},
+			expectedErr: entities.ErrSnapshotTruncated,
+			prepareMock: func(mock *snapshot.MockIRepository) {
+				mock.EXPECT().Count(gomock.Any()).Times(1).Return(entities.SnapshotCounts{}, nil)
+				mock.EXPECT().Insert(gomock.Any(), character).Times(1).Return(nil)
+			},
+		},
+		"Should return error record": {
+			records:     []entities.SnapshotRecord{header, {Kind: "dragon"}},
+			expectedErr: entities.ErrSnapshotRecord,
+			prepareMock: func(mock *snapshot.MockIRepository) {
+				mock.EXPECT().Count(gomock.Any()).Times(1).Return(entities.SnapshotCounts{}, nil)
+			},
+		},
+		"Should return error insert": {
+			records:     []entities.SnapshotRecord{header, character, footer},
+			expectedErr: errors.New("failed to insert character"),
+			prepareMock: func(mock *snapshot.MockIRepository) {
+				mock.EXPECT().Count(gomock.Any()).Times(1).Return(entities.SnapshotCounts{}, nil)
+				mock.EXPECT().Insert(gomock.Any(), character).Times(1).Return(errors.New("failed to insert character"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mock := snapshot.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			mockTx := transaction.NewMockIRepository(ctrl)
+			mockTx.EXPECT().
+				Run(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+					return fn(ctx)
+				})
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{Snapshot: mock, Transaction: mockTx}},
+				logger.NewLogrusLogger(),
+			)
+
+			records := cs.records
+			report, err := srv.Restore(ctx, func() (entities.SnapshotRecord, error) {
+				if len(records) == 0 {
+					return entities.SnapshotRecord{}, io.EOF
+				}
+				record := records[0]
+				records = records[1:]
+				return record, nil
+			}, cs.replace)
+
+			assert.Equal(t, cs.expectedErr, err)
+			assert.Equal(t, cs.expectedReport, report)
+		})
+	}
+}
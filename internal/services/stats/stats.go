@@ -0,0 +1,123 @@
+//go:generate mockgen -source=${GOFILE} -package=${GOPACKAGE} -destination=${GOPACKAGE}_mock.go
+package stats
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+)
+
+var timeNow = time.Now
+
+type (
+	IService interface {
+		// Stats aggregates the houses and the characters, counting the changes
+		// in q.Bucket, a month when none is given.
+		Stats(ctx context.Context, q entities.StatsQuery) (stats entities.Stats, err error)
+		Houses(ctx context.Context, q entities.StatsQuery) (stats entities.HouseStats, err error)
+		Characters(ctx context.Context, q entities.StatsQuery) (stats entities.CharacterStats, err error)
+	}
+
+	services struct {
+		repositories *repositories.Container
+		log          logger.Logger
+		// ttl keeps the aggregates for a while, the queries read every row
+		ttl time.Duration
+
+		mu    sync.Mutex
+		cache map[string]cached
+	}
+
+	cached struct {
+		stats   any
+		expires time.Time
+	}
+)
+
+// New creates the service, the aggregates are cached for ttl and a ttl of
+// zero doesn't cache them.
+func New(repo *repositories.Container, log logger.Logger, ttl time.Duration) IService {
+	return &services{repositories: repo, log: log, ttl: ttl, cache: make(map[string]cached)}
+}
+
+func (srv *services) Stats(ctx context.Context, q entities.StatsQuery) (stats entities.Stats, err error) {
+	ctx, span := tracer.Span(ctx, "services.stats.stats")
+	defer span.End()
+
+	if stats.Houses, err = srv.Houses(ctx, q); err != nil {
+		return stats, err
+	}
+
+	if stats.Characters, err = srv.Characters(ctx, q); err != nil {
+		return stats, err
+	}
+
+	return stats, nil
+}
+
+func (srv *services) Houses(ctx context.Context, q entities.StatsQuery) (stats entities.HouseStats, err error) {
+	ctx, span := tracer.Span(ctx, "services.stats.houses")
+	defer span.End()
+
+	return load(srv, "houses", q, func(q entities.StatsQuery) (entities.HouseStats, error) {
+		stats, err := srv.repositories.Database.Stats.Houses(ctx, q)
+		if err != nil {
+			srv.log.ErrorContext(ctx, "stats.Service.database.Houses", err)
+		}
+		return stats, err
+	})
+}
+
+func (srv *services) Characters(ctx context.Context, q entities.StatsQuery) (stats entities.CharacterStats, err error) {
+	ctx, span := tracer.Span(ctx, "services.stats.characters")
+	defer span.End()
+
+	return load(srv, "characters", q, func(q entities.StatsQuery) (entities.CharacterStats, error) {
+		stats, err := srv.repositories.Database.Stats.Characters(ctx, q)
+		if err != nil {
+			srv.log.ErrorContext(ctx, "stats.Service.database.Characters", err)
+		}
+		return stats, err
+	})
+}
+
+// load answers the aggregates of entity from the cache while they are fresh,
+// otherwise it reads them with fn. Failures aren't cached.
+func load[T any](srv *services, entity string, q entities.StatsQuery, fn func(q entities.StatsQuery) (T, error)) (T, error) {
+	if q.Bucket == "" {
+		q.Bucket = entities.DefaultStatsBucket
+	}
+	key := entity + "|" + q.Bucket + "|" + q.Since.UTC().Format(time.RFC3339Nano)
+
+	if srv.ttl > 0 {
+		srv.mu.Lock()
+		entry, ok := srv.cache[key]
+		srv.mu.Unlock()
+		if ok && timeNow().Before(entry.expires) {
+			return entry.stats.(T), nil
+		}
+	}
+
+	stats, err := fn(q)
+	if err != nil || srv.ttl <= 0 {
+		return stats, err
+	}
+
+	now := timeNow()
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	// since makes the keys endless, the expired ones are dropped as new ones come
+	for k, entry := range srv.cache {
+		if !now.Before(entry.expires) {
+			delete(srv.cache, k)
+		}
+	}
+	srv.cache[key] = cached{stats: stats, expires: now.Add(srv.ttl)}
+
+	return stats, nil
+}
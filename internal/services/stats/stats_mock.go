@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stats.go
+
+// Package stats is a generated GoMock package.
+package stats
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIService is a mock of IService interface.
+type MockIService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIServiceMockRecorder
+}
+
+// MockIServiceMockRecorder is the mock recorder for MockIService.
+type MockIServiceMockRecorder struct {
+	mock *MockIService
+}
+
+// NewMockIService creates a new mock instance.
+func NewMockIService(ctrl *gomock.Controller) *MockIService {
+	mock := &MockIService{ctrl: ctrl}
+	mock.recorder = &MockIServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIService) EXPECT() *MockIServiceMockRecorder {
+	return m.recorder
+}
+
+// Characters mocks base method.
+func (m *MockIService) Characters(ctx context.Context, q entities.StatsQuery) (entities.CharacterStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Characters", ctx, q)
+	ret0, _ := ret[0].(entities.CharacterStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Characters indicates an expected call of Characters.
+func (mr *MockIServiceMockRecorder) Characters(ctx, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Characters", reflect.TypeOf((*MockIService)(nil).Characters), ctx, q)
+}
+
+// Houses mocks base method.
+func (m *MockIService) Houses(ctx context.Context, q entities.StatsQuery) (entities.HouseStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Houses", ctx, q)
+	ret0, _ := ret[0].(entities.HouseStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Houses indicates an expected call of Houses.
+func (mr *MockIServiceMockRecorder) Houses(ctx, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Houses", reflect.TypeOf((*MockIService)(nil).Houses), ctx, q)
+}
+
+// Stats mocks base method.
+func (m *MockIService) Stats(ctx context.Context, q entities.StatsQuery) (entities.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, q)
+	ret0, _ := ret[0].(entities.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockIServiceMockRecorder) Stats(ctx, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockIService)(nil).Stats), ctx, q)
+}
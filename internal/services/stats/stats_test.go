@@ -0,0 +1,102 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PatrickChagastavares/game-of-thrones/internal/entities"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories/database/stats"
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Stats(t *testing.T) {
+	q := entities.StatsQuery{Bucket: entities.StatsMonth}
+	houses := entities.HouseStats{Total: 2, WithoutLord: 1, ByRegion: []entities.StatsCount{{Key: "north", Count: 2}}}
+	characters := entities.CharacterStats{Total: 1, Deleted: 1}
+
+	cases := map[string]struct {
+		input       entities.StatsQuery
+		expectedErr error
+		prepareMock func(mock *stats.MockIRepository)
+	}{
+		"Should return success with the month bucket by default": {
+			prepareMock: func(mock *stats.MockIRepository) {
+				mock.EXPECT().Houses(gomock.Any(), q).Times(1).Return(houses, nil)
+				mock.EXPECT().Characters(gomock.Any(), q).Times(1).Return(characters, nil)
+			},
+		},
+		"Should return error houses": {
+			input:       q,
+			expectedErr: errors.New("problem to count houses"),
+			prepareMock: func(mock *stats.MockIRepository) {
+				mock.EXPECT().Houses(gomock.Any(), q).Times(1).Return(entities.HouseStats{}, errors.New("problem to count houses"))
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl, ctx := gomock.WithContext(context.Background(), t)
+			mock := stats.NewMockIRepository(ctrl)
+			cs.prepareMock(mock)
+
+			srv := New(&repositories.Container{
+				Database: repositories.SqlContainer{Stats: mock}},
+				logger.NewLogrusLogger(),
+				0,
+			)
+
+			data, err := srv.Stats(ctx, cs.input)
+
+			assert.Equal(t, cs.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, entities.Stats{Houses: houses, Characters: characters}, data)
+			}
+		})
+	}
+}
+
+func Test_Cache(t *testing.T) {
+	now := time.Now()
+	timeNow = func() time.Time {
+		return now
+	}
+	defer func() { timeNow = time.Now }()
+
+	q := entities.StatsQuery{Bucket: entities.StatsDay}
+	first := entities.HouseStats{Total: 1}
+	second := entities.HouseStats{Total: 2}
+
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+	mock := stats.NewMockIRepository(ctrl)
+	gomock.InOrder(
+		mock.EXPECT().Houses(gomock.Any(), q).Times(1).Return(entities.HouseStats{}, errors.New("problem to count houses")),
+		mock.EXPECT().Houses(gomock.Any(), q).Times(1).Return(first, nil),
+		mock.EXPECT().Houses(gomock.Any(), q).Times(1).Return(second, nil),
+	)
+
+	srv := New(&repositories.Container{
+		Database: repositories.SqlContainer{Stats: mock}},
+		logger.NewLogrusLogger(),
+		time.Minute,
+	)
+
+	_, err := srv.Houses(ctx, q)
+	assert.Error(t, err, "a failure isn't cached")
+
+	data, _ := srv.Houses(ctx, q)
+	assert.Equal(t, first, data)
+
+	now = now.Add(30 * time.Second)
+	data, _ = srv.Houses(ctx, q)
+	assert.Equal(t, first, data, "answered from the cache")
+
+	now = now.Add(time.Minute)
+	data, _ = srv.Houses(ctx, q)
+	assert.Equal(t, second, data, "read again once expired")
+}
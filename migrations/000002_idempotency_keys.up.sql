@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    key                 varchar(255)    NOT NULL,
+    scope               varchar(255)    NOT NULL,
+    request_hash        varchar(64)     NOT NULL,
+    status_code         integer         NOT NULL    DEFAULT 0,
+    response            bytea,
+    created_at          TIMESTAMP       NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    expires_at          TIMESTAMP       NOT NULL,
+    PRIMARY KEY (key, scope)
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at ON idempotency_keys USING btree (expires_at);
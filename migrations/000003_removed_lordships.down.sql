@@ -0,0 +1 @@
+DROP TABLE IF EXISTS removed_lordships;
\ No newline at end of file
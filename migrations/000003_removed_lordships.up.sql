@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS removed_lordships
+(
+    house_id            varchar(40)     NOT NULL,
+    lord_id             varchar(40)     NOT NULL,
+    removed_at          TIMESTAMP       NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (house_id, lord_id)
+);
+
+CREATE INDEX IF NOT EXISTS removed_lordships_lord_id ON removed_lordships USING btree (lord_id);
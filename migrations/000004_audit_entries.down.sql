@@ -0,0 +1 @@
+DROP TABLE IF EXISTS audit_entries;
\ No newline at end of file
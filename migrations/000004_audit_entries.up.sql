@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS audit_entries
+(
+    id                  varchar(40)     PRIMARY KEY DEFAULT uuid_generate_v4(),
+    entity              varchar(20)     NOT NULL,
+    entity_id           varchar(40)     NOT NULL,
+    action              varchar(20)     NOT NULL,
+    actor               varchar(200)    NOT NULL    DEFAULT '',
+    request_id          varchar(100)    NOT NULL    DEFAULT '',
+    trace_id            varchar(32)     NOT NULL    DEFAULT '',
+    changes             jsonb           NOT NULL    DEFAULT '{}',
+    created_at          TIMESTAMP       NOT NULL    DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS audit_entries_entity ON audit_entries USING btree (entity, entity_id, created_at);
@@ -0,0 +1 @@
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS content_type varchar(255) NOT NULL DEFAULT 'application/json';
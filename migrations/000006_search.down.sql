@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS characters_name_trgm;
+DROP INDEX IF EXISTS houses_region_trgm;
+DROP INDEX IF EXISTS houses_name_trgm;
+DROP INDEX IF EXISTS characters_search;
+DROP INDEX IF EXISTS houses_search;
+
+ALTER TABLE characters DROP COLUMN IF EXISTS search;
+ALTER TABLE houses DROP COLUMN IF EXISTS search;
+
+DROP FUNCTION IF EXISTS character_search(text);
+DROP FUNCTION IF EXISTS house_search(text, text);
+DROP FUNCTION IF EXISTS search_unaccent(text);
@@ -0,0 +1,31 @@
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- unaccent is only stable, the search documents and the trigram indexes need
+-- an immutable one
+CREATE OR REPLACE FUNCTION search_unaccent(value text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+    AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, lower(value)) $$;
+
+-- the search documents, the repositories write them on create and update and
+-- clear them on delete so only the live rows are found
+CREATE OR REPLACE FUNCTION house_search(name text, region text) RETURNS tsvector
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT setweight(to_tsvector('simple', coalesce(search_unaccent(name), '')), 'A')
+              || setweight(to_tsvector('simple', coalesce(search_unaccent(region), '')), 'B') $$;
+
+CREATE OR REPLACE FUNCTION character_search(name text) RETURNS tsvector
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT setweight(to_tsvector('simple', coalesce(search_unaccent(name), '')), 'A') $$;
+
+ALTER TABLE houses ADD COLUMN IF NOT EXISTS search tsvector;
+ALTER TABLE characters ADD COLUMN IF NOT EXISTS search tsvector;
+
+UPDATE houses SET search = house_search(name, region) WHERE deleted_at IS NULL;
+UPDATE characters SET search = character_search(name) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS houses_search ON houses USING gin (search);
+CREATE INDEX IF NOT EXISTS characters_search ON characters USING gin (search);
+CREATE INDEX IF NOT EXISTS houses_name_trgm ON houses USING gin (search_unaccent(name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS houses_region_trgm ON houses USING gin (search_unaccent(region) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS characters_name_trgm ON characters USING gin (search_unaccent(name) gin_trgm_ops);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS houses_name_unique;
+
+CREATE UNIQUE INDEX IF NOT EXISTS houses_name ON houses USING btree (name,deleted_at);
+ALTER TABLE houses ADD CONSTRAINT houses_name_key UNIQUE (name);
+
+DROP FUNCTION IF EXISTS house_name_key(text);
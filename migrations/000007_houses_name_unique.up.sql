@@ -0,0 +1,23 @@
+-- the key two names of houses are told apart by: without case, accents or
+-- the spaces around and between the words
+CREATE OR REPLACE FUNCTION house_name_key(name text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+    AS $$ SELECT regexp_replace(btrim(search_unaccent(name)), '\s+', ' ', 'g') $$;
+
+-- only the live houses hold their names, a deleted one gives it up
+ALTER TABLE houses DROP CONSTRAINT IF EXISTS houses_name_key;
+DROP INDEX IF EXISTS houses_name;
+
+-- the live houses whose names already collide once normalized would fail the
+-- index: the oldest keeps its name and the others get their id appended
+UPDATE houses
+SET name = left(houses.name, 200 - length(houses.id) - 3) || ' (' || houses.id || ')',
+    updated_at = CURRENT_TIMESTAMP
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY house_name_key(name) ORDER BY created_at, id) AS position
+    FROM houses
+    WHERE deleted_at IS NULL
+) AS named
+WHERE houses.id = named.id AND named.position > 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS houses_name_unique ON houses USING btree (house_name_key(name)) WHERE deleted_at IS NULL;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS jobs
+(
+    id                  varchar(40)     PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind                varchar(40)     NOT NULL,
+    status              varchar(20)     NOT NULL    DEFAULT 'queued',
+    params              jsonb           NOT NULL    DEFAULT '{}',
+    progress            integer         NOT NULL    DEFAULT 0,
+    result              jsonb           NOT NULL    DEFAULT 'null',
+    error               text            NOT NULL    DEFAULT '',
+    cancel_requested    boolean         NOT NULL    DEFAULT false,
+    attempts            integer         NOT NULL    DEFAULT 0,
+    created_at          TIMESTAMP       NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    started_at          TIMESTAMP,
+    heartbeat_at        TIMESTAMP,
+    finished_at         TIMESTAMP
+);
+
+-- the workers only look for the jobs still to run or whose worker went silent
+CREATE INDEX IF NOT EXISTS jobs_pending ON jobs USING btree (created_at) WHERE status IN ('queued', 'running');
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    id                  varchar(40)     PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name                varchar(100)    NOT NULL,
+    prefix              varchar(20)     NOT NULL,
+    key_hash            varchar(64)     NOT NULL    UNIQUE,
+    scopes              text[]          NOT NULL,
+    created_at          TIMESTAMP       NOT NULL    DEFAULT CURRENT_TIMESTAMP,
+    expires_at          TIMESTAMP,
+    revoked_at          TIMESTAMP
+);
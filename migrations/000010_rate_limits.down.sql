@@ -0,0 +1,3 @@
+DROP FUNCTION IF EXISTS rate_limit_take(double precision);
+DROP FUNCTION IF EXISTS rate_limit_refill(double precision, double precision, integer, timestamp, timestamp);
+DROP TABLE IF EXISTS rate_limits;
@@ -0,0 +1,22 @@
+-- the buckets of the rate limits shared by the instances, losing them on a
+-- crash only refills them
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limits
+(
+    key                 varchar(300)        PRIMARY KEY,
+    tokens              double precision    NOT NULL,
+    rate                double precision    NOT NULL,
+    burst               integer             NOT NULL,
+    allowed             boolean             NOT NULL,
+    updated_at          TIMESTAMP           NOT NULL
+);
+
+-- the tokens of a bucket refilled at rate by second since updated_at, up to
+-- burst
+CREATE OR REPLACE FUNCTION rate_limit_refill(tokens double precision, rate double precision, burst integer, updated_at timestamp, at timestamp) RETURNS double precision
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT LEAST(burst, tokens + EXTRACT(EPOCH FROM at - updated_at)::double precision * rate) $$;
+
+-- the tokens left once a request took one, if there was one to take
+CREATE OR REPLACE FUNCTION rate_limit_take(refilled double precision) RETURNS double precision
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT CASE WHEN refilled >= 1 THEN refilled - 1 ELSE refilled END $$;
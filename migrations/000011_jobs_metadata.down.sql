@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS jobs_finished;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS request_id;
+ALTER TABLE jobs DROP COLUMN IF EXISTS actor;
@@ -0,0 +1,6 @@
+-- who submitted a job, so the audit entries of its work can name them
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS actor varchar(200) NOT NULL DEFAULT '';
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS request_id varchar(100) NOT NULL DEFAULT '';
+
+-- the cleanup deletes the jobs finished before the retention
+CREATE INDEX IF NOT EXISTS jobs_finished ON jobs USING btree (finished_at) WHERE finished_at IS NOT NULL;
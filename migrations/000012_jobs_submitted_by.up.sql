@@ -0,0 +1,3 @@
+-- the principal that submitted a job, the only one besides the admins that
+-- can read it
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS submitted_by varchar(255) NOT NULL DEFAULT '';
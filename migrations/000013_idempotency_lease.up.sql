@@ -0,0 +1,3 @@
+-- the lease of the request running with a key, a retry takes over a key whose
+-- request lost its lease without completing
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,5 @@
+UPDATE jobs
+SET submitted_by = substring(submitted_by FROM 5)
+WHERE submitted_by LIKE 'key:%' OR submitted_by LIKE 'jwt:%';
+
+UPDATE jobs SET submitted_by = '' WHERE submitted_by = 'bootstrap';
@@ -0,0 +1,8 @@
+-- the principals are prefixed by their credential, key: for the API keys and
+-- jwt: for the subjects of the tokens
+UPDATE jobs
+SET submitted_by = CASE
+    WHEN submitted_by IN (SELECT id FROM api_keys) THEN 'key:' || submitted_by
+    ELSE 'jwt:' || submitted_by
+END
+WHERE submitted_by <> '';
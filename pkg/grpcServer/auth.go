@@ -0,0 +1,99 @@
+package grpcServer
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	// Auth authenticates the calls by the bearer token of the authorization
+	// metadata or the key of the x-api-key one, the same credentials of the
+	// http requests.
+	Auth struct {
+		// APIKey returns the principal of a key, httpRouter.ErrUnauthenticated
+		// when the key is unknown, expired or revoked.
+		APIKey func(ctx context.Context, key string) (httpRouter.Principal, error)
+		// Bearer returns the principal of a token,
+		// httpRouter.ErrUnauthenticated when the token is invalid. Nil
+		// disables the bearer tokens.
+		Bearer func(ctx context.Context, token string) (httpRouter.Principal, error)
+		// Roles maps the full name of the methods to the role they need, the
+		// methods it doesn't map, as the health checks, need no credentials.
+		Roles map[string]string
+	}
+)
+
+// Unary is the interceptor that authenticates the calls and puts their
+// principal in the context.
+func (a Auth) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		role, ok := a.Roles[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		principal, err := a.authenticate(ctx)
+		if err == nil && !principal.HasRole(role) {
+			err = httpRouter.ErrForbidden
+		}
+		if err != nil {
+			return nil, deny(err)
+		}
+
+		return handler(httpRouter.ContextWithPrincipal(ctx, principal), req)
+	}
+}
+
+func (a Auth) authenticate(ctx context.Context) (httpRouter.Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	token, bearer := bearerToken(first(md, httpRouter.HeaderAuthorization))
+	switch key := first(md, httpRouter.HeaderAPIKey); {
+	case bearer && a.Bearer != nil:
+		return a.Bearer(ctx, token)
+	case len(key) > 0:
+		return a.APIKey(ctx, key)
+	default:
+		return httpRouter.Principal{}, httpRouter.ErrUnauthenticated
+	}
+}
+
+// deny converts the error of the authentication into the status of the call,
+// the failures of the authenticators are reported as unavailable so the
+// clients retry.
+func deny(err error) error {
+	switch {
+	case errors.Is(err, httpRouter.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, httpRouter.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Unavailable, "problem to authenticate the call")
+	}
+}
+
+func bearerToken(authorization string) (token string, ok bool) {
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, len(token) > 0
+}
+
+// first returns the first value of key, the keys of the metadata are lower
+// case.
+func first(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
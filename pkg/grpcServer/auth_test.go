@@ -0,0 +1,114 @@
+package grpcServer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func Test_Auth(t *testing.T) {
+	auth := Auth{
+		APIKey: func(ctx context.Context, key string) (httpRouter.Principal, error) {
+			switch key {
+			case "got_reader":
+				return httpRouter.Principal{ID: "id_1", Roles: []string{httpRouter.RoleReader}}, nil
+			case "got_broken":
+				return httpRouter.Principal{}, errors.New("connection refused")
+			}
+			return httpRouter.Principal{}, httpRouter.ErrUnauthenticated
+		},
+		Bearer: func(ctx context.Context, token string) (httpRouter.Principal, error) {
+			if token == "admin" {
+				return httpRouter.Principal{ID: "user_1", Roles: []string{httpRouter.RoleAdmin}}, nil
+			}
+			return httpRouter.Principal{}, httpRouter.ErrUnauthenticated
+		},
+	}
+
+	cases := map[string]struct {
+		role              string
+		metadata          metadata.MD
+		expectedCode      codes.Code
+		expectedPrincipal string
+	}{
+		"Should call with an API key of the role": {
+			role:              httpRouter.RoleReader,
+			metadata:          metadata.Pairs(httpRouter.HeaderAPIKey, "got_reader"),
+			expectedPrincipal: "id_1",
+		},
+		"Should call with a bearer token of a role above": {
+			role:              httpRouter.RoleEditor,
+			metadata:          metadata.Pairs(httpRouter.HeaderAuthorization, "Bearer admin"),
+			expectedPrincipal: "user_1",
+		},
+		"Should call a method without role and credentials": {},
+		"Should return error without credentials": {
+			role:         httpRouter.RoleReader,
+			expectedCode: codes.Unauthenticated,
+		},
+		"Should return error invalid token": {
+			role:         httpRouter.RoleReader,
+			metadata:     metadata.Pairs(httpRouter.HeaderAuthorization, "Bearer expired"),
+			expectedCode: codes.Unauthenticated,
+		},
+		"Should return error role": {
+			role:         httpRouter.RoleAdmin,
+			metadata:     metadata.Pairs(httpRouter.HeaderAPIKey, "got_reader"),
+			expectedCode: codes.PermissionDenied,
+		},
+		"Should return error on authenticate": {
+			role:         httpRouter.RoleReader,
+			metadata:     metadata.Pairs(httpRouter.HeaderAPIKey, "got_broken"),
+			expectedCode: codes.Unavailable,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ START SERVER ============
+			auth := auth
+			auth.Roles = map[string]string{}
+			if len(cs.role) > 0 {
+				auth.Roles[healthpb.Health_Check_FullMethodName] = cs.role
+			}
+
+			var principal httpRouter.Principal
+			server := NewGrpcServer().(*grpcServer)
+			server.Use(auth.Unary(), func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+				principal, _ = httpRouter.PrincipalFromContext(ctx)
+				return handler(ctx, req)
+			})
+
+			listener := bufconn.Listen(1024 * 1024)
+			go server.server.Serve(listener)
+			defer server.Stop()
+
+			// ============ START CLIENT ============
+			conn, err := grpc.Dial("bufnet",
+				grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+					return listener.DialContext(ctx)
+				}),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+			)
+			assert.NoError(t, err)
+			defer conn.Close()
+
+			// ============ START VALIDATION ============
+			ctx := metadata.NewOutgoingContext(context.Background(), cs.metadata)
+			_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+			assert.Equal(t, cs.expectedCode, status.Code(err))
+			assert.Equal(t, cs.expectedPrincipal, principal.ID)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package grpcServer
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+type (
+	grpcServer struct {
+		server       *grpc.Server
+		health       *health.Server
+		interceptors []grpc.UnaryServerInterceptor
+	}
+)
+
+// NewGrpcServer returns a server answering the standard health checks and
+// reflection, every service registered is reported as serving.
+func NewGrpcServer() Server {
+	s := &grpcServer{health: health.NewServer()}
+	s.server = grpc.NewServer(
+		// Set interceptor to tracer, the ones of Use run inside its span
+		grpc.ChainUnaryInterceptor(tracing(), s.intercept),
+	)
+
+	healthpb.RegisterHealthServer(s.server, s.health)
+	reflection.Register(s.server)
+
+	return s
+}
+
+func (s *grpcServer) Use(interceptors ...grpc.UnaryServerInterceptor) {
+	s.interceptors = append(s.interceptors, interceptors...)
+}
+
+func (s *grpcServer) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	s.server.RegisterService(desc, impl)
+	s.health.SetServingStatus(desc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+}
+
+func (s *grpcServer) Server(port string) error {
+	listener, err := net.Listen("tcp", port)
+	if err != nil {
+		return err
+	}
+
+	return s.server.Serve(listener)
+}
+
+func (s *grpcServer) Stop() {
+	// the clients watching the health see the server going away first
+	s.health.Shutdown()
+	s.server.GracefulStop()
+}
+
+// intercept runs the interceptors of Use in their order around handler.
+func (s *grpcServer) intercept(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	next := handler
+	for i := len(s.interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := s.interceptors[i], next
+		next = func(ctx context.Context, req any) (any, error) {
+			return interceptor(ctx, req, info, inner)
+		}
+	}
+	return next(ctx, req)
+}
+
+func tracing() grpc.UnaryServerInterceptor {
+	textMapPropagator := otel.GetTextMapPropagator()
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		// create ctx with propagators.
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = textMapPropagator.Extract(ctx, metadataCarrier(md))
+
+		service, method, _ := strings.Cut(strings.TrimPrefix(info.FullMethod, "/"), "/")
+
+		// Create a span
+		ctx, span := tracer.Span(ctx, info.FullMethod,
+			tracer.SpanStartOption{Key: string(semconv.RPCSystemKey), Value: "grpc"},
+			tracer.SpanStartOption{Key: string(semconv.RPCServiceKey), Value: service},
+			tracer.SpanStartOption{Key: string(semconv.RPCMethodKey), Value: method},
+		)
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		return resp, err
+	}
+}
+
+// metadataCarrier reads the trace propagated in the metadata of a call.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
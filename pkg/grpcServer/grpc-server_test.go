@@ -0,0 +1,70 @@
+package grpcServer
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type pingServer interface{}
+
+var pingDesc = grpc.ServiceDesc{
+	ServiceName: "test.PingService",
+	HandlerType: (*pingServer)(nil),
+}
+
+func Test_Health(t *testing.T) {
+	cases := map[string]struct {
+		service        string
+		expectedStatus healthpb.HealthCheckResponse_ServingStatus
+		expectedCode   codes.Code
+	}{
+		"Should return serving for the server": {
+			service:        "",
+			expectedStatus: healthpb.HealthCheckResponse_SERVING,
+		},
+		"Should return serving for a registered service": {
+			service:        "test.PingService",
+			expectedStatus: healthpb.HealthCheckResponse_SERVING,
+		},
+		"Should return error unknown service": {
+			service:      "test.UnknownService",
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ START SERVER ============
+			server := NewGrpcServer().(*grpcServer)
+			server.RegisterService(&pingDesc, struct{}{})
+
+			listener := bufconn.Listen(1024 * 1024)
+			go server.server.Serve(listener)
+			defer server.Stop()
+
+			// ============ START CLIENT ============
+			conn, err := grpc.Dial("bufnet",
+				grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+					return listener.DialContext(ctx)
+				}),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+			)
+			assert.NoError(t, err)
+			defer conn.Close()
+
+			// ============ START VALIDATION ============
+			resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{Service: cs.service})
+			assert.Equal(t, cs.expectedCode, status.Code(err))
+			assert.Equal(t, cs.expectedStatus, resp.GetStatus())
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package grpcServer
+
+import (
+	"google.golang.org/grpc"
+)
+
+type (
+	Server interface {
+		// RegisterService adds a service, it must be called before Server
+		grpc.ServiceRegistrar
+		// Use adds interceptors to the unary calls, it must be called before
+		// Server
+		Use(interceptors ...grpc.UnaryServerInterceptor)
+		// Server listens on port and blocks until the server stops
+		Server(port string) error
+		// Stop waits the pending calls and stops the server
+		Stop()
+	}
+)
@@ -0,0 +1,139 @@
+package httpRouter
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// HeaderAPIKey carries the API key of a request, the bearer tokens go in the
+// Authorization header.
+const (
+	HeaderAPIKey        = "X-API-Key"
+	HeaderAuthorization = "Authorization"
+)
+
+// The roles of a principal, ranked: an editor is also a reader and an admin
+// also an editor.
+const (
+	RoleReader = "reader"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+var roleRanks = map[string]int{RoleReader: 1, RoleEditor: 2, RoleAdmin: 3}
+
+var (
+	ErrUnauthenticated = errors.New("the request has no valid API key or bearer token")
+	ErrForbidden       = errors.New("the credentials don't have the role of the route")
+)
+
+type (
+	// Principal is who a request is authenticated as.
+	Principal struct {
+		ID    string
+		Name  string
+		Roles []string
+	}
+
+	// Auth authenticates the requests by the bearer token of the
+	// Authorization header or the key of the X-API-Key header.
+	Auth struct {
+		// APIKey returns the principal of a key, ErrUnauthenticated when the
+		// key is unknown, expired or revoked.
+		APIKey func(ctx context.Context, key string) (Principal, error)
+		// Bearer returns the principal of a token, ErrUnauthenticated when
+		// the token is invalid. Nil disables the bearer tokens.
+		Bearer func(ctx context.Context, token string) (Principal, error)
+		// Deny answers a request that failed the authentication with err,
+		// ErrUnauthenticated, ErrForbidden or an error of the authenticators.
+		Deny func(c Context, err error)
+	}
+
+	principalKey struct{}
+)
+
+// HasRole reports whether the principal has role or a role ranked above it.
+func (p Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if roleRanks[r] >= roleRanks[role] && roleRanks[r] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle is the middleware that authenticates the request and puts its
+// principal in the context, the routes check the role they need with Require.
+func (a Auth) Handle(c Context) {
+	var (
+		principal Principal
+		err       error
+	)
+
+	token, bearer := bearerToken(c.GetHeader(HeaderAuthorization))
+	switch {
+	case bearer && a.Bearer != nil:
+		principal, err = a.Bearer(c.Context(), token)
+	case len(c.GetHeader(HeaderAPIKey)) > 0:
+		principal, err = a.APIKey(c.Context(), c.GetHeader(HeaderAPIKey))
+	default:
+		err = ErrUnauthenticated
+	}
+	if err != nil {
+		a.deny(c, err)
+		return
+	}
+
+	c.SetContext(ContextWithPrincipal(c.Context(), principal))
+	c.Next()
+}
+
+// Require is the middleware of a route that needs role, it runs after
+// Handle.
+func (a Auth) Require(role string) HandlerFunc {
+	return func(c Context) {
+		principal, ok := PrincipalFromContext(c.Context())
+		if !ok {
+			a.deny(c, ErrUnauthenticated)
+			return
+		}
+		if !principal.HasRole(role) {
+			a.deny(c, ErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+func (a Auth) deny(c Context, err error) {
+	if errors.Is(err, ErrUnauthenticated) {
+		challenge := `ApiKey header="` + HeaderAPIKey + `"`
+		if a.Bearer != nil {
+			challenge = "Bearer, " + challenge
+		}
+		c.SetHeader("WWW-Authenticate", challenge)
+	}
+	a.Deny(c, err)
+	c.Abort()
+}
+
+func bearerToken(authorization string) (token string, ok bool) {
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, len(token) > 0
+}
+
+func ContextWithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal the request was authenticated
+// as, ok is false when it wasn't.
+func PrincipalFromContext(ctx context.Context) (principal Principal, ok bool) {
+	principal, ok = ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
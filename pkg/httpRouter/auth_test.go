@@ -0,0 +1,193 @@
+package httpRouter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Auth(t *testing.T) {
+	keys := map[string]Principal{
+		"reader": {ID: "id_1", Name: "reader", Roles: []string{RoleReader}},
+		"editor": {ID: "id_2", Name: "editor", Roles: []string{RoleEditor}},
+		"admin":  {ID: "id_3", Name: "admin", Roles: []string{RoleAdmin}},
+	}
+	tokens := map[string]Principal{
+		"token_editor": {ID: "user_1", Name: "user_1", Roles: []string{"unknown", RoleEditor}},
+	}
+
+	cases := map[string]struct {
+		method               string
+		path                 string
+		key                  string
+		authorization        string
+		noBearer             bool
+		expectedCode         int
+		expectedPrincipal    string
+		expectedAuthenticate string
+	}{
+		"Should return unauthorized without credentials": {
+			method:               http.MethodGet,
+			path:                 "/houses",
+			expectedCode:         http.StatusUnauthorized,
+			expectedAuthenticate: `Bearer, ApiKey header="X-API-Key"`,
+		},
+		"Should return unauthorized with an unknown key": {
+			method:               http.MethodGet,
+			path:                 "/houses",
+			key:                  "unknown",
+			expectedCode:         http.StatusUnauthorized,
+			expectedAuthenticate: `Bearer, ApiKey header="X-API-Key"`,
+		},
+		"Should return unauthorized with an invalid token": {
+			method:               http.MethodGet,
+			path:                 "/houses",
+			authorization:        "Bearer invalid",
+			expectedCode:         http.StatusUnauthorized,
+			expectedAuthenticate: `Bearer, ApiKey header="X-API-Key"`,
+		},
+		"Should return unauthorized with a token when bearer is disabled": {
+			method:               http.MethodGet,
+			path:                 "/houses",
+			authorization:        "Bearer token_editor",
+			noBearer:             true,
+			expectedCode:         http.StatusUnauthorized,
+			expectedAuthenticate: `ApiKey header="X-API-Key"`,
+		},
+		"Should return unavailable when the keys can't be read": {
+			method:       http.MethodGet,
+			path:         "/houses",
+			key:          "broken",
+			expectedCode: http.StatusServiceUnavailable,
+		},
+		"Should read as reader": {
+			method:            http.MethodGet,
+			path:              "/houses",
+			key:               "reader",
+			expectedCode:      http.StatusOK,
+			expectedPrincipal: "reader",
+		},
+		"Should return forbidden when writing as reader": {
+			method:       http.MethodPost,
+			path:         "/houses",
+			key:          "reader",
+			expectedCode: http.StatusForbidden,
+		},
+		"Should write with a token of an editor": {
+			method:            http.MethodPost,
+			path:              "/houses",
+			authorization:     "bearer token_editor",
+			expectedCode:      http.StatusOK,
+			expectedPrincipal: "user_1",
+		},
+		"Should prefer the token to the key": {
+			method:            http.MethodGet,
+			path:              "/houses",
+			key:               "admin",
+			authorization:     "Bearer token_editor",
+			expectedCode:      http.StatusOK,
+			expectedPrincipal: "user_1",
+		},
+		"Should return forbidden when deleting as editor": {
+			method:       http.MethodDelete,
+			path:         "/houses",
+			key:          "editor",
+			expectedCode: http.StatusForbidden,
+		},
+		"Should delete and read as admin": {
+			method:            http.MethodDelete,
+			path:              "/houses",
+			key:               "admin",
+			expectedCode:      http.StatusOK,
+			expectedPrincipal: "admin",
+		},
+		"Should allow the routes without role to any principal": {
+			method:            http.MethodGet,
+			path:              "/me",
+			key:               "reader",
+			expectedCode:      http.StatusOK,
+			expectedPrincipal: "reader",
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			auth := Auth{
+				APIKey: func(ctx context.Context, key string) (Principal, error) {
+					if key == "broken" {
+						return Principal{}, errors.New("problem to find key")
+					}
+					principal, ok := keys[key]
+					if !ok {
+						return Principal{}, ErrUnauthenticated
+					}
+					return principal, nil
+				},
+				Bearer: func(ctx context.Context, token string) (Principal, error) {
+					principal, ok := tokens[token]
+					if !ok {
+						return Principal{}, ErrUnauthenticated
+					}
+					return principal, nil
+				},
+				Deny: func(c Context, err error) {
+					switch {
+					case errors.Is(err, ErrUnauthenticated):
+						c.Respond(http.StatusUnauthorized, nil)
+					case errors.Is(err, ErrForbidden):
+						c.Respond(http.StatusForbidden, nil)
+					default:
+						c.Respond(http.StatusServiceUnavailable, nil)
+					}
+				},
+			}
+			if cs.noBearer {
+				auth.Bearer = nil
+			}
+
+			var principal Principal
+			handler := func(c Context) {
+				principal, _ = PrincipalFromContext(c.Context())
+				c.Respond(http.StatusOK, nil)
+			}
+
+			// ============ START ROUTER ============
+			router := NewGinRouter()
+			group := router.Group("", auth.Handle)
+			group.Get("/houses", auth.Require(RoleReader), handler)
+			group.Post("/houses", auth.Require(RoleEditor), handler)
+			group.Delete("/houses", auth.Require(RoleAdmin), handler)
+			group.Get("/me", handler)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(cs.method, cs.path, nil)
+			if len(cs.key) > 0 {
+				request.Header.Set(HeaderAPIKey, cs.key)
+			}
+			if len(cs.authorization) > 0 {
+				request.Header.Set(HeaderAuthorization, cs.authorization)
+			}
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			assert.Equal(t, cs.expectedCode, writer.Code)
+			assert.Equal(t, cs.expectedPrincipal, principal.Name)
+			assert.Equal(t, cs.expectedAuthenticate, writer.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func Test_HasRole(t *testing.T) {
+	assert.True(t, Principal{Roles: []string{RoleAdmin}}.HasRole(RoleReader))
+	assert.True(t, Principal{Roles: []string{RoleReader, RoleEditor}}.HasRole(RoleEditor))
+	assert.False(t, Principal{Roles: []string{RoleEditor}}.HasRole(RoleAdmin))
+	assert.False(t, Principal{Roles: []string{"owner"}}.HasRole("owner"))
+	assert.False(t, Principal{}.HasRole(RoleReader))
+}
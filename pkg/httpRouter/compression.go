@@ -0,0 +1,169 @@
+package httpRouter
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+)
+
+// The content codings of the responses and, gzip only, of the request bodies.
+const (
+	EncodingGzip = "gzip"
+	EncodingZstd = "zstd"
+)
+
+type (
+	// Compression compresses the responses in the coding the Accept-Encoding
+	// header prefers among Encodings, the first one on ties. Empty Encodings
+	// don't compress. The responses smaller than MinSize go as they are, the
+	// coding would cost more than it saves.
+	Compression struct {
+		Encodings []string `mapstructure:"encodings"`
+		MinSize   int      `mapstructure:"min_size"`
+	}
+
+	encodingWriter interface {
+		io.WriteCloser
+		Flush() error
+		Reset(w io.Writer)
+	}
+
+	// compressWriter holds the body until it reaches the minimum size, then
+	// decides whether it goes compressed.
+	compressWriter struct {
+		gin.ResponseWriter
+		encoding string
+		minSize  int
+		buf      []byte
+		decided  bool
+		encoder  encodingWriter
+	}
+)
+
+// contentEncoders reuses the encoders of each coding, their buffers are costly to
+// allocate for every response.
+var contentEncoders = map[string]*sync.Pool{
+	EncodingGzip: {New: func() any {
+		return gzip.NewWriter(nil)
+	}},
+	EncodingZstd: {New: func() any {
+		encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return encoder
+	}},
+}
+
+func (r *ginRouter) Compress(opts Compression) error {
+	if len(opts.Encodings) == 0 {
+		return nil
+	}
+	for _, encoding := range opts.Encodings {
+		if _, ok := contentEncoders[encoding]; !ok {
+			return fmt.Errorf("httpRouter: unknown encoding %q", encoding)
+		}
+	}
+
+	handler := compress(opts)
+	if r.group == &r.router.RouterGroup {
+		// through the engine they also run for the requests without route
+		r.router.Use(handler)
+		return nil
+	}
+	r.group.Use(handler)
+	return nil
+}
+
+func compress(opts Compression) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		// the response depends on the header, even the ones left uncompressed
+		ctx.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(ctx.GetHeader("Accept-Encoding"), opts.Encodings)
+		if encoding == "" || ctx.Request.Method == http.MethodHead {
+			ctx.Next()
+			return
+		}
+
+		w := &compressWriter{ResponseWriter: ctx.Writer, encoding: encoding, minSize: opts.MinSize}
+		ctx.Writer = w
+		ctx.Next()
+		w.finish()
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	switch {
+	case w.encoder != nil:
+		return w.encoder.Write(b)
+	case w.decided:
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buf = append(w.buf, b...)
+	if len(w.buf) >= w.minSize {
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush compresses what was held, a streamed response is worth it whatever
+// its size.
+func (w *compressWriter) Flush() {
+	if !w.decided && len(w.buf) > 0 {
+		w.decide()
+	}
+	if w.encoder != nil {
+		w.encoder.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide starts the compression, unless the response can't take it, and
+// writes the body held so far.
+func (w *compressWriter) decide() error {
+	w.decided = true
+	buf := w.buf
+	w.buf = nil
+
+	header := w.Header()
+	switch {
+	case w.Status() == http.StatusPartialContent, w.Status() == http.StatusNoContent,
+		w.Status() == http.StatusNotModified, len(header.Get("Content-Encoding")) > 0:
+		_, err := w.ResponseWriter.Write(buf)
+		return err
+	}
+
+	header.Set("Content-Encoding", w.encoding)
+	header.Del("Content-Length")
+
+	w.encoder = contentEncoders[w.encoding].Get().(encodingWriter)
+	w.encoder.Reset(w.ResponseWriter)
+	_, err := w.encoder.Write(buf)
+	return err
+}
+
+// finish writes the body smaller than the minimum size as it is, or ends the
+// compressed one.
+func (w *compressWriter) finish() {
+	if !w.decided {
+		if len(w.buf) > 0 {
+			w.ResponseWriter.Write(w.buf)
+		}
+		return
+	}
+	if w.encoder != nil {
+		w.encoder.Close()
+		contentEncoders[w.encoding].Put(w.encoder)
+		w.encoder = nil
+	}
+}
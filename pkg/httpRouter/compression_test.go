@@ -0,0 +1,116 @@
+package httpRouter
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Compress(t *testing.T) {
+	large := strings.Repeat("House Stark of Winterfell. ", 100)
+
+	cases := map[string]struct {
+		opts             Compression
+		acceptEncoding   string
+		body             string
+		stream           bool
+		expectedEncoding string
+	}{
+		"Should compress with gzip": {
+			opts:             Compression{Encodings: []string{EncodingZstd, EncodingGzip}, MinSize: 1024},
+			acceptEncoding:   "gzip",
+			body:             large,
+			expectedEncoding: EncodingGzip,
+		},
+		"Should compress with zstd": {
+			opts:             Compression{Encodings: []string{EncodingZstd, EncodingGzip}, MinSize: 1024},
+			acceptEncoding:   "gzip, zstd",
+			body:             large,
+			expectedEncoding: EncodingZstd,
+		},
+		"Should not compress under the minimum size": {
+			opts:           Compression{Encodings: []string{EncodingZstd, EncodingGzip}, MinSize: 1024},
+			acceptEncoding: "gzip, zstd",
+			body:           "House Stark",
+		},
+		"Should compress a stream under the minimum size": {
+			opts:             Compression{Encodings: []string{EncodingGzip}, MinSize: 1024},
+			acceptEncoding:   "gzip",
+			body:             "House Stark",
+			stream:           true,
+			expectedEncoding: EncodingGzip,
+		},
+		"Should not compress without an acceptable coding": {
+			opts:           Compression{Encodings: []string{EncodingZstd, EncodingGzip}, MinSize: 1024},
+			acceptEncoding: "br",
+			body:           large,
+		},
+		"Should not compress without encodings": {
+			acceptEncoding: "gzip",
+			body:           large,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ START ROUTER ============
+			router := NewGinRouter()
+			assert.NoError(t, router.Compress(cs.opts))
+			handler := func(c Context) {
+				c.SetHeader("Content-Length", "100000")
+				if !cs.stream {
+					c.Data(http.StatusOK, "text/plain", []byte(cs.body))
+					return
+				}
+				c.GetResponseWriter().Write([]byte(cs.body))
+				c.GetResponseWriter().(http.Flusher).Flush()
+			}
+			router.Get("/houses", handler)
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodGet, "/houses", nil)
+			request.Header.Set("Accept-Encoding", cs.acceptEncoding)
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			assert.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, cs.expectedEncoding, writer.Header().Get("Content-Encoding"))
+			if len(cs.opts.Encodings) > 0 {
+				assert.Contains(t, writer.Header().Values("Vary"), "Accept-Encoding")
+			}
+
+			var body io.Reader = writer.Body
+			switch cs.expectedEncoding {
+			case EncodingGzip:
+				assert.Empty(t, writer.Header().Get("Content-Length"))
+				reader, err := gzip.NewReader(body)
+				assert.NoError(t, err)
+				body = reader
+			case EncodingZstd:
+				assert.Empty(t, writer.Header().Get("Content-Length"))
+				reader, err := zstd.NewReader(body)
+				assert.NoError(t, err)
+				defer reader.Close()
+				body = reader
+			}
+			data, err := io.ReadAll(body)
+			assert.NoError(t, err)
+			assert.Equal(t, cs.body, string(data))
+		})
+	}
+}
+
+func Test_CompressUnknownEncoding(t *testing.T) {
+	err := NewGinRouter().Compress(Compression{Encodings: []string{"br"}})
+
+	assert.EqualError(t, err, `httpRouter: unknown encoding "br"`)
+}
@@ -0,0 +1,109 @@
+package httpRouter
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORS lets the browsers of other origins call the API. The zero value allows
+// no origin, "*" in AllowOrigins allows any of them but never with the
+// credentials, those are only shared with the origins listed.
+type CORS struct {
+	AllowOrigins     []string      `mapstructure:"allow_origins"`
+	AllowMethods     []string      `mapstructure:"allow_methods"`
+	AllowHeaders     []string      `mapstructure:"allow_headers"`
+	ExposeHeaders    []string      `mapstructure:"expose_headers"`
+	AllowCredentials bool          `mapstructure:"allow_credentials"`
+	MaxAge           time.Duration `mapstructure:"max_age"`
+}
+
+// CrossOrigin returns the middleware that sets the CORS headers of the
+// requests of the allowed origins and answers their preflights. The requests
+// of the other origins go on without them, their browsers block the response.
+func CrossOrigin(cors CORS) HandlerFunc {
+	if len(cors.AllowOrigins) == 0 {
+		return func(c Context) {
+			c.Next()
+		}
+	}
+
+	var (
+		anyOrigin     bool
+		methods       = strings.Join(cors.AllowMethods, ", ")
+		headers       = strings.Join(cors.AllowHeaders, ", ")
+		exposeHeaders = strings.Join(cors.ExposeHeaders, ", ")
+		maxAge        = strconv.FormatInt(int64(cors.MaxAge.Seconds()), 10)
+	)
+	for _, origin := range cors.AllowOrigins {
+		anyOrigin = anyOrigin || origin == "*"
+	}
+
+	return func(c Context) {
+		origin := c.GetHeader("Origin")
+		if len(origin) == 0 {
+			c.Next()
+			return
+		}
+
+		header := c.GetResponseWriter().Header()
+		preflight := c.GetRequestReader().Method == http.MethodOptions && len(c.GetHeader("Access-Control-Request-Method")) > 0
+		if preflight {
+			header.Add("Vary", "Origin, Access-Control-Request-Method, Access-Control-Request-Headers")
+		} else if !anyOrigin {
+			header.Add("Vary", "Origin")
+		}
+
+		listed := cors.listed(origin)
+		switch {
+		case listed:
+			c.SetHeader("Access-Control-Allow-Origin", origin)
+			if cors.AllowCredentials {
+				c.SetHeader("Access-Control-Allow-Credentials", "true")
+			}
+		case anyOrigin:
+			c.SetHeader("Access-Control-Allow-Origin", "*")
+		default:
+			if preflight {
+				c.GetResponseWriter().WriteHeader(http.StatusForbidden)
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if !preflight {
+			if len(exposeHeaders) > 0 {
+				c.SetHeader("Access-Control-Expose-Headers", exposeHeaders)
+			}
+			c.Next()
+			return
+		}
+
+		if len(methods) > 0 {
+			c.SetHeader("Access-Control-Allow-Methods", methods)
+		}
+		if len(headers) > 0 {
+			c.SetHeader("Access-Control-Allow-Headers", headers)
+		}
+		if cors.MaxAge > 0 {
+			c.SetHeader("Access-Control-Max-Age", maxAge)
+		}
+		// a preflight has no body, nor the content type set for the API
+		header.Del("Content-Type")
+		c.GetResponseWriter().WriteHeader(http.StatusNoContent)
+		c.Abort()
+	}
+}
+
+// listed reports whether origin is one of the AllowOrigins, "*" aside.
+func (cors CORS) listed(origin string) bool {
+	for _, allowed := range cors.AllowOrigins {
+		if allowed != "*" && strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
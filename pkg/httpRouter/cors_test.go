@@ -0,0 +1,134 @@
+package httpRouter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CrossOrigin(t *testing.T) {
+	cors := CORS{
+		AllowOrigins:     []string{"https://app.example.com"},
+		AllowMethods:     []string{"GET", "POST"},
+		AllowHeaders:     []string{"Authorization", "Content-Type"},
+		ExposeHeaders:    []string{"Location", "Retry-After"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+
+	cases := map[string]struct {
+		cors           CORS
+		method         string
+		origin         string
+		requestMethod  string
+		expectedCode   int
+		expectedHeader http.Header
+	}{
+		"Should answer the preflight of an allowed origin": {
+			cors:          cors,
+			method:        http.MethodOptions,
+			origin:        "https://app.example.com",
+			requestMethod: http.MethodPost,
+			expectedCode:  http.StatusNoContent,
+			expectedHeader: http.Header{
+				"Access-Control-Allow-Origin":      {"https://app.example.com"},
+				"Access-Control-Allow-Credentials": {"true"},
+				"Access-Control-Allow-Methods":     {"GET, POST"},
+				"Access-Control-Allow-Headers":     {"Authorization, Content-Type"},
+				"Access-Control-Max-Age":           {"600"},
+				"Vary":                             {"Origin, Access-Control-Request-Method, Access-Control-Request-Headers"},
+			},
+		},
+		"Should reject the preflight of another origin": {
+			cors:          cors,
+			method:        http.MethodOptions,
+			origin:        "https://evil.example.com",
+			requestMethod: http.MethodPost,
+			expectedCode:  http.StatusForbidden,
+			expectedHeader: http.Header{
+				"Vary": {"Origin, Access-Control-Request-Method, Access-Control-Request-Headers"},
+			},
+		},
+		"Should share the response with an allowed origin": {
+			cors:         cors,
+			method:       http.MethodGet,
+			origin:       "https://app.example.com",
+			expectedCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Access-Control-Allow-Origin":      {"https://app.example.com"},
+				"Access-Control-Allow-Credentials": {"true"},
+				"Access-Control-Expose-Headers":    {"Location, Retry-After"},
+				"Vary":                             {"Origin", "Accept"},
+			},
+		},
+		"Should not share the response with another origin": {
+			cors:         cors,
+			method:       http.MethodGet,
+			origin:       "https://evil.example.com",
+			expectedCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Vary": {"Origin", "Accept"},
+			},
+		},
+		"Should share with any origin without the credentials": {
+			cors:         CORS{AllowOrigins: []string{"*"}, AllowCredentials: true},
+			method:       http.MethodGet,
+			origin:       "https://app.example.com",
+			expectedCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Access-Control-Allow-Origin": {"*"},
+				"Vary":                        {"Accept"},
+			},
+		},
+		"Should not answer without origins": {
+			method:        http.MethodOptions,
+			origin:        "https://app.example.com",
+			requestMethod: http.MethodPost,
+			expectedCode:  http.StatusNotFound,
+		},
+		"Should ignore the requests of the same origin": {
+			cors:         cors,
+			method:       http.MethodGet,
+			expectedCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Vary": {"Accept"},
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ START ROUTER ============
+			router := NewGinRouter()
+			router.Use(CrossOrigin(cs.cors))
+			router.Get("/houses", func(c Context) {
+				c.Respond(http.StatusOK, nil)
+			})
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(cs.method, "/houses", nil)
+			if len(cs.origin) > 0 {
+				request.Header.Set("Origin", cs.origin)
+			}
+			if len(cs.requestMethod) > 0 {
+				request.Header.Set("Access-Control-Request-Method", cs.requestMethod)
+			}
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			assert.Equal(t, cs.expectedCode, writer.Code)
+			for _, key := range []string{
+				"Access-Control-Allow-Origin", "Access-Control-Allow-Credentials", "Access-Control-Allow-Methods",
+				"Access-Control-Allow-Headers", "Access-Control-Max-Age", "Access-Control-Expose-Headers", "Vary",
+			} {
+				assert.Equal(t, cs.expectedHeader.Values(key), writer.Header().Values(key), key)
+			}
+		})
+	}
+}
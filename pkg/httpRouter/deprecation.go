@@ -0,0 +1,33 @@
+package httpRouter
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Deprecation announces that a group of routes is going away. The zero value
+// announces nothing, otherwise the responses carry the Deprecation (RFC 9745)
+// and Sunset (RFC 8594) headers, and a link to the same route under the
+// Successor prefix.
+type Deprecation struct {
+	Date      time.Time `mapstructure:"date"`
+	Sunset    time.Time `mapstructure:"sunset"`
+	Successor string    `mapstructure:"successor"`
+}
+
+// Deprecate returns the middleware that sets the headers of d.
+func Deprecate(d Deprecation) HandlerFunc {
+	return func(c Context) {
+		if !d.Date.IsZero() {
+			c.SetHeader("Deprecation", fmt.Sprintf("@%d", d.Date.Unix()))
+		}
+		if !d.Sunset.IsZero() {
+			c.SetHeader("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if len(d.Successor) > 0 {
+			link := d.Successor + c.GetRequestReader().URL.Path
+			c.SetHeader("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, link))
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package httpRouter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Deprecate(t *testing.T) {
+	deprecation := Deprecation{
+		Date:      time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+		Sunset:    time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC),
+		Successor: "/v1",
+	}
+
+	cases := map[string]struct {
+		path                string
+		expectedDeprecation string
+		expectedSunset      string
+		expectedLink        string
+	}{
+		"Should announce the deprecation of the root group": {
+			path:                "/houses",
+			expectedDeprecation: "@1792281600",
+			expectedSunset:      "Fri, 30 Apr 2027 00:00:00 GMT",
+			expectedLink:        `</v1/houses>; rel="successor-version"`,
+		},
+		"Should not announce anything on the current group": {
+			path: "/v1/houses",
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ START ROUTER ============
+			router := NewGinRouter()
+			router.Group("/v1", Deprecate(Deprecation{})).Get("/houses", func(c Context) {
+				c.Respond(http.StatusOK, nil)
+			})
+			router.Group("", Deprecate(deprecation)).Get("/houses", func(c Context) {
+				c.Respond(http.StatusOK, nil)
+			})
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodGet, cs.path, nil)
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			assert.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, cs.expectedDeprecation, writer.Header().Get("Deprecation"))
+			assert.Equal(t, cs.expectedSunset, writer.Header().Get("Sunset"))
+			assert.Equal(t, cs.expectedLink, writer.Header().Get("Link"))
+		})
+	}
+}
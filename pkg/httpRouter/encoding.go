@@ -0,0 +1,297 @@
+package httpRouter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/ugorji/go/codec"
+	"gopkg.in/yaml.v3"
+)
+
+// The media types Respond encodes, JSON stays the default.
+const (
+	JSONContentType        = "application/json"
+	CSVContentType         = "text/csv"
+	YAMLContentType        = "application/yaml"
+	MessagePackContentType = "application/msgpack"
+)
+
+type (
+	encoder struct {
+		contentType string
+		encode      func(w io.Writer, data any) error
+	}
+
+	// Representation is another media type of the data given to Respond, like
+	// HAL, rendered as JSON. Data is only called when the client picks it.
+	Representation struct {
+		ContentType string
+		Data        func() any
+	}
+
+	// object is a JSON object that keeps the order of its keys, so the columns
+	// of a CSV follow the fields of the struct.
+	object struct {
+		keys   []string
+		values map[string]any
+	}
+)
+
+var encoders = []encoder{
+	{JSONContentType, encodeJSON},
+	{CSVContentType, encodeCSV},
+	{YAMLContentType, encodeYAML},
+	{MessagePackContentType, encodeMessagePack},
+	// the names in use before the types were registered
+	{"application/x-yaml", encodeYAML},
+	{"application/x-msgpack", encodeMessagePack},
+}
+
+// msgpackHandle sorts the keys of the maps, so a response always has the same bytes.
+var msgpackHandle = func() *codec.MsgpackHandle {
+	h := &codec.MsgpackHandle{WriteExt: true}
+	h.Canonical = true
+	return h
+}()
+
+// csvSeparator joins the items of a list in a single CSV cell, e.g. tv_series.
+const csvSeparator = ";"
+
+func encodeJSON(w io.Writer, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// encodeCSV writes a row per item of a list, or a single row for an object.
+// Nested objects become columns named by their path, lists of values are
+// joined in one cell.
+func encodeCSV(w io.Writer, data any) error {
+	value, err := decode(data)
+	if err != nil {
+		return err
+	}
+
+	items, ok := value.([]any)
+	if !ok {
+		items = []any{value}
+	}
+
+	var columns []string
+	seen := map[string]bool{}
+	rows := make([]map[string]string, len(items))
+	for i, item := range items {
+		rows[i] = map[string]string{}
+		flatten("", item, rows[i], func(column string) {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		})
+	}
+
+	writer := csv.NewWriter(w)
+	if len(columns) > 0 {
+		writer.Write(columns)
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = row[column]
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func flatten(prefix string, value any, row map[string]string, column func(string)) {
+	if obj, ok := value.(*object); ok {
+		if prefix != "" {
+			prefix += "."
+		}
+		for _, key := range obj.keys {
+			flatten(prefix+key, obj.values[key], row, column)
+		}
+		return
+	}
+
+	if prefix == "" {
+		prefix = "value"
+	}
+	column(prefix)
+	row[prefix] = cell(value)
+}
+
+func cell(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case []any:
+		cells := make([]string, len(v))
+		for i, item := range v {
+			if _, ok := item.(*object); ok {
+				// lists of objects don't fit in a cell, they stay as JSON
+				b, _ := json.Marshal(plain(v))
+				return string(b)
+			}
+			cells[i] = cell(item)
+		}
+		return strings.Join(cells, csvSeparator)
+	default:
+		b, _ := json.Marshal(plain(v))
+		return string(b)
+	}
+}
+
+func encodeYAML(w io.Writer, data any) error {
+	value, err := decode(data)
+	if err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(yamlNode(value)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func yamlNode(value any) *yaml.Node {
+	switch v := value.(type) {
+	case *object:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, key := range v.keys {
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+				yamlNode(v.values[key]),
+			)
+		}
+		return node
+	case []any:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range v {
+			node.Content = append(node.Content, yamlNode(item))
+		}
+		return node
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: v.String()}
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: v.String()}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: cell(v)}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	}
+}
+
+func encodeMessagePack(w io.Writer, data any) error {
+	value, err := decode(data)
+	if err != nil {
+		return err
+	}
+
+	return codec.NewEncoder(w, msgpackHandle).Encode(plain(value))
+}
+
+// decode turns data into the values of its JSON document, so every format
+// takes the field names and the omissions of the json tags.
+func decode(data any) (any, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	return decodeValue(decoder)
+}
+
+func decodeValue(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		obj := &object{values: map[string]any{}}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			obj.keys = append(obj.keys, key.(string))
+			obj.values[key.(string)] = value
+		}
+		_, err = decoder.Token()
+		return obj, err
+	case json.Delim('['):
+		list := []any{}
+		for decoder.More() {
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = decoder.Token()
+		return list, err
+	case json.Delim('}'), json.Delim(']'):
+		return nil, errors.New("httpRouter: unexpected end of JSON value")
+	default:
+		return token, nil
+	}
+}
+
+// plain replaces the ordered objects by maps and the numbers by integers or
+// floats, the values the other encoders know.
+func plain(value any) any {
+	switch v := value.(type) {
+	case *object:
+		m := make(map[string]any, len(v.keys))
+		for _, key := range v.keys {
+			m[key] = plain(v.values[key])
+		}
+		return m
+	case []any:
+		list := make([]any, len(v))
+		for i, item := range v {
+			list[i] = plain(item)
+		}
+		return list
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	default:
+		return v
+	}
+}
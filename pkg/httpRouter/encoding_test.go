@@ -0,0 +1,125 @@
+package httpRouter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	testSeat struct {
+		Castle string `json:"castle"`
+	}
+	testHouse struct {
+		Name     string    `json:"name"`
+		Seat     *testSeat `json:"seat,omitempty"`
+		TVSeries []string  `json:"tv_series"`
+		Words    string    `json:"words,omitempty"`
+	}
+)
+
+func Test_Respond(t *testing.T) {
+	houses := []testHouse{
+		{Name: "Stark", Seat: &testSeat{Castle: "Winterfell"}, TVSeries: []string{"Season 1", "Season 2"}},
+		{Name: "Lannister, of the Rock", TVSeries: []string{}, Words: "Hear Me Roar!"},
+	}
+
+	cases := map[string]struct {
+		accept              string
+		data                any
+		statusCode          int
+		expectedCode        int
+		expectedContentType string
+		expectedData        string
+	}{
+		"Should return json without accept": {
+			data:                houses[:1],
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/json",
+			expectedData:        `[{"name":"Stark","seat":{"castle":"Winterfell"},"tv_series":["Season 1","Season 2"]}]`,
+		},
+		"Should return csv flattening the lists": {
+			accept:              "text/csv",
+			data:                houses,
+			expectedCode:        http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedData:        "name,seat.castle,tv_series,words\nStark,Winterfell,Season 1;Season 2,\n\"Lannister, of the Rock\",,,Hear Me Roar!\n",
+		},
+		"Should return csv of a single row": {
+			accept:              "text/csv",
+			data:                houses[1],
+			expectedCode:        http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedData:        "name,tv_series,words\n\"Lannister, of the Rock\",,Hear Me Roar!\n",
+		},
+		"Should return yaml": {
+			accept:              "application/yaml",
+			data:                houses[:1],
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/yaml",
+			expectedData:        "- name: Stark\n  seat:\n    castle: Winterfell\n  tv_series:\n    - Season 1\n    - Season 2\n",
+		},
+		"Should return msgpack": {
+			accept:              "application/msgpack",
+			data:                testSeat{Castle: "Winterfell"},
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/msgpack",
+			expectedData:        "\x81\xa6castle\xaaWinterfell",
+		},
+		"Should return the representation asked": {
+			accept:              "application/hal+json",
+			data:                houses[:1],
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/hal+json",
+			expectedData:        `{"_links":{"self":{"href":"/houses?a=1&b=2"}}}`,
+		},
+		"Should return without body": {
+			accept:       "application/xml",
+			statusCode:   http.StatusNoContent,
+			expectedCode: http.StatusNoContent,
+			// the default content type stays, there is no body to negotiate
+			expectedContentType: "application/json",
+		},
+		"Should return error not acceptable": {
+			accept:              "application/xml",
+			data:                houses,
+			expectedCode:        http.StatusNotAcceptable,
+			expectedContentType: "application/json",
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			statusCode := cs.statusCode
+			if statusCode == 0 {
+				statusCode = http.StatusOK
+			}
+
+			// ============ START ROUTER ============
+			router := NewGinRouter()
+			router.Get("/houses", func(c Context) {
+				c.Respond(statusCode, cs.data, Representation{
+					ContentType: "application/hal+json",
+					Data: func() any {
+						return map[string]any{"_links": map[string]any{"self": map[string]string{"href": "/houses?a=1&b=2"}}}
+					},
+				})
+			})
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodGet, "/houses", nil)
+			request.Header.Set("Accept", cs.accept)
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			assert.Equal(t, cs.expectedCode, writer.Code)
+			assert.Equal(t, cs.expectedContentType, writer.Header().Get("Content-Type"))
+			assert.Equal(t, cs.expectedData, writer.Body.String())
+		})
+	}
+}
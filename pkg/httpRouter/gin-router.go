@@ -1,9 +1,13 @@
 package httpRouter
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/tracer"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/validator"
@@ -18,25 +22,42 @@ import (
 type (
 	ginRouter struct {
 		router *gin.Engine
+		// group receives the routes and middlewares, the engine's root group
+		// unless the router was created by Group
+		group *gin.RouterGroup
+		// notAcceptable is shared by the groups of the router
+		notAcceptable *[]HandlerFunc
 	}
 )
 
 const (
-	ginTracerKey = "gin-tracer"
+	ginTracerKey        = "gin-tracer"
+	ginNotAcceptableKey = "gin-not-acceptable"
+
+	ProblemContentType = "application/problem+json"
 )
 
 func NewGinRouter() Router {
 	router := gin.Default()
+	router.SetTrustedProxies(nil)
+	notAcceptable := &[]HandlerFunc{}
 
 	router.Use(
-		// Set the content type default = application/json
-		setContentType("application/json"),
+		// Set the content type default = application/json, the handlers that
+		// negotiate another one replace it through Respond
+		setContentType(JSONContentType),
 		// Set middleware to tracer
 		tracing(),
+		func(ctx *gin.Context) {
+			ctx.Set(ginNotAcceptableKey, notAcceptable)
+			ctx.Next()
+		},
 	)
 
 	return &ginRouter{
-		router: router,
+		router:        router,
+		group:         &router.RouterGroup,
+		notAcceptable: notAcceptable,
 	}
 }
 
@@ -96,28 +117,56 @@ func tracing() func(ctx *gin.Context) {
 	}
 }
 
-func (r *ginRouter) Get(path string, f HandlerFunc) {
-	r.router.GET(path, func(ctx *gin.Context) {
-		f(newGinContext(ctx))
-	})
+func (r *ginRouter) Get(path string, f ...HandlerFunc) {
+	r.group.GET(path, ginHandlers(f)...)
+}
+
+func (r *ginRouter) Post(path string, f ...HandlerFunc) {
+	r.group.POST(path, ginHandlers(f)...)
+}
+
+func (r *ginRouter) Put(path string, f ...HandlerFunc) {
+	r.group.PUT(path, ginHandlers(f)...)
+}
+
+func (r *ginRouter) Delete(path string, f ...HandlerFunc) {
+	r.group.DELETE(path, ginHandlers(f)...)
+}
+
+func (r *ginRouter) Use(f ...HandlerFunc) {
+	if r.group == &r.router.RouterGroup {
+		// through the engine they also run for the requests without route
+		r.router.Use(ginHandlers(f)...)
+		return
+	}
+	r.group.Use(ginHandlers(f)...)
+}
+
+func (r *ginRouter) Group(prefix string, f ...HandlerFunc) Router {
+	return &ginRouter{
+		router:        r.router,
+		group:         r.group.Group(prefix, ginHandlers(f)...),
+		notAcceptable: r.notAcceptable,
+	}
 }
 
-func (r *ginRouter) Post(path string, f HandlerFunc) {
-	r.router.POST(path, func(ctx *gin.Context) {
-		f(newGinContext(ctx))
-	})
+func (r *ginRouter) NoRoute(f ...HandlerFunc) {
+	r.router.NoRoute(ginHandlers(f)...)
 }
 
-func (r *ginRouter) Put(path string, f HandlerFunc) {
-	r.router.PUT(path, func(ctx *gin.Context) {
-		f(newGinContext(ctx))
-	})
+func (r *ginRouter) NotAcceptable(f ...HandlerFunc) {
+	*r.notAcceptable = f
 }
 
-func (r *ginRouter) Delete(path string, f HandlerFunc) {
-	r.router.DELETE(path, func(ctx *gin.Context) {
-		f(newGinContext(ctx))
-	})
+func ginHandlers(handlers []HandlerFunc) []gin.HandlerFunc {
+	ginHandlers := make([]gin.HandlerFunc, len(handlers))
+	for i, f := range handlers {
+		f := f
+		ginHandlers[i] = func(ctx *gin.Context) {
+			f(newGinContext(ctx))
+		}
+	}
+	return ginHandlers
 }
 
 func (r *ginRouter) Server(port string) error {
@@ -128,6 +177,10 @@ func (r *ginRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	r.router.ServeHTTP(w, req)
 }
 
+func (r *ginRouter) TrustProxies(proxies []string) error {
+	return r.router.SetTrustedProxies(proxies)
+}
+
 func (m *ginRouter) ParseHandler(h http.HandlerFunc) HandlerFunc {
 	return func(c Context) {
 		h(c.GetResponseWriter(), c.GetRequestReader())
@@ -150,8 +203,103 @@ func (c *ginContext) Context() context.Context {
 	return c.r.Request.Context()
 }
 
-func (c *ginContext) JSON(statusCode int, data any) {
-	c.r.JSON(statusCode, data)
+func (c *ginContext) SetContext(ctx context.Context) {
+	c.r.Request = c.r.Request.WithContext(ctx)
+}
+
+func (c *ginContext) Respond(statusCode int, data any, representations ...Representation) {
+	if !bodyAllowed(statusCode) {
+		c.r.Status(statusCode)
+		return
+	}
+
+	offers := make([]string, 0, len(encoders)+len(representations))
+	for _, e := range encoders {
+		offers = append(offers, e.contentType)
+	}
+	for _, r := range representations {
+		offers = append(offers, r.ContentType)
+	}
+
+	// the response depends on the header, caches must keep one per value. Added
+	// to the other headers the response varies by, like the Origin of CORS
+	c.r.Writer.Header().Add("Vary", "Accept")
+	contentType := negotiate(c.r.GetHeader("Accept"), offers)
+	if contentType == "" {
+		c.notAcceptable()
+		return
+	}
+
+	for _, r := range representations {
+		if r.ContentType == contentType {
+			c.Render(statusCode, contentType, r.Data())
+			return
+		}
+	}
+
+	for _, e := range encoders {
+		if e.contentType != contentType {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := e.encode(&buf, data); err != nil {
+			c.r.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		if strings.HasPrefix(contentType, "text/") {
+			contentType += "; charset=utf-8"
+		}
+		c.Data(statusCode, contentType, buf.Bytes())
+		return
+	}
+}
+
+// bodyAllowed reports whether a response of statusCode carries a body.
+func bodyAllowed(statusCode int) bool {
+	switch {
+	case statusCode >= 100 && statusCode <= 199:
+		return false
+	case statusCode == http.StatusNoContent, statusCode == http.StatusNotModified:
+		return false
+	}
+	return true
+}
+
+func (c *ginContext) notAcceptable() {
+	handlers, _ := c.r.Value(ginNotAcceptableKey).(*[]HandlerFunc)
+	if handlers == nil || len(*handlers) == 0 {
+		c.r.AbortWithStatus(http.StatusNotAcceptable)
+		return
+	}
+
+	for _, f := range *handlers {
+		f(c)
+	}
+	c.r.Abort()
+}
+
+func (c *ginContext) Problem(statusCode int, data any) {
+	c.Render(statusCode, ProblemContentType, data)
+}
+
+func (c *ginContext) Render(statusCode int, contentType string, data any) {
+	// the links carry query strings, so & and friends are kept unescaped
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(data); err != nil {
+		c.r.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Data(statusCode, contentType, bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+}
+
+func (c *ginContext) Data(statusCode int, contentType string, body []byte) {
+	// gin keeps a content type already set, like the default one
+	c.r.Header("Content-Type", contentType)
+	c.r.Data(statusCode, contentType, body)
 }
 
 func (c *ginContext) Decode(data any) error {
@@ -180,3 +328,46 @@ func (c *ginContext) Validate(input any) error {
 	}
 	return nil
 }
+
+func (c *ginContext) GetHeader(key string) string {
+	return c.r.GetHeader(key)
+}
+
+func (c *ginContext) ClientIP() string {
+	return c.r.ClientIP()
+}
+
+func (c *ginContext) SetHeader(key, value string) {
+	c.r.Header(key, value)
+}
+
+func (c *ginContext) Next() {
+	c.r.Next()
+}
+
+func (c *ginContext) Abort() {
+	c.r.Abort()
+}
+
+func (c *ginContext) Status() int {
+	return c.r.Writer.Status()
+}
+
+func (c *ginContext) TeeResponse(w io.Writer) {
+	c.r.Writer = &teeWriter{ResponseWriter: c.r.Writer, tee: w}
+}
+
+type teeWriter struct {
+	gin.ResponseWriter
+	tee io.Writer
+}
+
+func (w *teeWriter) Write(b []byte) (int, error) {
+	w.tee.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *teeWriter) WriteString(s string) (int, error) {
+	w.tee.Write([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
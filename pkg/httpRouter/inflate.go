@@ -0,0 +1,72 @@
+package httpRouter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	// ErrBodyEncoding the request body has a Content-Encoding other than gzip.
+	ErrBodyEncoding = errors.New("the request body must be sent as is or with Content-Encoding gzip")
+	// ErrBodyCorrupt the request body doesn't inflate.
+	ErrBodyCorrupt = errors.New("the request body isn't valid gzip")
+	// ErrBodyTooLarge the request body inflates over the maximum size.
+	ErrBodyTooLarge = errors.New("the request body is too large once inflated")
+)
+
+// Inflater decompresses the request bodies sent with Content-Encoding gzip.
+// The body is inflated before the handlers run and only up to MaxSize bytes,
+// so a small body can't inflate into a bomb and the handlers see the plain
+// body, with its Content-Length.
+type Inflater struct {
+	MaxSize int64
+	// Deny answers the bodies it can't inflate with ErrBodyEncoding,
+	// ErrBodyCorrupt or ErrBodyTooLarge.
+	Deny func(c Context, err error)
+}
+
+// Handle is the middleware of the routes that take compressed bodies.
+func (i Inflater) Handle(c Context) {
+	req := c.GetRequestReader()
+
+	switch strings.ToLower(strings.TrimSpace(req.Header.Get("Content-Encoding"))) {
+	case "", "identity":
+		c.Next()
+		return
+	case EncodingGzip:
+	default:
+		i.deny(c, ErrBodyEncoding)
+		return
+	}
+
+	reader, err := gzip.NewReader(req.Body)
+	if err != nil {
+		i.deny(c, ErrBodyCorrupt)
+		return
+	}
+	defer reader.Close()
+
+	body, err := io.ReadAll(io.LimitReader(reader, i.MaxSize+1))
+	if err != nil {
+		i.deny(c, ErrBodyCorrupt)
+		return
+	}
+	if int64(len(body)) > i.MaxSize {
+		i.deny(c, ErrBodyTooLarge)
+		return
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.Header.Del("Content-Encoding")
+	req.Header.Del("Content-Length")
+	c.Next()
+}
+
+func (i Inflater) deny(c Context, err error) {
+	i.Deny(c, err)
+	c.Abort()
+}
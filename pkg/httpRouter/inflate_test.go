@@ -0,0 +1,107 @@
+package httpRouter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func gzipped(s string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte(s))
+	w.Close()
+	return buf.Bytes()
+}
+
+func Test_Inflater(t *testing.T) {
+	bomb := gzipped(strings.Repeat("0", 1<<20))
+
+	cases := map[string]struct {
+		encoding     string
+		body         []byte
+		expectedCode int
+		expectedErr  error
+		expectedBody string
+	}{
+		"Should inflate a gzip body": {
+			encoding:     "gzip",
+			body:         gzipped(`[{"name":"House Stark"}]`),
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"name":"House Stark"}]`,
+		},
+		"Should let a plain body through": {
+			body:         []byte(`[{"name":"House Stark"}]`),
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"name":"House Stark"}]`,
+		},
+		"Should reject a body inflating over the maximum size": {
+			encoding:     "gzip",
+			body:         bomb,
+			expectedCode: http.StatusRequestEntityTooLarge,
+			expectedErr:  ErrBodyTooLarge,
+		},
+		"Should reject a corrupt body": {
+			encoding:     "gzip",
+			body:         []byte(`[{"name":"House Stark"}]`),
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  ErrBodyCorrupt,
+		},
+		"Should reject another encoding": {
+			encoding:     "br",
+			body:         []byte(`[{"name":"House Stark"}]`),
+			expectedCode: http.StatusUnsupportedMediaType,
+			expectedErr:  ErrBodyEncoding,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			inflater := Inflater{
+				MaxSize: 64 << 10,
+				Deny: func(c Context, err error) {
+					assert.ErrorIs(t, err, cs.expectedErr)
+					switch err {
+					case ErrBodyTooLarge:
+						c.Respond(http.StatusRequestEntityTooLarge, nil)
+					case ErrBodyEncoding:
+						c.Respond(http.StatusUnsupportedMediaType, nil)
+					default:
+						c.Respond(http.StatusBadRequest, nil)
+					}
+				},
+			}
+
+			// ============ START ROUTER ============
+			var body []byte
+			router := NewGinRouter()
+			router.Post("/houses/bulk", inflater.Handle, func(c Context) {
+				req := c.GetRequestReader()
+				assert.Empty(t, req.Header.Get("Content-Encoding"))
+				body, _ = io.ReadAll(req.Body)
+				assert.Equal(t, int64(len(body)), req.ContentLength)
+				c.Respond(http.StatusOK, nil)
+			})
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodPost, "/houses/bulk", bytes.NewReader(cs.body))
+			if len(cs.encoding) > 0 {
+				request.Header.Set("Content-Encoding", cs.encoding)
+			}
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			assert.Equal(t, cs.expectedCode, writer.Code)
+			assert.Equal(t, cs.expectedBody, string(body))
+		})
+	}
+}
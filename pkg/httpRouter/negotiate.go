@@ -0,0 +1,133 @@
+package httpRouter
+
+import (
+	"strconv"
+	"strings"
+)
+
+// negotiate returns the offer the Accept header prefers, ties go to the
+// first offer. Each offer takes the quality of the most specific media range
+// matching it, an empty header accepts anything. It returns an empty string
+// when no offer is acceptable.
+func negotiate(accept string, offers []string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	ranges := parseAccept(accept)
+
+	best, bestQuality := "", 0.0
+	for _, offer := range offers {
+		quality, specificity := 0.0, -1
+		for _, r := range ranges {
+			if s := r.matches(offer); s > specificity {
+				quality, specificity = r.quality, s
+			}
+		}
+		if quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+
+	return best
+}
+
+type mediaRange struct {
+	mediaType string
+	subType   string
+	quality   float64
+}
+
+func parseAccept(accept string) []mediaRange {
+	parts := strings.Split(accept, ",")
+	ranges := make([]mediaRange, 0, len(parts))
+
+	for _, part := range parts {
+		fields := strings.Split(part, ";")
+		mediaType, subType, _ := strings.Cut(strings.ToLower(strings.TrimSpace(fields[0])), "/")
+		if mediaType == "" {
+			continue
+		}
+
+		r := mediaRange{mediaType: mediaType, subType: subType, quality: 1}
+		for _, param := range fields[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if key != "q" {
+				continue
+			}
+			if quality, err := strconv.ParseFloat(value, 64); err == nil {
+				r.quality = quality
+			}
+		}
+		ranges = append(ranges, r)
+	}
+
+	return ranges
+}
+
+// matches returns how specific the range is when it covers contentType, -1
+// when it doesn't.
+func (r mediaRange) matches(contentType string) int {
+	mediaType, subType, _ := strings.Cut(contentType, "/")
+
+	switch {
+	case r.mediaType == "*" && r.subType == "*":
+		return 0
+	case r.mediaType != mediaType:
+		return -1
+	case r.subType == "*":
+		return 1
+	case r.subType == subType:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// negotiateEncoding returns the offer the Accept-Encoding header prefers, ties
+// go to the first offer. * covers the offers the header doesn't name. It
+// returns an empty string when no offer is acceptable, the response goes
+// uncompressed.
+func negotiateEncoding(acceptEncoding string, offers []string) string {
+	named, others := map[string]float64{}, -1.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(fields[0]))
+		if coding == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if key != "q" {
+				continue
+			}
+			if q, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = q
+			}
+		}
+
+		if coding == "*" {
+			others = quality
+			continue
+		}
+		named[coding] = quality
+	}
+
+	best, bestQuality := "", 0.0
+	for _, offer := range offers {
+		quality, ok := named[offer]
+		if !ok {
+			quality = others
+		}
+		if quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,85 @@
+package httpRouter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_negotiate(t *testing.T) {
+	offers := []string{"application/json", "application/hal+json", "application/ld+json"}
+	cases := map[string]struct {
+		accept   string
+		expected string
+	}{
+		"Should return the first offer without accept": {
+			accept:   "",
+			expected: "application/json",
+		},
+		"Should return the first offer for any type": {
+			accept:   "*/*",
+			expected: "application/json",
+		},
+		"Should return the offer asked": {
+			accept:   "application/hal+json",
+			expected: "application/hal+json",
+		},
+		"Should return the offer of higher quality": {
+			accept:   "application/json;q=0.5, application/ld+json",
+			expected: "application/ld+json",
+		},
+		"Should prefer the most specific range": {
+			accept:   "application/*;q=0.2, application/json;q=0.1, application/hal+json;q=0.3",
+			expected: "application/hal+json",
+		},
+		"Should return empty when nothing is acceptable": {
+			accept:   "text/html, application/json;q=0",
+			expected: "",
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, cs.expected, negotiate(cs.accept, offers))
+		})
+	}
+}
+
+func Test_negotiateEncoding(t *testing.T) {
+	offers := []string{EncodingZstd, EncodingGzip}
+	cases := map[string]struct {
+		acceptEncoding string
+		expected       string
+	}{
+		"Should not compress without accept encoding": {
+			acceptEncoding: "",
+			expected:       "",
+		},
+		"Should return the coding asked": {
+			acceptEncoding: "gzip, deflate, br",
+			expected:       EncodingGzip,
+		},
+		"Should return the first offer on ties": {
+			acceptEncoding: "gzip, zstd",
+			expected:       EncodingZstd,
+		},
+		"Should return the coding of higher quality": {
+			acceptEncoding: "zstd;q=0.5, gzip",
+			expected:       EncodingGzip,
+		},
+		"Should cover the offers not named by any": {
+			acceptEncoding: "zstd;q=0, *",
+			expected:       EncodingGzip,
+		},
+		"Should return empty when nothing is acceptable": {
+			acceptEncoding: "identity, gzip;q=0",
+			expected:       "",
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, cs.expected, negotiateEncoding(cs.acceptEncoding, offers))
+		})
+	}
+}
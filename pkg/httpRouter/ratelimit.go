@@ -0,0 +1,196 @@
+package httpRouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited the client took all the tokens of its bucket.
+var ErrRateLimited = errors.New("the client sent too many requests, retry later")
+
+var timeNow = time.Now
+
+// sweepInterval is how often the memory store forgets the full buckets.
+const sweepInterval = time.Minute
+
+type (
+	// Limit is a token bucket: it holds Burst tokens, Requests of them
+	// refilled every Period, and a request takes one. A zero Burst holds
+	// Requests tokens, a zero Requests or Period doesn't limit.
+	Limit struct {
+		Requests int           `mapstructure:"requests"`
+		Period   time.Duration `mapstructure:"period"`
+		Burst    int           `mapstructure:"burst"`
+	}
+
+	// LimitStore keeps the buckets of the clients.
+	LimitStore interface {
+		// Take takes a token of the bucket of key, refilled by limit since
+		// the last take. It returns the tokens left and whether there was
+		// one to take.
+		Take(ctx context.Context, key string, limit Limit) (tokens float64, allowed bool, err error)
+	}
+
+	// RateLimiter limits the requests of each client, by its principal or,
+	// without one, its IP.
+	RateLimiter struct {
+		Store LimitStore
+		// Deny answers a request over the limit with ErrRateLimited.
+		Deny func(c Context, err error)
+	}
+
+	// MemoryStore keeps the buckets in the memory of the instance, each
+	// instance limits on its own.
+	MemoryStore struct {
+		mu        sync.Mutex
+		buckets   map[string]*bucket
+		lastSweep time.Time
+	}
+
+	bucket struct {
+		tokens  float64
+		updated time.Time
+		limit   Limit
+	}
+)
+
+// Enabled reports whether the limit limits anything.
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// Rate is the tokens refilled by second.
+func (l Limit) Rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Capacity is the tokens a full bucket holds.
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Requests
+}
+
+// refill returns the tokens of a bucket with tokens after elapsed.
+func (l Limit) refill(tokens float64, elapsed time.Duration) float64 {
+	return math.Min(float64(l.Capacity()), tokens+elapsed.Seconds()*l.Rate())
+}
+
+// until is the time the bucket takes to hold want tokens from tokens.
+func (l Limit) until(tokens, want float64) time.Duration {
+	if tokens >= want {
+		return 0
+	}
+	return time.Duration((want - tokens) / l.Rate() * float64(time.Second))
+}
+
+// Limit is the middleware that limits the requests of each client to the
+// routes of group. The responses carry the RateLimit-* headers and the denied
+// ones Retry-After. When the store fails the request goes through, the limit
+// protects the API and shouldn't take it down.
+func (r RateLimiter) Limit(group string, limit Limit) HandlerFunc {
+	return r.limit(group, limit, ClientKey)
+}
+
+// LimitIP is the middleware that limits the requests of each IP, whatever
+// their principal. It runs before the authentication, so the requests with
+// invalid credentials are limited too.
+func (r RateLimiter) LimitIP(group string, limit Limit) HandlerFunc {
+	return r.limit(group, limit, func(c Context) string {
+		return "ip:" + c.ClientIP()
+	})
+}
+
+func (r RateLimiter) limit(group string, limit Limit, clientKey func(c Context) string) HandlerFunc {
+	if !limit.Enabled() {
+		return func(c Context) {
+			c.Next()
+		}
+	}
+
+	policy := fmt.Sprintf("%d;w=%d", limit.Requests, int64(math.Ceil(limit.Period.Seconds())))
+	if limit.Capacity() != limit.Requests {
+		policy += fmt.Sprintf(";burst=%d", limit.Capacity())
+	}
+
+	return func(c Context) {
+		tokens, allowed, err := r.Store.Take(c.Context(), group+":"+clientKey(c), limit)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		c.SetHeader("RateLimit-Policy", policy)
+		c.SetHeader("RateLimit-Limit", strconv.Itoa(limit.Capacity()))
+		c.SetHeader("RateLimit-Remaining", strconv.Itoa(int(math.Max(0, math.Floor(tokens)))))
+		c.SetHeader("RateLimit-Reset", seconds(limit.until(tokens, float64(limit.Capacity()))))
+
+		if !allowed {
+			c.SetHeader("Retry-After", seconds(limit.until(tokens, 1)))
+			r.Deny(c, ErrRateLimited)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// ClientKey identifies the client of the request for the rate limits: its
+// principal once authenticated, otherwise its IP.
+func ClientKey(c Context) string {
+	if principal, ok := PrincipalFromContext(c.Context()); ok && len(principal.ID) > 0 {
+		return "principal:" + principal.ID
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (tokens float64, allowed bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := timeNow()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Capacity()), updated: now}
+		s.buckets[key] = b
+	}
+	b.tokens = limit.refill(b.tokens, now.Sub(b.updated))
+	b.updated = now
+	b.limit = limit
+
+	if b.tokens < 1 {
+		return b.tokens, false, nil
+	}
+	b.tokens--
+	return b.tokens, true, nil
+}
+
+// sweep forgets the buckets refilled by now, a missing bucket is a full one.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if b.limit.refill(b.tokens, now.Sub(b.updated)) >= float64(b.limit.Capacity()) {
+			delete(s.buckets, key)
+		}
+	}
+}
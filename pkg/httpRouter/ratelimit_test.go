@@ -0,0 +1,178 @@
+package httpRouter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit Limit) (float64, bool, error) {
+	return 0, false, errors.New("problem to take token")
+}
+
+func Test_RateLimiter(t *testing.T) {
+	now := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	type request struct {
+		remoteAddr    string
+		forwardedFor  string
+		principal     string
+		after         time.Duration
+		expectedCode  int
+		expectedLeft  string
+		expectedReset string
+		expectedRetry string
+	}
+
+	cases := map[string]struct {
+		limit          Limit
+		byIP           bool
+		store          LimitStore
+		requests       []request
+		expectedPolicy string
+	}{
+		"Should limit the client once the burst is taken": {
+			limit:          Limit{Requests: 1, Period: time.Second, Burst: 2},
+			expectedPolicy: "1;w=1;burst=2",
+			requests: []request{
+				{expectedCode: http.StatusOK, expectedLeft: "1", expectedReset: "1"},
+				{expectedCode: http.StatusOK, expectedLeft: "0", expectedReset: "2"},
+				{expectedCode: http.StatusTooManyRequests, expectedLeft: "0", expectedReset: "2", expectedRetry: "1"},
+				{after: 500 * time.Millisecond, expectedCode: http.StatusTooManyRequests, expectedLeft: "0", expectedReset: "2", expectedRetry: "1"},
+				{after: 500 * time.Millisecond, expectedCode: http.StatusOK, expectedLeft: "0", expectedReset: "2"},
+			},
+		},
+		"Should keep a bucket by client": {
+			limit:          Limit{Requests: 1, Period: time.Minute},
+			expectedPolicy: "1;w=60",
+			requests: []request{
+				{remoteAddr: "10.0.0.1:1234", expectedCode: http.StatusOK, expectedLeft: "0", expectedReset: "60"},
+				{remoteAddr: "10.0.0.2:1234", expectedCode: http.StatusOK, expectedLeft: "0", expectedReset: "60"},
+				{remoteAddr: "10.0.0.1:4321", expectedCode: http.StatusTooManyRequests, expectedLeft: "0", expectedReset: "60", expectedRetry: "60"},
+				{remoteAddr: "10.0.0.1:4321", principal: "key_1", expectedCode: http.StatusOK, expectedLeft: "0", expectedReset: "60"},
+			},
+		},
+		"Should limit the IP whatever its principal": {
+			limit:          Limit{Requests: 1, Period: time.Minute},
+			byIP:           true,
+			expectedPolicy: "1;w=60",
+			requests: []request{
+				{principal: "key_1", expectedCode: http.StatusOK, expectedLeft: "0", expectedReset: "60"},
+				{principal: "key_2", expectedCode: http.StatusTooManyRequests, expectedLeft: "0", expectedReset: "60", expectedRetry: "60"},
+			},
+		},
+		"Should ignore the X-Forwarded-For of untrusted proxies": {
+			limit:          Limit{Requests: 1, Period: time.Minute},
+			expectedPolicy: "1;w=60",
+			requests: []request{
+				{forwardedFor: "10.0.0.1", expectedCode: http.StatusOK, expectedLeft: "0", expectedReset: "60"},
+				{forwardedFor: "10.0.0.2", expectedCode: http.StatusTooManyRequests, expectedLeft: "0", expectedReset: "60", expectedRetry: "60"},
+			},
+		},
+		"Should let the requests through when the store fails": {
+			limit: Limit{Requests: 1, Period: time.Minute},
+			store: failingStore{},
+			requests: []request{
+				{expectedCode: http.StatusOK},
+				{expectedCode: http.StatusOK},
+			},
+		},
+		"Should not limit without a limit": {
+			requests: []request{
+				{expectedCode: http.StatusOK},
+				{expectedCode: http.StatusOK},
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			if cs.store == nil {
+				cs.store = NewMemoryStore()
+			}
+			limiter := RateLimiter{
+				Store: cs.store,
+				Deny: func(c Context, err error) {
+					assert.ErrorIs(t, err, ErrRateLimited)
+					c.Respond(http.StatusTooManyRequests, nil)
+				},
+			}
+
+			limit := limiter.Limit("houses", cs.limit)
+			if cs.byIP {
+				limit = limiter.LimitIP("ip", cs.limit)
+			}
+
+			// ============ START ROUTER ============
+			router := NewGinRouter()
+			group := router.Group("", func(c Context) {
+				if principal := c.GetHeader("X-Principal"); len(principal) > 0 {
+					c.SetContext(ContextWithPrincipal(c.Context(), Principal{ID: principal}))
+				}
+			}, limit)
+			group.Get("/houses", func(c Context) {
+				c.Respond(http.StatusOK, nil)
+			})
+
+			for i, r := range cs.requests {
+				now = now.Add(r.after)
+
+				// ============ START MOCK REQUEST ============
+				request := httptest.NewRequest(http.MethodGet, "/houses", nil)
+				if len(r.remoteAddr) > 0 {
+					request.RemoteAddr = r.remoteAddr
+				}
+				if len(r.forwardedFor) > 0 {
+					request.Header.Set("X-Forwarded-For", r.forwardedFor)
+				}
+				if len(r.principal) > 0 {
+					request.Header.Set("X-Principal", r.principal)
+				}
+				writer := httptest.NewRecorder()
+
+				// ============ START SERVER HTTP ============
+				router.ServeHTTP(writer, request)
+
+				// ============ START VALIDATION ============
+				assert.Equal(t, r.expectedCode, writer.Code, i)
+				assert.Equal(t, r.expectedLeft, writer.Header().Get("RateLimit-Remaining"), i)
+				assert.Equal(t, r.expectedReset, writer.Header().Get("RateLimit-Reset"), i)
+				assert.Equal(t, r.expectedRetry, writer.Header().Get("Retry-After"), i)
+				if len(r.expectedLeft) > 0 {
+					assert.Equal(t, cs.expectedPolicy, writer.Header().Get("RateLimit-Policy"), i)
+				}
+			}
+		})
+	}
+}
+
+func Test_MemoryStoreSweep(t *testing.T) {
+	now := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	store := NewMemoryStore()
+	limit := Limit{Requests: 1, Period: time.Minute}
+
+	store.Take(context.Background(), "a", limit)
+	now = now.Add(30 * time.Second)
+	store.Take(context.Background(), "b", limit)
+	assert.Len(t, store.buckets, 2)
+
+	// a refilled its token, b not yet
+	now = now.Add(35 * time.Second)
+	store.Take(context.Background(), "c", limit)
+	assert.Len(t, store.buckets, 2)
+	assert.NotContains(t, store.buckets, "a")
+	assert.Contains(t, store.buckets, "b")
+	assert.Contains(t, store.buckets, "c")
+}
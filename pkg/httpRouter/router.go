@@ -2,6 +2,7 @@ package httpRouter
 
 import (
 	"context"
+	"io"
 	"net/http"
 )
 
@@ -9,23 +10,63 @@ type (
 	Router interface {
 		Server(port string) error
 		ServeHTTP(w http.ResponseWriter, req *http.Request)
-		Get(path string, f HandlerFunc)
-		Post(path string, f HandlerFunc)
-		Put(path string, f HandlerFunc)
-		Delete(paht string, f HandlerFunc)
+		// the handlers run in order, the ones before the last act as middlewares
+		Get(path string, f ...HandlerFunc)
+		Post(path string, f ...HandlerFunc)
+		Put(path string, f ...HandlerFunc)
+		Delete(paht string, f ...HandlerFunc)
+		// Use registers middlewares for the routes added after it
+		Use(f ...HandlerFunc)
+		// Group returns a router whose routes are registered under prefix and run
+		// f before their own handlers
+		Group(prefix string, f ...HandlerFunc) Router
+		// NoRoute registers the handlers of requests that match no route
+		NoRoute(f ...HandlerFunc)
+		// NotAcceptable registers the handlers of the responses whose formats
+		// the Accept header rejects
+		NotAcceptable(f ...HandlerFunc)
 		ParseHandler(h http.HandlerFunc) HandlerFunc
+		// TrustProxies lists the proxies, IPs or CIDRs, whose X-Forwarded-For
+		// and X-Real-IP headers ClientIP believes. None are trusted by default.
+		TrustProxies(proxies []string) error
+		// Compress registers the compression of the responses of the routes
+		// added after it, it fails on an unknown encoding
+		Compress(opts Compression) error
 	}
 
 	HandlerFunc func(ctx Context)
 
 	Context interface {
 		Context() context.Context
-		JSON(statusCode int, data any)
+		// SetContext replaces the context of the request for the next handlers
+		SetContext(ctx context.Context)
+		// Respond writes data in the format the Accept header prefers among
+		// JSON, CSV, YAML, MessagePack and the representations. When it accepts
+		// none of them the NotAcceptable handlers answer instead.
+		Respond(statusCode int, data any, representations ...Representation)
+		// Problem writes data as an RFC 7807 problem, used for error responses
+		Problem(statusCode int, data any)
+		// Render writes data encoded as JSON under contentType, for the media
+		// types based on JSON
+		Render(statusCode int, contentType string, data any)
+		// Data writes body as is, e.g. a response stored before
+		Data(statusCode int, contentType string, body []byte)
 		Decode(data any) error
 		GetResponseWriter() http.ResponseWriter
 		GetRequestReader() *http.Request
 		GetQuery(param string) string
 		GetParam(param string) string
 		Validate(input any) error
+		GetHeader(key string) string
+		// ClientIP returns the IP of the client, through the trusted proxies
+		ClientIP() string
+		SetHeader(key, value string)
+		// Next runs the remaining handlers of the route, Abort prevents them from running
+		Next()
+		Abort()
+		// Status returns the status code written to the response
+		Status() int
+		// TeeResponse copies the response body written from now on into w
+		TeeResponse(w io.Writer)
 	}
 )
@@ -0,0 +1,52 @@
+package httpRouter
+
+import (
+	"strconv"
+	"time"
+)
+
+// SecurityHeaders are the headers that harden the responses in the browsers.
+// X-Content-Type-Options: nosniff is always sent, the zero value of the other
+// fields doesn't send their header.
+type SecurityHeaders struct {
+	// HSTS is the max-age of Strict-Transport-Security, only meant for the
+	// environments served over https.
+	HSTS                  time.Duration `mapstructure:"hsts"`
+	HSTSIncludeSubdomains bool          `mapstructure:"hsts_include_subdomains"`
+	// FrameOptions is DENY or SAMEORIGIN.
+	FrameOptions string `mapstructure:"frame_options"`
+	// ContentSecurityPolicy is the policy of the API responses, the pages
+	// like the swagger UI set their own with ContentSecurityPolicy.
+	ContentSecurityPolicy string `mapstructure:"content_security_policy"`
+}
+
+// Secure returns the middleware that sets the headers of s.
+func Secure(s SecurityHeaders) HandlerFunc {
+	hsts := "max-age=" + strconv.FormatInt(int64(s.HSTS.Seconds()), 10)
+	if s.HSTSIncludeSubdomains {
+		hsts += "; includeSubDomains"
+	}
+
+	return func(c Context) {
+		c.SetHeader("X-Content-Type-Options", "nosniff")
+		if s.HSTS > 0 {
+			c.SetHeader("Strict-Transport-Security", hsts)
+		}
+		if len(s.FrameOptions) > 0 {
+			c.SetHeader("X-Frame-Options", s.FrameOptions)
+		}
+		if len(s.ContentSecurityPolicy) > 0 {
+			c.SetHeader("Content-Security-Policy", s.ContentSecurityPolicy)
+		}
+	}
+}
+
+// ContentSecurityPolicy returns the middleware of a route that replaces the
+// policy of Secure, an empty policy keeps it.
+func ContentSecurityPolicy(policy string) HandlerFunc {
+	return func(c Context) {
+		if len(policy) > 0 {
+			c.SetHeader("Content-Security-Policy", policy)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package httpRouter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Secure(t *testing.T) {
+	cases := map[string]struct {
+		security       SecurityHeaders
+		path           string
+		expectedHeader http.Header
+	}{
+		"Should harden the API responses": {
+			security: SecurityHeaders{
+				HSTS:                  365 * 24 * time.Hour,
+				HSTSIncludeSubdomains: true,
+				FrameOptions:          "DENY",
+				ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+			},
+			path: "/houses",
+			expectedHeader: http.Header{
+				"X-Content-Type-Options":    {"nosniff"},
+				"Strict-Transport-Security": {"max-age=31536000; includeSubDomains"},
+				"X-Frame-Options":           {"DENY"},
+				"Content-Security-Policy":   {"default-src 'none'; frame-ancestors 'none'"},
+			},
+		},
+		"Should set the policy of the page": {
+			security: SecurityHeaders{ContentSecurityPolicy: "default-src 'none'"},
+			path:     "/swagger/index.html",
+			expectedHeader: http.Header{
+				"X-Content-Type-Options":  {"nosniff"},
+				"Content-Security-Policy": {"default-src 'self'"},
+			},
+		},
+		"Should only send nosniff without configuration": {
+			path: "/houses",
+			expectedHeader: http.Header{
+				"X-Content-Type-Options": {"nosniff"},
+			},
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ============ START ROUTER ============
+			router := NewGinRouter()
+			router.Use(Secure(cs.security))
+			router.Get("/houses", func(c Context) {
+				c.Respond(http.StatusOK, nil)
+			})
+			router.Get("/swagger/*any", ContentSecurityPolicy("default-src 'self'"), func(c Context) {
+				c.Respond(http.StatusOK, nil)
+			})
+
+			// ============ START MOCK REQUEST ============
+			request := httptest.NewRequest(http.MethodGet, cs.path, nil)
+			writer := httptest.NewRecorder()
+
+			// ============ START SERVER HTTP ============
+			router.ServeHTTP(writer, request)
+
+			// ============ START VALIDATION ============
+			assert.Equal(t, http.StatusOK, writer.Code)
+			for _, key := range []string{"X-Content-Type-Options", "Strict-Transport-Security", "X-Frame-Options", "Content-Security-Policy"} {
+				assert.Equal(t, cs.expectedHeader.Values(key), writer.Header().Values(key), key)
+			}
+		})
+	}
+}
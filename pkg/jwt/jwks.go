@@ -0,0 +1,269 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultRefresh = time.Hour
+	// minRefetch keeps the tokens of unknown keys from hammering the URL.
+	minRefetch  = 30 * time.Second
+	maxJWKSSize = 1 << 20
+)
+
+type (
+	key struct {
+		alg    string
+		public crypto.PublicKey
+	}
+
+	// keySet holds the keys of the JWKS, by kid.
+	keySet struct {
+		url     string
+		client  *http.Client
+		refresh time.Duration
+		// fetches shares a fetch of the URL between the tokens waiting for it
+		fetches singleflight.Group
+
+		// mu guards the keys only, it isn't held during the fetches
+		mu        sync.RWMutex
+		keys      map[string]key
+		fetchedAt time.Time
+	}
+
+	jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	jwk struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		Alg string `json:"alg"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		Crv string `json:"crv"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
+	}
+)
+
+func newKeySet(opts Options) (*keySet, error) {
+	set := &keySet{url: opts.JWKSURL, client: opts.Client, refresh: opts.Refresh}
+	if set.client == nil {
+		set.client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if set.refresh <= 0 {
+		set.refresh = defaultRefresh
+	}
+
+	switch {
+	case len(opts.JWKSFile) > 0:
+		data, err := os.ReadFile(opts.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: read jwks: %w", err)
+		}
+		if set.keys, err = parseJWKS(data); err != nil {
+			return nil, err
+		}
+		// the keys of a file never expire
+		set.url = ""
+	case len(opts.JWKSURL) == 0:
+		return nil, errors.New("jwt: a jwks file or url is required")
+	}
+	return set, nil
+}
+
+// find returns the key of kid, fetching the JWKS of the URL when it is
+// missing or stale. An empty kid matches the only key of the set.
+func (s *keySet) find(ctx context.Context, kid string) (key, error) {
+	k, ok, stale := s.lookup(kid)
+	if stale {
+		err := s.fetch(ctx)
+		if k, ok, _ = s.lookup(kid); !ok && err != nil && s.empty() {
+			return key{}, err
+		}
+	}
+
+	if !ok {
+		return key{}, fmt.Errorf("%w: unknown key %q", ErrInvalidToken, kid)
+	}
+	return k, nil
+}
+
+// lookup returns the key of kid and whether the JWKS of the URL should be
+// fetched again.
+func (s *keySet) lookup(kid string) (k key, ok, stale bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	k, ok = s.get(kid)
+	if len(s.url) > 0 {
+		age := timeNow().Sub(s.fetchedAt)
+		stale = s.keys == nil || age > s.refresh || (!ok && age > minRefetch)
+	}
+	return k, ok, stale
+}
+
+func (s *keySet) get(kid string) (key, bool) {
+	if len(kid) == 0 && len(s.keys) == 1 {
+		for _, k := range s.keys {
+			return k, true
+		}
+	}
+	k, ok := s.keys[kid]
+	return k, ok
+}
+
+func (s *keySet) empty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keys == nil
+}
+
+// fetch replaces the keys by the ones of the URL, the tokens that need them
+// at the same time wait for a single request, made with the context of the
+// first one. A failed fetch keeps the keys it had, the tokens of the known
+// keys go on being verified.
+func (s *keySet) fetch(ctx context.Context) error {
+	_, err, _ := s.fetches.Do(s.url, func() (any, error) {
+		keys, err := s.download(ctx)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.fetchedAt = timeNow()
+		if err != nil {
+			return nil, err
+		}
+		s.keys = keys
+		return nil, nil
+	})
+	return err
+}
+
+func (s *keySet) download(ctx context.Context) (map[string]key, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: fetch jwks: %w", err)
+	}
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: fetch jwks: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwt: fetch jwks: status %d", response.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxJWKSSize))
+	if err != nil {
+		return nil, fmt.Errorf("jwt: fetch jwks: %w", err)
+	}
+
+	return parseJWKS(data)
+}
+
+// parseJWKS returns the signing keys of the JWKS, the RSA and EC ones. The
+// keys of other types or uses are skipped.
+func parseJWKS(data []byte) (map[string]key, error) {
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("jwt: parse jwks: %w", err)
+	}
+
+	keys := make(map[string]key, len(set.Keys))
+	for _, k := range set.Keys {
+		if len(k.Use) > 0 && k.Use != "sig" {
+			continue
+		}
+
+		var (
+			public crypto.PublicKey
+			err    error
+		)
+		switch k.Kty {
+		case "RSA":
+			public, err = k.rsa()
+		case "EC":
+			public, err = k.ecdsa()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("jwt: parse jwks: key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key{alg: k.Alg, public: public}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("jwt: parse jwks: no signing keys")
+	}
+	return keys, nil
+}
+
+func (k jwk) rsa() (*rsa.PublicKey, error) {
+	n, err := decodeInt(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeInt(k.E)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+		return nil, errors.New("bad exponent")
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func (k jwk) ecdsa() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+
+	x, err := decodeInt(k.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeInt(k.Y)
+	if err != nil {
+		return nil, err
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, errors.New("point is not on the curve")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func decodeInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("bad base64url number")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
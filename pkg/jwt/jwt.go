@@ -0,0 +1,183 @@
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	// ErrInvalidToken every token the verifier rejects wraps it.
+	ErrInvalidToken = errors.New("jwt: invalid token")
+	ErrTokenExpired = fmt.Errorf("%w: token is expired", ErrInvalidToken)
+)
+
+var timeNow = time.Now
+
+type (
+	// Verifier checks the bearer tokens issued by the platform.
+	Verifier interface {
+		// Verify checks the signature of token against the JWKS and its
+		// issuer, audience and validity, returning its claims. The errors of
+		// a rejected token wrap ErrInvalidToken.
+		Verify(ctx context.Context, token string) (claims Claims, err error)
+	}
+
+	// Options of the verifier. One of JWKSFile or JWKSURL is needed, the file
+	// is read once and the URL fetched again after Refresh or when a token
+	// is signed by an unknown key.
+	Options struct {
+		JWKSFile string
+		JWKSURL  string
+		Issuer   string
+		Audience string
+		// Leeway tolerates the clock skew on exp and nbf.
+		Leeway  time.Duration
+		Refresh time.Duration
+		Client  *http.Client
+	}
+
+	// Claims of a verified token.
+	Claims struct {
+		Subject   string
+		Issuer    string
+		Audience  []string
+		ExpiresAt time.Time
+		// Raw holds all the claims of the token, the numbers as json.Number.
+		Raw map[string]any
+	}
+
+	verifier struct {
+		keys   *keySet
+		parser *gojwt.Parser
+	}
+)
+
+// algorithms the tokens may be signed with. HMAC and none are left out on
+// purpose, the platform signs with asymmetric keys only.
+var algorithms = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+}
+
+// New returns the verifier of opts, it fails when the options are incomplete
+// or the JWKS file can't be read.
+func New(opts Options) (Verifier, error) {
+	if len(opts.Issuer) == 0 || len(opts.Audience) == 0 {
+		return nil, errors.New("jwt: issuer and audience are required")
+	}
+
+	keys, err := newKeySet(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &verifier{
+		keys: keys,
+		parser: gojwt.NewParser(
+			gojwt.WithValidMethods(algorithms),
+			gojwt.WithIssuer(opts.Issuer),
+			gojwt.WithAudience(opts.Audience),
+			gojwt.WithExpirationRequired(),
+			gojwt.WithLeeway(opts.Leeway),
+			gojwt.WithTimeFunc(func() time.Time { return timeNow() }),
+			gojwt.WithJSONNumber(),
+		),
+	}, nil
+}
+
+func (v *verifier) Verify(ctx context.Context, token string) (claims Claims, err error) {
+	// the errors of the JWKS fetch aren't the token's fault, they are
+	// returned as they are
+	var fetchErr error
+	keyFunc := func(token *gojwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := v.keys.find(ctx, kid)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidToken) {
+				fetchErr = err
+			}
+			return nil, err
+		}
+		if len(key.alg) > 0 && key.alg != token.Method.Alg() {
+			return nil, fmt.Errorf("%w: key %q is not for %s", ErrInvalidToken, kid, token.Method.Alg())
+		}
+		return key.public, nil
+	}
+
+	raw := gojwt.MapClaims{}
+	_, err = v.parser.ParseWithClaims(token, raw, keyFunc)
+	switch {
+	case fetchErr != nil:
+		return claims, fetchErr
+	case errors.Is(err, gojwt.ErrTokenExpired):
+		return claims, ErrTokenExpired
+	case err != nil:
+		return claims, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	claims.Raw = raw
+	return claims, claims.parse()
+}
+
+func (c *Claims) parse() error {
+	c.Subject, _ = c.Raw["sub"].(string)
+	c.Issuer, _ = c.Raw["iss"].(string)
+	c.Audience = c.Strings("aud")
+
+	if exp, ok := c.Raw["exp"]; ok {
+		expiresAt, ok := c.time("exp")
+		if !ok {
+			return fmt.Errorf("%w: exp %v", ErrInvalidToken, exp)
+		}
+		c.ExpiresAt = expiresAt
+	}
+	return nil
+}
+
+func (c Claims) time(name string) (t time.Time, ok bool) {
+	number, ok := c.Raw[name].(json.Number)
+	if !ok {
+		return t, false
+	}
+	seconds, err := number.Float64()
+	if err != nil {
+		return t, false
+	}
+	return time.Unix(int64(seconds), 0), true
+}
+
+// Strings returns the strings of the claim at path, with dots between the
+// names of nested objects (realm_access.roles). The claim may be a string,
+// split by spaces like scope, or an array of strings.
+func (c Claims) Strings(path string) []string {
+	var value any = c.Raw
+	for _, name := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[name]
+	}
+
+	switch value := value.(type) {
+	case string:
+		return strings.Fields(value)
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+
+func Test_Verify(t *testing.T) {
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	file := writeJWKS(t, rsaJWK("rsa", "RS256", &rsaKey.PublicKey), ecJWK("ec", &ecKey.PublicKey))
+	verifier, err := New(Options{JWKSFile: file, Issuer: "https://auth.got", Audience: "got-api", Leeway: time.Minute})
+	assert.NoError(t, err)
+
+	valid := map[string]any{
+		"iss":   "https://auth.got",
+		"aud":   []string{"got-api", "other"},
+		"sub":   "user_1",
+		"exp":   now.Add(time.Hour).Unix(),
+		"roles": []string{"editor"},
+	}
+	with := func(name string, value any) map[string]any {
+		claims := map[string]any{}
+		for k, v := range valid {
+			claims[k] = v
+		}
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+
+	cases := map[string]struct {
+		token           string
+		expectedSubject string
+		expectedErr     error
+	}{
+		"Should verify RS256": {
+			token:           signRSA(t, "RS256", "rsa", rsaKey, valid),
+			expectedSubject: "user_1",
+		},
+		"Should verify ES256": {
+			token:           signEC(t, "ec", ecKey, valid),
+			expectedSubject: "user_1",
+		},
+		"Should verify within the leeway": {
+			token:           signRSA(t, "RS256", "rsa", rsaKey, with("exp", now.Add(-30*time.Second).Unix())),
+			expectedSubject: "user_1",
+		},
+		"Should return error signature": {
+			token:       signRSA(t, "RS256", "rsa", otherKey, valid),
+			expectedErr: ErrInvalidToken,
+		},
+		"Should return error alg of the key": {
+			token:       signRSA(t, "PS256", "rsa", rsaKey, valid),
+			expectedErr: ErrInvalidToken,
+		},
+		"Should return error alg none": {
+			token:       encode(t, map[string]any{"alg": "none", "kid": "rsa"}) + "." + encode(t, valid) + ".",
+			expectedErr: ErrInvalidToken,
+		},
+		"Should return error unknown key": {
+			token:       signRSA(t, "RS256", "unknown", rsaKey, valid),
+			expectedErr: ErrInvalidToken,
+		},
+		"Should return error expired": {
+			token:       signRSA(t, "RS256", "rsa", rsaKey, with("exp", now.Add(-time.Hour).Unix())),
+			expectedErr: ErrTokenExpired,
+		},
+		"Should return error without exp": {
+			token:       signRSA(t, "RS256", "rsa", rsaKey, with("exp", nil)),
+			expectedErr: ErrInvalidToken,
+		},
+		"Should return error not valid yet": {
+			token:       signRSA(t, "RS256", "rsa", rsaKey, with("nbf", now.Add(time.Hour).Unix())),
+			expectedErr: ErrInvalidToken,
+		},
+		"Should return error issuer": {
+			token:       signRSA(t, "RS256", "rsa", rsaKey, with("iss", "https://evil.got")),
+			expectedErr: ErrInvalidToken,
+		},
+		"Should return error audience": {
+			token:       signRSA(t, "RS256", "rsa", rsaKey, with("aud", "other")),
+			expectedErr: ErrInvalidToken,
+		},
+		"Should return error malformed": {
+			token:       "not.a-token",
+			expectedErr: ErrInvalidToken,
+		},
+	}
+
+	for name, cs := range cases {
+		t.Run(name, func(t *testing.T) {
+			claims, err := verifier.Verify(context.Background(), cs.token)
+			if cs.expectedErr != nil {
+				assert.True(t, errors.Is(err, cs.expectedErr), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, cs.expectedSubject, claims.Subject)
+			assert.Equal(t, []string{"editor"}, claims.Strings("roles"))
+		})
+	}
+}
+
+func Test_Strings(t *testing.T) {
+	claims := Claims{Raw: map[string]any{
+		"scope":        "read write",
+		"realm_access": map[string]any{"roles": []any{"admin", 1, "reader"}},
+	}}
+
+	assert.Equal(t, []string{"read", "write"}, claims.Strings("scope"))
+	assert.Equal(t, []string{"admin", "reader"}, claims.Strings("realm_access.roles"))
+	assert.Nil(t, claims.Strings("realm_access.groups"))
+	assert.Nil(t, claims.Strings("scope.roles"))
+}
+
+func Test_VerifyURL(t *testing.T) {
+	current := now
+	timeNow = func() time.Time { return current }
+	defer func() { timeNow = time.Now }()
+
+	first, _ := rsa.GenerateKey(rand.Reader, 2048)
+	second, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	var (
+		fetches int32
+		keys    atomic.Value
+	)
+	keys.Store(jwks{Keys: []jwk{rsaJWK("first", "", &first.PublicKey)}})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		json.NewEncoder(w).Encode(keys.Load())
+	}))
+	defer server.Close()
+
+	verifier, err := New(Options{JWKSURL: server.URL, Issuer: "https://auth.got", Audience: "got-api"})
+	assert.NoError(t, err)
+
+	claims := map[string]any{"iss": "https://auth.got", "aud": "got-api", "sub": "user_1", "exp": now.Add(24 * time.Hour).Unix()}
+
+	_, err = verifier.Verify(context.Background(), signRSA(t, "RS256", "first", first, claims))
+	assert.NoError(t, err)
+	_, err = verifier.Verify(context.Background(), signRSA(t, "RS256", "first", first, claims))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	// the key rotates, the tokens of the new key are verified once the
+	// minimum time between two fetches passed
+	keys.Store(jwks{Keys: []jwk{rsaJWK("second", "", &second.PublicKey)}})
+	_, err = verifier.Verify(context.Background(), signRSA(t, "RS256", "second", second, claims))
+	assert.True(t, errors.Is(err, ErrInvalidToken))
+
+	current = now.Add(time.Minute)
+	_, err = verifier.Verify(context.Background(), signRSA(t, "RS256", "second", second, claims))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+}
+
+func Test_VerifyURLConcurrent(t *testing.T) {
+	current := now
+	timeNow = func() time.Time { return current }
+	defer func() { timeNow = time.Now }()
+
+	first, _ := rsa.GenerateKey(rand.Reader, 2048)
+	second, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	var (
+		fetches  int32
+		fetching = make(chan struct{}, 1)
+		release  = make(chan struct{})
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&fetches, 1) > 1 {
+			fetching <- struct{}{}
+			<-release
+		}
+		json.NewEncoder(w).Encode(jwks{Keys: []jwk{rsaJWK("first", "", &first.PublicKey), rsaJWK("second", "", &second.PublicKey)}})
+	}))
+	defer server.Close()
+
+	v, err := New(Options{JWKSURL: server.URL, Issuer: "https://auth.got", Audience: "got-api"})
+	assert.NoError(t, err)
+
+	claims := map[string]any{"iss": "https://auth.got", "aud": "got-api", "sub": "user_1", "exp": now.Add(24 * time.Hour).Unix()}
+	_, err = v.Verify(context.Background(), signRSA(t, "RS256", "first", first, claims))
+	assert.NoError(t, err)
+
+	// the keys go stale, the tokens verified during the fetch share it
+	current = now.Add(2 * time.Hour)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := v.Verify(context.Background(), signRSA(t, "RS256", "second", second, claims))
+			assert.NoError(t, err)
+		}()
+	}
+	<-fetching
+
+	// the fetch doesn't hold the lock of the keys
+	set := v.(*verifier).keys
+	_, ok, _ := set.lookup("first")
+	assert.True(t, ok)
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+}
+
+func Test_New(t *testing.T) {
+	_, err := New(Options{JWKSFile: "missing.json", Issuer: "https://auth.got", Audience: "got-api"})
+	assert.Error(t, err)
+
+	_, err = New(Options{Issuer: "https://auth.got", Audience: "got-api"})
+	assert.Error(t, err)
+
+	_, err = New(Options{JWKSURL: "http://localhost/jwks.json", Audience: "got-api"})
+	assert.Error(t, err)
+
+	file := writeJWKS(t, jwk{Kty: "oct", Kid: "hmac"})
+	_, err = New(Options{JWKSFile: file, Issuer: "https://auth.got", Audience: "got-api"})
+	assert.Error(t, err)
+}
+
+func writeJWKS(t *testing.T, keys ...jwk) string {
+	data, _ := json.Marshal(jwks{Keys: keys})
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(file, data, 0o600))
+	return file
+}
+
+func rsaJWK(kid, alg string, public *rsa.PublicKey) jwk {
+	return jwk{Kty: "RSA", Kid: kid, Alg: alg, Use: "sig",
+		N: base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+		E: base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())}
+}
+
+func ecJWK(kid string, public *ecdsa.PublicKey) jwk {
+	return jwk{Kty: "EC", Kid: kid, Crv: "P-256",
+		X: base64.RawURLEncoding.EncodeToString(public.X.FillBytes(make([]byte, 32))),
+		Y: base64.RawURLEncoding.EncodeToString(public.Y.FillBytes(make([]byte, 32)))}
+}
+
+func encode(t *testing.T, v any) string {
+	data, err := json.Marshal(v)
+	assert.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signRSA(t *testing.T, alg, kid string, private *rsa.PrivateKey, claims map[string]any) string {
+	signed := encode(t, map[string]any{"alg": alg, "kid": kid, "typ": "JWT"}) + "." + encode(t, claims)
+	digest := sha256(signed)
+
+	var (
+		signature []byte
+		err       error
+	)
+	if alg == "PS256" {
+		signature, err = rsa.SignPSS(rand.Reader, private, crypto.SHA256, digest, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	} else {
+		signature, err = rsa.SignPKCS1v15(rand.Reader, private, crypto.SHA256, digest)
+	}
+	assert.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func signEC(t *testing.T, kid string, private *ecdsa.PrivateKey, claims map[string]any) string {
+	signed := encode(t, map[string]any{"alg": "ES256", "kid": kid}) + "." + encode(t, claims)
+
+	r, s, err := ecdsa.Sign(rand.Reader, private, sha256(signed))
+	assert.NoError(t, err)
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func sha256(value string) []byte {
+	hasher := crypto.SHA256.New()
+	hasher.Write([]byte(value))
+	return hasher.Sum(nil)
+}
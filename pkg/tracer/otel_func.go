@@ -33,3 +33,13 @@ func parseOptions(startOptions ...SpanStartOption) []trace.SpanStartOption {
 
 	return opts
 }
+
+// TraceID returns the id of the trace carried by ctx, or an empty string when
+// there is none.
+func TraceID(ctx context.Context) string {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.HasTraceID() {
+		return ""
+	}
+	return spanCtx.TraceID().String()
+}
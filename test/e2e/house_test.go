@@ -16,6 +16,7 @@ import (
 	"github.com/PatrickChagastavares/game-of-thrones/internal/handlers"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/repositories"
 	"github.com/PatrickChagastavares/game-of-thrones/internal/services"
+	"github.com/PatrickChagastavares/game-of-thrones/internal/services/apikeys"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/httpRouter"
 	"github.com/PatrickChagastavares/game-of-thrones/pkg/logger"
 	migration "github.com/PatrickChagastavares/game-of-thrones/pkg/migrations"
@@ -54,8 +55,9 @@ func Test_housesE2E(t *testing.T) {
 			Log:        log,
 		})
 		services = services.New(services.Options{
-			Repo: repositories,
-			Log:  log,
+			Repo:    repositories,
+			Log:     log,
+			APIKeys: apikeys.Options{BootstrapKey: apiKey},
 		})
 		controllers = controllers.New(controllers.Options{
 			Srv: services,
@@ -196,7 +198,10 @@ func Test_housesE2E(t *testing.T) {
 	})
 }
 
-const baseURL = "http://localhost:3003"
+const (
+	baseURL = "http://localhost:3003/v1"
+	apiKey  = "got_e2e_bootstrap_key"
+)
 
 func request(ctx context.Context, method, endpoint string, body interface{}, data interface{}) error {
 	requestBody, err := json.Marshal(body)
@@ -211,6 +216,7 @@ func request(ctx context.Context, method, endpoint string, body interface{}, dat
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(httpRouter.HeaderAPIKey, apiKey)
 
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {